@@ -15,6 +15,7 @@ type Config struct {
 	RateLimit      RateLimitConfig
 	CircuitBreaker CircuitBreakerConfig
 	Logging        LoggingConfig
+	Tracing        TracingConfig
 }
 
 type ServerConfig struct {
@@ -86,6 +87,15 @@ type LoggingConfig struct {
 	Format string
 }
 
+// TracingConfig configures OpenTelemetry tracing
+type TracingConfig struct {
+	Exporter     string // otlp, stdout or none
+	OTLPEndpoint string
+	OTLPInsecure bool
+	ServiceName  string
+	SampleRatio  float64
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() *Config {
 	return &Config{
@@ -142,6 +152,13 @@ func LoadConfig() *Config {
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
+		Tracing: TracingConfig{
+			Exporter:     getEnv("OTEL_TRACES_EXPORTER", "none"),
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			OTLPInsecure: getBoolEnv("OTEL_EXPORTER_OTLP_INSECURE", true),
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "event-service"),
+			SampleRatio:  getFloatEnv("OTEL_TRACES_SAMPLER_ARG", 1.0),
+		},
 	}
 }
 
@@ -171,6 +188,15 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getBoolEnv(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -126,7 +126,7 @@ func (h *EventHandler) HandleAttemptEvent(c *gin.Context) {
 
 	// Process event if processor is enabled
 	if h.processor != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), 5*time.Second)
 		defer cancel()
 
 		processedEvent, err := h.processor.ProcessEventSync(ctx, attemptEvent, models.EventTypeAttempt)
@@ -138,8 +138,8 @@ func (h *EventHandler) HandleAttemptEvent(c *gin.Context) {
 		}
 	}
 
-	// Publish to Kafka
-	ctx, cancel := context.WithTimeout(context.Background(), h.config.Kafka.ProducerTimeout)
+	// Publish to Kafka in the request's trace, finishing even if the client disconnects
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), h.config.Kafka.ProducerTimeout)
 	defer cancel()
 
 	if err := h.publisher.PublishAttemptEvent(ctx, attemptEvent); err != nil {
@@ -213,7 +213,7 @@ func (h *EventHandler) HandleSessionEvent(c *gin.Context) {
 
 	// Process event if processor is enabled
 	if h.processor != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), 5*time.Second)
 		defer cancel()
 
 		processedEvent, err := h.processor.ProcessEventSync(ctx, sessionEvent, models.EventTypeSession)
@@ -226,7 +226,7 @@ func (h *EventHandler) HandleSessionEvent(c *gin.Context) {
 	}
 
 	// Publish to Kafka
-	ctx, cancel := context.WithTimeout(context.Background(), h.config.Kafka.ProducerTimeout)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), h.config.Kafka.ProducerTimeout)
 	defer cancel()
 
 	if err := h.publisher.PublishSessionEvent(ctx, sessionEvent); err != nil {
@@ -300,7 +300,7 @@ func (h *EventHandler) HandlePlacementEvent(c *gin.Context) {
 
 	// Process event if processor is enabled
 	if h.processor != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), 5*time.Second)
 		defer cancel()
 
 		processedEvent, err := h.processor.ProcessEventSync(ctx, placementEvent, models.EventTypePlacement)
@@ -313,7 +313,7 @@ func (h *EventHandler) HandlePlacementEvent(c *gin.Context) {
 	}
 
 	// Publish to Kafka
-	ctx, cancel := context.WithTimeout(context.Background(), h.config.Kafka.ProducerTimeout)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), h.config.Kafka.ProducerTimeout)
 	defer cancel()
 
 	if err := h.publisher.PublishPlacementEvent(ctx, placementEvent); err != nil {
@@ -465,7 +465,7 @@ func (h *EventHandler) HandleBatchEvents(c *gin.Context) {
 
 	// Publish batch to Kafka if we have any valid events
 	if len(processedEvents) > 0 {
-		ctx, cancel := context.WithTimeout(context.Background(), h.config.Kafka.ProducerTimeout)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), h.config.Kafka.ProducerTimeout)
 		defer cancel()
 
 		if err := h.publisher.PublishBatch(ctx, processedEvents); err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"event-service/internal/config"
 	"event-service/internal/models"
+	"event-service/internal/tracing"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -70,6 +71,7 @@ func NewEventAggregator(cfg *config.Config) *EventAggregator {
 		ReadTimeout:  cfg.Redis.ReadTimeout,
 		WriteTimeout: cfg.Redis.WriteTimeout,
 	})
+	redisClient.AddHook(tracing.NewRedisHook())
 
 	aggregator := &EventAggregator{
 		config:      cfg,
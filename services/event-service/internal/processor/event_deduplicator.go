@@ -12,6 +12,7 @@ import (
 
 	"event-service/internal/config"
 	"event-service/internal/models"
+	"event-service/internal/tracing"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -62,6 +63,7 @@ func NewEventDeduplicator(cfg *config.Config) *EventDeduplicator {
 		ReadTimeout:  cfg.Redis.ReadTimeout,
 		WriteTimeout: cfg.Redis.WriteTimeout,
 	})
+	redisClient.AddHook(tracing.NewRedisHook())
 
 	cache := &DeduplicationCache{
 		cache: make(map[string]*DeduplicationEntry),
@@ -10,6 +10,7 @@ import (
 
 	"event-service/internal/config"
 	"event-service/internal/models"
+	"event-service/internal/tracing"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -80,6 +81,7 @@ func NewEventEnricher(cfg *config.Config) *EventEnricher {
 		ReadTimeout:  cfg.Redis.ReadTimeout,
 		WriteTimeout: cfg.Redis.WriteTimeout,
 	})
+	redisClient.AddHook(tracing.NewRedisHook())
 
 	cache := &EnrichmentCache{
 		userCache: make(map[string]*UserContext),
@@ -10,10 +10,13 @@ import (
 
 	"event-service/internal/config"
 	"event-service/internal/models"
+	"event-service/internal/tracing"
 
 	"github.com/klauspost/compress/snappy"
 	"github.com/segmentio/kafka-go"
 	"github.com/segmentio/kafka-go/compress"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // EventPublisher interface defines the contract for event publishing
@@ -288,8 +291,13 @@ func (p *KafkaPublisher) GetMetrics() *PublisherMetrics {
 	}
 }
 
-// publishWithRetry implements retry logic for single message publishing
-func (p *KafkaPublisher) publishWithRetry(ctx context.Context, message kafka.Message, eventType string) error {
+// publishWithRetry implements retry logic for single message publishing. The message
+// headers carry the trace context so consumers continue the request's trace.
+func (p *KafkaPublisher) publishWithRetry(ctx context.Context, message kafka.Message, eventType string) (err error) {
+	ctx, span := tracing.StartProducerSpan(ctx, message.Topic)
+	defer endProducerSpan(span, &err)
+	message.Headers = tracing.InjectKafkaHeaders(ctx, message.Headers)
+
 	var lastErr error
 
 	for attempt := 0; attempt <= p.retryPolicy.MaxRetries; attempt++ {
@@ -350,7 +358,13 @@ func (p *KafkaPublisher) publishWithRetry(ctx context.Context, message kafka.Mes
 }
 
 // publishBatchWithRetry implements retry logic for batch publishing
-func (p *KafkaPublisher) publishBatchWithRetry(ctx context.Context, messages []kafka.Message) error {
+func (p *KafkaPublisher) publishBatchWithRetry(ctx context.Context, messages []kafka.Message) (err error) {
+	ctx, span := tracing.StartBatchProducerSpan(ctx, len(messages))
+	defer endProducerSpan(span, &err)
+	for i := range messages {
+		messages[i].Headers = tracing.InjectKafkaHeaders(ctx, messages[i].Headers)
+	}
+
 	var lastErr error
 
 	for attempt := 0; attempt <= p.retryPolicy.MaxRetries; attempt++ {
@@ -410,6 +424,14 @@ func (p *KafkaPublisher) publishBatchWithRetry(ctx context.Context, messages []k
 		p.retryPolicy.MaxRetries+1, lastErr)
 }
 
+// endProducerSpan ends the span of a publish, marking it failed by the publish error
+func endProducerSpan(span trace.Span, err *error) {
+	if *err != nil {
+		span.SetStatus(otelcodes.Error, (*err).Error())
+	}
+	span.End()
+}
+
 // isRetryableError checks if an error should trigger a retry
 func (p *KafkaPublisher) isRetryableError(err error) bool {
 	if err == nil {
@@ -11,6 +11,7 @@ import (
 	"event-service/internal/handlers"
 	"event-service/internal/middleware"
 	"event-service/internal/publisher"
+	"event-service/internal/tracing"
 
 	"github.com/gin-gonic/gin"
 )
@@ -88,7 +89,8 @@ func (s *Server) Start() error {
 	// Create Gin router
 	router := gin.New()
 
-	// Add middleware
+	// Add middleware; tracing comes first so the request's span covers the others
+	router.Use(tracing.Middleware())
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(s.corsMiddleware())
@@ -177,10 +179,14 @@ func (s *Server) corsMiddleware() gin.HandlerFunc {
 	}
 }
 
-// requestIDMiddleware adds a unique request ID to each request
+// requestIDMiddleware adds a unique request ID to each request. Without an X-Request-ID
+// header the trace ID is used, so logs can be matched with the request's trace.
 func (s *Server) requestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = tracing.TraceID(c)
+		}
 		if requestID == "" {
 			requestID = generateRequestID()
 		}
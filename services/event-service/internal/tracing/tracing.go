@@ -0,0 +1,69 @@
+// Package tracing wires the OpenTelemetry instrumentation of shared/tracing to the
+// event service's configuration and tracer. Trace context follows the W3C Trace
+// Context format, so traces continue across the user, event and scheduler services.
+package tracing
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"event-service/internal/config"
+	sharedTracing "shared/tracing"
+	"shared/tracing/gintracing"
+)
+
+// instrumentationName names the tracer of the service's instrumentation
+const instrumentationName = "event-service"
+
+// NewProvider installs the global tracer provider and trace context propagator
+// configured for the service
+func NewProvider(ctx context.Context, cfg *config.TracingConfig) (*sharedTracing.Provider, error) {
+	return sharedTracing.NewProvider(ctx, sharedTracing.Config{
+		Exporter:     cfg.Exporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		OTLPInsecure: cfg.OTLPInsecure,
+		ServiceName:  cfg.ServiceName,
+		SampleRatio:  cfg.SampleRatio,
+	})
+}
+
+// Tracer returns the tracer of the service's instrumentation
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Middleware records a server span for each request to the HTTP API
+func Middleware() gin.HandlerFunc {
+	return gintracing.Middleware(Tracer())
+}
+
+// TraceID returns the trace ID of the request's span, or "" when the request is not
+// traced
+func TraceID(c *gin.Context) string {
+	return gintracing.TraceID(c)
+}
+
+// NewRedisHook creates a Redis hook, to be added with AddHook
+func NewRedisHook() *sharedTracing.RedisHook {
+	return sharedTracing.NewRedisHook(Tracer())
+}
+
+// StartProducerSpan starts the span of publishing a message to a topic
+func StartProducerSpan(ctx context.Context, topic string) (context.Context, trace.Span) {
+	return sharedTracing.StartProducerSpan(ctx, Tracer(), topic)
+}
+
+// StartBatchProducerSpan starts the span of publishing a batch of messages, which may
+// go to several topics
+func StartBatchProducerSpan(ctx context.Context, count int) (context.Context, trace.Span) {
+	return sharedTracing.StartBatchProducerSpan(ctx, Tracer(), count)
+}
+
+// InjectKafkaHeaders adds the trace context of ctx to the headers of a message
+func InjectKafkaHeaders(ctx context.Context, headers []kafka.Header) []kafka.Header {
+	return sharedTracing.InjectKafkaHeaders(ctx, headers)
+}
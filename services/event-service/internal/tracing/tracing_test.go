@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"event-service/internal/config"
+)
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+// recordSpans installs a tracer provider recording finished spans for the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestMiddleware_ExposesTraceID(t *testing.T) {
+	recorder := recordSpans(t)
+	gin.SetMode(gin.TestMode)
+
+	var traceID string
+	router := gin.New()
+	router.Use(Middleware())
+	router.POST("/api/v1/events", func(c *gin.Context) {
+		traceID = TraceID(c)
+		c.Status(http.StatusAccepted)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/events", nil)
+	req.Header.Set("traceparent", testTraceParent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if traceID != testTraceID {
+		t.Errorf("Expected handler to see trace %s, got %s", testTraceID, traceID)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "POST /api/v1/events" {
+		t.Errorf("Expected span named after the route, got %s", spans[0].Name())
+	}
+	if spans[0].InstrumentationScope().Name != instrumentationName {
+		t.Errorf("Expected the service's instrumentation scope, got %s", spans[0].InstrumentationScope().Name)
+	}
+}
+
+func TestTraceID_UntracedRequest(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	if traceID := TraceID(c); traceID != "" {
+		t.Errorf("Expected no trace ID outside a trace, got %s", traceID)
+	}
+}
+
+func TestStartBatchProducerSpan_CarriedByHeaders(t *testing.T) {
+	recorder := recordSpans(t)
+
+	ctx, span := StartBatchProducerSpan(context.Background(), 2)
+	headers := InjectKafkaHeaders(ctx, []kafka.Header{{Key: "event-type", Value: []byte("attempt.recorded")}})
+	span.End()
+
+	carrier := propagation.MapCarrier{}
+	for _, header := range headers {
+		carrier[header.Key] = string(header.Value)
+	}
+	consumed := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(context.Background(), carrier))
+	if consumed.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("Expected consumers to continue from the batch span")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].SpanKind() != trace.SpanKindProducer {
+		t.Fatalf("Expected 1 producer span, got %v", spans)
+	}
+	if spans[0].InstrumentationScope().Name != instrumentationName {
+		t.Errorf("Expected the service's instrumentation scope, got %s", spans[0].InstrumentationScope().Name)
+	}
+}
+
+func TestNewProvider_RejectsUnknownExporter(t *testing.T) {
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	provider, err := NewProvider(context.Background(), &config.TracingConfig{Exporter: "none"})
+	if err != nil {
+		t.Fatalf("Expected the none exporter to be accepted, got %v", err)
+	}
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected shutting down without an exporter to succeed, got %v", err)
+	}
+
+	if _, err := NewProvider(context.Background(), &config.TracingConfig{Exporter: "zipkin"}); err == nil {
+		t.Error("Expected an unknown exporter to be rejected")
+	}
+}
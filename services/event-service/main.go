@@ -10,12 +10,19 @@ import (
 
 	"event-service/internal/config"
 	"event-service/internal/server"
+	"event-service/internal/tracing"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
+	// Initialize tracing before the server creates its clients
+	tracerProvider, err := tracing.NewProvider(context.Background(), &cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
 	// Create and start server
 	srv := server.NewServer(cfg)
 
@@ -43,4 +50,9 @@ func main() {
 	} else {
 		log.Println("Server shutdown complete")
 	}
-}
\ No newline at end of file
+
+	// Export the remaining spans
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		log.Printf("Error shutting down tracing: %v", err)
+	}
+}
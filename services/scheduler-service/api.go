@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/handlers"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/server"
+	"scheduler-service/internal/tracing"
+)
+
+// onboardingAPIPrefix is the path the onboarding REST API is mounted under
+const onboardingAPIPrefix = "/api/v1/onboarding/"
+
+// newOnboardingAPI builds the authenticated onboarding REST API. Returns nil when no
+// access token keys are configured, since the routes must never be served unauthenticated.
+func newOnboardingAPI(cfg *config.Config, log *logger.Logger, schedulerService *server.SchedulerService) http.Handler {
+	verifier, err := auth.NewVerifier(&cfg.Auth)
+	if err != nil {
+		log.Fatalf("Failed to load access token keys: %v", err)
+	}
+	if verifier == nil {
+		log.Warn("No JWT_SECRET, AUTH_JWKS_FILE or AUTH_JWT_PUBLIC_KEY_FILE set, onboarding HTTP API disabled")
+		return nil
+	}
+
+	handler := handlers.NewOnboardingHandler(
+		log, schedulerService, schedulerService.OnboardingService(), verifier,
+	)
+
+	router := mux.NewRouter()
+	router.Use(tracing.RouteMiddleware)
+	handler.RegisterRoutes(router)
+
+	// CORS runs before routing so preflight requests are answered without a token
+	return tracing.HTTPMiddleware(handler.RequestLoggingMiddleware(handler.CORSMiddleware(router)))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"scheduler-service/internal/importer"
+	"scheduler-service/internal/logger"
+)
+
+const importUsage = "usage: scheduler-service import [-dry-run] [-source app] [-grade-scale sm2|anki] [-format csv|jsonl] <file|->"
+
+// runImport implements the "import" subcommand, which replays a review log and prints
+// the import report as JSON. Returns the process exit code.
+func runImport(reviewImporter *importer.Importer, log *logger.Logger, args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	dryRun := flags.Bool("dry-run", false, "report what would be imported without storing anything")
+	source := flags.String("source", "", "app the log was exported from, for item_external_ids mappings")
+	gradeScale := flags.String("grade-scale", importer.GradeScaleSM2, "grade scale of the log: sm2 or anki")
+	format := flags.String("format", "", "log format: csv or jsonl; inferred from the file extension when unset")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, importUsage)
+		return 2
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			*format = importer.FormatCSV
+		case ".jsonl", ".ndjson":
+			*format = importer.FormatJSONL
+		default:
+			fmt.Fprintln(os.Stderr, "cannot infer the log format from the file name; set -format")
+			return 2
+		}
+	}
+
+	input := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Errorf("Failed to open review log: %v", err)
+			return 1
+		}
+		defer file.Close()
+		input = file
+	}
+
+	report, err := reviewImporter.Import(context.Background(), input, importer.Options{
+		Format:     *format,
+		Source:     *source,
+		GradeScale: *gradeScale,
+		DryRun:     *dryRun,
+	})
+	if err != nil {
+		log.Errorf("Import failed: %v", err)
+		return 1
+	}
+
+	return printJSON(log, report)
+}
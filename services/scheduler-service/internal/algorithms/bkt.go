@@ -2,6 +2,7 @@ package algorithms
 
 import (
 	"math"
+	"sync"
 	"time"
 
 	pb "scheduler-service/proto"
@@ -9,6 +10,9 @@ import (
 
 // BKTAlgorithm implements Bayesian Knowledge Tracing for topic-based mastery tracking
 type BKTAlgorithm struct {
+	// Guards the default parameters, which are calibrated and reloaded at runtime
+	mu sync.RWMutex
+
 	// Default BKT parameters (can be calibrated per topic)
 	DefaultProbGuess float64 // P(G) - probability of guessing correctly when not knowing
 	DefaultProbSlip  float64 // P(S) - probability of slipping when knowing
@@ -30,6 +34,24 @@ func NewBKTAlgorithm() *BKTAlgorithm {
 	}
 }
 
+// BKTParameters are the default BKT parameters of new topic states
+type BKTParameters struct {
+	ProbInit  float64
+	ProbGuess float64
+	ProbSlip  float64
+	ProbLearn float64
+}
+
+// SetParameters changes the default parameters. Existing states keep theirs.
+func (bkt *BKTAlgorithm) SetParameters(params BKTParameters) {
+	bkt.mu.Lock()
+	defer bkt.mu.Unlock()
+	bkt.DefaultProbInit = params.ProbInit
+	bkt.DefaultProbGuess = params.ProbGuess
+	bkt.DefaultProbSlip = params.ProbSlip
+	bkt.DefaultProbLearn = params.ProbLearn
+}
+
 // BKTState represents the knowledge state for a specific topic
 type BKTState struct {
 	ProbKnowledge float64   `json:"prob_knowledge"` // P(L) - current probability of knowing
@@ -44,6 +66,9 @@ type BKTState struct {
 
 // InitializeState creates initial BKT state for a new topic
 func (bkt *BKTAlgorithm) InitializeState(topic string) *BKTState {
+	bkt.mu.RLock()
+	defer bkt.mu.RUnlock()
+
 	return &BKTState{
 		ProbKnowledge: bkt.DefaultProbInit,
 		ProbGuess:     bkt.DefaultProbGuess,
@@ -58,6 +83,15 @@ func (bkt *BKTAlgorithm) InitializeState(topic string) *BKTState {
 
 // UpdateState updates BKT state based on user response
 func (bkt *BKTAlgorithm) UpdateState(state *BKTState, correct bool) *BKTState {
+	return bkt.UpdateStateWeighted(state, correct, 1.0)
+}
+
+// UpdateStateWeighted updates BKT state based on a user response that is only partial
+// evidence of knowledge, such as a correct answer reached with hints. The Bayesian
+// posterior is moved towards by weight in [0, 1]; the learning transition applies in full.
+func (bkt *BKTAlgorithm) UpdateStateWeighted(state *BKTState, correct bool, weight float64) *BKTState {
+	weight = math.Max(0.0, math.Min(1.0, weight))
+
 	newState := &BKTState{
 		ProbGuess:     state.ProbGuess,
 		ProbSlip:      state.ProbSlip,
@@ -96,6 +130,9 @@ func (bkt *BKTAlgorithm) UpdateState(state *BKTState, correct bool) *BKTState {
 		}
 	}
 
+	// Discount the evidence: P(L) moves from the prior towards the posterior by weight
+	newState.ProbKnowledge = state.ProbKnowledge + weight*(newState.ProbKnowledge-state.ProbKnowledge)
+
 	// Apply learning: P(L_new) = P(L_old) + (1 - P(L_old)) * P(T)
 	newState.ProbKnowledge = newState.ProbKnowledge + (1-newState.ProbKnowledge)*state.ProbLearn
 
@@ -228,6 +265,9 @@ func (bkt *BKTAlgorithm) CalibrateParameters(states []*BKTState, responses []boo
 	// Update parameters with smoothing
 	alpha := 0.1 // Learning rate for parameter updates
 
+	bkt.mu.Lock()
+	defer bkt.mu.Unlock()
+
 	if guessCount > 0 {
 		newGuess := totalGuess / float64(guessCount)
 		bkt.DefaultProbGuess = (1-alpha)*bkt.DefaultProbGuess + alpha*newGuess
@@ -328,13 +368,14 @@ func (bkt *BKTAlgorithm) GetVisualizationData(state *BKTState) map[string]any {
 		"attempts_count":        state.AttemptsCount,
 		"accuracy_rate":         float64(state.CorrectCount) / math.Max(1, float64(state.AttemptsCount)),
 		"mastery_progress":      state.ProbKnowledge / bkt.MasteryThreshold,
-		"learning_velocity":     bkt.calculateLearningVelocity(state),
+		"learning_velocity":     bkt.CalculateLearningVelocity(state),
 		"time_to_mastery":       bkt.estimateTimeToMastery(state),
 	}
 }
 
-// calculateLearningVelocity estimates how quickly the user is learning
-func (bkt *BKTAlgorithm) calculateLearningVelocity(state *BKTState) float64 {
+// CalculateLearningVelocity estimates how quickly the user is learning, as the
+// expected gain in knowledge probability per attempt
+func (bkt *BKTAlgorithm) CalculateLearningVelocity(state *BKTState) float64 {
 	if state.AttemptsCount < 2 {
 		return 0.0
 	}
@@ -393,3 +393,29 @@ func TestBKTBoundaryConditions(t *testing.T) {
 		t.Errorf("Knowledge probability out of bounds with zero guess/slip")
 	}
 }
+
+func TestBKTUpdateStateWeighted(t *testing.T) {
+	bkt := NewBKTAlgorithm()
+	state := bkt.InitializeState("traffic_signs")
+
+	full := bkt.UpdateState(state, true)
+	weighted := bkt.UpdateStateWeighted(state, true, 1.0)
+	if weighted.ProbKnowledge != full.ProbKnowledge {
+		t.Errorf("Expected full weight to match UpdateState, got %f and %f", weighted.ProbKnowledge, full.ProbKnowledge)
+	}
+
+	// Without evidence only the learning transition applies
+	none := bkt.UpdateStateWeighted(state, true, 0.0)
+	expected := state.ProbKnowledge + (1-state.ProbKnowledge)*state.ProbLearn
+	if math.Abs(none.ProbKnowledge-expected) > 1e-9 {
+		t.Errorf("Expected ProbKnowledge %f with no evidence, got %f", expected, none.ProbKnowledge)
+	}
+	if none.AttemptsCount != 1 || none.CorrectCount != 1 {
+		t.Errorf("Expected the attempt to be counted, got %d attempts and %d correct", none.AttemptsCount, none.CorrectCount)
+	}
+
+	half := bkt.UpdateStateWeighted(state, true, 0.5)
+	if half.ProbKnowledge <= none.ProbKnowledge || half.ProbKnowledge >= full.ProbKnowledge {
+		t.Errorf("Expected half weight between %f and %f, got %f", none.ProbKnowledge, full.ProbKnowledge, half.ProbKnowledge)
+	}
+}
@@ -859,7 +859,9 @@ func (p *PlacementTestAlgorithm) getAvailableTopics(countryCode string) []string
 	}
 }
 
-// OptimizePlacementParameters optimizes placement test parameters based on historical data
+// OptimizePlacementParameters summarizes historical placement results so stopping
+// criteria and item selection can be tuned. The summary is logged; the persisted
+// equivalent is produced by BuildPlacementReport.
 func (p *PlacementTestAlgorithm) OptimizePlacementParameters(ctx context.Context, historicalResults []PlacementResult) error {
 	if len(historicalResults) < 10 {
 		return fmt.Errorf("insufficient historical data for optimization")
@@ -869,77 +871,98 @@ func (p *PlacementTestAlgorithm) OptimizePlacementParameters(ctx context.Context
 		"historical_results": len(historicalResults),
 	}).Info("Optimizing placement test parameters")
 
-	// Analyze stopping criteria effectiveness
-	p.analyzeStoppingCriteria(historicalResults)
+	stoppingReasons := p.analyzeStoppingCriteria(historicalResults)
+	_, meanLength := p.analyzeItemSelection(historicalResults)
+	terminationSE := p.analyzeMeasurementPrecision(historicalResults)
 
-	// Analyze item selection effectiveness
-	p.analyzeItemSelection(historicalResults)
-
-	// Analyze measurement precision
-	p.analyzeMeasurementPrecision(historicalResults)
+	reasons := make(map[string]interface{}, len(stoppingReasons))
+	for _, reason := range stoppingReasons {
+		reasons[reason.Reason] = reason.Tests
+	}
 
-	p.logger.WithContext(ctx).Info("Placement test parameters optimized")
+	p.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"mean_test_length":  meanLength,
+		"stopping_reasons":  reasons,
+		"termination_se":    terminationSE.Mean,
+		"termination_se_90": terminationSE.P90,
+		"target_se":         p.TargetSE,
+	}).Info("Placement test parameters optimized")
 
 	return nil
 }
 
-// analyzeStoppingCriteria analyzes the effectiveness of stopping criteria
-func (p *PlacementTestAlgorithm) analyzeStoppingCriteria(results []PlacementResult) {
-	// Count stopping reasons
-	reasonCounts := make(map[string]int)
+// analyzeStoppingCriteria breaks results down by stopping reason, most frequent first
+func (p *PlacementTestAlgorithm) analyzeStoppingCriteria(results []PlacementResult) []StoppingReasonStats {
+	byReason := make(map[string]*StoppingReasonStats)
 	for _, result := range results {
-		reasonCounts[result.StoppingReason]++
+		stats, exists := byReason[result.StoppingReason]
+		if !exists {
+			stats = &StoppingReasonStats{Reason: result.StoppingReason}
+			byReason[result.StoppingReason] = stats
+		}
+		stats.Tests++
+		stats.MeanTestLength += float64(result.ItemsAdministered)
+		stats.MeanFinalSE += result.FinalSE
 	}
 
-	// Analyze average items administered by stopping reason
-	reasonItems := make(map[string][]int)
-	for _, result := range results {
-		reasonItems[result.StoppingReason] = append(reasonItems[result.StoppingReason], result.ItemsAdministered)
+	breakdown := make([]StoppingReasonStats, 0, len(byReason))
+	for _, stats := range byReason {
+		stats.Share = float64(stats.Tests) / float64(len(results))
+		stats.MeanTestLength /= float64(stats.Tests)
+		stats.MeanFinalSE /= float64(stats.Tests)
+		breakdown = append(breakdown, *stats)
 	}
 
-	// TODO: Implement parameter adjustments based on analysis
+	sort.Slice(breakdown, func(i, j int) bool {
+		if breakdown[i].Tests != breakdown[j].Tests {
+			return breakdown[i].Tests > breakdown[j].Tests
+		}
+		return breakdown[i].Reason < breakdown[j].Reason
+	})
+
+	return breakdown
 }
 
-// analyzeItemSelection analyzes the effectiveness of item selection
-func (p *PlacementTestAlgorithm) analyzeItemSelection(results []PlacementResult) {
-	// Analyze measurement precision vs items administered
-	var precisionSum, itemSum float64
+// analyzeItemSelection returns how many tests administered each number of items,
+// and the mean test length
+func (p *PlacementTestAlgorithm) analyzeItemSelection(results []PlacementResult) (map[int]int, float64) {
+	distribution := make(map[int]int)
+	if len(results) == 0 {
+		return distribution, 0
+	}
+
+	var itemSum float64
 	for _, result := range results {
-		precisionSum += result.MeasurementPrecision
+		distribution[result.ItemsAdministered]++
 		itemSum += float64(result.ItemsAdministered)
 	}
 
-	avgPrecision := precisionSum / float64(len(results))
-	avgItems := itemSum / float64(len(results))
-
-	// TODO: Adjust selection weights based on efficiency analysis
-	_ = avgPrecision
-	_ = avgItems
+	return distribution, itemSum / float64(len(results))
 }
 
-// analyzeMeasurementPrecision analyzes measurement precision across different ability levels
-func (p *PlacementTestAlgorithm) analyzeMeasurementPrecision(results []PlacementResult) {
-	// Group results by ability level
-	abilityGroups := make(map[string][]PlacementResult)
-	for _, result := range results {
-		level := p.determineRecommendedLevel(result.OverallAbility, result.OverallConfidence)
-		abilityGroups[level] = append(abilityGroups[level], result)
+// analyzeMeasurementPrecision summarizes the standard error at which tests terminated
+func (p *PlacementTestAlgorithm) analyzeMeasurementPrecision(results []PlacementResult) SEDistribution {
+	if len(results) == 0 {
+		return SEDistribution{}
 	}
 
-	// Analyze precision by ability level
-	for level, groupResults := range abilityGroups {
-		if len(groupResults) == 0 {
-			continue
-		}
+	ses := make([]float64, len(results))
+	var sum float64
+	for i, result := range results {
+		ses[i] = result.FinalSE
+		sum += result.FinalSE
+	}
+	sort.Float64s(ses)
 
-		var precisionSum float64
-		for _, result := range groupResults {
-			precisionSum += result.MeasurementPrecision
-		}
-		avgPrecision := precisionSum / float64(len(groupResults))
+	median := ses[len(ses)/2]
+	if len(ses)%2 == 0 {
+		median = (ses[len(ses)/2-1] + ses[len(ses)/2]) / 2
+	}
 
-		// TODO: Adjust parameters based on precision by level
-		_ = level
-		_ = avgPrecision
+	return SEDistribution{
+		Mean:   sum / float64(len(ses)),
+		Median: median,
+		P90:    ses[int(math.Ceil(0.9*float64(len(ses))))-1],
+		Max:    ses[len(ses)-1],
 	}
 }
@@ -0,0 +1,178 @@
+package algorithms
+
+import (
+	"math"
+	"sort"
+)
+
+// Item fit thresholds. Mean-square fit statistics outside [placementFitLower,
+// placementFitUpper] flag an item for review once it has enough responses to be
+// judged; below that the statistics are reported but the item is not flagged.
+const (
+	placementFitLower        = 0.7
+	placementFitUpper        = 1.3
+	placementFitMinResponses = 10
+	placementFitMinVariance  = 1e-4 // Floor on P(1-P) so near-certain responses do not explode outfit
+)
+
+// CompletedPlacementTest is a finished placement test together with the items and
+// responses it was scored from
+type CompletedPlacementTest struct {
+	Result    PlacementResult     `json:"result"`
+	Items     []PlacementItem     `json:"items"`
+	Responses []PlacementResponse `json:"responses"`
+}
+
+// PlacementReport aggregates completed placement tests into the statistics content
+// authors use to tune the test and its item bank
+type PlacementReport struct {
+	TestsAnalyzed      int                   `json:"tests_analyzed"`
+	MeanTestLength     float64               `json:"mean_test_length"`
+	LengthDistribution map[int]int           `json:"length_distribution"` // Items administered -> number of tests
+	StoppingReasons    []StoppingReasonStats `json:"stopping_reasons"`
+	TerminationSE      SEDistribution        `json:"termination_se"`
+	ItemFit            []ItemFitStatistics   `json:"item_fit"`
+}
+
+// StoppingReasonStats describes the tests that ended for one stopping reason
+type StoppingReasonStats struct {
+	Reason         string  `json:"reason"`
+	Tests          int     `json:"tests"`
+	Share          float64 `json:"share"` // Fraction of all tests analyzed
+	MeanTestLength float64 `json:"mean_test_length"`
+	MeanFinalSE    float64 `json:"mean_final_se"`
+}
+
+// SEDistribution summarizes the standard error of ability at test termination
+type SEDistribution struct {
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P90    float64 `json:"p90"`
+	Max    float64 `json:"max"`
+}
+
+// ItemFitStatistics are the Rasch-style mean-square fit statistics of one item,
+// computed from the residuals of its responses against each test's final ability.
+// Both have an expected value of 1; infit weights residuals by item information
+// and is sensitive to misfit near the item's difficulty, while outfit is
+// unweighted and sensitive to unexpected responses far from it.
+type ItemFitStatistics struct {
+	ItemID            string  `json:"item_id"`
+	Responses         int     `json:"responses"`
+	ProportionCorrect float64 `json:"proportion_correct"`
+	ExpectedCorrect   float64 `json:"expected_correct"` // Mean model probability of a correct response
+	Infit             float64 `json:"infit"`
+	Outfit            float64 `json:"outfit"`
+	Misfit            bool    `json:"misfit"`
+}
+
+// BuildPlacementReport aggregates completed placement tests into test-length,
+// stopping-reason, termination SE and item fit statistics
+func (p *PlacementTestAlgorithm) BuildPlacementReport(tests []CompletedPlacementTest) *PlacementReport {
+	results := make([]PlacementResult, len(tests))
+	for i, test := range tests {
+		results[i] = test.Result
+	}
+
+	distribution, meanLength := p.analyzeItemSelection(results)
+
+	return &PlacementReport{
+		TestsAnalyzed:      len(tests),
+		MeanTestLength:     meanLength,
+		LengthDistribution: distribution,
+		StoppingReasons:    p.analyzeStoppingCriteria(results),
+		TerminationSE:      p.analyzeMeasurementPrecision(results),
+		ItemFit:            p.analyzeItemFit(tests),
+	}
+}
+
+// itemFitAccumulator collects the residuals of one item across tests
+type itemFitAccumulator struct {
+	responses       int
+	correct         int
+	expected        float64
+	squaredResidual float64 // Sum of (x - P)^2
+	variance        float64 // Sum of P(1 - P)
+	squaredZ        float64 // Sum of (x - P)^2 / P(1 - P)
+}
+
+// analyzeItemFit computes infit and outfit for every item answered in the tests,
+// worst fitting first
+func (p *PlacementTestAlgorithm) analyzeItemFit(tests []CompletedPlacementTest) []ItemFitStatistics {
+	accumulators := make(map[string]*itemFitAccumulator)
+
+	for _, test := range tests {
+		// Items are administered in order and each response answers the item at its index
+		for i, response := range test.Responses {
+			if i >= len(test.Items) || test.Items[i].ItemID != response.ItemID {
+				continue
+			}
+			item := test.Items[i]
+
+			probability := p.irtAlgorithm.calculateProbability(test.Result.OverallAbility, &ItemParameters{
+				Difficulty:     item.Difficulty,
+				Discrimination: item.Discrimination,
+				Guessing:       item.Guessing,
+			})
+			variance := math.Max(probability*(1-probability), placementFitMinVariance)
+
+			observed := 0.0
+			if response.Correct {
+				observed = 1.0
+			}
+			residual := observed - probability
+
+			acc, exists := accumulators[item.ItemID]
+			if !exists {
+				acc = &itemFitAccumulator{}
+				accumulators[item.ItemID] = acc
+			}
+			acc.responses++
+			if response.Correct {
+				acc.correct++
+			}
+			acc.expected += probability
+			acc.squaredResidual += residual * residual
+			acc.variance += variance
+			acc.squaredZ += residual * residual / variance
+		}
+	}
+
+	fit := make([]ItemFitStatistics, 0, len(accumulators))
+	for itemID, acc := range accumulators {
+		stats := ItemFitStatistics{
+			ItemID:            itemID,
+			Responses:         acc.responses,
+			ProportionCorrect: float64(acc.correct) / float64(acc.responses),
+			ExpectedCorrect:   acc.expected / float64(acc.responses),
+			Infit:             acc.squaredResidual / acc.variance,
+			Outfit:            acc.squaredZ / float64(acc.responses),
+		}
+		stats.Misfit = stats.Responses >= placementFitMinResponses &&
+			(!withinFitBounds(stats.Infit) || !withinFitBounds(stats.Outfit))
+		fit = append(fit, stats)
+	}
+
+	sort.Slice(fit, func(i, j int) bool {
+		if fit[i].Misfit != fit[j].Misfit {
+			return fit[i].Misfit
+		}
+		di, dj := fitDeviation(fit[i]), fitDeviation(fit[j])
+		if di != dj {
+			return di > dj
+		}
+		return fit[i].ItemID < fit[j].ItemID
+	})
+
+	return fit
+}
+
+// withinFitBounds reports whether a mean-square fit statistic is acceptable
+func withinFitBounds(meanSquare float64) bool {
+	return meanSquare >= placementFitLower && meanSquare <= placementFitUpper
+}
+
+// fitDeviation is how far an item's worse fit statistic is from its expected value of 1
+func fitDeviation(stats ItemFitStatistics) float64 {
+	return math.Max(math.Abs(stats.Infit-1), math.Abs(stats.Outfit-1))
+}
@@ -0,0 +1,162 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+)
+
+func newTestPlacementAlgorithm() *PlacementTestAlgorithm {
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	return NewPlacementTestAlgorithm(NewIRTAlgorithm(), log)
+}
+
+// completedTest builds a completed test of the given ability answering each item
+// in order with the given correctness
+func completedTest(ability, finalSE float64, reason string, items []PlacementItem, correct []bool) CompletedPlacementTest {
+	responses := make([]PlacementResponse, len(correct))
+	for i := range correct {
+		responses[i] = PlacementResponse{ItemID: items[i].ItemID, Correct: correct[i]}
+	}
+	return CompletedPlacementTest{
+		Result: PlacementResult{
+			OverallAbility:    ability,
+			FinalSE:           finalSE,
+			ItemsAdministered: len(correct),
+			StoppingReason:    reason,
+		},
+		Items:     items[:len(correct)],
+		Responses: responses,
+	}
+}
+
+func TestBuildPlacementReport_TestLevelAggregates(t *testing.T) {
+	algorithm := newTestPlacementAlgorithm()
+
+	items := make([]PlacementItem, 25)
+	for i := range items {
+		items[i] = PlacementItem{ItemID: fmt.Sprintf("item_%d", i), Discrimination: 1.0, Guessing: 0.2}
+	}
+	answers := func(n int) []bool { return make([]bool, n) }
+
+	tests := []CompletedPlacementTest{
+		completedTest(0, 0.28, "target_precision_achieved", items, answers(15)),
+		completedTest(0, 0.30, "target_precision_achieved", items, answers(17)),
+		completedTest(0, 0.29, "target_precision_achieved", items, answers(15)),
+		completedTest(0, 0.45, "maximum_items_reached", items, answers(25)),
+	}
+
+	report := algorithm.BuildPlacementReport(tests)
+
+	if report.TestsAnalyzed != 4 {
+		t.Errorf("Expected 4 tests analyzed, got %d", report.TestsAnalyzed)
+	}
+	if report.MeanTestLength != 18 {
+		t.Errorf("Expected mean test length 18, got %f", report.MeanTestLength)
+	}
+	if report.LengthDistribution[15] != 2 || report.LengthDistribution[17] != 1 || report.LengthDistribution[25] != 1 {
+		t.Errorf("Unexpected length distribution %v", report.LengthDistribution)
+	}
+
+	if len(report.StoppingReasons) != 2 {
+		t.Fatalf("Expected 2 stopping reasons, got %d", len(report.StoppingReasons))
+	}
+	first := report.StoppingReasons[0]
+	if first.Reason != "target_precision_achieved" || first.Tests != 3 || first.Share != 0.75 {
+		t.Errorf("Expected the most frequent reason first, got %+v", first)
+	}
+	if math.Abs(first.MeanTestLength-47.0/3) > 1e-9 || math.Abs(first.MeanFinalSE-0.29) > 1e-9 {
+		t.Errorf("Unexpected stopping reason means %+v", first)
+	}
+
+	se := report.TerminationSE
+	if math.Abs(se.Median-0.295) > 1e-9 {
+		t.Errorf("Expected median SE 0.295, got %f", se.Median)
+	}
+	if se.P90 != 0.45 || se.Max != 0.45 {
+		t.Errorf("Expected p90 and max SE 0.45, got %f and %f", se.P90, se.Max)
+	}
+	if math.Abs(se.Mean-0.33) > 1e-9 {
+		t.Errorf("Expected mean SE 0.33, got %f", se.Mean)
+	}
+}
+
+func TestBuildPlacementReport_ItemFit(t *testing.T) {
+	algorithm := newTestPlacementAlgorithm()
+
+	// The first item behaves as the model predicts; the second is answered wrongly by
+	// able test takers and correctly by weak ones, as a miskeyed item would be
+	items := []PlacementItem{
+		{ItemID: "fitting", Difficulty: 0, Discrimination: 1.0},
+		{ItemID: "miskeyed", Difficulty: 0, Discrimination: 1.0},
+	}
+
+	var tests []CompletedPlacementTest
+	for _, ability := range []float64{2.0, -2.0} {
+		// The share of correct answers to the fitting item matches the model probability
+		probability := algorithm.irtAlgorithm.calculateProbability(ability, &ItemParameters{Difficulty: 0, Discrimination: 1.0})
+		expectedCorrect := int(math.Round(probability * 50))
+		for i := 0; i < 50; i++ {
+			tests = append(tests, completedTest(ability, 0.3, "target_precision_achieved", items,
+				[]bool{i < expectedCorrect, ability < 0}))
+		}
+	}
+
+	report := algorithm.BuildPlacementReport(tests)
+
+	if len(report.ItemFit) != 2 {
+		t.Fatalf("Expected fit statistics for 2 items, got %d", len(report.ItemFit))
+	}
+
+	miskeyed := report.ItemFit[0]
+	if miskeyed.ItemID != "miskeyed" || !miskeyed.Misfit {
+		t.Errorf("Expected the miskeyed item first and flagged, got %+v", miskeyed)
+	}
+	if miskeyed.Infit <= placementFitUpper || miskeyed.Outfit <= placementFitUpper {
+		t.Errorf("Expected infit and outfit above %f, got %f and %f", placementFitUpper, miskeyed.Infit, miskeyed.Outfit)
+	}
+
+	fitting := report.ItemFit[1]
+	if fitting.ItemID != "fitting" || fitting.Misfit {
+		t.Errorf("Expected the fitting item not to be flagged, got %+v", fitting)
+	}
+	if fitting.Responses != 100 || math.Abs(fitting.Infit-1) > 0.1 || math.Abs(fitting.Outfit-1) > 0.1 {
+		t.Errorf("Expected fit statistics close to 1 over 100 responses, got %+v", fitting)
+	}
+}
+
+func TestBuildPlacementReport_SkipsUnansweredAndFewResponses(t *testing.T) {
+	algorithm := newTestPlacementAlgorithm()
+
+	items := []PlacementItem{
+		{ItemID: "answered", Difficulty: 3, Discrimination: 1.0},
+		{ItemID: "unanswered", Difficulty: 0, Discrimination: 1.0},
+	}
+
+	// Finished early: the second item was administered but never answered
+	test := completedTest(-3, 0.6, "user_finished", items, []bool{true})
+	test.Items = items
+
+	report := algorithm.BuildPlacementReport([]CompletedPlacementTest{test})
+
+	if len(report.ItemFit) != 1 || report.ItemFit[0].ItemID != "answered" {
+		t.Fatalf("Expected fit statistics for the answered item only, got %+v", report.ItemFit)
+	}
+	if report.ItemFit[0].Misfit {
+		t.Errorf("Expected an item with too few responses not to be flagged, got %+v", report.ItemFit[0])
+	}
+}
+
+func TestBuildPlacementReport_Empty(t *testing.T) {
+	report := newTestPlacementAlgorithm().BuildPlacementReport(nil)
+
+	if report.TestsAnalyzed != 0 || report.MeanTestLength != 0 {
+		t.Errorf("Expected an empty report, got %+v", report)
+	}
+	if len(report.StoppingReasons) != 0 || len(report.ItemFit) != 0 {
+		t.Errorf("Expected no breakdowns in an empty report, got %+v", report)
+	}
+}
@@ -2,6 +2,7 @@ package algorithms
 
 import (
 	"math"
+	"sync"
 	"time"
 
 	pb "scheduler-service/proto"
@@ -11,23 +12,68 @@ import (
 
 // SM2Algorithm implements the SuperMemo-2 spaced repetition algorithm
 type SM2Algorithm struct {
+	// Guards the parameters that can change at runtime, set with SetParameters
+	mu sync.RWMutex
+
 	// Configuration parameters
-	MinEasinessFactor float64 // Minimum easiness factor (default: 1.3)
-	MaxEasinessFactor float64 // Maximum easiness factor (default: 2.5)
-	InitialInterval   int     // Initial interval in days (default: 1)
-	SecondInterval    int     // Second interval in days (default: 6)
+	InitialEasinessFactor float64 // Easiness factor of new items (default: 2.5)
+	MinEasinessFactor     float64 // Minimum easiness factor (default: 1.3)
+	MaxEasinessFactor     float64 // Maximum easiness factor (default: 2.5)
+	InitialInterval       int     // Initial interval in days (default: 1)
+	SecondInterval        int     // Second interval in days (default: 6)
+
+	// Exam-aware scheduling parameters
+	ExamReviewBufferDays int // Days before the exam by which the last review must land (default: 1)
+	ExamHorizonDays      int // Days before the exam when review intensification starts (default: 30)
+
+	// Lapses after which an item is a leech; 0 disables leech detection (default: 8)
+	LeechThreshold int
 }
 
 // NewSM2Algorithm creates a new SM-2 algorithm instance with default parameters
 func NewSM2Algorithm() *SM2Algorithm {
 	return &SM2Algorithm{
-		MinEasinessFactor: 1.3,
-		MaxEasinessFactor: 2.5,
-		InitialInterval:   1,
-		SecondInterval:    6,
+		InitialEasinessFactor: 2.5,
+		MinEasinessFactor:     1.3,
+		MaxEasinessFactor:     2.5,
+		InitialInterval:       1,
+		SecondInterval:        6,
+
+		ExamReviewBufferDays: 1,
+		ExamHorizonDays:      30,
+
+		LeechThreshold: 8,
 	}
 }
 
+// SM2Parameters are the SM-2 parameters that can change at runtime
+type SM2Parameters struct {
+	InitialEasinessFactor float64
+	MinEasinessFactor     float64
+	LeechThreshold        int
+}
+
+// Parameters returns the current runtime parameters
+func (sm2 *SM2Algorithm) Parameters() SM2Parameters {
+	sm2.mu.RLock()
+	defer sm2.mu.RUnlock()
+	return SM2Parameters{
+		InitialEasinessFactor: sm2.InitialEasinessFactor,
+		MinEasinessFactor:     sm2.MinEasinessFactor,
+		LeechThreshold:        sm2.LeechThreshold,
+	}
+}
+
+// SetParameters changes the runtime parameters. Existing states keep their easiness
+// factor until their next review.
+func (sm2 *SM2Algorithm) SetParameters(params SM2Parameters) {
+	sm2.mu.Lock()
+	defer sm2.mu.Unlock()
+	sm2.InitialEasinessFactor = params.InitialEasinessFactor
+	sm2.MinEasinessFactor = params.MinEasinessFactor
+	sm2.LeechThreshold = params.LeechThreshold
+}
+
 // SM2State represents the internal state for SM-2 algorithm
 type SM2State struct {
 	EasinessFactor float64   `json:"easiness_factor"`
@@ -35,14 +81,15 @@ type SM2State struct {
 	Repetition     int       `json:"repetition"`
 	NextDue        time.Time `json:"next_due"`
 	LastReviewed   time.Time `json:"last_reviewed"`
+	Lapses         int       `json:"lapses"` // Failed reviews after the first attempt
 }
 
 // InitializeState creates initial SM-2 state for a new item
 func (sm2 *SM2Algorithm) InitializeState() *SM2State {
 	now := time.Now()
 	return &SM2State{
-		EasinessFactor: 2.5, // Default easiness factor
-		Interval:       0,   // Will be set on first review
+		EasinessFactor: sm2.Parameters().InitialEasinessFactor,
+		Interval:       0, // Will be set on first review
 		Repetition:     0,
 		NextDue:        now, // Available immediately
 		LastReviewed:   now,
@@ -67,21 +114,26 @@ func (sm2 *SM2Algorithm) UpdateState(state *SM2State, quality int) *SM2State {
 		Interval:       state.Interval,
 		Repetition:     state.Repetition,
 		LastReviewed:   time.Now(),
+		Lapses:         state.Lapses,
 	}
 
 	// Update easiness factor based on quality
 	newState.EasinessFactor = state.EasinessFactor + (0.1 - float64(5-quality)*(0.08+float64(5-quality)*0.02))
 
 	// Ensure easiness factor stays within bounds
-	if newState.EasinessFactor < sm2.MinEasinessFactor {
-		newState.EasinessFactor = sm2.MinEasinessFactor
+	if minEasiness := sm2.Parameters().MinEasinessFactor; newState.EasinessFactor < minEasiness {
+		newState.EasinessFactor = minEasiness
 	}
 	if newState.EasinessFactor > sm2.MaxEasinessFactor {
 		newState.EasinessFactor = sm2.MaxEasinessFactor
 	}
 
-	// If quality < 3, reset the repetition sequence
+	// If quality < 3, reset the repetition sequence. Failing an item already on a
+	// review interval is a lapse.
 	if quality < 3 {
+		if state.Interval > 0 {
+			newState.Lapses++
+		}
 		newState.Repetition = 0
 		newState.Interval = sm2.InitialInterval
 	} else {
@@ -130,6 +182,22 @@ func (sm2 *SM2Algorithm) IsDue(state *SM2State, currentTime time.Time) bool {
 	return currentTime.After(state.NextDue) || currentTime.Equal(state.NextDue)
 }
 
+// ReviewedWithin reports whether the item was reviewed within the window before
+// currentTime. Initialized states carry a review time but have never been reviewed.
+func (sm2 *SM2Algorithm) ReviewedWithin(state *SM2State, currentTime time.Time, window time.Duration) bool {
+	if state.Interval == 0 {
+		return false
+	}
+	return currentTime.Sub(state.LastReviewed) < window
+}
+
+// IsLeech reports whether an item has lapsed often enough to be a leech, an item the
+// learner keeps forgetting that further reviews alone are unlikely to fix
+func (sm2 *SM2Algorithm) IsLeech(state *SM2State) bool {
+	threshold := sm2.Parameters().LeechThreshold
+	return threshold > 0 && state.Lapses >= threshold
+}
+
 // GetDaysUntilDue returns the number of days until the item is due
 // Negative values indicate overdue items
 func (sm2 *SM2Algorithm) GetDaysUntilDue(state *SM2State, currentTime time.Time) float64 {
@@ -205,3 +273,102 @@ func (sm2 *SM2Algorithm) GetAnalytics(state *SM2State, currentTime time.Time) ma
 		"optimal_interval":      sm2.CalculateOptimalInterval(state, 0.85),
 	}
 }
+
+// UpdateStateForExam updates SM-2 state like UpdateState but keeps the next review
+// ahead of the learner's exam date
+func (sm2 *SM2Algorithm) UpdateStateForExam(state *SM2State, quality int, examDate time.Time) *SM2State {
+	return sm2.CapIntervalForExam(sm2.UpdateState(state, quality), examDate)
+}
+
+// CapIntervalForExam limits the interval so that the next review happens before the exam.
+// Inside the exam horizon the interval is further shortened in proportion to the easiness
+// factor, so that hard items get several reviews before the exam date.
+func (sm2 *SM2Algorithm) CapIntervalForExam(state *SM2State, examDate time.Time) *SM2State {
+	if examDate.IsZero() {
+		return state
+	}
+
+	daysUntilExam := int(math.Floor(examDate.Sub(state.LastReviewed).Hours() / 24.0))
+	if daysUntilExam <= 0 {
+		// Exam has passed or is today, regular scheduling applies
+		return state
+	}
+
+	maxInterval := daysUntilExam - sm2.ExamReviewBufferDays
+
+	if daysUntilExam <= sm2.ExamHorizonDays {
+		// Easy items (EF 2.5) get at least two more reviews, hard items (EF 1.3) around four
+		intensified := int(math.Floor(float64(daysUntilExam) * state.EasinessFactor / sm2.MaxEasinessFactor / 2.0))
+		if intensified < maxInterval {
+			maxInterval = intensified
+		}
+	}
+
+	if maxInterval < 1 {
+		maxInterval = 1
+	}
+
+	if state.Interval <= maxInterval {
+		return state
+	}
+
+	capped := *state
+	capped.Interval = maxInterval
+	capped.NextDue = capped.LastReviewed.AddDate(0, 0, maxInterval)
+
+	return &capped
+}
+
+// CalculateOptimalIntervalForExam calculates the optimal interval for the target retention,
+// bounded so that the next review lands before the exam date
+func (sm2 *SM2Algorithm) CalculateOptimalIntervalForExam(state *SM2State, targetRetention float64, examDate, currentTime time.Time) int {
+	interval := sm2.CalculateOptimalInterval(state, targetRetention)
+	if examDate.IsZero() {
+		return interval
+	}
+
+	daysUntilExam := int(math.Floor(examDate.Sub(currentTime).Hours() / 24.0))
+	if daysUntilExam <= 0 {
+		return interval
+	}
+
+	maxInterval := int(math.Max(1, float64(daysUntilExam-sm2.ExamReviewBufferDays)))
+	if interval > maxInterval {
+		return maxInterval
+	}
+
+	return interval
+}
+
+// GetExamPressure returns how close the exam is within the exam horizon.
+// Returns 0 outside the horizon or after the exam, rising to 1 on the exam day.
+func (sm2 *SM2Algorithm) GetExamPressure(currentTime, examDate time.Time) float64 {
+	return examPressure(currentTime, examDate, sm2.ExamHorizonDays)
+}
+
+// GetExamReviewBoost scores how much an item needs review before the exam, combining
+// exam pressure with the retention the item would have on the exam day without review
+func (sm2 *SM2Algorithm) GetExamReviewBoost(state *SM2State, currentTime, examDate time.Time) float64 {
+	pressure := sm2.GetExamPressure(currentTime, examDate)
+	if pressure == 0 {
+		return 0.0
+	}
+
+	retentionAtExam := sm2.GetRetentionProbability(state, examDate)
+
+	return pressure * (1.0 - retentionAtExam)
+}
+
+// examPressure maps the days remaining before an exam to a value in [0, 1]
+func examPressure(currentTime, examDate time.Time, horizonDays int) float64 {
+	if examDate.IsZero() || horizonDays <= 0 || !examDate.After(currentTime) {
+		return 0.0
+	}
+
+	daysUntilExam := examDate.Sub(currentTime).Hours() / 24.0
+	if daysUntilExam >= float64(horizonDays) {
+		return 0.0
+	}
+
+	return 1.0 - daysUntilExam/float64(horizonDays)
+}
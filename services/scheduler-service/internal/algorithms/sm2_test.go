@@ -154,6 +154,44 @@ func TestIsDue(t *testing.T) {
 	assert.True(t, sm2.IsDue(state, now))
 }
 
+func TestReviewedWithin(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	now := time.Now()
+
+	// A new item has not been reviewed yet
+	state := sm2.InitializeState()
+	assert.False(t, sm2.ReviewedWithin(state, now, 24*time.Hour))
+
+	state = sm2.UpdateState(state, 4)
+	assert.True(t, sm2.ReviewedWithin(state, now, 24*time.Hour))
+	assert.False(t, sm2.ReviewedWithin(state, now.Add(25*time.Hour), 24*time.Hour))
+}
+
+func TestUpdateState_Lapses(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	sm2.LeechThreshold = 2
+
+	// Failing a new item is not a lapse
+	state := sm2.UpdateState(sm2.InitializeState(), 1)
+	assert.Equal(t, 0, state.Lapses)
+
+	state = sm2.UpdateState(state, 4)
+	state = sm2.UpdateState(state, 2)
+	assert.Equal(t, 1, state.Lapses)
+	assert.False(t, sm2.IsLeech(state))
+
+	// Lapses are kept through successful reviews
+	state = sm2.UpdateState(state, 5)
+	assert.Equal(t, 1, state.Lapses)
+
+	state = sm2.UpdateState(state, 0)
+	assert.Equal(t, 2, state.Lapses)
+	assert.True(t, sm2.IsLeech(state))
+
+	sm2.LeechThreshold = 0
+	assert.False(t, sm2.IsLeech(state))
+}
+
 func TestGetDaysUntilDue(t *testing.T) {
 	sm2 := NewSM2Algorithm()
 	now := time.Now()
@@ -296,6 +334,104 @@ func TestGetAnalytics(t *testing.T) {
 }
 
 // Benchmark tests
+func TestCapIntervalForExam_CapsBeforeExam(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	now := time.Now()
+	state := &SM2State{
+		EasinessFactor: 2.5,
+		Interval:       60,
+		Repetition:     4,
+		LastReviewed:   now,
+		NextDue:        now.AddDate(0, 0, 60),
+	}
+
+	// Exam outside the horizon: next review must land before the exam with a buffer day
+	examDate := now.AddDate(0, 0, 45)
+	capped := sm2.CapIntervalForExam(state, examDate)
+
+	assert.Equal(t, 44, capped.Interval)
+	assert.True(t, capped.NextDue.Before(examDate))
+	assert.Equal(t, 60, state.Interval) // Original state is not modified
+}
+
+func TestCapIntervalForExam_IntensifiesWithinHorizon(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	now := time.Now()
+	examDate := now.AddDate(0, 0, 20).Add(time.Hour)
+
+	easy := &SM2State{EasinessFactor: 2.5, Interval: 30, Repetition: 4, LastReviewed: now}
+	hard := &SM2State{EasinessFactor: 1.3, Interval: 30, Repetition: 4, LastReviewed: now}
+
+	cappedEasy := sm2.CapIntervalForExam(easy, examDate)
+	cappedHard := sm2.CapIntervalForExam(hard, examDate)
+
+	assert.Equal(t, 10, cappedEasy.Interval) // Half the remaining days
+	assert.Equal(t, 5, cappedHard.Interval)  // Hard items are reviewed more often
+}
+
+func TestCapIntervalForExam_NoChange(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	now := time.Now()
+	state := &SM2State{EasinessFactor: 2.5, Interval: 6, Repetition: 2, LastReviewed: now}
+
+	// Short interval well before the exam is kept
+	assert.Equal(t, 6, sm2.CapIntervalForExam(state, now.AddDate(0, 0, 90)).Interval)
+
+	// Past exam and zero exam date leave scheduling unchanged
+	assert.Equal(t, 6, sm2.CapIntervalForExam(state, now.AddDate(0, 0, -1)).Interval)
+	assert.Equal(t, 6, sm2.CapIntervalForExam(state, time.Time{}).Interval)
+}
+
+func TestUpdateStateForExam(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	state := sm2.InitializeState()
+	state = sm2.UpdateState(state, 5)
+	state = sm2.UpdateState(state, 5)
+
+	examDate := time.Now().AddDate(0, 0, 3).Add(time.Hour)
+	newState := sm2.UpdateStateForExam(state, 5, examDate)
+
+	require.NotNil(t, newState)
+	assert.Equal(t, 3, newState.Repetition)
+	assert.Equal(t, 1, newState.Interval)
+	assert.True(t, newState.NextDue.Before(examDate))
+}
+
+func TestCalculateOptimalIntervalForExam(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	now := time.Now()
+	state := &SM2State{EasinessFactor: 2.5, Interval: 6, Repetition: 2, LastReviewed: now}
+
+	unbounded := sm2.CalculateOptimalInterval(state, 0.5)
+	assert.Equal(t, unbounded, sm2.CalculateOptimalIntervalForExam(state, 0.5, time.Time{}, now))
+	assert.Equal(t, 1, sm2.CalculateOptimalIntervalForExam(state, 0.5, now.AddDate(0, 0, 2).Add(time.Hour), now))
+}
+
+func TestGetExamPressure(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	now := time.Now()
+
+	assert.Equal(t, 0.0, sm2.GetExamPressure(now, now.AddDate(0, 0, 60)))
+	assert.Equal(t, 0.0, sm2.GetExamPressure(now, now.AddDate(0, 0, -1)))
+	assert.InDelta(t, 0.5, sm2.GetExamPressure(now, now.AddDate(0, 0, 15)), 0.01)
+	assert.True(t, sm2.GetExamPressure(now, now.Add(time.Hour)) > 0.99)
+}
+
+func TestGetExamReviewBoost(t *testing.T) {
+	sm2 := NewSM2Algorithm()
+	now := time.Now()
+	examDate := now.AddDate(0, 0, 5)
+
+	fresh := &SM2State{EasinessFactor: 2.5, Interval: 30, Repetition: 4, LastReviewed: now}
+	fading := &SM2State{EasinessFactor: 1.5, Interval: 2, Repetition: 2, LastReviewed: now.AddDate(0, 0, -2)}
+
+	freshBoost := sm2.GetExamReviewBoost(fresh, now, examDate)
+	fadingBoost := sm2.GetExamReviewBoost(fading, now, examDate)
+
+	assert.True(t, fadingBoost > freshBoost, "low-retention items should be boosted more")
+	assert.Equal(t, 0.0, sm2.GetExamReviewBoost(fading, now, now.AddDate(0, 0, 60)))
+}
+
 func BenchmarkUpdateState(b *testing.B) {
 	sm2 := NewSM2Algorithm()
 	state := sm2.InitializeState()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"scheduler-service/internal/logger"
@@ -12,6 +13,9 @@ import (
 // UnifiedScoringAlgorithm implements the unified scoring function that combines
 // SM-2 urgency, BKT mastery gaps, IRT difficulty matching, and contextual bandit exploration
 type UnifiedScoringAlgorithm struct {
+	// Guards the weights and scoring strategies, which can change at runtime
+	mu sync.RWMutex
+
 	// Component weights (should sum to 1.0)
 	WeightUrgency     float64 // Weight for SM-2 urgency component
 	WeightMastery     float64 // Weight for BKT mastery gap component
@@ -32,6 +36,10 @@ type UnifiedScoringAlgorithm struct {
 	NoveltyBonus       float64 // Bonus for items not recently attempted
 	VarietyBonus       float64 // Bonus for topic variety in session
 
+	// Exam preparation parameters
+	ExamHorizonDays int     // Days before the exam when exam pressure starts to build
+	ExamWeightShift float64 // Share of difficulty/exploration weight moved to urgency/mastery at full exam pressure
+
 	// A/B testing framework
 	ABTestingEnabled  bool                       // Whether A/B testing is enabled
 	ScoringStrategies map[string]ScoringStrategy // Available scoring strategies
@@ -76,9 +84,17 @@ type SessionContext struct {
 	CorrectCount      int           `json:"correct_count"`
 	TopicsPracticed   []string      `json:"topics_practiced"`
 	RecentItems       []string      `json:"recent_items"`
+	RecentSiblings    []string      `json:"recent_siblings,omitempty"` // Sibling groups of the recent items
 	AverageDifficulty float64       `json:"average_difficulty"`
 	TargetItemCount   int           `json:"target_item_count"`
 	TimeRemaining     time.Duration `json:"time_remaining"`
+
+	// Exam preparation context (optional)
+	ExamDate         *time.Time         `json:"exam_date,omitempty"`
+	ExamTopicWeights map[string]float64 `json:"exam_topic_weights,omitempty"` // Relative weight of each topic in the exam
+
+	// Mastery gap multipliers (>= 1) for topics targeted by the user's open learning goals (optional)
+	GoalTopicWeights map[string]float64 `json:"goal_topic_weights,omitempty"`
 }
 
 // ItemCandidate represents a candidate item for selection
@@ -91,6 +107,7 @@ type ItemCandidate struct {
 	EstimatedTime  time.Duration          `json:"estimated_time"`
 	LastAttempted  *time.Time             `json:"last_attempted,omitempty"`
 	AttemptCount   int                    `json:"attempt_count"`
+	SiblingGroup   string                 `json:"sibling_group,omitempty"` // Items testing the same sign or rule
 	Metadata       map[string]interface{} `json:"metadata"`
 }
 
@@ -112,6 +129,7 @@ type ComponentScores struct {
 	ExplorationScore float64 `json:"exploration_score"`
 	NoveltyBonus     float64 `json:"novelty_bonus"`
 	VarietyBonus     float64 `json:"variety_bonus"`
+	ExamBoost        float64 `json:"exam_boost"`
 }
 
 // ConstraintResults indicates whether session constraints are satisfied
@@ -120,6 +138,7 @@ type ConstraintResults struct {
 	InterleavingOK      bool   `json:"interleaving_ok"`
 	DifficultyVariance  bool   `json:"difficulty_variance"`
 	RecentItemsOK       bool   `json:"recent_items_ok"`
+	SiblingsOK          bool   `json:"siblings_ok"`
 	ConstraintViolation string `json:"constraint_violation,omitempty"`
 }
 
@@ -146,6 +165,10 @@ func NewUnifiedScoringAlgorithm(logger *logger.Logger) *UnifiedScoringAlgorithm
 		NoveltyBonus:       0.15,
 		VarietyBonus:       0.10,
 
+		// Exam preparation parameters
+		ExamHorizonDays: 30,
+		ExamWeightShift: 0.6,
+
 		// A/B testing
 		ABTestingEnabled: true,
 		DefaultStrategy:  "balanced",
@@ -245,6 +268,22 @@ func (usa *UnifiedScoringAlgorithm) initializeScoringStrategies() {
 				DifficultyTolerance: 0.4,
 			},
 		},
+		"exam_prep": {
+			Name:        "exam_prep",
+			Description: "Prioritizes retention and weak exam topics ahead of a booked exam",
+			Weights: ScoringWeights{
+				Urgency:     0.40,
+				Mastery:     0.40,
+				Difficulty:  0.15,
+				Exploration: 0.05,
+			},
+			Parameters: ScoringParameters{
+				ExplorationRate:     0.05,
+				NoveltyWeight:       0.05,
+				VarietyWeight:       0.10,
+				DifficultyTolerance: 0.35,
+			},
+		},
 	}
 }
 
@@ -259,11 +298,13 @@ func (usa *UnifiedScoringAlgorithm) ComputeUnifiedScore(
 	strategy string,
 ) (*ScoringResult, error) {
 	// Get scoring strategy
+	usa.mu.RLock()
 	scoringStrategy, exists := usa.ScoringStrategies[strategy]
 	if !exists {
 		scoringStrategy = usa.ScoringStrategies[usa.DefaultStrategy]
 		strategy = usa.DefaultStrategy
 	}
+	usa.mu.RUnlock()
 
 	// Initialize result
 	result := &ScoringResult{
@@ -275,6 +316,7 @@ func (usa *UnifiedScoringAlgorithm) ComputeUnifiedScore(
 			InterleavingOK:     true,
 			DifficultyVariance: true,
 			RecentItemsOK:      true,
+			SiblingsOK:         true,
 		},
 	}
 
@@ -285,12 +327,36 @@ func (usa *UnifiedScoringAlgorithm) ComputeUnifiedScore(
 		return result, nil
 	}
 
+	currentTime := time.Now()
+	weights := scoringStrategy.Weights
+
 	// Calculate SM-2 urgency component
-	urgencyScore := usa.calculateUrgencyScore(sm2State, time.Now())
-	result.ComponentScores.UrgencyScore = urgencyScore
+	urgencyScore := usa.calculateUrgencyScore(sm2State, currentTime)
 
 	// Calculate BKT mastery gap component
 	masteryGapScore := usa.calculateMasteryGapScore(candidate.Topics, bktStates)
+
+	// Shift emphasis toward retention and weak, high-weight topics as the exam approaches
+	if sessionContext.ExamDate != nil {
+		pressure := examPressure(currentTime, *sessionContext.ExamDate, usa.ExamHorizonDays)
+		if pressure > 0 {
+			weights = usa.AdjustWeightsForExam(weights, pressure)
+
+			examBoost := usa.calculateExamBoost(sm2State, *sessionContext.ExamDate, pressure)
+			result.ComponentScores.ExamBoost = examBoost
+			urgencyScore = math.Min(1.0, urgencyScore+examBoost)
+
+			topicWeight := usa.calculateExamTopicWeight(candidate.Topics, sessionContext.ExamTopicWeights)
+			masteryGapScore = math.Max(0.0, math.Min(1.0, masteryGapScore*(1.0+pressure*(topicWeight-1.0))))
+		}
+	}
+
+	// Close gaps in topics the user has set goals for first
+	if goalWeight := calculateGoalTopicWeight(candidate.Topics, sessionContext.GoalTopicWeights); goalWeight > 1.0 {
+		masteryGapScore = math.Min(1.0, masteryGapScore*goalWeight)
+	}
+
+	result.ComponentScores.UrgencyScore = urgencyScore
 	result.ComponentScores.MasteryGapScore = masteryGapScore
 
 	// Calculate IRT difficulty matching component
@@ -310,10 +376,10 @@ func (usa *UnifiedScoringAlgorithm) ComputeUnifiedScore(
 	result.ComponentScores.VarietyBonus = varietyBonus
 
 	// Compute weighted unified score
-	result.UnifiedScore = scoringStrategy.Weights.Urgency*urgencyScore +
-		scoringStrategy.Weights.Mastery*masteryGapScore +
-		scoringStrategy.Weights.Difficulty*difficultyScore +
-		scoringStrategy.Weights.Exploration*explorationScore +
+	result.UnifiedScore = weights.Urgency*urgencyScore +
+		weights.Mastery*masteryGapScore +
+		weights.Difficulty*difficultyScore +
+		weights.Exploration*explorationScore +
 		noveltyBonus + varietyBonus
 
 	// Ensure score is within bounds [0, 1]
@@ -333,6 +399,7 @@ func (usa *UnifiedScoringAlgorithm) ComputeUnifiedScore(
 			"exploration":   explorationScore,
 			"novelty_bonus": noveltyBonus,
 			"variety_bonus": varietyBonus,
+			"exam_boost":    result.ComponentScores.ExamBoost,
 		}).Debug("Computed unified score")
 	}
 
@@ -374,6 +441,87 @@ func (usa *UnifiedScoringAlgorithm) calculateRetentionProbability(sm2State *SM2S
 	return math.Max(0.0, math.Min(1.0, retention))
 }
 
+// AdjustWeightsForExam moves weight from difficulty matching and exploration to urgency
+// and mastery in proportion to exam pressure. The total weight is preserved.
+func (usa *UnifiedScoringAlgorithm) AdjustWeightsForExam(weights ScoringWeights, pressure float64) ScoringWeights {
+	pressure = math.Max(0.0, math.Min(1.0, pressure))
+	shift := usa.ExamWeightShift * pressure
+
+	movedDifficulty := weights.Difficulty * shift
+	movedExploration := weights.Exploration * shift
+	moved := movedDifficulty + movedExploration
+
+	adjusted := ScoringWeights{
+		Urgency:     weights.Urgency,
+		Mastery:     weights.Mastery,
+		Difficulty:  weights.Difficulty - movedDifficulty,
+		Exploration: weights.Exploration - movedExploration,
+	}
+
+	// Split moved weight between urgency and mastery according to their current ratio
+	base := weights.Urgency + weights.Mastery
+	if base > 0 {
+		adjusted.Urgency += moved * weights.Urgency / base
+		adjusted.Mastery += moved * weights.Mastery / base
+	} else {
+		adjusted.Urgency += moved / 2.0
+		adjusted.Mastery += moved / 2.0
+	}
+
+	return adjusted
+}
+
+// calculateExamBoost raises urgency for items whose retention would be low on the exam day
+func (usa *UnifiedScoringAlgorithm) calculateExamBoost(sm2State *SM2State, examDate time.Time, pressure float64) float64 {
+	if sm2State == nil {
+		return 0.0
+	}
+
+	retentionAtExam := usa.calculateRetentionProbability(sm2State, examDate)
+
+	return pressure * (1.0 - retentionAtExam)
+}
+
+// calculateExamTopicWeight returns the average exam weight of the candidate's topics
+// relative to the mean topic weight, so 1.0 means an average-weight topic
+func (usa *UnifiedScoringAlgorithm) calculateExamTopicWeight(topics []string, topicWeights map[string]float64) float64 {
+	if len(topics) == 0 || len(topicWeights) == 0 {
+		return 1.0
+	}
+
+	meanWeight := 0.0
+	for _, weight := range topicWeights {
+		meanWeight += weight
+	}
+	meanWeight /= float64(len(topicWeights))
+	if meanWeight <= 0 {
+		return 1.0
+	}
+
+	total := 0.0
+	for _, topic := range topics {
+		if weight, exists := topicWeights[topic]; exists {
+			total += weight / meanWeight
+		} else {
+			total += 1.0
+		}
+	}
+
+	return total / float64(len(topics))
+}
+
+// calculateGoalTopicWeight returns the largest goal weight among the candidate's topics,
+// since practicing any one goal topic moves that goal forward
+func calculateGoalTopicWeight(topics []string, goalWeights map[string]float64) float64 {
+	weight := 1.0
+	for _, topic := range topics {
+		if goalWeight, exists := goalWeights[topic]; exists && goalWeight > weight {
+			weight = goalWeight
+		}
+	}
+	return weight
+}
+
 // calculateMasteryGapScore computes BKT mastery gap component
 func (usa *UnifiedScoringAlgorithm) calculateMasteryGapScore(topics []string, bktStates map[string]*BKTState) float64 {
 	if len(topics) == 0 {
@@ -571,6 +719,17 @@ func (usa *UnifiedScoringAlgorithm) checkSessionConstraints(
 		}
 	}
 
+	// Check sibling constraint: a rewording of a recent item is a near-duplicate
+	if candidate.SiblingGroup != "" {
+		for _, recentGroup := range sessionContext.RecentSiblings {
+			if recentGroup == candidate.SiblingGroup {
+				constraints.SiblingsOK = false
+				constraints.ConstraintViolation = "sibling of an item attempted recently"
+				return false
+			}
+		}
+	}
+
 	// Check topic interleaving constraint
 	if len(sessionContext.TopicsPracticed) > 0 && len(candidate.Topics) > 0 {
 		recentTopicCount := usa.countRecentTopicOccurrences(candidate.Topics[0], sessionContext.TopicsPracticed)
@@ -672,6 +831,11 @@ func (usa *UnifiedScoringAlgorithm) generateScoringReason(
 		reasons = append(reasons, "explores new content")
 	}
 
+	// Items at risk of being forgotten before the exam take precedence
+	if result.ComponentScores.ExamBoost > 0.2 {
+		reasons = append([]string{"needs review before exam"}, reasons...)
+	}
+
 	// Add bonus reasons
 	if result.ComponentScores.NoveltyBonus > 0.1 {
 		reasons = append(reasons, "novel content")
@@ -689,6 +853,9 @@ func (usa *UnifiedScoringAlgorithm) generateScoringReason(
 
 // GetScoringStrategy returns the specified scoring strategy
 func (usa *UnifiedScoringAlgorithm) GetScoringStrategy(strategyName string) (ScoringStrategy, bool) {
+	usa.mu.RLock()
+	defer usa.mu.RUnlock()
+
 	strategy, exists := usa.ScoringStrategies[strategyName]
 	return strategy, exists
 }
@@ -703,11 +870,15 @@ func (usa *UnifiedScoringAlgorithm) AddScoringStrategy(strategy ScoringStrategy)
 		return fmt.Errorf("strategy weights must sum to 1.0, got %.3f", totalWeight)
 	}
 
+	usa.mu.Lock()
+	defer usa.mu.Unlock()
+
 	usa.ScoringStrategies[strategy.Name] = strategy
 	return nil
 }
 
-// UpdateWeights updates the weights for the unified scoring algorithm
+// UpdateWeights updates the weights for the unified scoring algorithm, which are the
+// weights of the default strategy
 func (usa *UnifiedScoringAlgorithm) UpdateWeights(weights ScoringWeights) error {
 	totalWeight := weights.Urgency + weights.Mastery + weights.Difficulty + weights.Exploration
 
@@ -715,6 +886,14 @@ func (usa *UnifiedScoringAlgorithm) UpdateWeights(weights ScoringWeights) error
 		return fmt.Errorf("weights must sum to 1.0, got %.3f", totalWeight)
 	}
 
+	usa.mu.Lock()
+	defer usa.mu.Unlock()
+
+	if strategy, ok := usa.ScoringStrategies[usa.DefaultStrategy]; ok {
+		strategy.Weights = weights
+		usa.ScoringStrategies[usa.DefaultStrategy] = strategy
+	}
+
 	usa.WeightUrgency = weights.Urgency
 	usa.WeightMastery = weights.Mastery
 	usa.WeightDifficulty = weights.Difficulty
@@ -725,6 +904,9 @@ func (usa *UnifiedScoringAlgorithm) UpdateWeights(weights ScoringWeights) error
 
 // GetAnalytics returns analytics data for the unified scoring algorithm
 func (usa *UnifiedScoringAlgorithm) GetAnalytics() map[string]interface{} {
+	usa.mu.RLock()
+	defer usa.mu.RUnlock()
+
 	return map[string]interface{}{
 		"weights": map[string]float64{
 			"urgency":     usa.WeightUrgency,
@@ -756,6 +938,9 @@ func (usa *UnifiedScoringAlgorithm) GetAnalytics() map[string]interface{} {
 
 // ValidateConfiguration validates the unified scoring algorithm configuration
 func (usa *UnifiedScoringAlgorithm) ValidateConfiguration() error {
+	usa.mu.RLock()
+	defer usa.mu.RUnlock()
+
 	// Check weights sum to 1.0
 	totalWeight := usa.WeightUrgency + usa.WeightMastery + usa.WeightDifficulty + usa.WeightExploration
 	if math.Abs(totalWeight-1.0) > 0.01 {
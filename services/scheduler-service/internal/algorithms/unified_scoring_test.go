@@ -334,6 +334,7 @@ func TestSessionConstraints(t *testing.T) {
 		ItemsCompleted:    5,
 		TopicsPracticed:   []string{"topic1", "topic1", "topic2"},
 		RecentItems:       []string{"item1", "item2", "item3"},
+		RecentSiblings:    []string{"stop_sign"},
 		TimeRemaining:     5 * time.Minute,
 		AverageDifficulty: 0.5,
 	}
@@ -374,6 +375,28 @@ func TestSessionConstraints(t *testing.T) {
 			shouldPass:  false,
 			description: "should fail recent items constraint",
 		},
+		{
+			name: "sibling of recent item",
+			candidate: &ItemCandidate{
+				ItemID:        "stop_sign_reworded",
+				Topics:        []string{"topic3"},
+				EstimatedTime: 2 * time.Minute,
+				SiblingGroup:  "stop_sign",
+			},
+			shouldPass:  false,
+			description: "should fail sibling constraint",
+		},
+		{
+			name: "unrelated sibling group",
+			candidate: &ItemCandidate{
+				ItemID:        "yield_sign",
+				Topics:        []string{"topic3"},
+				EstimatedTime: 2 * time.Minute,
+				SiblingGroup:  "yield_sign",
+			},
+			shouldPass:  true,
+			description: "should pass when no sibling was attempted recently",
+		},
 	}
 
 	for _, tt := range tests {
@@ -585,6 +608,147 @@ func TestGetUnifiedScoringAnalytics(t *testing.T) {
 	}
 }
 
+func TestAdjustWeightsForExam(t *testing.T) {
+	cfg := &config.LoggingConfig{Level: "debug", Format: "text"}
+	log := logger.New(cfg)
+	usa := NewUnifiedScoringAlgorithm(log)
+
+	base := usa.ScoringStrategies["balanced"].Weights
+
+	// No pressure leaves weights unchanged
+	unchanged := usa.AdjustWeightsForExam(base, 0.0)
+	if unchanged != base {
+		t.Errorf("Expected unchanged weights at zero pressure, got %+v", unchanged)
+	}
+
+	adjusted := usa.AdjustWeightsForExam(base, 1.0)
+
+	total := adjusted.Urgency + adjusted.Mastery + adjusted.Difficulty + adjusted.Exploration
+	if total < 0.99 || total > 1.01 {
+		t.Errorf("Expected adjusted weights to sum to 1.0, got %.3f", total)
+	}
+	if adjusted.Urgency <= base.Urgency || adjusted.Mastery <= base.Mastery {
+		t.Errorf("Expected urgency and mastery weights to increase, got %+v", adjusted)
+	}
+	if adjusted.Difficulty >= base.Difficulty || adjusted.Exploration >= base.Exploration {
+		t.Errorf("Expected difficulty and exploration weights to decrease, got %+v", adjusted)
+	}
+}
+
+func TestComputeUnifiedScore_ExamPreparation(t *testing.T) {
+	cfg := &config.LoggingConfig{Level: "debug", Format: "text"}
+	log := logger.New(cfg)
+	usa := NewUnifiedScoringAlgorithm(log)
+
+	if _, exists := usa.ScoringStrategies["exam_prep"]; !exists {
+		t.Fatal("Expected exam_prep strategy to be initialized")
+	}
+
+	now := time.Now()
+	examDate := now.AddDate(0, 0, 3)
+
+	sm2State := &SM2State{
+		EasinessFactor: 1.5,
+		Interval:       2,
+		Repetition:     2,
+		NextDue:        now.Add(24 * time.Hour),
+		LastReviewed:   now.Add(-24 * time.Hour),
+	}
+
+	bktStates := map[string]*BKTState{
+		"road_rules": {ProbKnowledge: 0.3, Confidence: 0.7},
+		"parking":    {ProbKnowledge: 0.3, Confidence: 0.7},
+	}
+
+	newContext := func(withExam bool) *SessionContext {
+		sessionContext := &SessionContext{
+			SessionID:       "exam_session",
+			SessionType:     "review",
+			TimeRemaining:   30 * time.Minute,
+			TargetItemCount: 10,
+			ExamTopicWeights: map[string]float64{
+				"road_rules": 0.6,
+				"parking":    0.1,
+			},
+		}
+		if withExam {
+			sessionContext.ExamDate = &examDate
+		}
+		return sessionContext
+	}
+
+	heavyTopic := &ItemCandidate{ItemID: "road_item", Topics: []string{"road_rules"}, Difficulty: 0.5, Discrimination: 1.0, EstimatedTime: 60 * time.Second}
+	lightTopic := &ItemCandidate{ItemID: "parking_item", Topics: []string{"parking"}, Difficulty: 0.5, Discrimination: 1.0, EstimatedTime: 60 * time.Second}
+
+	ctx := context.Background()
+	irtStates := map[string]*IRTState{}
+
+	withoutExam, err := usa.ComputeUnifiedScore(ctx, heavyTopic, sm2State, bktStates, irtStates, newContext(false), "exam_prep")
+	if err != nil {
+		t.Fatalf("ComputeUnifiedScore failed: %v", err)
+	}
+	heavyResult, err := usa.ComputeUnifiedScore(ctx, heavyTopic, sm2State, bktStates, irtStates, newContext(true), "exam_prep")
+	if err != nil {
+		t.Fatalf("ComputeUnifiedScore failed: %v", err)
+	}
+	lightResult, err := usa.ComputeUnifiedScore(ctx, lightTopic, sm2State, bktStates, irtStates, newContext(true), "exam_prep")
+	if err != nil {
+		t.Fatalf("ComputeUnifiedScore failed: %v", err)
+	}
+
+	if heavyResult.ComponentScores.ExamBoost <= 0 {
+		t.Error("Expected exam boost for low-retention item close to the exam")
+	}
+	if withoutExam.ComponentScores.ExamBoost != 0 {
+		t.Error("Expected no exam boost without an exam date")
+	}
+	if heavyResult.UnifiedScore <= withoutExam.UnifiedScore {
+		t.Errorf("Expected exam context to raise score: %.3f <= %.3f", heavyResult.UnifiedScore, withoutExam.UnifiedScore)
+	}
+	if heavyResult.UnifiedScore <= lightResult.UnifiedScore {
+		t.Errorf("Expected high-weight topic to score higher: %.3f <= %.3f", heavyResult.UnifiedScore, lightResult.UnifiedScore)
+	}
+}
+
+func TestComputeUnifiedScore_GoalTopics(t *testing.T) {
+	cfg := &config.LoggingConfig{Level: "debug", Format: "text"}
+	log := logger.New(cfg)
+	usa := NewUnifiedScoringAlgorithm(log)
+
+	bktStates := map[string]*BKTState{
+		"road_rules": {ProbKnowledge: 0.3, Confidence: 0.7},
+		"parking":    {ProbKnowledge: 0.3, Confidence: 0.7},
+	}
+	sessionContext := &SessionContext{
+		SessionID:        "goal_session",
+		SessionType:      "practice",
+		TimeRemaining:    30 * time.Minute,
+		TargetItemCount:  10,
+		GoalTopicWeights: map[string]float64{"parking": 1.5},
+	}
+
+	goalTopic := &ItemCandidate{ItemID: "parking_item", Topics: []string{"parking"}, Difficulty: 0.5, Discrimination: 1.0, EstimatedTime: 60 * time.Second}
+	otherTopic := &ItemCandidate{ItemID: "road_item", Topics: []string{"road_rules"}, Difficulty: 0.5, Discrimination: 1.0, EstimatedTime: 60 * time.Second}
+
+	ctx := context.Background()
+	goalResult, err := usa.ComputeUnifiedScore(ctx, goalTopic, nil, bktStates, nil, sessionContext, "balanced")
+	if err != nil {
+		t.Fatalf("ComputeUnifiedScore failed: %v", err)
+	}
+	otherResult, err := usa.ComputeUnifiedScore(ctx, otherTopic, nil, bktStates, nil, sessionContext, "balanced")
+	if err != nil {
+		t.Fatalf("ComputeUnifiedScore failed: %v", err)
+	}
+
+	if goalResult.ComponentScores.MasteryGapScore <= otherResult.ComponentScores.MasteryGapScore {
+		t.Errorf("Expected goal topic to have a larger mastery gap score: %.3f <= %.3f",
+			goalResult.ComponentScores.MasteryGapScore, otherResult.ComponentScores.MasteryGapScore)
+	}
+	if goalResult.ComponentScores.MasteryGapScore > 1.0 {
+		t.Errorf("Expected mastery gap score to stay within [0, 1], got %.3f", goalResult.ComponentScores.MasteryGapScore)
+	}
+}
+
 func BenchmarkComputeUnifiedScore(b *testing.B) {
 	cfg := &config.LoggingConfig{Level: "debug", Format: "text"}
 	log := logger.New(cfg)
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"scheduler-service/internal/config"
+)
+
+var (
+	// ErrInvalidToken is returned for malformed tokens or bad signatures
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is returned for tokens past their expiry or not yet valid
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// Claims holds the access token claims issued by the auth service
+type Claims struct {
+	Subject     string   `json:"sub"`
+	Email       string   `json:"email,omitempty"`
+	CountryCode string   `json:"countryCode,omitempty"`
+	TenantID    string   `json:"tenantId,omitempty"`
+	Issuer      string   `json:"iss,omitempty"`
+	Audience    Audience `json:"aud,omitempty"`
+	IssuedAt    int64    `json:"iat,omitempty"`
+	ExpiresAt   int64    `json:"exp,omitempty"`
+	NotBefore   int64    `json:"nbf,omitempty"`
+}
+
+// Audience is the "aud" claim, which may be a single string or a list
+type Audience []string
+
+// UnmarshalJSON accepts both forms of the claim
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Contains reports whether the audience includes the given one
+func (a Audience) Contains(audience string) bool {
+	for _, value := range a {
+		if value == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier validates access tokens signed with the shared auth service secret (HS256)
+// or with the public keys of a key set (RS256, ES256)
+type Verifier struct {
+	secret   []byte
+	keys     []signingKey
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// NewHMACVerifier creates a verifier for tokens signed with the given secret
+func NewHMACVerifier(secret string) *Verifier {
+	return &Verifier{
+		secret: []byte(secret),
+		leeway: 30 * time.Second,
+		now:    time.Now,
+	}
+}
+
+// NewVerifier creates a verifier for the keys, issuer and audience of the configuration.
+// Returns nil when no secret, key set or public key is configured.
+func NewVerifier(cfg *config.AuthConfig) (*Verifier, error) {
+	if cfg.JWTSecret == "" && cfg.JWKSFile == "" && cfg.JWTPublicKeyFile == "" {
+		return nil, nil
+	}
+
+	verifier := NewHMACVerifier(cfg.JWTSecret)
+	verifier.issuer = cfg.Issuer
+	verifier.audience = cfg.Audience
+
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWKS file %s: %w", cfg.JWKSFile, err)
+		}
+		verifier.keys = append(verifier.keys, keys...)
+	}
+
+	if cfg.JWTPublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT public key file: %w", err)
+		}
+		key, err := parsePublicKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT public key file %s: %w", cfg.JWTPublicKeyFile, err)
+		}
+		verifier.keys = append(verifier.keys, key)
+	}
+
+	return verifier, nil
+}
+
+// Verify checks the token signature and validity window and returns its claims
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if err := v.verifySignature(header.Alg, header.Kid, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	now := v.now()
+	if claims.ExpiresAt == 0 || now.After(time.Unix(claims.ExpiresAt, 0).Add(v.leeway)) {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Add(v.leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, ErrTokenExpired
+	}
+
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	if v.audience != "" && !claims.Audience.Contains(v.audience) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+
+	return &claims, nil
+}
+
+// verifySignature checks the signature with a key of the token's algorithm. Only the
+// algorithms we have keys for are accepted, never "none".
+func (v *Verifier) verifySignature(alg, kid, signingInput string, signature []byte) error {
+	switch alg {
+	case "HS256":
+		if len(v.secret) == 0 {
+			break
+		}
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+		return nil
+	case "RS256", "ES256":
+		digest := sha256.Sum256([]byte(signingInput))
+		candidates := 0
+		for _, key := range v.keys {
+			if !key.supports(alg) || (kid != "" && key.kid != "" && key.kid != kid) {
+				continue
+			}
+			candidates++
+			if key.verify(digest[:], signature) {
+				return nil
+			}
+		}
+		if candidates == 0 {
+			return fmt.Errorf("%w: no %s key for key ID %q", ErrInvalidToken, alg, kid)
+		}
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+	return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+}
+
+type claimsKey struct{}
+
+// ContextWithClaims returns a context carrying the authenticated claims
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the authenticated claims, if any
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signToken(t *testing.T, secret, alg string, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	verifier := NewHMACVerifier("secret")
+	verifier.now = func() time.Time { return now }
+
+	valid := map[string]interface{}{
+		"sub":         "user-1",
+		"countryCode": "US",
+		"iat":         now.Unix(),
+		"exp":         now.Add(15 * time.Minute).Unix(),
+	}
+
+	claims, err := verifier.Verify(signToken(t, "secret", "HS256", valid))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "US", claims.CountryCode)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"malformed", "not-a-token", ErrInvalidToken},
+		{"wrong secret", signToken(t, "other", "HS256", valid), ErrInvalidToken},
+		{"none algorithm", signToken(t, "secret", "none", valid), ErrInvalidToken},
+		{"missing subject", signToken(t, "secret", "HS256", map[string]interface{}{"exp": now.Add(time.Minute).Unix()}), ErrInvalidToken},
+		{"missing expiry", signToken(t, "secret", "HS256", map[string]interface{}{"sub": "user-1"}), ErrTokenExpired},
+		{"expired", signToken(t, "secret", "HS256", map[string]interface{}{"sub": "user-1", "exp": now.Add(-time.Minute).Unix()}), ErrTokenExpired},
+		{"not yet valid", signToken(t, "secret", "HS256", map[string]interface{}{"sub": "user-1", "exp": now.Add(time.Hour).Unix(), "nbf": now.Add(time.Minute).Unix()}), ErrTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestClaimsContext(t *testing.T) {
+	_, ok := ClaimsFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithClaims(context.Background(), &Claims{Subject: "user-1"})
+	claims, ok := ClaimsFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "user-1", claims.Subject)
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// signingKey is a public key tokens may be signed with, identified by its key ID
+type signingKey struct {
+	kid string
+	rsa *rsa.PublicKey
+	ec  *ecdsa.PublicKey
+}
+
+// supports reports whether the key verifies signatures of an algorithm
+func (k signingKey) supports(alg string) bool {
+	switch alg {
+	case "RS256":
+		return k.rsa != nil
+	case "ES256":
+		return k.ec != nil && k.ec.Curve == elliptic.P256()
+	}
+	return false
+}
+
+// verify checks a signature over a SHA-256 digest
+func (k signingKey) verify(digest, signature []byte) bool {
+	if k.rsa != nil {
+		return rsa.VerifyPKCS1v15(k.rsa, crypto.SHA256, digest, signature) == nil
+	}
+	if k.ec != nil {
+		// JWS encodes ECDSA signatures as the fixed-size concatenation r || s
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(k.ec, digest, r, s)
+	}
+	return false
+}
+
+// jsonWebKey is a key of a JSON Web Key Set
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS reads the signature keys of a JSON Web Key Set. Encryption keys and key
+// types other than RSA and EC are skipped.
+func parseJWKS(data []byte) ([]signingKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid key set: %w", err)
+	}
+
+	var keys []signingKey
+	for _, jwk := range set.Keys {
+		if jwk.Use == "enc" {
+			continue
+		}
+		switch jwk.Kty {
+		case "RSA":
+			key, err := parseRSAKey(jwk)
+			if err != nil {
+				return nil, fmt.Errorf("invalid RSA key %q: %w", jwk.Kid, err)
+			}
+			keys = append(keys, signingKey{kid: jwk.Kid, rsa: key})
+		case "EC":
+			key, err := parseECKey(jwk)
+			if err != nil {
+				return nil, fmt.Errorf("invalid EC key %q: %w", jwk.Kid, err)
+			}
+			keys = append(keys, signingKey{kid: jwk.Kid, ec: key})
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no RSA or EC signature keys")
+	}
+	return keys, nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid modulus")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+func parseECKey(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	if jwk.Crv != "P-256" {
+		return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, errors.New("invalid x coordinate")
+	}
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, errors.New("invalid y coordinate")
+	}
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, errors.New("point is not on the curve")
+	}
+	return key, nil
+}
+
+// parsePublicKeyPEM reads an RSA or P-256 EC public key in PKIX PEM form
+func parsePublicKeyPEM(data []byte) (signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return signingKey{}, errors.New("no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return signingKey{rsa: key}, nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return signingKey{}, errors.New("only P-256 EC keys are supported")
+		}
+		return signingKey{ec: key}, nil
+	}
+	return signingKey{}, fmt.Errorf("unsupported public key type %T", key)
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scheduler-service/internal/config"
+)
+
+// signAsymmetric signs a token with an RSA (RS256) or P-256 (ES256) private key
+func signAsymmetric(t *testing.T, key crypto.Signer, kid string, claims map[string]interface{}) string {
+	t.Helper()
+
+	alg := "RS256"
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		alg = "ES256"
+	}
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT", "kid": kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeJWKS(t *testing.T, rsaKey *rsa.PublicKey, ecKey *ecdsa.PublicKey) string {
+	t.Helper()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	set := map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encode(ecKey.X.Bytes()), "y": encode(ecKey.Y.Bytes())},
+		{"kty": "oct", "kid": "symmetric", "k": "c2VjcmV0"},
+	}}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestNewVerifier_JWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	verifier, err := NewVerifier(&config.AuthConfig{
+		JWKSFile: writeJWKS(t, &rsaKey.PublicKey, &ecKey.PublicKey),
+		Issuer:   "https://auth.example.com",
+		Audience: "scheduler",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, verifier)
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"sub": "user-1",
+		"iss": "https://auth.example.com",
+		"aud": []string{"scheduler", "content"},
+		"exp": now.Add(time.Minute).Unix(),
+	}
+
+	verified, err := verifier.Verify(signAsymmetric(t, rsaKey, "rsa-1", claims))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", verified.Subject)
+
+	_, err = verifier.Verify(signAsymmetric(t, ecKey, "ec-1", claims))
+	assert.NoError(t, err)
+
+	// Tokens without a key ID are checked against every key of their algorithm
+	_, err = verifier.Verify(signAsymmetric(t, rsaKey, "", claims))
+	assert.NoError(t, err)
+
+	wrongIssuer := map[string]interface{}{"sub": "user-1", "iss": "https://evil.example.com", "aud": "scheduler", "exp": now.Add(time.Minute).Unix()}
+	wrongAudience := map[string]interface{}{"sub": "user-1", "iss": "https://auth.example.com", "aud": "content", "exp": now.Add(time.Minute).Unix()}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"unknown signing key", signAsymmetric(t, otherKey, "rsa-1", claims)},
+		{"unknown key ID", signAsymmetric(t, rsaKey, "rsa-2", claims)},
+		{"HS256 without a secret", signToken(t, "secret", "HS256", claims)},
+		{"wrong issuer", signAsymmetric(t, rsaKey, "rsa-1", wrongIssuer)},
+		{"wrong audience", signAsymmetric(t, rsaKey, "rsa-1", wrongAudience)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(tt.token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestNewVerifier_PublicKeyFile(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	verifier, err := NewVerifier(&config.AuthConfig{JWTPublicKeyFile: path, JWTSecret: "secret"})
+	require.NoError(t, err)
+
+	claims := map[string]interface{}{"sub": "user-1", "exp": time.Now().Add(time.Minute).Unix()}
+	_, err = verifier.Verify(signAsymmetric(t, ecKey, "", claims))
+	assert.NoError(t, err)
+
+	// The shared secret is accepted alongside the public key
+	_, err = verifier.Verify(signToken(t, "secret", "HS256", claims))
+	assert.NoError(t, err)
+}
+
+func TestNewVerifier_Configuration(t *testing.T) {
+	verifier, err := NewVerifier(&config.AuthConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, verifier)
+
+	_, err = NewVerifier(&config.AuthConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`), 0o600))
+	_, err = NewVerifier(&config.AuthConfig{JWKSFile: path})
+	assert.Error(t, err, "a key set without signature keys is rejected")
+}
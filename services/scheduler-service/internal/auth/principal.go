@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"scheduler-service/internal/config"
+)
+
+// Scopes granted to service accounts
+const (
+	// ScopeLearnersRead allows reading the learning state of any user
+	ScopeLearnersRead = "learners:read"
+	// ScopeLearnersWrite allows changing the learning state of any user
+	ScopeLearnersWrite = "learners:write"
+	// ScopeLearnersErase allows deleting all learning state of any user
+	ScopeLearnersErase = "learners:erase"
+	// ScopeReportsRead allows reading aggregate reports across users
+	ScopeReportsRead = "reports:read"
+	// ScopeAdmin allows calling methods without a more specific policy
+	ScopeAdmin = "scheduler:admin"
+)
+
+var (
+	// ErrUnauthenticated is returned when a request carries no credentials
+	ErrUnauthenticated = errors.New("missing credentials")
+	// ErrUnknownServiceAccount is returned for client certificates of services without scopes
+	ErrUnknownServiceAccount = errors.New("unknown service account")
+)
+
+// Principal is the authenticated caller of a request: a learner acting on their own
+// learning state, or a service account acting on behalf of others within its scopes
+type Principal struct {
+	Subject string   // User ID of a learner, or the service account name
+	Service bool     // Whether the principal is a service account
+	Scopes  []string // Scopes of a service account
+	Tenant  string   // Tenant a learner's access token is bound to, if any
+}
+
+// HasScope reports whether a service account was granted a scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a context carrying the authenticated principal
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// Authenticator identifies the callers of gRPC requests from a bearer access token in
+// the "authorization" metadata or, for service-to-service calls, a verified client
+// certificate. Tokens always identify learners and certificates service accounts, so a
+// learner token can never carry service scopes. A token takes precedence, so services
+// forwarding a learner's token act as that learner.
+type Authenticator struct {
+	verifier        *Verifier
+	serviceAccounts map[string][]string
+}
+
+// NewAuthenticator creates an authenticator. The verifier may be nil when tokens are
+// not accepted.
+func NewAuthenticator(cfg *config.AuthConfig, verifier *Verifier) *Authenticator {
+	return &Authenticator{
+		verifier:        verifier,
+		serviceAccounts: cfg.ServiceAccounts,
+	}
+}
+
+// Authenticate returns the principal of a request
+func (a *Authenticator) Authenticate(ctx context.Context) (*Principal, error) {
+	if token, ok := bearerToken(ctx); ok {
+		if a.verifier == nil {
+			return nil, fmt.Errorf("%w: access tokens are not accepted", ErrInvalidToken)
+		}
+		claims, err := a.verifier.Verify(token)
+		if err != nil {
+			return nil, err
+		}
+		// Tokens only identify learners, even when their subject names a service account
+		return &Principal{Subject: claims.Subject, Tenant: claims.TenantID}, nil
+	}
+
+	if name, ok := clientCertificateName(ctx); ok {
+		// Certificates only identify services, never learners
+		scopes, ok := a.serviceAccounts[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownServiceAccount, name)
+		}
+		return &Principal{Subject: name, Service: true, Scopes: scopes}, nil
+	}
+
+	return nil, ErrUnauthenticated
+}
+
+// bearerToken returns the access token of the request's "authorization" metadata
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	for _, value := range md.Get("authorization") {
+		if token, found := strings.CutPrefix(value, "Bearer "); found && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// clientCertificateName returns the common name of the verified client certificate of
+// the request's connection, falling back to its first DNS name
+func clientCertificateName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return certificateName(tlsInfo.State.VerifiedChains[0][0])
+}
+
+func certificateName(cert *x509.Certificate) (string, bool) {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName, true
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0], true
+	}
+	return "", false
+}
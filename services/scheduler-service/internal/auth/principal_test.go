@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"scheduler-service/internal/config"
+)
+
+func newTestAuthenticator() *Authenticator {
+	cfg := &config.AuthConfig{
+		ServiceAccounts: map[string][]string{
+			"content-service": {ScopeReportsRead},
+			"gateway":         {ScopeLearnersRead, ScopeLearnersWrite},
+		},
+	}
+	return NewAuthenticator(cfg, NewHMACVerifier("secret"))
+}
+
+func withBearer(t *testing.T, subject string) context.Context {
+	token := signToken(t, "secret", "HS256", map[string]interface{}{
+		"sub": subject,
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func withClientCertificate(commonName string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	authenticator := newTestAuthenticator()
+
+	learner, err := authenticator.Authenticate(withBearer(t, "user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", learner.Subject)
+	assert.False(t, learner.Service)
+
+	// A token naming a service account still identifies a learner
+	impostor, err := authenticator.Authenticate(withBearer(t, "gateway"))
+	require.NoError(t, err)
+	assert.False(t, impostor.Service)
+	assert.Empty(t, impostor.Scopes)
+
+	service, err := authenticator.Authenticate(withClientCertificate("gateway"))
+	require.NoError(t, err)
+	assert.True(t, service.Service)
+	assert.True(t, service.HasScope(ScopeLearnersWrite))
+	assert.False(t, service.HasScope(ScopeReportsRead))
+
+	certified, err := authenticator.Authenticate(withClientCertificate("content-service"))
+	require.NoError(t, err)
+	assert.True(t, certified.Service)
+	assert.True(t, certified.HasScope(ScopeReportsRead))
+
+	_, err = authenticator.Authenticate(withClientCertificate("unknown-service"))
+	assert.ErrorIs(t, err, ErrUnknownServiceAccount)
+
+	_, err = authenticator.Authenticate(context.Background())
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	bad := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer not-a-token"))
+	_, err = authenticator.Authenticate(bad)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestAuthenticator_TenantClaim(t *testing.T) {
+	authenticator := newTestAuthenticator()
+	token := signToken(t, "secret", "HS256", map[string]interface{}{
+		"sub":      "user-1",
+		"tenantId": "school-1",
+		"exp":      time.Now().Add(time.Minute).Unix(),
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+
+	learner, err := authenticator.Authenticate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "school-1", learner.Tenant)
+
+	service, err := authenticator.Authenticate(withClientCertificate("gateway"))
+	require.NoError(t, err)
+	assert.Empty(t, service.Tenant)
+}
+
+func TestAuthenticator_TokenTakesPrecedence(t *testing.T) {
+	authenticator := newTestAuthenticator()
+
+	// A gateway with a client certificate forwarding a learner's token acts as the learner
+	ctx := withClientCertificate("gateway")
+	md, _ := metadata.FromIncomingContext(withBearer(t, "user-1"))
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	principal, err := authenticator.Authenticate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", principal.Subject)
+	assert.False(t, principal.Service)
+}
+
+func TestAuthenticator_WithoutVerifier(t *testing.T) {
+	authenticator := NewAuthenticator(&config.AuthConfig{}, nil)
+
+	_, err := authenticator.Authenticate(withBearer(t, "user-1"))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPrincipalContext(t *testing.T) {
+	_, ok := PrincipalFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithPrincipal(context.Background(), &Principal{Subject: "user-1"})
+	principal, ok := PrincipalFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "user-1", principal.Subject)
+}
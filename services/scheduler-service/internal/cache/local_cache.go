@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LocalCache is an in-process LRU cache of encoded values with a per-entry TTL.
+// Entries are indexed by user so that all of a user's state can be dropped at once.
+type LocalCache struct {
+	maxEntries int
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+	byUser  map[string]map[string]struct{}
+
+	now func() time.Time
+}
+
+type localEntry struct {
+	key       string
+	userID    string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLocalCache creates an LRU cache holding at most maxEntries values for up to ttl each
+func NewLocalCache(maxEntries int, ttl time.Duration) *LocalCache {
+	return &LocalCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		byUser:     make(map[string]map[string]struct{}),
+		now:        time.Now,
+	}
+}
+
+// SetTTL changes how long values stored from now on are kept
+func (c *LocalCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the value stored for key if present and not expired
+func (c *LocalCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*localEntry)
+	if c.now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores value for key, owned by userID, evicting the least recently used entry if full
+func (c *LocalCache) Set(userID, key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+
+	entry := &localEntry{
+		key:       key,
+		userID:    userID,
+		value:     value,
+		expiresAt: c.now().Add(c.ttl),
+	}
+	c.entries[key] = c.order.PushFront(entry)
+
+	keys, ok := c.byUser[userID]
+	if !ok {
+		keys = make(map[string]struct{})
+		c.byUser[userID] = keys
+	}
+	keys[key] = struct{}{}
+
+	for c.order.Len() > c.maxEntries {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Delete removes the given keys
+func (c *LocalCache) Delete(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.removeElement(elem)
+		}
+	}
+}
+
+// DeleteUser removes every entry owned by userID. Returns the number of entries removed.
+func (c *LocalCache) DeleteUser(userID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := c.byUser[userID]
+	removed := len(keys)
+	for key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.removeElement(elem)
+		}
+	}
+
+	return removed
+}
+
+// Clear removes all entries
+func (c *LocalCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+	c.byUser = make(map[string]map[string]struct{})
+}
+
+// Len returns the number of entries, including expired ones not yet evicted
+func (c *LocalCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// removeElement unlinks an entry from the LRU list and both indexes. Caller must hold mu.
+func (c *LocalCache) removeElement(elem *list.Element) {
+	entry := c.order.Remove(elem).(*localEntry)
+	delete(c.entries, entry.key)
+
+	if keys, ok := c.byUser[entry.userID]; ok {
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.byUser, entry.userID)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalCache_GetSet(t *testing.T) {
+	c := NewLocalCache(10, time.Minute)
+
+	_, ok := c.Get("sm2:u1:i1")
+	assert.False(t, ok)
+
+	c.Set("u1", "sm2:u1:i1", []byte(`{"interval":3}`))
+	value, ok := c.Get("sm2:u1:i1")
+	require.True(t, ok)
+	assert.Equal(t, `{"interval":3}`, string(value))
+
+	// Overwriting keeps a single entry
+	c.Set("u1", "sm2:u1:i1", []byte(`{"interval":6}`))
+	value, ok = c.Get("sm2:u1:i1")
+	require.True(t, ok)
+	assert.Equal(t, `{"interval":6}`, string(value))
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestLocalCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLocalCache(2, time.Minute)
+
+	c.Set("u1", "a", []byte("1"))
+	c.Set("u1", "b", []byte("2"))
+
+	// Touch "a" so "b" becomes the eviction candidate
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	c.Set("u2", "c", []byte("3"))
+
+	assert.Equal(t, 2, c.Len())
+	_, ok = c.Get("b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+}
+
+func TestLocalCache_ExpiresEntries(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewLocalCache(10, 30*time.Second)
+	c.now = func() time.Time { return now }
+
+	c.Set("u1", "a", []byte("1"))
+
+	now = now.Add(29 * time.Second)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(2 * time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len(), "expired entry should be removed on read")
+}
+
+func TestLocalCache_DeleteUser(t *testing.T) {
+	c := NewLocalCache(10, time.Minute)
+
+	c.Set("u1", "sm2:u1:i1", []byte("1"))
+	c.Set("u1", "bkt:u1:signs", []byte("2"))
+	c.Set("u2", "sm2:u2:i1", []byte("3"))
+
+	assert.Equal(t, 2, c.DeleteUser("u1"))
+	assert.Equal(t, 0, c.DeleteUser("u1"))
+
+	_, ok := c.Get("sm2:u1:i1")
+	assert.False(t, ok)
+	_, ok = c.Get("bkt:u1:signs")
+	assert.False(t, ok)
+	_, ok = c.Get("sm2:u2:i1")
+	assert.True(t, ok, "other users' entries should be kept")
+}
+
+func TestLocalCache_DeleteAndClear(t *testing.T) {
+	c := NewLocalCache(10, time.Minute)
+
+	c.Set("u1", "a", []byte("1"))
+	c.Set("u1", "b", []byte("2"))
+	c.Set("u2", "c", []byte("3"))
+
+	c.Delete("a", "missing")
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 2, c.Len())
+
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, 0, c.DeleteUser("u2"))
+}
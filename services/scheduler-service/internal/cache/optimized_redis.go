@@ -10,6 +10,7 @@ import (
 	"scheduler-service/internal/config"
 	"scheduler-service/internal/logger"
 	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/tracing"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -18,29 +19,30 @@ import (
 type OptimizedRedisClient struct {
 	client  redis.UniversalClient
 	config  *config.CachePerformanceConfig
-	logger  logger.Logger
-	metrics metrics.Metrics
-	
+	logger  *logger.Logger
+	metrics *metrics.Metrics
+
 	// Performance statistics
 	stats    *CacheStats
 	statsMux sync.RWMutex
-	
+
 	// Pipeline for batch operations
 	pipelineChan chan *PipelineOperation
 	pipelineWG   sync.WaitGroup
+	done         chan struct{}
 }
 
 // CacheStats tracks cache performance metrics
 type CacheStats struct {
-	Hits              int64
-	Misses            int64
-	Sets              int64
-	Deletes           int64
-	Errors            int64
-	TotalOperations   int64
-	AverageLatency    time.Duration
-	PipelineOps       int64
-	ConnectionErrors  int64
+	Hits             int64
+	Misses           int64
+	Sets             int64
+	Deletes          int64
+	Errors           int64
+	TotalOperations  int64
+	AverageLatency   time.Duration
+	PipelineOps      int64
+	ConnectionErrors int64
 }
 
 // PipelineOperation represents a batched Redis operation
@@ -52,14 +54,16 @@ type PipelineOperation struct {
 	Result    chan error
 }
 
-// NewOptimizedRedisClient creates a new optimized Redis client
-func NewOptimizedRedisClient(redisURL string, perfConfig *config.CachePerformanceConfig, logger logger.Logger, metrics metrics.Metrics) (*OptimizedRedisClient, error) {
+// NewOptimizedRedisClient creates a new optimized Redis client.
+// It shares the database of the regular client so both see the same keys.
+func NewOptimizedRedisClient(cfg *config.RedisConfig, perfConfig *config.CachePerformanceConfig, logger *logger.Logger, metrics *metrics.Metrics) (*OptimizedRedisClient, error) {
 	// Parse Redis URL and create options
-	opt, err := redis.ParseURL(redisURL)
+	opt, err := redis.ParseURL(cfg.URL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
 	}
-	
+	opt.DB = cfg.DB
+
 	// Configure connection pool for optimal performance
 	opt.PoolSize = perfConfig.PoolSize
 	opt.MinIdleConns = perfConfig.MinIdleConns
@@ -68,21 +72,21 @@ func NewOptimizedRedisClient(redisURL string, perfConfig *config.CachePerformanc
 	opt.IdleTimeout = perfConfig.IdleTimeout
 	opt.IdleCheckFrequency = perfConfig.IdleCheckFrequency
 	opt.MaxRetries = perfConfig.MaxRetries
-	opt.RetryDelayFunc = func(retry int, err error) time.Duration {
-		return perfConfig.RetryDelay * time.Duration(retry+1)
-	}
-	
+	opt.MinRetryBackoff = perfConfig.RetryDelay
+	opt.MaxRetryBackoff = perfConfig.RetryDelay * time.Duration(perfConfig.MaxRetries+1)
+
 	// Create Redis client
 	client := redis.NewClient(opt)
-	
+	client.AddHook(tracing.NewRedisHook())
+
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	
+
 	if err := client.Ping(ctx).Err(); err != nil {
 		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
 	}
-	
+
 	redisClient := &OptimizedRedisClient{
 		client:       client,
 		config:       perfConfig,
@@ -90,14 +94,16 @@ func NewOptimizedRedisClient(redisURL string, perfConfig *config.CachePerformanc
 		metrics:      metrics,
 		stats:        &CacheStats{},
 		pipelineChan: make(chan *PipelineOperation, perfConfig.PipelineSize),
+		done:         make(chan struct{}),
 	}
-	
+
 	// Start pipeline processor
+	redisClient.pipelineWG.Add(1)
 	go redisClient.processPipeline()
-	
+
 	// Start monitoring
 	go redisClient.monitorCache()
-	
+
 	logger.Info("Optimized Redis client initialized successfully")
 	return redisClient, nil
 }
@@ -105,12 +111,12 @@ func NewOptimizedRedisClient(redisURL string, perfConfig *config.CachePerformanc
 // Get retrieves a value from cache with performance monitoring
 func (r *OptimizedRedisClient) Get(ctx context.Context, key string) (string, error) {
 	start := time.Now()
-	
+
 	result, err := r.client.Get(ctx, key).Result()
-	
+
 	duration := time.Since(start)
 	r.recordOperation(duration)
-	
+
 	if err == redis.Nil {
 		r.recordMiss()
 		return "", ErrCacheMiss
@@ -119,9 +125,9 @@ func (r *OptimizedRedisClient) Get(ctx context.Context, key string) (string, err
 		r.logger.Errorf("Redis GET failed for key %s: %v", key, err)
 		return "", err
 	}
-	
+
 	r.recordHit()
-	r.metrics.RecordCacheLatency("get", duration.Seconds())
+	r.metrics.RecordCacheOperation("get", duration)
 	return result, nil
 }
 
@@ -131,36 +137,36 @@ func (r *OptimizedRedisClient) GetJSON(ctx context.Context, key string, dest int
 	if err != nil {
 		return err
 	}
-	
+
 	if err := json.Unmarshal([]byte(value), dest); err != nil {
 		r.recordError()
 		return fmt.Errorf("failed to unmarshal JSON for key %s: %w", key, err)
 	}
-	
+
 	return nil
 }
 
 // Set stores a value in cache with performance monitoring
 func (r *OptimizedRedisClient) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	start := time.Now()
-	
+
 	if ttl == 0 {
 		ttl = r.config.DefaultTTL
 	}
-	
+
 	err := r.client.Set(ctx, key, value, ttl).Err()
-	
+
 	duration := time.Since(start)
 	r.recordOperation(duration)
-	
+
 	if err != nil {
 		r.recordError()
 		r.logger.Errorf("Redis SET failed for key %s: %v", key, err)
 		return err
 	}
-	
+
 	r.recordSet()
-	r.metrics.RecordCacheLatency("set", duration.Seconds())
+	r.metrics.RecordCacheOperation("set", duration)
 	return nil
 }
 
@@ -171,45 +177,53 @@ func (r *OptimizedRedisClient) SetJSON(ctx context.Context, key string, value in
 		r.recordError()
 		return fmt.Errorf("failed to marshal JSON for key %s: %w", key, err)
 	}
-	
+
 	return r.Set(ctx, key, jsonData, ttl)
 }
 
-// Delete removes a value from cache
-func (r *OptimizedRedisClient) Delete(ctx context.Context, key string) error {
+// Delete removes values from cache
+func (r *OptimizedRedisClient) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
 	start := time.Now()
-	
-	err := r.client.Del(ctx, key).Err()
-	
+
+	err := r.client.Del(ctx, keys...).Err()
+
 	duration := time.Since(start)
 	r.recordOperation(duration)
-	
+
 	if err != nil {
 		r.recordError()
-		r.logger.Errorf("Redis DEL failed for key %s: %v", key, err)
+		r.logger.Errorf("Redis DEL failed for keys %v: %v", keys, err)
 		return err
 	}
-	
+
 	r.recordDelete()
-	r.metrics.RecordCacheLatency("delete", duration.Seconds())
+	r.metrics.RecordCacheOperation("delete", duration)
 	return nil
 }
 
 // MGet retrieves multiple values in a single operation
 func (r *OptimizedRedisClient) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
 	start := time.Now()
-	
+
 	result, err := r.client.MGet(ctx, keys...).Result()
-	
+
 	duration := time.Since(start)
 	r.recordOperation(duration)
-	
+
 	if err != nil {
 		r.recordError()
 		r.logger.Errorf("Redis MGET failed: %v", err)
 		return nil, err
 	}
-	
+
 	// Count hits and misses
 	for _, val := range result {
 		if val == nil {
@@ -218,47 +232,91 @@ func (r *OptimizedRedisClient) MGet(ctx context.Context, keys ...string) ([]inte
 			r.recordHit()
 		}
 	}
-	
-	r.metrics.RecordCacheLatency("mget", duration.Seconds())
+
+	r.metrics.RecordCacheOperation("mget", duration)
 	return result, nil
 }
 
+// MGetJSON retrieves multiple JSON values in a single operation.
+// newDest is called for every key that was found and must return a pointer to unmarshal into.
+// Returns the keys that were not found or could not be decoded.
+func (r *OptimizedRedisClient) MGetJSON(ctx context.Context, keys []string, newDest func(key string) interface{}) ([]string, error) {
+	values, err := r.MGet(ctx, keys...)
+	if err != nil {
+		return keys, err
+	}
+
+	var missing []string
+	for i, val := range values {
+		str, ok := val.(string)
+		if !ok {
+			missing = append(missing, keys[i])
+			continue
+		}
+		if err := json.Unmarshal([]byte(str), newDest(keys[i])); err != nil {
+			r.recordError()
+			missing = append(missing, keys[i])
+		}
+	}
+
+	return missing, nil
+}
+
 // MSet stores multiple values in a single operation
 func (r *OptimizedRedisClient) MSet(ctx context.Context, pairs map[string]interface{}, ttl time.Duration) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+
 	start := time.Now()
-	
+
+	if ttl == 0 {
+		ttl = r.config.DefaultTTL
+	}
+
 	pipe := r.client.Pipeline()
-	
 	for key, value := range pairs {
-		if ttl == 0 {
-			ttl = r.config.DefaultTTL
-		}
 		pipe.Set(ctx, key, value, ttl)
 	}
-	
+
 	_, err := pipe.Exec(ctx)
-	
+
 	duration := time.Since(start)
 	r.recordOperation(duration)
-	
+
 	if err != nil {
 		r.recordError()
 		r.logger.Errorf("Redis MSET failed: %v", err)
 		return err
 	}
-	
+
 	r.statsMux.Lock()
 	r.stats.Sets += int64(len(pairs))
 	r.statsMux.Unlock()
-	
-	r.metrics.RecordCacheLatency("mset", duration.Seconds())
+
+	r.metrics.RecordCacheOperation("mset", duration)
 	return nil
 }
 
+// MSetJSON marshals and stores multiple JSON values in a single operation
+func (r *OptimizedRedisClient) MSetJSON(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
+	pairs := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		jsonData, err := json.Marshal(value)
+		if err != nil {
+			r.recordError()
+			return fmt.Errorf("failed to marshal JSON for key %s: %w", key, err)
+		}
+		pairs[key] = jsonData
+	}
+
+	return r.MSet(ctx, pairs, ttl)
+}
+
 // SetAsync stores a value asynchronously using pipeline
 func (r *OptimizedRedisClient) SetAsync(key string, value interface{}, ttl time.Duration) <-chan error {
 	result := make(chan error, 1)
-	
+
 	op := &PipelineOperation{
 		Operation: "SET",
 		Key:       key,
@@ -266,7 +324,15 @@ func (r *OptimizedRedisClient) SetAsync(key string, value interface{}, ttl time.
 		TTL:       ttl,
 		Result:    result,
 	}
-	
+
+	select {
+	case <-r.done:
+		result <- fmt.Errorf("redis client closed")
+		close(result)
+		return result
+	default:
+	}
+
 	select {
 	case r.pipelineChan <- op:
 		return result
@@ -276,35 +342,64 @@ func (r *OptimizedRedisClient) SetAsync(key string, value interface{}, ttl time.
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 			result <- r.Set(ctx, key, value, ttl)
+			close(result)
 		}()
 		return result
 	}
 }
 
+// SetJSONAsync marshals a value and stores it asynchronously using pipeline
+func (r *OptimizedRedisClient) SetJSONAsync(key string, value interface{}, ttl time.Duration) <-chan error {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		r.recordError()
+		result := make(chan error, 1)
+		result <- fmt.Errorf("failed to marshal JSON for key %s: %w", key, err)
+		close(result)
+		return result
+	}
+
+	return r.SetAsync(key, jsonData, ttl)
+}
+
 // processPipeline processes batched operations for better performance
 func (r *OptimizedRedisClient) processPipeline() {
+	defer r.pipelineWG.Done()
+
 	ticker := time.NewTicker(r.config.PipelineTimeout)
 	defer ticker.Stop()
-	
+
 	var operations []*PipelineOperation
-	
+
 	for {
 		select {
 		case op := <-r.pipelineChan:
 			operations = append(operations, op)
-			
+
 			// Execute when batch is full
 			if len(operations) >= r.config.PipelineSize {
 				r.executePipeline(operations)
 				operations = operations[:0]
 			}
-			
+
 		case <-ticker.C:
 			// Execute pending operations on timeout
 			if len(operations) > 0 {
 				r.executePipeline(operations)
 				operations = operations[:0]
 			}
+
+		case <-r.done:
+			// Drain anything queued before shutdown
+			for {
+				select {
+				case op := <-r.pipelineChan:
+					operations = append(operations, op)
+				default:
+					r.executePipeline(operations)
+					return
+				}
+			}
 		}
 	}
 }
@@ -314,13 +409,13 @@ func (r *OptimizedRedisClient) executePipeline(operations []*PipelineOperation)
 	if len(operations) == 0 {
 		return
 	}
-	
+
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(context.Background(), r.config.PipelineTimeout)
 	defer cancel()
-	
+
 	pipe := r.client.Pipeline()
-	
+
 	for _, op := range operations {
 		switch op.Operation {
 		case "SET":
@@ -331,65 +426,62 @@ func (r *OptimizedRedisClient) executePipeline(operations []*PipelineOperation)
 			pipe.Set(ctx, op.Key, op.Value, ttl)
 		}
 	}
-	
+
 	_, err := pipe.Exec(ctx)
 	duration := time.Since(start)
-	
+
 	// Send results back to callers
 	for _, op := range operations {
 		op.Result <- err
 		close(op.Result)
 	}
-	
+
 	r.statsMux.Lock()
 	r.stats.PipelineOps += int64(len(operations))
 	r.statsMux.Unlock()
-	
+
 	if err != nil {
 		r.recordError()
 		r.logger.Errorf("Pipeline execution failed: %v", err)
 	} else {
 		r.logger.Debugf("Pipeline executed %d operations in %v", len(operations), duration)
 	}
-	
-	r.metrics.RecordCacheLatency("pipeline", duration.Seconds())
+
+	r.metrics.RecordCacheOperation("pipeline", duration)
 }
 
 // recordOperation records operation statistics
 func (r *OptimizedRedisClient) recordOperation(duration time.Duration) {
 	r.statsMux.Lock()
 	defer r.statsMux.Unlock()
-	
+
 	r.stats.TotalOperations++
-	r.stats.AverageLatency = time.Duration(
-		(int64(r.stats.AverageLatency)*r.stats.TotalOperations + int64(duration)) /
-		(r.stats.TotalOperations + 1),
-	)
+	r.stats.AverageLatency += (duration - r.stats.AverageLatency) / time.Duration(r.stats.TotalOperations)
 }
 
 // recordHit records cache hit statistics
 func (r *OptimizedRedisClient) recordHit() {
 	r.statsMux.Lock()
 	defer r.statsMux.Unlock()
-	
+
 	r.stats.Hits++
-	r.metrics.IncrementCacheHits()
+	r.metrics.RecordCacheHit("redis_optimized")
 }
 
 // recordMiss records cache miss statistics
 func (r *OptimizedRedisClient) recordMiss() {
 	r.statsMux.Lock()
 	defer r.statsMux.Unlock()
-	
+
 	r.stats.Misses++
-	r.metrics.IncrementCacheMisses()
+	r.metrics.RecordCacheMiss("redis_optimized")
 }
 
 // recordSet records cache set statistics
 func (r *OptimizedRedisClient) recordSet() {
 	r.statsMux.Lock()
 	defer r.statsMux.Unlock()
-	
+
 	r.stats.Sets++
 }
 
@@ -397,7 +489,7 @@ func (r *OptimizedRedisClient) recordSet() {
 func (r *OptimizedRedisClient) recordDelete() {
 	r.statsMux.Lock()
 	defer r.statsMux.Unlock()
-	
+
 	r.stats.Deletes++
 }
 
@@ -405,37 +497,40 @@ func (r *OptimizedRedisClient) recordDelete() {
 func (r *OptimizedRedisClient) recordError() {
 	r.statsMux.Lock()
 	defer r.statsMux.Unlock()
-	
+
 	r.stats.Errors++
-	r.metrics.IncrementCacheErrors()
+	r.metrics.RecordCacheError("redis_optimized")
 }
 
 // monitorCache monitors cache performance
 func (r *OptimizedRedisClient) monitorCache() {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
-	
-	for range ticker.C {
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		}
+
 		r.statsMux.RLock()
 		stats := *r.stats
 		r.statsMux.RUnlock()
-		
+
 		// Calculate hit ratio
 		totalRequests := stats.Hits + stats.Misses
 		var hitRatio float64
 		if totalRequests > 0 {
 			hitRatio = float64(stats.Hits) / float64(totalRequests)
 		}
-		
+
 		// Update metrics
-		r.metrics.SetGauge("cache_hit_ratio", hitRatio)
-		r.metrics.SetGauge("cache_total_operations", float64(stats.TotalOperations))
-		r.metrics.SetGauge("cache_average_latency_seconds", stats.AverageLatency.Seconds())
-		r.metrics.SetGauge("cache_pipeline_operations", float64(stats.PipelineOps))
-		
+		r.metrics.CacheHitRatio.Set(hitRatio)
+
 		// Log statistics
-		r.logger.Debugf("Cache Stats - Hits: %d, Misses: %d, Hit Ratio: %.2f%%, Avg Latency: %v",
-			stats.Hits, stats.Misses, hitRatio*100, stats.AverageLatency)
+		r.logger.Debugf("Cache Stats - Hits: %d, Misses: %d, Hit Ratio: %.2f%%, Avg Latency: %v, Pipeline Ops: %d",
+			stats.Hits, stats.Misses, hitRatio*100, stats.AverageLatency, stats.PipelineOps)
 	}
 }
 
@@ -443,7 +538,7 @@ func (r *OptimizedRedisClient) monitorCache() {
 func (r *OptimizedRedisClient) GetStats() *CacheStats {
 	r.statsMux.RLock()
 	defer r.statsMux.RUnlock()
-	
+
 	statsCopy := *r.stats
 	return &statsCopy
 }
@@ -452,28 +547,25 @@ func (r *OptimizedRedisClient) GetStats() *CacheStats {
 func (r *OptimizedRedisClient) HealthCheck(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	
+
 	if err := r.client.Ping(ctx).Err(); err != nil {
 		r.recordError()
 		return fmt.Errorf("Redis health check failed: %w", err)
 	}
-	
+
 	return nil
 }
 
-// Close closes the Redis client and cleans up resources
+// Close flushes pending pipelined writes, closes the Redis client and cleans up resources
 func (r *OptimizedRedisClient) Close() error {
-	close(r.pipelineChan)
+	close(r.done)
 	r.pipelineWG.Wait()
-	
+
 	if err := r.client.Close(); err != nil {
 		r.logger.Errorf("Error closing Redis client: %v", err)
 		return err
 	}
-	
+
 	r.logger.Info("Optimized Redis client closed successfully")
 	return nil
 }
-
-// ErrCacheMiss is returned when a key is not found in cache
-var ErrCacheMiss = fmt.Errorf("cache miss")
\ No newline at end of file
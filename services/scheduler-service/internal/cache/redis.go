@@ -4,20 +4,33 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"scheduler-service/internal/config"
 	"scheduler-service/internal/logger"
 	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/tracing"
 
 	"github.com/go-redis/redis/v8"
 )
 
+// defaultStateTTL is how long learner states are cached unless configured otherwise
+const defaultStateTTL = 30 * time.Minute
+
 // RedisClient wraps Redis client with additional functionality
 type RedisClient struct {
 	client  *redis.Client
 	metrics *metrics.Metrics
 	logger  *logger.Logger
+
+	// How long learner states are cached, in nanoseconds; changed at runtime by SetTTLs
+	stateTTL atomic.Int64
+
+	// Optional in-process tier for user-scoped reads
+	local            *LocalCache
+	instanceID       string
+	stopInvalidation context.CancelFunc
 }
 
 // CacheItem represents a cached item with TTL
@@ -38,6 +51,7 @@ func New(cfg *config.RedisConfig, metrics *metrics.Metrics, log *logger.Logger)
 	opt.PoolSize = cfg.PoolSize
 
 	client := redis.NewClient(opt)
+	client.AddHook(tracing.NewRedisHook())
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -49,15 +63,46 @@ func New(cfg *config.RedisConfig, metrics *metrics.Metrics, log *logger.Logger)
 
 	log.Info("Redis connection established successfully")
 
-	return &RedisClient{
+	redisClient := &RedisClient{
 		client:  client,
 		metrics: metrics,
 		logger:  log,
-	}, nil
+	}
+
+	redisClient.stateTTL.Store(int64(cfg.StateTTL))
+
+	if cfg.LocalCacheSize > 0 {
+		redisClient.EnableLocalCache(cfg.LocalCacheSize, cfg.LocalCacheTTL)
+	}
+
+	return redisClient, nil
+}
+
+// StateTTL is how long SM-2, BKT and IRT states and user settings stay cached
+func (r *RedisClient) StateTTL() time.Duration {
+	if r == nil {
+		return defaultStateTTL
+	}
+	if ttl := time.Duration(r.stateTTL.Load()); ttl > 0 {
+		return ttl
+	}
+	return defaultStateTTL
+}
+
+// SetTTLs changes how long learner states are cached in Redis and values are kept in
+// the in-process tier, for values cached from now on
+func (r *RedisClient) SetTTLs(stateTTL, localTTL time.Duration) {
+	r.stateTTL.Store(int64(stateTTL))
+	if r.local != nil {
+		r.local.SetTTL(localTTL)
+	}
 }
 
 // Close closes the Redis connection
 func (r *RedisClient) Close() error {
+	if r.stopInvalidation != nil {
+		r.stopInvalidation()
+	}
 	return r.client.Close()
 }
 
@@ -107,6 +152,10 @@ func (r *RedisClient) Delete(ctx context.Context, keys ...string) error {
 		return nil
 	}
 
+	if r.local != nil {
+		r.local.Delete(keys...)
+	}
+
 	err := r.client.Del(ctx, keys...).Err()
 	if err != nil {
 		return fmt.Errorf("failed to delete cache keys: %w", err)
@@ -115,6 +164,44 @@ func (r *RedisClient) Delete(ctx context.Context, keys ...string) error {
 	return nil
 }
 
+// ScanKeys returns the keys matching a glob pattern. It iterates with SCAN so large
+// keyspaces do not block Redis.
+func (r *RedisClient) ScanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iter := r.client.Scan(ctx, 0, pattern, 500).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan cache keys %s: %w", pattern, err)
+	}
+	return keys, nil
+}
+
+// DeletePattern removes the keys matching a glob pattern and returns how many were removed
+func (r *RedisClient) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	keys, err := r.ScanKeys(ctx, pattern)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.Delete(ctx, keys...); err != nil {
+		return 0, err
+	}
+	return len(keys), nil
+}
+
+// GetRaw retrieves the stored value of a key without unmarshaling it
+func (r *RedisClient) GetRaw(ctx context.Context, key string) ([]byte, error) {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrCacheMiss
+		}
+		return nil, fmt.Errorf("failed to get cache key %s: %w", key, err)
+	}
+	return data, nil
+}
+
 // Exists checks if a key exists in Redis
 func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
 	count, err := r.client.Exists(ctx, key).Result()
@@ -140,6 +227,61 @@ func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{},
 	return success, nil
 }
 
+// AcquireLock tries to take a distributed lock identified by key, owned by token
+func (r *RedisClient) AcquireLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	acquired, err := r.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+
+	return acquired, nil
+}
+
+// refreshLockScript extends the lock TTL only if it is still owned by the caller
+var refreshLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLockScript deletes the lock only if it is still owned by the caller
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RefreshLock extends a lock held by token. Returns false if the lock was lost.
+func (r *RedisClient) RefreshLock(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	result, err := refreshLockScript.Run(ctx, r.client, []string{key}, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to refresh lock %s: %w", key, err)
+	}
+
+	return result == 1, nil
+}
+
+// ReleaseLock releases a lock held by token
+func (r *RedisClient) ReleaseLock(ctx context.Context, key, token string) error {
+	if err := releaseLockScript.Run(ctx, r.client, []string{key}, token).Err(); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// RunScript runs a Lua script, loading it on the server on first use
+func (r *RedisClient) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	result, err := script.Run(ctx, r.client, keys, args...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run script: %w", err)
+	}
+
+	return result, nil
+}
+
 // Increment atomically increments a counter
 func (r *RedisClient) Increment(ctx context.Context, key string) (int64, error) {
 	val, err := r.client.Incr(ctx, key).Result()
@@ -150,6 +292,20 @@ func (r *RedisClient) Increment(ctx context.Context, key string) (int64, error)
 	return val, nil
 }
 
+// Publish marshals message to JSON and publishes it on a pub/sub channel
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if err := r.client.Publish(ctx, channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish to channel %s: %w", channel, err)
+	}
+
+	return nil
+}
+
 // HSet sets a field in a hash
 func (r *RedisClient) HSet(ctx context.Context, key, field string, value interface{}) error {
 	data, err := json.Marshal(value)
@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// InvalidationChannel is the Redis pub/sub channel used to drop a user's state
+// from the in-process tier of every replica
+const InvalidationChannel = "scheduler:cache:invalidate"
+
+// EnableLocalCache puts an in-process LRU tier in front of Redis for user-scoped
+// reads and starts listening for invalidations published by other replicas.
+// Local entries live for at most ttl, which bounds staleness if a message is lost.
+func (r *RedisClient) EnableLocalCache(maxEntries int, ttl time.Duration) {
+	r.local = NewLocalCache(maxEntries, ttl)
+	r.instanceID = newInstanceID()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.stopInvalidation = cancel
+
+	pubsub := r.client.Subscribe(ctx, InvalidationChannel)
+	go r.listenForInvalidations(ctx, pubsub)
+
+	r.logger.WithFields(map[string]interface{}{
+		"max_entries": maxEntries,
+		"ttl":         ttl.String(),
+	}).Info("In-process cache tier enabled")
+}
+
+// GetUser retrieves a user-scoped value, checking the in-process tier before Redis
+func (r *RedisClient) GetUser(ctx context.Context, userID, key string, dest interface{}) error {
+	if err := r.GetLocal(key, dest); err == nil {
+		return nil
+	}
+
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			r.metrics.RecordCacheMiss("redis")
+			return ErrCacheMiss
+		}
+		return fmt.Errorf("failed to get cache key %s: %w", key, err)
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("failed to unmarshal cached value: %w", err)
+	}
+
+	r.metrics.RecordCacheHit("redis")
+	if r.local != nil {
+		r.local.Set(userID, key, data)
+	}
+
+	return nil
+}
+
+// SetUser stores a user-scoped value in Redis and the in-process tier
+func (r *RedisClient) SetUser(ctx context.Context, userID, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	if err := r.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		r.metrics.RecordCacheMiss("redis")
+		return fmt.Errorf("failed to set cache key %s: %w", key, err)
+	}
+
+	if r.local != nil {
+		r.local.Set(userID, key, data)
+	}
+
+	return nil
+}
+
+// GetLocal retrieves a value from the in-process tier only
+func (r *RedisClient) GetLocal(key string, dest interface{}) error {
+	if r == nil || r.local == nil {
+		return ErrCacheMiss
+	}
+
+	data, ok := r.local.Get(key)
+	if !ok {
+		r.metrics.RecordCacheMiss("local")
+		return ErrCacheMiss
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		r.local.Delete(key)
+		return fmt.Errorf("failed to unmarshal local cached value: %w", err)
+	}
+
+	r.metrics.RecordCacheHit("local")
+	return nil
+}
+
+// SetLocal stores a user-scoped value in the in-process tier only, for values that
+// were read from Redis through another client
+func (r *RedisClient) SetLocal(userID, key string, value interface{}) {
+	if r == nil || r.local == nil {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	r.local.Set(userID, key, data)
+}
+
+// InvalidateUser drops a user's entries from the in-process tier and tells other
+// replicas to do the same. Call it after any change to the user's scheduler state.
+func (r *RedisClient) InvalidateUser(ctx context.Context, userID string) error {
+	if r.local == nil {
+		return nil
+	}
+
+	r.local.DeleteUser(userID)
+
+	if err := r.client.Publish(ctx, InvalidationChannel, r.instanceID+"|"+userID).Err(); err != nil {
+		return fmt.Errorf("failed to publish cache invalidation for user %s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// listenForInvalidations applies invalidations published by other replicas
+func (r *RedisClient) listenForInvalidations(ctx context.Context, pubsub *redis.PubSub) {
+	defer pubsub.Close()
+
+	subscribed := false
+	for msg := range pubsub.ChannelWithSubscriptions(ctx, 100) {
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			if m.Kind != "subscribe" {
+				continue
+			}
+			// A resubscribe means the connection dropped and invalidations may have been missed
+			if subscribed {
+				r.local.Clear()
+				r.logger.Warn("Cache invalidation channel reconnected, cleared in-process tier")
+			}
+			subscribed = true
+
+		case *redis.Message:
+			instanceID, userID, ok := strings.Cut(m.Payload, "|")
+			if !ok || instanceID == r.instanceID {
+				continue
+			}
+			r.local.DeleteUser(userID)
+		}
+	}
+}
+
+// newInstanceID identifies this replica so it can ignore its own invalidations
+func newInstanceID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
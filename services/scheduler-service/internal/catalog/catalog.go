@@ -0,0 +1,253 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/curriculum"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+)
+
+// Item is a published item with the metadata the scheduler selects and scores by
+type Item struct {
+	ID             string
+	Slug           string
+	Topics         []string
+	Jurisdictions  []string // Empty when the item is used in every jurisdiction
+	Difficulty     float64  // IRT difficulty
+	Discrimination float64
+	Guessing       float64
+	EstimatedTime  time.Duration
+	SiblingGroup   string // Items testing the same sign or rule in different wordings; empty when none
+}
+
+// AvailableIn reports whether the item is used in a jurisdiction. Users of the
+// default jurisdiction have no jurisdiction of their own and may see every item.
+func (i Item) AvailableIn(jurisdiction string) bool {
+	if len(i.Jurisdictions) == 0 || jurisdiction == curriculum.DefaultJurisdiction {
+		return true
+	}
+	for _, itemJurisdiction := range i.Jurisdictions {
+		if strings.EqualFold(itemJurisdiction, jurisdiction) {
+			return true
+		}
+	}
+	return false
+}
+
+// Catalog is an in-process snapshot of the published, active items of the shared items
+// table, reloaded once it is older than the refresh interval. Until a load succeeds the
+// catalog is empty, and callers keep their placeholder item metadata. A nil catalog is empty.
+type Catalog struct {
+	db              *database.DB
+	logger          *logger.Logger
+	refreshInterval time.Duration
+
+	mu       sync.RWMutex
+	items    map[string]Item
+	loadedAt time.Time
+
+	// Serializes reloads so concurrent requests share one query
+	refreshMu sync.Mutex
+}
+
+// NewCatalog creates an item catalog over the shared items table
+func NewCatalog(cfg *config.CatalogConfig, db *database.DB, logger *logger.Logger) *Catalog {
+	return &Catalog{
+		db:              db,
+		logger:          logger,
+		refreshInterval: cfg.RefreshInterval,
+	}
+}
+
+// itemRow is an items row as read by the catalog
+type itemRow struct {
+	ID             string
+	Slug           string
+	Topics         []byte
+	Jurisdictions  []byte
+	Difficulty     float64
+	Discrimination *float64
+	Guessing       *float64
+	EstimatedTime  *int
+	SiblingGroup   *string
+}
+
+// Refresh reloads the catalog when it is older than the refresh interval. Failed loads
+// are logged and retried after the next interval, keeping the previous snapshot.
+func (c *Catalog) Refresh(ctx context.Context) {
+	if c == nil || !c.stale() {
+		return
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if !c.stale() {
+		return
+	}
+
+	items, err := c.load(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Now()
+	if err != nil {
+		c.logger.WithContext(ctx).WithError(err).Warn("Failed to load item catalog, keeping previous snapshot")
+		return
+	}
+	c.items = items
+}
+
+// stale reports whether the snapshot is due for a reload
+func (c *Catalog) stale() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.loadedAt.IsZero() || time.Since(c.loadedAt) >= c.refreshInterval
+}
+
+// load reads all published, active items
+func (c *Catalog) load(ctx context.Context) (map[string]Item, error) {
+	var rows []itemRow
+	err := c.db.WithContext(ctx).Raw(
+		`SELECT id, slug, topics, jurisdictions, difficulty, discrimination, guessing, estimated_time, sibling_group
+		FROM items WHERE status = 'published' AND is_active IS NOT FALSE`).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load items: %w", err)
+	}
+
+	items := make(map[string]Item, len(rows))
+	for _, row := range rows {
+		item, err := toItem(row)
+		if err != nil {
+			return nil, err
+		}
+		items[item.ID] = item
+	}
+	return items, nil
+}
+
+// Lookup returns an item of the current snapshot without reloading it
+func (c *Catalog) Lookup(itemID string) (Item, bool) {
+	if c == nil {
+		return Item{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	item, ok := c.items[itemID]
+	return item, ok
+}
+
+// LookupSlug returns the item of the current snapshot with a slug
+func (c *Catalog) LookupSlug(slug string) (Item, bool) {
+	if c == nil || slug == "" {
+		return Item{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, item := range c.items {
+		if item.Slug == slug {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// Available reports whether an item may be scheduled in a jurisdiction. While the
+// catalog is empty every item is available; once loaded, items missing from it are
+// unpublished or inactive and are not.
+func (c *Catalog) Available(itemID, jurisdiction string) bool {
+	if c.Len() == 0 {
+		return true
+	}
+	item, ok := c.Lookup(itemID)
+	return ok && item.AvailableIn(jurisdiction)
+}
+
+// SiblingGroup returns the sibling group of an item, or "" when the item has no
+// siblings or is missing from the snapshot
+func (c *Catalog) SiblingGroup(itemID string) string {
+	item, ok := c.Lookup(itemID)
+	if !ok {
+		return ""
+	}
+	return item.SiblingGroup
+}
+
+// Siblings returns the other items of an item's sibling group, easiest first
+func (c *Catalog) Siblings(itemID string) []Item {
+	item, ok := c.Lookup(itemID)
+	if !ok || item.SiblingGroup == "" {
+		return nil
+	}
+
+	c.mu.RLock()
+	var siblings []Item
+	for _, other := range c.items {
+		if other.SiblingGroup == item.SiblingGroup && other.ID != itemID {
+			siblings = append(siblings, other)
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(siblings, func(i, j int) bool {
+		if siblings[i].Difficulty != siblings[j].Difficulty {
+			return siblings[i].Difficulty < siblings[j].Difficulty
+		}
+		return siblings[i].ID < siblings[j].ID
+	})
+	return siblings
+}
+
+// Len returns the number of items in the current snapshot
+func (c *Catalog) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.items)
+}
+
+func toItem(row itemRow) (Item, error) {
+	item := Item{
+		ID:             row.ID,
+		Slug:           row.Slug,
+		Difficulty:     row.Difficulty,
+		Discrimination: 1.0,
+		Guessing:       0.25,
+		EstimatedTime:  60 * time.Second,
+	}
+	if row.Discrimination != nil {
+		item.Discrimination = *row.Discrimination
+	}
+	if row.Guessing != nil {
+		item.Guessing = *row.Guessing
+	}
+	if row.EstimatedTime != nil {
+		item.EstimatedTime = time.Duration(*row.EstimatedTime) * time.Second
+	}
+	if row.SiblingGroup != nil {
+		item.SiblingGroup = *row.SiblingGroup
+	}
+	if err := unmarshalList(row.Topics, &item.Topics); err != nil {
+		return Item{}, fmt.Errorf("failed to unmarshal topics of item %s: %w", row.ID, err)
+	}
+	if err := unmarshalList(row.Jurisdictions, &item.Jurisdictions); err != nil {
+		return Item{}, fmt.Errorf("failed to unmarshal jurisdictions of item %s: %w", row.ID, err)
+	}
+	return item, nil
+}
+
+func unmarshalList(data []byte, list *[]string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, list)
+}
@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_AvailableIn(t *testing.T) {
+	everywhere := Item{ID: "a"}
+	britishOnly := Item{ID: "b", Jurisdictions: []string{"GB"}}
+
+	assert.True(t, everywhere.AvailableIn("US"))
+	assert.True(t, britishOnly.AvailableIn("GB"))
+	assert.True(t, britishOnly.AvailableIn("gb"))
+	assert.False(t, britishOnly.AvailableIn("US"))
+
+	// Users without a jurisdiction see every item
+	assert.True(t, britishOnly.AvailableIn("default"))
+}
+
+func TestCatalog_Available(t *testing.T) {
+	c := &Catalog{}
+
+	// An empty catalog does not filter
+	assert.True(t, c.Available("anything", "US"))
+
+	c.items = map[string]Item{
+		"shared":  {ID: "shared"},
+		"british": {ID: "british", Jurisdictions: []string{"GB"}},
+	}
+	assert.True(t, c.Available("shared", "US"))
+	assert.False(t, c.Available("british", "US"))
+	assert.True(t, c.Available("british", "GB"))
+	assert.False(t, c.Available("unpublished", "GB"))
+
+	assert.Equal(t, "", c.SiblingGroup("shared"))
+
+	c.items["shared"] = Item{ID: "shared", Slug: "shared-slug"}
+	item, ok := c.LookupSlug("shared-slug")
+	assert.True(t, ok)
+	assert.Equal(t, "shared", item.ID)
+	_, ok = c.LookupSlug("")
+	assert.False(t, ok)
+
+	var empty *Catalog
+	assert.True(t, empty.Available("anything", "US"))
+	assert.Equal(t, "", empty.SiblingGroup("anything"))
+	_, ok = empty.Lookup("anything")
+	assert.False(t, ok)
+	_, ok = empty.LookupSlug("anything")
+	assert.False(t, ok)
+}
+
+func TestToItem(t *testing.T) {
+	discrimination := 1.4
+	estimatedTime := 45
+	siblingGroup := "stop_sign"
+
+	item, err := toItem(itemRow{
+		ID:             "item-1",
+		Slug:           "stop-sign-meaning",
+		Topics:         []byte(`["traffic_signs","road_rules"]`),
+		Jurisdictions:  []byte(`[]`),
+		Difficulty:     -0.5,
+		Discrimination: &discrimination,
+		EstimatedTime:  &estimatedTime,
+		SiblingGroup:   &siblingGroup,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"traffic_signs", "road_rules"}, item.Topics)
+	assert.Empty(t, item.Jurisdictions)
+	assert.Equal(t, -0.5, item.Difficulty)
+	assert.Equal(t, 1.4, item.Discrimination)
+	assert.Equal(t, 0.25, item.Guessing)
+	assert.Equal(t, 45*time.Second, item.EstimatedTime)
+	assert.Equal(t, "stop_sign", item.SiblingGroup)
+	assert.Equal(t, "stop-sign-meaning", item.Slug)
+
+	_, err = toItem(itemRow{ID: "item-2", Topics: []byte(`{"bad":true}`)})
+	assert.Error(t, err)
+}
+
+func TestCatalog_Siblings(t *testing.T) {
+	c := &Catalog{items: map[string]Item{
+		"stop_hard":   {ID: "stop_hard", SiblingGroup: "stop_sign", Difficulty: 1.2},
+		"stop_easy":   {ID: "stop_easy", SiblingGroup: "stop_sign", Difficulty: -0.8},
+		"stop_medium": {ID: "stop_medium", SiblingGroup: "stop_sign", Difficulty: 0.1},
+		"yield":       {ID: "yield", SiblingGroup: "yield_sign"},
+		"standalone":  {ID: "standalone"},
+	}}
+
+	siblings := c.Siblings("stop_hard")
+	require.Len(t, siblings, 2)
+	assert.Equal(t, "stop_easy", siblings[0].ID)
+	assert.Equal(t, "stop_medium", siblings[1].ID)
+
+	assert.Empty(t, c.Siblings("yield"))
+	assert.Empty(t, c.Siblings("standalone"))
+	assert.Empty(t, c.Siblings("unknown"))
+}
@@ -0,0 +1,287 @@
+package cohort
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+)
+
+var (
+	// ErrNotRefreshed is returned for reports of a tenant whose summaries have not been refreshed yet
+	ErrNotRefreshed = errors.New("cohort summaries have not been refreshed yet")
+	// ErrInvalidPageToken is returned for page tokens not issued by a previous page
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// Cursor is the position after the last row of a page, in the order of the report
+type Cursor struct {
+	Value float64 `json:"v,omitempty"`
+	ID    string  `json:"id"`
+}
+
+// EncodeCursor encodes a cursor as an opaque page token
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a page token, returning nil for the first page
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidPageToken
+	}
+	return &cursor, nil
+}
+
+// Page is a request for one page of a cohort report
+type Page struct {
+	Size  int
+	Token string
+}
+
+// MasteryReport is the mastery heatmap of a tenant: every topic's distribution and
+// one page of learners' mastery by topic
+type MasteryReport struct {
+	Topics        []TopicSummary
+	Learners      []LearnerSummary
+	TotalLearners int
+	NextPageToken string
+	RefreshedAt   time.Time
+}
+
+// RiskReport is one page of a tenant's learners at risk, riskiest first
+type RiskReport struct {
+	Learners      []LearnerSummary
+	NextPageToken string
+	RefreshedAt   time.Time
+}
+
+// FailedItemsReport is one page of the items a tenant's learners failed, most failures first
+type FailedItemsReport struct {
+	Items         []ItemFailures
+	NextPageToken string
+	RefreshedAt   time.Time
+}
+
+// Service summarizes each tenant's learners from the state and attempts tables into
+// cohort summaries, refreshed in the background, and serves instructors' reports from
+// them a page at a time
+type Service struct {
+	logger     *logger.Logger
+	repository *Repository
+	thresholds Thresholds
+
+	activityWindow time.Duration
+	overdueAfter   time.Duration
+	riskThreshold  float64
+	pageSize       int
+	maxPageSize    int
+}
+
+// NewService creates a new cohort summary service. Topics are mastered from the BKT
+// mastery threshold.
+func NewService(cfg *config.CohortConfig, logger *logger.Logger, db *database.DB, masteryThreshold float64) *Service {
+	return &Service{
+		logger:     logger,
+		repository: NewRepository(db),
+		thresholds: Thresholds{
+			Mastered:      masteryThreshold,
+			Struggling:    cfg.StrugglingMastery,
+			InactiveAfter: cfg.InactiveAfter,
+		},
+		activityWindow: cfg.ActivityWindow,
+		overdueAfter:   cfg.OverdueAfter,
+		riskThreshold:  cfg.RiskThreshold,
+		pageSize:       cfg.PageSize,
+		maxPageSize:    cfg.MaxPageSize,
+	}
+}
+
+// RefreshAll refreshes the summaries of every active tenant and returns how many
+// tenants were refreshed
+func (s *Service) RefreshAll(ctx context.Context, now time.Time) (int, error) {
+	tenantIDs, err := s.repository.ActiveTenants(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	for _, tenantID := range tenantIDs {
+		if ctx.Err() != nil {
+			return refreshed, ctx.Err()
+		}
+		if err := s.Refresh(ctx, tenantID, now); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("tenant", tenantID).Warn("Failed to refresh cohort summaries")
+			continue
+		}
+		refreshed++
+	}
+
+	return refreshed, nil
+}
+
+// Refresh rebuilds a tenant's summaries from its learners' current state
+func (s *Service) Refresh(ctx context.Context, tenantID string, now time.Time) error {
+	activeSince := now.Add(-s.activityWindow)
+
+	stats, err := s.repository.LearnerStats(ctx, tenantID, now, now.Add(-s.overdueAfter), activeSince)
+	if err != nil {
+		return err
+	}
+
+	learners := make([]LearnerSummary, 0, len(stats))
+	atRisk := 0
+	for _, learner := range stats {
+		summary := Summarize(learner, s.thresholds, now)
+		if summary.RiskScore >= s.riskThreshold {
+			atRisk++
+		}
+		learners = append(learners, summary)
+	}
+	topics := SummarizeTopics(learners, s.thresholds)
+
+	if err := s.repository.ReplaceSummaries(ctx, tenantID, learners, topics, activeSince, now); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"tenant":   tenantID,
+		"learners": len(learners),
+		"topics":   len(topics),
+		"at_risk":  atRisk,
+	}).Info("Refreshed cohort summaries")
+
+	return nil
+}
+
+// Mastery returns a tenant's topic mastery and a page of its learners' mastery by
+// topic, ordered by user ID
+func (s *Service) Mastery(ctx context.Context, tenantID string, page Page) (*MasteryReport, error) {
+	cursor, err := DecodeCursor(page.Token)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := s.lastRefresh(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, err := s.repository.Topics(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	after := ""
+	if cursor != nil {
+		after = cursor.ID
+	}
+	size := s.size(page.Size)
+	learners, err := s.repository.LearnersAfter(ctx, tenantID, after, size+1)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MasteryReport{
+		Topics:        topics,
+		Learners:      learners,
+		TotalLearners: refresh.Learners,
+		RefreshedAt:   refresh.RefreshedAt,
+	}
+	if len(learners) > size {
+		report.Learners = learners[:size]
+		report.NextPageToken = EncodeCursor(Cursor{ID: learners[size-1].UserID})
+	}
+	return report, nil
+}
+
+// AtRisk returns a page of a tenant's learners with a risk score of at least minScore,
+// riskiest first. The configured risk threshold is used when minScore is not positive.
+func (s *Service) AtRisk(ctx context.Context, tenantID string, minScore float64, page Page) (*RiskReport, error) {
+	cursor, err := DecodeCursor(page.Token)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := s.lastRefresh(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if minScore <= 0 {
+		minScore = s.riskThreshold
+	}
+	size := s.size(page.Size)
+	learners, err := s.repository.LearnersAtRisk(ctx, tenantID, minScore, cursor, size+1)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RiskReport{Learners: learners, RefreshedAt: refresh.RefreshedAt}
+	if len(learners) > size {
+		last := learners[size-1]
+		report.Learners = learners[:size]
+		report.NextPageToken = EncodeCursor(Cursor{Value: last.RiskScore, ID: last.UserID})
+	}
+	return report, nil
+}
+
+// FailedItems returns a page of the items a tenant's learners failed within the
+// activity window, most failures first
+func (s *Service) FailedItems(ctx context.Context, tenantID string, page Page) (*FailedItemsReport, error) {
+	cursor, err := DecodeCursor(page.Token)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := s.lastRefresh(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	size := s.size(page.Size)
+	items, err := s.repository.FailedItems(ctx, tenantID, cursor, size+1)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FailedItemsReport{Items: items, RefreshedAt: refresh.RefreshedAt}
+	if len(items) > size {
+		last := items[size-1]
+		report.Items = items[:size]
+		report.NextPageToken = EncodeCursor(Cursor{Value: float64(last.Failures), ID: last.ItemID})
+	}
+	return report, nil
+}
+
+// lastRefresh returns when a tenant's summaries were last refreshed
+func (s *Service) lastRefresh(ctx context.Context, tenantID string) (*Refresh, error) {
+	refresh, err := s.repository.LastRefresh(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if refresh == nil {
+		return nil, fmt.Errorf("%w: tenant %s", ErrNotRefreshed, tenantID)
+	}
+	return refresh, nil
+}
+
+// size returns the page size to serve for a requested one
+func (s *Service) size(requested int) int {
+	if requested <= 0 {
+		return s.pageSize
+	}
+	return min(requested, s.maxPageSize)
+}
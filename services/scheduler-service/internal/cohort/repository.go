@@ -0,0 +1,439 @@
+package cohort
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// Repository reads tenants' learner state and stores their cohort summaries in the
+// cohort_learner_summaries, cohort_topic_mastery, cohort_item_failures and
+// cohort_refreshes tables
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new cohort summary repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Refresh records when a tenant's summaries were last refreshed
+type Refresh struct {
+	TenantID    string
+	Learners    int
+	RefreshedAt time.Time
+}
+
+// learnerStatsRow is a learner's aggregated state as read by LearnerStats
+type learnerStatsRow struct {
+	UserID         string
+	Jurisdiction   string
+	TopicMastery   []byte
+	ReviewItems    int
+	DueItems       int
+	OverdueItems   int
+	Lapses         int
+	RecentAttempts int
+	RecentCorrect  int
+	LastActiveAt   *time.Time
+	ExamDate       *time.Time
+}
+
+// learnerSummaryRow is a cohort_learner_summaries row
+type learnerSummaryRow struct {
+	TenantID       string     `gorm:"column:tenant_id"`
+	UserID         string     `gorm:"column:user_id"`
+	Jurisdiction   string     `gorm:"column:jurisdiction"`
+	TopicMastery   []byte     `gorm:"column:topic_mastery;type:jsonb"`
+	MeanMastery    float64    `gorm:"column:mean_mastery"`
+	TopicsStarted  int        `gorm:"column:topics_started"`
+	TopicsMastered int        `gorm:"column:topics_mastered"`
+	WeakestTopic   *string    `gorm:"column:weakest_topic"`
+	WeakestMastery *float64   `gorm:"column:weakest_mastery"`
+	ReviewItems    int        `gorm:"column:review_items"`
+	DueItems       int        `gorm:"column:due_items"`
+	OverdueItems   int        `gorm:"column:overdue_items"`
+	Lapses         int        `gorm:"column:lapses"`
+	RecentAttempts int        `gorm:"column:recent_attempts"`
+	RecentCorrect  int        `gorm:"column:recent_correct"`
+	LastActiveAt   *time.Time `gorm:"column:last_active_at"`
+	ExamDate       *time.Time `gorm:"column:exam_date"`
+	RiskScore      float64    `gorm:"column:risk_score"`
+	RiskReasons    []byte     `gorm:"column:risk_reasons;type:jsonb"`
+	RefreshedAt    time.Time  `gorm:"column:refreshed_at"`
+}
+
+// topicSummaryRow is a cohort_topic_mastery row
+type topicSummaryRow struct {
+	TenantID     string    `gorm:"column:tenant_id"`
+	Topic        string    `gorm:"column:topic"`
+	Learners     int       `gorm:"column:learners"`
+	MeanMastery  float64   `gorm:"column:mean_mastery"`
+	Mastered     int       `gorm:"column:mastered"`
+	Struggling   int       `gorm:"column:struggling"`
+	Distribution []byte    `gorm:"column:distribution;type:jsonb"`
+	RefreshedAt  time.Time `gorm:"column:refreshed_at"`
+}
+
+// ItemFailures is how often a tenant's learners failed an item within the activity window
+type ItemFailures struct {
+	ItemID         string
+	Attempts       int
+	Failures       int
+	FailureRate    float64
+	Learners       int
+	LearnersFailed int
+	LastFailedAt   *time.Time
+}
+
+// activeJurisdiction is the SQL expression of a user's normalized active jurisdiction,
+// matching state.NormalizeJurisdiction
+const activeJurisdiction = `CASE WHEN COALESCE(TRIM(s.country_code), '') = '' OR LOWER(TRIM(s.country_code)) = 'default'
+	THEN 'default' ELSE UPPER(TRIM(s.country_code)) END`
+
+// learnerSummaryColumns are the columns learner summaries are read with
+const learnerSummaryColumns = `tenant_id, user_id::text AS user_id, jurisdiction, topic_mastery, mean_mastery,
+	topics_started, topics_mastered, weakest_topic, weakest_mastery, review_items, due_items, overdue_items,
+	lapses, recent_attempts, recent_correct, last_active_at, exam_date, risk_score, risk_reasons, refreshed_at`
+
+// ActiveTenants returns the IDs of the active tenants
+func (r *Repository) ActiveTenants(ctx context.Context) ([]string, error) {
+	var tenantIDs []string
+	err := r.db.WithContext(ctx).Raw(`SELECT id FROM tenants WHERE is_active ORDER BY id`).Scan(&tenantIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	return tenantIDs, nil
+}
+
+// LearnerStats aggregates the state of every learner of a tenant: BKT mastery by topic
+// in their active jurisdiction, their SM-2 review backlog as of now, and their
+// attempts since activeSince. Learners are last active at their latest review or
+// attempt; BKT updates are not counted since the decay job makes them too.
+func (r *Repository) LearnerStats(ctx context.Context, tenantID string, now, overdueBefore, activeSince time.Time) ([]LearnerStats, error) {
+	var rows []learnerStatsRow
+	err := r.db.WithContext(ctx).Raw(`
+		WITH members AS (
+			SELECT m.user_id, `+activeJurisdiction+` AS jurisdiction, s.exam_date
+			FROM tenant_members m
+			LEFT JOIN user_scheduler_settings s ON s.user_id = m.user_id
+			WHERE m.tenant_id = ? AND m.role = 'learner'
+		),
+		mastery AS (
+			SELECT b.user_id, jsonb_object_agg(b.topic, b.prob_knowledge) AS topic_mastery
+			FROM bkt_states b
+			JOIN members mb ON mb.user_id = b.user_id AND mb.jurisdiction = b.jurisdiction
+			GROUP BY b.user_id
+		),
+		reviews AS (
+			SELECT r.user_id, COUNT(*) AS review_items,
+				COUNT(*) FILTER (WHERE r.next_due <= ?) AS due_items,
+				COUNT(*) FILTER (WHERE r.next_due <= ?) AS overdue_items,
+				COALESCE(SUM(r.lapses), 0) AS lapses,
+				MAX(r.last_reviewed) AS last_reviewed
+			FROM sm2_states r
+			JOIN members mb ON mb.user_id = r.user_id
+			GROUP BY r.user_id
+		),
+		recent AS (
+			SELECT a.user_id, COUNT(*) AS attempts, COUNT(*) FILTER (WHERE a.correct) AS correct,
+				MAX(COALESCE(a.timestamp, a.created_at)) AS last_attempt
+			FROM attempts a
+			JOIN members mb ON mb.user_id = a.user_id
+			WHERE a.created_at >= ?
+			GROUP BY a.user_id
+		)
+		SELECT mb.user_id::text AS user_id, mb.jurisdiction, ma.topic_mastery,
+			COALESCE(rv.review_items, 0) AS review_items, COALESCE(rv.due_items, 0) AS due_items,
+			COALESCE(rv.overdue_items, 0) AS overdue_items, COALESCE(rv.lapses, 0) AS lapses,
+			COALESCE(rc.attempts, 0) AS recent_attempts, COALESCE(rc.correct, 0) AS recent_correct,
+			GREATEST(rv.last_reviewed, rc.last_attempt) AS last_active_at,
+			mb.exam_date
+		FROM members mb
+		LEFT JOIN mastery ma ON ma.user_id = mb.user_id
+		LEFT JOIN reviews rv ON rv.user_id = mb.user_id
+		LEFT JOIN recent rc ON rc.user_id = mb.user_id
+		ORDER BY mb.user_id`, tenantID, now, overdueBefore, activeSince).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate learner state: %w", err)
+	}
+
+	stats := make([]LearnerStats, 0, len(rows))
+	for _, row := range rows {
+		learner := LearnerStats{
+			UserID:         row.UserID,
+			Jurisdiction:   row.Jurisdiction,
+			TopicMastery:   map[string]float64{},
+			ReviewItems:    row.ReviewItems,
+			DueItems:       row.DueItems,
+			OverdueItems:   row.OverdueItems,
+			Lapses:         row.Lapses,
+			RecentAttempts: row.RecentAttempts,
+			RecentCorrect:  row.RecentCorrect,
+			LastActiveAt:   row.LastActiveAt,
+			ExamDate:       row.ExamDate,
+		}
+		if len(row.TopicMastery) > 0 {
+			if err := json.Unmarshal(row.TopicMastery, &learner.TopicMastery); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal topic mastery of user %s: %w", row.UserID, err)
+			}
+		}
+		stats = append(stats, learner)
+	}
+	return stats, nil
+}
+
+// ReplaceSummaries replaces a tenant's summaries in one transaction, so reports read
+// either the previous refresh or this one. Item failures are aggregated from the
+// attempts its learners made since activeSince, on the tenant's items if it restricts them.
+func (r *Repository) ReplaceSummaries(ctx context.Context, tenantID string, learners []LearnerSummary, topics []TopicSummary, activeSince, refreshedAt time.Time) error {
+	learnerRows := make([]learnerSummaryRow, 0, len(learners))
+	for _, learner := range learners {
+		row, err := toLearnerSummaryRow(tenantID, learner, refreshedAt)
+		if err != nil {
+			return err
+		}
+		learnerRows = append(learnerRows, row)
+	}
+	topicRows := make([]topicSummaryRow, 0, len(topics))
+	for _, topic := range topics {
+		distribution, err := json.Marshal(topic.Distribution)
+		if err != nil {
+			return fmt.Errorf("failed to marshal distribution of topic %s: %w", topic.Topic, err)
+		}
+		topicRows = append(topicRows, topicSummaryRow{
+			TenantID:     tenantID,
+			Topic:        topic.Topic,
+			Learners:     topic.Learners,
+			MeanMastery:  topic.MeanMastery,
+			Mastered:     topic.Mastered,
+			Struggling:   topic.Struggling,
+			Distribution: distribution,
+			RefreshedAt:  refreshedAt,
+		})
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"cohort_learner_summaries", "cohort_topic_mastery", "cohort_item_failures"} {
+			if err := tx.Exec(`DELETE FROM `+table+` WHERE tenant_id = ?`, tenantID).Error; err != nil {
+				return fmt.Errorf("failed to clear %s: %w", table, err)
+			}
+		}
+
+		if len(learnerRows) > 0 {
+			if err := tx.Table("cohort_learner_summaries").CreateInBatches(learnerRows, 500).Error; err != nil {
+				return fmt.Errorf("failed to store learner summaries: %w", err)
+			}
+		}
+		if len(topicRows) > 0 {
+			if err := tx.Table("cohort_topic_mastery").CreateInBatches(topicRows, 500).Error; err != nil {
+				return fmt.Errorf("failed to store topic summaries: %w", err)
+			}
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO cohort_item_failures
+				(tenant_id, item_id, attempts, failures, failure_rate, learners, learners_failed, last_failed_at, refreshed_at)
+			SELECT ?, a.item_id::text, COUNT(*), COUNT(*) FILTER (WHERE NOT a.correct),
+				COUNT(*) FILTER (WHERE NOT a.correct)::float8 / COUNT(*),
+				COUNT(DISTINCT a.user_id), COUNT(DISTINCT a.user_id) FILTER (WHERE NOT a.correct),
+				MAX(COALESCE(a.timestamp, a.created_at)) FILTER (WHERE NOT a.correct), ?
+			FROM attempts a
+			JOIN tenant_members m ON m.user_id = a.user_id AND m.tenant_id = ? AND m.role = 'learner'
+			WHERE a.created_at >= ?
+				AND (NOT EXISTS (SELECT 1 FROM tenant_items ti WHERE ti.tenant_id = ?)
+					OR EXISTS (SELECT 1 FROM tenant_items ti WHERE ti.tenant_id = ? AND ti.item_id = a.item_id::text))
+			GROUP BY a.item_id
+			HAVING COUNT(*) FILTER (WHERE NOT a.correct) > 0`,
+			tenantID, refreshedAt, tenantID, activeSince, tenantID, tenantID).Error; err != nil {
+			return fmt.Errorf("failed to aggregate item failures: %w", err)
+		}
+
+		return tx.Exec(`
+			INSERT INTO cohort_refreshes (tenant_id, learners, refreshed_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (tenant_id) DO UPDATE SET learners = EXCLUDED.learners, refreshed_at = EXCLUDED.refreshed_at`,
+			tenantID, len(learners), refreshedAt).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace cohort summaries: %w", err)
+	}
+	return nil
+}
+
+// LastRefresh returns when a tenant's summaries were last refreshed, or nil if never
+func (r *Repository) LastRefresh(ctx context.Context, tenantID string) (*Refresh, error) {
+	var refreshes []Refresh
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT tenant_id, learners, refreshed_at FROM cohort_refreshes WHERE tenant_id = ?`, tenantID).
+		Scan(&refreshes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cohort refresh: %w", err)
+	}
+	if len(refreshes) == 0 {
+		return nil, nil
+	}
+	return &refreshes[0], nil
+}
+
+// Topics returns a tenant's topic summaries, sorted by topic
+func (r *Repository) Topics(ctx context.Context, tenantID string) ([]TopicSummary, error) {
+	var rows []topicSummaryRow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT tenant_id, topic, learners, mean_mastery, mastered, struggling, distribution, refreshed_at
+		FROM cohort_topic_mastery WHERE tenant_id = ?
+		ORDER BY topic`, tenantID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic summaries: %w", err)
+	}
+
+	topics := make([]TopicSummary, 0, len(rows))
+	for _, row := range rows {
+		topic := TopicSummary{
+			Topic:       row.Topic,
+			Learners:    row.Learners,
+			MeanMastery: row.MeanMastery,
+			Mastered:    row.Mastered,
+			Struggling:  row.Struggling,
+		}
+		if err := json.Unmarshal(row.Distribution, &topic.Distribution); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal distribution of topic %s: %w", row.Topic, err)
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
+// LearnersAfter returns up to limit learner summaries of a tenant, ordered by user ID
+// and starting after a user ID
+func (r *Repository) LearnersAfter(ctx context.Context, tenantID, afterUserID string, limit int) ([]LearnerSummary, error) {
+	query := r.db.WithContext(ctx).Table("cohort_learner_summaries").
+		Select(learnerSummaryColumns).
+		Where("tenant_id = ?", tenantID)
+	if afterUserID != "" {
+		query = query.Where("user_id::text > ?", afterUserID)
+	}
+
+	var rows []learnerSummaryRow
+	if err := query.Order("user_id").Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get learner summaries: %w", err)
+	}
+	return toLearnerSummaries(rows)
+}
+
+// LearnersAtRisk returns up to limit learner summaries of a tenant with a risk score of
+// at least minScore, riskiest first, starting after a score and user ID
+func (r *Repository) LearnersAtRisk(ctx context.Context, tenantID string, minScore float64, after *Cursor, limit int) ([]LearnerSummary, error) {
+	query := r.db.WithContext(ctx).Table("cohort_learner_summaries").
+		Select(learnerSummaryColumns).
+		Where("tenant_id = ? AND risk_score >= ?", tenantID, minScore)
+	if after != nil {
+		query = query.Where("(risk_score < ? OR (risk_score = ? AND user_id::text > ?))", after.Value, after.Value, after.ID)
+	}
+
+	var rows []learnerSummaryRow
+	if err := query.Order("risk_score DESC, user_id").Limit(limit).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get learners at risk: %w", err)
+	}
+	return toLearnerSummaries(rows)
+}
+
+// FailedItems returns up to limit of the items a tenant's learners failed, most
+// failures first, starting after a failure count and item ID
+func (r *Repository) FailedItems(ctx context.Context, tenantID string, after *Cursor, limit int) ([]ItemFailures, error) {
+	query := r.db.WithContext(ctx).Table("cohort_item_failures").
+		Select("item_id, attempts, failures, failure_rate, learners, learners_failed, last_failed_at").
+		Where("tenant_id = ?", tenantID)
+	if after != nil {
+		query = query.Where("(failures < ? OR (failures = ? AND item_id > ?))", int(after.Value), int(after.Value), after.ID)
+	}
+
+	var items []ItemFailures
+	if err := query.Order("failures DESC, item_id").Limit(limit).Scan(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to get failed items: %w", err)
+	}
+	return items, nil
+}
+
+func toLearnerSummaryRow(tenantID string, learner LearnerSummary, refreshedAt time.Time) (learnerSummaryRow, error) {
+	topicMastery, err := json.Marshal(learner.TopicMastery)
+	if err != nil {
+		return learnerSummaryRow{}, fmt.Errorf("failed to marshal topic mastery of user %s: %w", learner.UserID, err)
+	}
+	reasons, err := json.Marshal(learner.RiskReasons)
+	if err != nil {
+		return learnerSummaryRow{}, fmt.Errorf("failed to marshal risk reasons of user %s: %w", learner.UserID, err)
+	}
+
+	row := learnerSummaryRow{
+		TenantID:       tenantID,
+		UserID:         learner.UserID,
+		Jurisdiction:   learner.Jurisdiction,
+		TopicMastery:   topicMastery,
+		MeanMastery:    learner.MeanMastery,
+		TopicsStarted:  learner.TopicsStarted,
+		TopicsMastered: learner.TopicsMastered,
+		ReviewItems:    learner.ReviewItems,
+		DueItems:       learner.DueItems,
+		OverdueItems:   learner.OverdueItems,
+		Lapses:         learner.Lapses,
+		RecentAttempts: learner.RecentAttempts,
+		RecentCorrect:  learner.RecentCorrect,
+		LastActiveAt:   learner.LastActiveAt,
+		ExamDate:       learner.ExamDate,
+		RiskScore:      learner.RiskScore,
+		RiskReasons:    reasons,
+		RefreshedAt:    refreshedAt,
+	}
+	if learner.WeakestTopic != "" {
+		row.WeakestTopic = &learner.WeakestTopic
+		row.WeakestMastery = &learner.WeakestMastery
+	}
+	return row, nil
+}
+
+func toLearnerSummaries(rows []learnerSummaryRow) ([]LearnerSummary, error) {
+	learners := make([]LearnerSummary, 0, len(rows))
+	for _, row := range rows {
+		learner := LearnerSummary{
+			LearnerStats: LearnerStats{
+				UserID:         row.UserID,
+				Jurisdiction:   row.Jurisdiction,
+				ReviewItems:    row.ReviewItems,
+				DueItems:       row.DueItems,
+				OverdueItems:   row.OverdueItems,
+				Lapses:         row.Lapses,
+				RecentAttempts: row.RecentAttempts,
+				RecentCorrect:  row.RecentCorrect,
+				LastActiveAt:   row.LastActiveAt,
+				ExamDate:       row.ExamDate,
+			},
+			MeanMastery:    row.MeanMastery,
+			TopicsStarted:  row.TopicsStarted,
+			TopicsMastered: row.TopicsMastered,
+			RiskScore:      row.RiskScore,
+		}
+		if row.WeakestTopic != nil {
+			learner.WeakestTopic = *row.WeakestTopic
+		}
+		if row.WeakestMastery != nil {
+			learner.WeakestMastery = *row.WeakestMastery
+		}
+		if err := json.Unmarshal(row.TopicMastery, &learner.TopicMastery); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal topic mastery of user %s: %w", row.UserID, err)
+		}
+		if err := json.Unmarshal(row.RiskReasons, &learner.RiskReasons); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal risk reasons of user %s: %w", row.UserID, err)
+		}
+		learners = append(learners, learner)
+	}
+	return learners, nil
+}
@@ -0,0 +1,207 @@
+package cohort
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Reasons a learner is at risk
+const (
+	ReasonLowMastery     = "low_mastery"
+	ReasonInactive       = "inactive"
+	ReasonOverdueReviews = "overdue_reviews"
+	ReasonLowAccuracy    = "low_accuracy"
+	ReasonExamSoon       = "exam_soon"
+)
+
+// Weights of the risk factors; they sum to 1
+const (
+	masteryRiskWeight  = 0.35
+	activityRiskWeight = 0.25
+	overdueRiskWeight  = 0.20
+	accuracyRiskWeight = 0.20
+)
+
+const (
+	// overdueShareAtRisk is the share of overdue reviews from which a learner is behind
+	overdueShareAtRisk = 0.25
+	// minAttemptsForAccuracy is the number of recent attempts accuracy is judged on
+	minAttemptsForAccuracy = 10
+	// accuracyAtRisk is the recent accuracy below which a learner is at risk
+	accuracyAtRisk = 0.6
+	// examHorizon is how close an exam must be to raise a learner's risk
+	examHorizon = 14 * 24 * time.Hour
+	// examRiskFactor scales the risk of learners whose exam is near
+	examRiskFactor = 1.5
+	// masteryBands is the number of mastery bands of a topic's distribution
+	masteryBands = 5
+)
+
+// LearnerStats are the aggregates of one learner's state tables a summary is built from
+type LearnerStats struct {
+	UserID         string
+	Jurisdiction   string
+	TopicMastery   map[string]float64
+	ReviewItems    int
+	DueItems       int
+	OverdueItems   int
+	Lapses         int
+	RecentAttempts int
+	RecentCorrect  int
+	LastActiveAt   *time.Time
+	ExamDate       *time.Time
+}
+
+// LearnerSummary is the materialized summary of a learner of a tenant
+type LearnerSummary struct {
+	LearnerStats
+
+	MeanMastery    float64
+	TopicsStarted  int
+	TopicsMastered int
+	WeakestTopic   string
+	WeakestMastery float64
+	RiskScore      float64
+	RiskReasons    []string
+}
+
+// RecentAccuracy returns the share of recent attempts answered correctly, or 0 without any
+func (s LearnerStats) RecentAccuracy() float64 {
+	if s.RecentAttempts == 0 {
+		return 0
+	}
+	return float64(s.RecentCorrect) / float64(s.RecentAttempts)
+}
+
+// TopicSummary is the mastery distribution of a tenant's learners in one topic
+type TopicSummary struct {
+	Topic        string
+	Learners     int
+	MeanMastery  float64
+	Mastered     int
+	Struggling   int
+	Distribution []int // Learners per mastery band of 0.2, lowest first
+}
+
+// Thresholds are the mastery levels and time spans summaries are judged by
+type Thresholds struct {
+	Mastered      float64 // Mastery from which a topic is mastered
+	Struggling    float64 // Mastery below which a learner is struggling with a topic
+	InactiveAfter time.Duration
+}
+
+// Summarize builds a learner's summary and assesses their risk of falling behind.
+// Each factor contributes a severity between 0 and 1: mean mastery between the
+// struggling and mastered levels, time since the learner was last active, the share
+// of overdue reviews and recent accuracy. A near exam raises the score.
+func Summarize(stats LearnerStats, thresholds Thresholds, now time.Time) LearnerSummary {
+	summary := LearnerSummary{LearnerStats: stats, RiskReasons: []string{}}
+
+	for _, topic := range sortedTopics(stats.TopicMastery) {
+		mastery := stats.TopicMastery[topic]
+		summary.TopicsStarted++
+		summary.MeanMastery += mastery
+		if mastery >= thresholds.Mastered {
+			summary.TopicsMastered++
+		}
+		if summary.WeakestTopic == "" || mastery < summary.WeakestMastery {
+			summary.WeakestTopic = topic
+			summary.WeakestMastery = mastery
+		}
+	}
+	if summary.TopicsStarted > 0 {
+		summary.MeanMastery /= float64(summary.TopicsStarted)
+	}
+
+	score := 0.0
+
+	if summary.TopicsStarted > 0 && thresholds.Mastered > thresholds.Struggling {
+		severity := clamp01((thresholds.Mastered - summary.MeanMastery) / (thresholds.Mastered - thresholds.Struggling))
+		score += masteryRiskWeight * severity
+		if summary.MeanMastery < thresholds.Struggling {
+			summary.RiskReasons = append(summary.RiskReasons, ReasonLowMastery)
+		}
+	}
+
+	inactivity := 1.0
+	if stats.LastActiveAt != nil && thresholds.InactiveAfter > 0 {
+		idle := now.Sub(*stats.LastActiveAt)
+		inactivity = clamp01(idle.Hours() / (2 * thresholds.InactiveAfter.Hours()))
+	}
+	score += activityRiskWeight * inactivity
+	if inactivity >= 0.5 {
+		summary.RiskReasons = append(summary.RiskReasons, ReasonInactive)
+	}
+
+	if stats.ReviewItems > 0 {
+		share := float64(stats.OverdueItems) / float64(stats.ReviewItems)
+		score += overdueRiskWeight * clamp01(share/(2*overdueShareAtRisk))
+		if share >= overdueShareAtRisk {
+			summary.RiskReasons = append(summary.RiskReasons, ReasonOverdueReviews)
+		}
+	}
+
+	if stats.RecentAttempts >= minAttemptsForAccuracy {
+		accuracy := stats.RecentAccuracy()
+		score += accuracyRiskWeight * clamp01((0.8-accuracy)/0.4)
+		if accuracy < accuracyAtRisk {
+			summary.RiskReasons = append(summary.RiskReasons, ReasonLowAccuracy)
+		}
+	}
+
+	if stats.ExamDate != nil && stats.ExamDate.After(now) && stats.ExamDate.Sub(now) <= examHorizon &&
+		summary.TopicsMastered < summary.TopicsStarted {
+		score *= examRiskFactor
+		summary.RiskReasons = append(summary.RiskReasons, ReasonExamSoon)
+	}
+
+	summary.RiskScore = math.Round(clamp01(score)*10000) / 10000
+	return summary
+}
+
+// SummarizeTopics builds the mastery distribution of every topic practised by learners,
+// sorted by topic
+func SummarizeTopics(learners []LearnerSummary, thresholds Thresholds) []TopicSummary {
+	byTopic := make(map[string]*TopicSummary)
+	for _, learner := range learners {
+		for topic, mastery := range learner.TopicMastery {
+			summary, ok := byTopic[topic]
+			if !ok {
+				summary = &TopicSummary{Topic: topic, Distribution: make([]int, masteryBands)}
+				byTopic[topic] = summary
+			}
+			summary.Learners++
+			summary.MeanMastery += mastery
+			if mastery >= thresholds.Mastered {
+				summary.Mastered++
+			}
+			if mastery < thresholds.Struggling {
+				summary.Struggling++
+			}
+			band := min(int(mastery*masteryBands), masteryBands-1)
+			summary.Distribution[max(band, 0)]++
+		}
+	}
+
+	topics := make([]TopicSummary, 0, len(byTopic))
+	for _, summary := range byTopic {
+		summary.MeanMastery /= float64(summary.Learners)
+		topics = append(topics, *summary)
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Topic < topics[j].Topic })
+	return topics
+}
+
+func sortedTopics(mastery map[string]float64) []string {
+	topics := make([]string, 0, len(mastery))
+	for topic := range mastery {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+func clamp01(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
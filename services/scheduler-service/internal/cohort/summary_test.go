@@ -0,0 +1,118 @@
+package cohort
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testThresholds = Thresholds{Mastered: 0.85, Struggling: 0.4, InactiveAfter: 7 * 24 * time.Hour}
+
+func TestSummarize_OnTrackLearner(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	lastActive := now.Add(-24 * time.Hour)
+
+	summary := Summarize(LearnerStats{
+		UserID:         "user-1",
+		TopicMastery:   map[string]float64{"parking": 0.95, "traffic_signs": 0.9},
+		ReviewItems:    20,
+		RecentAttempts: 20,
+		RecentCorrect:  18,
+		LastActiveAt:   &lastActive,
+	}, testThresholds, now)
+
+	assert.Equal(t, 2, summary.TopicsStarted)
+	assert.Equal(t, 2, summary.TopicsMastered)
+	assert.InDelta(t, 0.925, summary.MeanMastery, 1e-9)
+	assert.Equal(t, "traffic_signs", summary.WeakestTopic)
+	assert.InDelta(t, 0.9, summary.WeakestMastery, 1e-9)
+	assert.Empty(t, summary.RiskReasons)
+	assert.Less(t, summary.RiskScore, 0.05)
+}
+
+func TestSummarize_LearnerAtRisk(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	stats := LearnerStats{
+		UserID:       "user-2",
+		TopicMastery: map[string]float64{"parking": 0.2, "traffic_signs": 0.3},
+		ReviewItems:  10,
+		OverdueItems: 5,
+	}
+
+	summary := Summarize(stats, testThresholds, now)
+	assert.Equal(t, "parking", summary.WeakestTopic)
+	assert.InDelta(t, 0.8, summary.RiskScore, 1e-9)
+	assert.Equal(t, []string{ReasonLowMastery, ReasonInactive, ReasonOverdueReviews}, summary.RiskReasons)
+
+	examDate := now.Add(7 * 24 * time.Hour)
+	stats.ExamDate = &examDate
+	summary = Summarize(stats, testThresholds, now)
+	assert.Equal(t, 1.0, summary.RiskScore)
+	assert.Contains(t, summary.RiskReasons, ReasonExamSoon)
+}
+
+func TestSummarize_LowAccuracy(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	lastActive := now.Add(-time.Hour)
+
+	summary := Summarize(LearnerStats{
+		UserID:         "user-3",
+		RecentAttempts: 10,
+		RecentCorrect:  4,
+		LastActiveAt:   &lastActive,
+	}, testThresholds, now)
+	assert.Equal(t, []string{ReasonLowAccuracy}, summary.RiskReasons)
+	assert.InDelta(t, 0.4, summary.RecentAccuracy(), 1e-9)
+
+	// Too few attempts to judge accuracy on
+	summary = Summarize(LearnerStats{UserID: "user-4", RecentAttempts: 3, LastActiveAt: &lastActive}, testThresholds, now)
+	assert.Empty(t, summary.RiskReasons)
+}
+
+func TestSummarizeTopics(t *testing.T) {
+	learners := []LearnerSummary{
+		{LearnerStats: LearnerStats{UserID: "user-1", TopicMastery: map[string]float64{"parking": 0.9, "traffic_signs": 0.1}}},
+		{LearnerStats: LearnerStats{UserID: "user-2", TopicMastery: map[string]float64{"parking": 0.5}}},
+		{LearnerStats: LearnerStats{UserID: "user-3", TopicMastery: map[string]float64{"parking": 1}}},
+	}
+
+	topics := SummarizeTopics(learners, testThresholds)
+	require.Len(t, topics, 2)
+
+	parking := topics[0]
+	assert.Equal(t, "parking", parking.Topic)
+	assert.Equal(t, 3, parking.Learners)
+	assert.InDelta(t, 0.8, parking.MeanMastery, 1e-9)
+	assert.Equal(t, 2, parking.Mastered)
+	assert.Equal(t, 0, parking.Struggling)
+	assert.Equal(t, []int{0, 0, 1, 0, 2}, parking.Distribution)
+
+	signs := topics[1]
+	assert.Equal(t, "traffic_signs", signs.Topic)
+	assert.Equal(t, 1, signs.Struggling)
+	assert.Equal(t, []int{1, 0, 0, 0, 0}, signs.Distribution)
+}
+
+func TestCursor(t *testing.T) {
+	cursor, err := DecodeCursor(EncodeCursor(Cursor{Value: 0.6123, ID: "user-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, &Cursor{Value: 0.6123, ID: "user-1"}, cursor)
+
+	first, err := DecodeCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, first)
+
+	for _, token := range []string{"not base64!", "bm90IGpzb24", EncodeCursor(Cursor{Value: 1})} {
+		_, err := DecodeCursor(token)
+		assert.ErrorIs(t, err, ErrInvalidPageToken, token)
+	}
+}
+
+func TestService_Size(t *testing.T) {
+	s := &Service{pageSize: 50, maxPageSize: 500}
+	assert.Equal(t, 50, s.size(0))
+	assert.Equal(t, 20, s.size(20))
+	assert.Equal(t, 500, s.size(10000))
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse_Defaults(t *testing.T) {
+	cfg, err := Parse(Values{})
+	if err != nil {
+		t.Fatalf("Default configuration is invalid: %v", err)
+	}
+
+	if cfg.SM2.InitialEasiness != 2.5 {
+		t.Errorf("Expected default initial easiness 2.5, got %v", cfg.SM2.InitialEasiness)
+	}
+	if cfg.Redis.StateTTL != 30*time.Minute {
+		t.Errorf("Expected default state TTL 30m, got %v", cfg.Redis.StateTTL)
+	}
+	if !cfg.Worker.Enabled {
+		t.Errorf("Expected workers enabled by default")
+	}
+}
+
+func TestParse_Values(t *testing.T) {
+	cfg, err := Parse(Values{
+		"BKT_LEARN_PROBABILITY":   "0.2",
+		"REDIS_STATE_TTL_SECONDS": "600",
+		"WORKER_ENABLED":          "false",
+		"KAFKA_BROKERS":           "a:9092, b:9092",
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if cfg.BKT.LearnProbability != 0.2 {
+		t.Errorf("Expected learn probability 0.2, got %v", cfg.BKT.LearnProbability)
+	}
+	if cfg.Redis.StateTTL != 10*time.Minute {
+		t.Errorf("Expected state TTL 10m, got %v", cfg.Redis.StateTTL)
+	}
+	if cfg.Worker.Enabled {
+		t.Errorf("Expected workers disabled")
+	}
+	if len(cfg.Kafka.Brokers) != 2 || cfg.Kafka.Brokers[1] != "b:9092" {
+		t.Errorf("Expected two brokers, got %v", cfg.Kafka.Brokers)
+	}
+}
+
+func TestParse_RejectsMalformedValues(t *testing.T) {
+	_, err := Parse(Values{"SM2_LEECH_THRESHOLD": "eight", "WORKER_ENABLED": "yes please"})
+	if err == nil {
+		t.Fatal("Expected malformed values to be rejected")
+	}
+	for _, key := range []string{"SM2_LEECH_THRESHOLD", "WORKER_ENABLED"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected error to name %s, got %v", key, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		values Values
+		key    string
+	}{
+		{"weights must sum to 1", Values{"WEIGHT_URGENCY": "0.5"}, "WEIGHT_*"},
+		{"probability out of range", Values{"BKT_SLIP_PROBABILITY": "1.5"}, "BKT_SLIP_PROBABILITY"},
+		{"guess and slip below 1", Values{"BKT_GUESS_PROBABILITY": "0.6", "BKT_SLIP_PROBABILITY": "0.5"}, "BKT_GUESS_PROBABILITY"},
+		{"initial easiness below minimum", Values{"SM2_INITIAL_EASINESS": "1.2"}, "SM2_INITIAL_EASINESS"},
+		{"unknown quality source", Values{"QUALITY_SOURCE": "teacher"}, "QUALITY_SOURCE"},
+		{"non-positive TTL", Values{"REDIS_STATE_TTL_SECONDS": "0"}, "REDIS_STATE_TTL_SECONDS"},
+		{"unauthenticated outside development", Values{"GO_ENV": "production"}, "JWT_SECRET"},
+		{"service accounts without client CA", Values{"AUTH_SERVICE_ACCOUNTS": "gateway=learners:read"}, "AUTH_SERVICE_ACCOUNTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.values)
+			if err == nil {
+				t.Fatal("Expected configuration to be invalid")
+			}
+			if !strings.Contains(err.Error(), tt.key) {
+				t.Errorf("Expected error to name %s, got %v", tt.key, err)
+			}
+		})
+	}
+}
+
+func TestValidate_AuthOutsideDevelopment(t *testing.T) {
+	if _, err := Parse(Values{"GO_ENV": "production", "JWT_SECRET": "secret"}); err != nil {
+		t.Errorf("Expected configuration with a token key to be valid, got %v", err)
+	}
+	if _, err := Parse(Values{"GO_ENV": "production", "GRPC_TLS_CLIENT_CA_FILE": "/etc/ca.pem"}); err != nil {
+		t.Errorf("Expected configuration with a client CA to be valid, got %v", err)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema := Schema()
+	if len(schema) == 0 {
+		t.Fatal("Expected a non-empty schema")
+	}
+
+	byKey := make(map[string]Setting)
+	for _, setting := range schema {
+		byKey[setting.Key] = setting
+	}
+
+	if setting := byKey["WEIGHT_MASTERY"]; !setting.Hot || setting.Default != "0.35" {
+		t.Errorf("Expected WEIGHT_MASTERY to be hot with default 0.35, got %+v", setting)
+	}
+	if setting := byKey["DATABASE_URL"]; setting.Hot || !setting.Secret {
+		t.Errorf("Expected DATABASE_URL to be a secret needing a restart, got %+v", setting)
+	}
+	for key := range hotKeys {
+		if !Known(key) {
+			t.Errorf("Hot-reloadable setting %s is not read by the configuration", key)
+		}
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.yaml")
+	data := "WEIGHT_URGENCY: 0.4\nKAFKA_BROKERS:\n  - a:9092\n  - b:9092\nWORKER_ENABLED: false\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	want := Values{"WEIGHT_URGENCY": "0.4", "KAFKA_BROKERS": "a:9092,b:9092", "WORKER_ENABLED": "false"}
+	for key, value := range want {
+		if values[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, values[key])
+		}
+	}
+}
+
+func TestReadFile_RejectsUnknownSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.yaml")
+	if err := os.WriteFile(path, []byte("WEIGHT_URGENTCY: 0.4\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadFile(path); err == nil || !strings.Contains(err.Error(), "WEIGHT_URGENTCY") {
+		t.Errorf("Expected unknown setting to be rejected, got %v", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge(Values{"A": "file", "B": "file"}, Values{"B": "env"})
+	if merged["A"] != "file" || merged["B"] != "env" {
+		t.Errorf("Expected later layers to override earlier ones, got %v", merged)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Validate checks that settings are within their valid ranges. Errors name the
+// environment variable of the offending setting.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.check(c.Database.MaxOpenConns > 0, "DB_MAX_OPEN_CONNS must be positive, got %d", c.Database.MaxOpenConns)
+	v.check(c.Database.MaxIdleConns >= 0, "DB_MAX_IDLE_CONNS must not be negative, got %d", c.Database.MaxIdleConns)
+	v.check(c.Redis.PoolSize > 0, "REDIS_POOL_SIZE must be positive, got %d", c.Redis.PoolSize)
+	v.check(c.Redis.LocalCacheSize >= 0, "REDIS_LOCAL_CACHE_SIZE must not be negative, got %d", c.Redis.LocalCacheSize)
+	v.check(c.Redis.LocalCacheTTL > 0, "REDIS_LOCAL_CACHE_TTL_SECONDS must be positive, got %v", c.Redis.LocalCacheTTL)
+	v.check(c.Redis.StateTTL > 0, "REDIS_STATE_TTL_SECONDS must be positive, got %v", c.Redis.StateTTL)
+
+	v.check(c.SM2.MinEasiness >= 1.0, "SM2_MIN_EASINESS must be at least 1.0, got %v", c.SM2.MinEasiness)
+	v.check(c.SM2.InitialEasiness >= c.SM2.MinEasiness,
+		"SM2_INITIAL_EASINESS must be at least SM2_MIN_EASINESS, got %v", c.SM2.InitialEasiness)
+	v.check(c.SM2.LeechThreshold >= 0, "SM2_LEECH_THRESHOLD must not be negative, got %d", c.SM2.LeechThreshold)
+
+	v.probability("BKT_INITIAL_KNOWLEDGE", c.BKT.InitialKnowledge)
+	v.probability("BKT_GUESS_PROBABILITY", c.BKT.GuessProbability)
+	v.probability("BKT_SLIP_PROBABILITY", c.BKT.SlipProbability)
+	v.probability("BKT_LEARN_PROBABILITY", c.BKT.LearnProbability)
+	// Otherwise a correct answer would be evidence against knowing the topic
+	v.check(c.BKT.GuessProbability+c.BKT.SlipProbability < 1,
+		"BKT_GUESS_PROBABILITY and BKT_SLIP_PROBABILITY must sum to less than 1, got %v",
+		c.BKT.GuessProbability+c.BKT.SlipProbability)
+
+	v.check(!math.IsNaN(c.IRT.InitialAbility) && math.Abs(c.IRT.InitialAbility) <= 4,
+		"IRT_INITIAL_ABILITY must be between -4 and 4, got %v", c.IRT.InitialAbility)
+
+	v.probability("WEIGHT_URGENCY", c.Scoring.WeightUrgency)
+	v.probability("WEIGHT_MASTERY", c.Scoring.WeightMastery)
+	v.probability("WEIGHT_DIFFICULTY", c.Scoring.WeightDifficulty)
+	v.probability("WEIGHT_EXPLORATION", c.Scoring.WeightExploration)
+	totalWeight := c.Scoring.WeightUrgency + c.Scoring.WeightMastery + c.Scoring.WeightDifficulty + c.Scoring.WeightExploration
+	v.check(math.Abs(totalWeight-1.0) <= 0.01, "WEIGHT_* scoring weights must sum to 1.0, got %.3f", totalWeight)
+
+	source := strings.ToLower(c.Quality.Source)
+	v.check(source == "inferred" || source == "client",
+		"QUALITY_SOURCE must be inferred or client, got %q", c.Quality.Source)
+	v.check(c.Quality.FastRatio > 0 && c.Quality.FastRatio < c.Quality.SlowRatio,
+		"QUALITY_FAST_RATIO must be positive and below QUALITY_SLOW_RATIO, got %v and %v", c.Quality.FastRatio, c.Quality.SlowRatio)
+	v.check(c.Quality.HintPenalty >= 0, "QUALITY_HINT_PENALTY must not be negative, got %d", c.Quality.HintPenalty)
+	v.check(c.Quality.LowConfidence >= 1 && c.Quality.LowConfidence < c.Quality.HighConfidence && c.Quality.HighConfidence <= 5,
+		"QUALITY_LOW_CONFIDENCE and QUALITY_HIGH_CONFIDENCE must satisfy 1 <= low < high <= 5, got %d and %d",
+		c.Quality.LowConfidence, c.Quality.HighConfidence)
+	v.probability("QUALITY_GUESS_EVIDENCE_WEIGHT", c.Quality.GuessEvidenceWeight)
+	v.probability("QUALITY_HINT_EVIDENCE_DECAY", c.Quality.HintEvidenceDecay)
+	v.probability("QUALITY_MIN_EVIDENCE_WEIGHT", c.Quality.MinEvidenceWeight)
+
+	v.check(c.Worker.LeaderBackend == "postgres" || c.Worker.LeaderBackend == "redis",
+		"WORKER_LEADER_BACKEND must be postgres or redis, got %q", c.Worker.LeaderBackend)
+	v.check(c.Worker.BatchSize > 0, "WORKER_BATCH_SIZE must be positive, got %d", c.Worker.BatchSize)
+
+	v.probability("CURRICULUM_UNLOCK_MASTERY", c.Curriculum.UnlockMastery)
+	v.probability("CURRICULUM_TRANSFER_FACTOR", c.Curriculum.TransferFactor)
+
+	// Without token keys or a client CA every caller could act on any user's state
+	authConfigured := c.Auth.JWTSecret != "" || c.Auth.JWKSFile != "" || c.Auth.JWTPublicKeyFile != "" || c.Auth.ClientCAFile != ""
+	v.check(authConfigured || c.Server.Env == "development",
+		"JWT_SECRET, AUTH_JWKS_FILE, AUTH_JWT_PUBLIC_KEY_FILE or GRPC_TLS_CLIENT_CA_FILE must be set outside development, GO_ENV is %q", c.Server.Env)
+	// Service accounts only authenticate with client certificates
+	v.check(len(c.Auth.ServiceAccounts) == 0 || c.Auth.ClientCAFile != "",
+		"AUTH_SERVICE_ACCOUNTS requires GRPC_TLS_CLIENT_CA_FILE")
+
+	v.check(c.Tracing.Exporter == "otlp" || c.Tracing.Exporter == "stdout" || c.Tracing.Exporter == "none",
+		"OTEL_TRACES_EXPORTER must be otlp, stdout or none, got %q", c.Tracing.Exporter)
+	v.probability("OTEL_TRACES_SAMPLER_ARG", c.Tracing.SampleRatio)
+
+	v.check(c.RateLimit.Backend == "redis" || c.RateLimit.Backend == "local",
+		"RATE_LIMIT_BACKEND must be redis or local, got %q", c.RateLimit.Backend)
+	v.rateLimit("RATE_LIMIT_USER_RPS", "RATE_LIMIT_USER_BURST", c.RateLimit.User)
+	v.rateLimit("RATE_LIMIT_SERVICE_RPS", "RATE_LIMIT_SERVICE_BURST", c.RateLimit.Service)
+	v.rateLimit("RATE_LIMIT_TENANT_RPS", "RATE_LIMIT_TENANT_BURST", c.RateLimit.Tenant)
+	for name, limit := range c.RateLimit.Services {
+		v.rateLimit("RATE_LIMIT_SERVICES "+name, "RATE_LIMIT_SERVICES "+name, limit)
+	}
+	for method, limit := range c.RateLimit.Methods {
+		v.rateLimit("RATE_LIMIT_METHODS "+method, "RATE_LIMIT_METHODS "+method, limit)
+	}
+
+	v.check(c.Tenant.RefreshInterval > 0, "TENANT_REFRESH_SECONDS must be positive, got %v", c.Tenant.RefreshInterval)
+	v.check(c.Tenant.MembershipTTL > 0, "TENANT_MEMBERSHIP_CACHE_SECONDS must be positive, got %v", c.Tenant.MembershipTTL)
+
+	v.probability("COHORT_STRUGGLING_MASTERY", c.Cohort.StrugglingMastery)
+	v.probability("COHORT_RISK_THRESHOLD", c.Cohort.RiskThreshold)
+	v.check(c.Cohort.ActivityWindow > 0, "COHORT_ACTIVITY_WINDOW_DAYS must be positive, got %v", c.Cohort.ActivityWindow)
+	v.check(c.Cohort.InactiveAfter > 0, "COHORT_INACTIVE_AFTER_DAYS must be positive, got %v", c.Cohort.InactiveAfter)
+	v.check(c.Cohort.OverdueAfter >= 0, "COHORT_OVERDUE_AFTER_DAYS must not be negative, got %v", c.Cohort.OverdueAfter)
+	v.check(c.Cohort.PageSize > 0 && c.Cohort.PageSize <= c.Cohort.MaxPageSize,
+		"COHORT_PAGE_SIZE must be positive and at most COHORT_MAX_PAGE_SIZE, got %d and %d", c.Cohort.PageSize, c.Cohort.MaxPageSize)
+
+	v.check(c.Reload.Interval >= 0, "CONFIG_RELOAD_SECONDS must not be negative, got %v", c.Reload.Interval)
+
+	return errors.Join(v.errs...)
+}
+
+// validator collects the settings that fail their checks
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, format string, args ...interface{}) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf(format, args...))
+	}
+}
+
+func (v *validator) probability(key string, value float64) {
+	v.check(value >= 0 && value <= 1, "%s must be between 0 and 1, got %v", key, value)
+}
+
+// rateLimit checks that a limit's rate is not negative and that enabled limits allow
+// at least one request at once
+func (v *validator) rateLimit(rateKey, burstKey string, limit RateLimit) {
+	v.check(limit.Rate >= 0, "%s rate must not be negative, got %v", rateKey, limit.Rate)
+	v.check(limit.Rate == 0 || limit.Burst >= 1, "%s burst must be at least 1, got %d", burstKey, limit.Burst)
+}
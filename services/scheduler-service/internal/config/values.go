@@ -0,0 +1,293 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Values are configuration settings by environment variable name
+type Values map[string]string
+
+// hotKeys are the settings the reloader applies at runtime; changing any other
+// setting takes a restart
+var hotKeys = map[string]bool{
+	"SM2_INITIAL_EASINESS":          true,
+	"SM2_MIN_EASINESS":              true,
+	"SM2_LEECH_THRESHOLD":           true,
+	"BKT_INITIAL_KNOWLEDGE":         true,
+	"BKT_GUESS_PROBABILITY":         true,
+	"BKT_SLIP_PROBABILITY":          true,
+	"BKT_LEARN_PROBABILITY":         true,
+	"WEIGHT_URGENCY":                true,
+	"WEIGHT_MASTERY":                true,
+	"WEIGHT_DIFFICULTY":             true,
+	"WEIGHT_EXPLORATION":            true,
+	"QUALITY_SOURCE":                true,
+	"QUALITY_FAST_RATIO":            true,
+	"QUALITY_SLOW_RATIO":            true,
+	"QUALITY_HINT_PENALTY":          true,
+	"QUALITY_LOW_CONFIDENCE":        true,
+	"QUALITY_HIGH_CONFIDENCE":       true,
+	"QUALITY_GUESS_EVIDENCE_WEIGHT": true,
+	"QUALITY_HINT_EVIDENCE_DECAY":   true,
+	"QUALITY_MIN_EVIDENCE_WEIGHT":   true,
+	"REDIS_LOCAL_CACHE_TTL_SECONDS": true,
+	"REDIS_STATE_TTL_SECONDS":       true,
+	"RATE_LIMIT_USER_RPS":           true,
+	"RATE_LIMIT_USER_BURST":         true,
+	"RATE_LIMIT_SERVICE_RPS":        true,
+	"RATE_LIMIT_SERVICE_BURST":      true,
+	"RATE_LIMIT_TENANT_RPS":         true,
+	"RATE_LIMIT_TENANT_BURST":       true,
+	"RATE_LIMIT_SERVICES":           true,
+	"RATE_LIMIT_METHODS":            true,
+}
+
+// secretKeys are the settings whose values must not be shown
+var secretKeys = map[string]bool{
+	"DATABASE_URL": true,
+	"REDIS_URL":    true,
+	"JWT_SECRET":   true,
+}
+
+// Setting describes a configuration setting
+type Setting struct {
+	Key     string `json:"key"`
+	Default string `json:"default"`
+	Hot     bool   `json:"hot"`    // Applied at runtime without a restart
+	Secret  bool   `json:"secret"` // Holds credentials
+}
+
+var (
+	schemaOnce sync.Once
+	schema     []Setting
+)
+
+// Schema returns every configuration setting, sorted by key
+func Schema() []Setting {
+	schemaOnce.Do(func() {
+		p := &parser{values: Values{}, defaults: make(map[string]string)}
+		p.config()
+
+		for key, defaultValue := range p.defaults {
+			schema = append(schema, Setting{Key: key, Default: defaultValue, Hot: hotKeys[key], Secret: secretKeys[key]})
+		}
+		sort.Slice(schema, func(i, j int) bool { return schema[i].Key < schema[j].Key })
+	})
+	return append([]Setting(nil), schema...)
+}
+
+// Known reports whether key is a configuration setting
+func Known(key string) bool {
+	for _, setting := range Schema() {
+		if setting.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// HotReloadable reports whether a setting is applied at runtime without a restart
+func HotReloadable(key string) bool {
+	return hotKeys[key]
+}
+
+// EnvValues returns the settings set in the environment. Empty variables count as unset.
+func EnvValues() Values {
+	values := make(Values)
+	for _, setting := range Schema() {
+		if value := os.Getenv(setting.Key); value != "" {
+			values[setting.Key] = value
+		}
+	}
+	return values
+}
+
+// LoadValues returns the settings of the file named by CONFIG_FILE, if set, overridden
+// by environment variables
+func LoadValues() (Values, error) {
+	env := EnvValues()
+	fileValues, err := ReadFile(env["CONFIG_FILE"])
+	if err != nil {
+		return nil, err
+	}
+	return Merge(fileValues, env), nil
+}
+
+// ReadFile reads a YAML or JSON file of settings by environment variable name. Lists may
+// be given as sequences. No file is read when path is empty.
+func ReadFile(path string) (Values, error) {
+	values := make(Values)
+	if path == "" {
+		return values, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	for key, value := range raw {
+		if !Known(key) {
+			return nil, fmt.Errorf("config file %s: unknown setting %s", path, key)
+		}
+
+		switch v := value.(type) {
+		case nil:
+			values[key] = ""
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		case map[string]interface{}:
+			return nil, fmt.Errorf("config file %s: setting %s must be a scalar or a list", path, key)
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return values, nil
+}
+
+// Merge layers values, later layers overriding earlier ones
+func Merge(layers ...Values) Values {
+	merged := make(Values)
+	for _, layer := range layers {
+		for key, value := range layer {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// parser reads settings from values, recording the defaults of the settings it reads
+// and the values it cannot parse. Empty values count as unset.
+type parser struct {
+	values   Values
+	defaults map[string]string
+	errs     []error
+}
+
+func (p *parser) lookup(key, defaultValue string) (string, bool) {
+	if p.defaults != nil {
+		p.defaults[key] = defaultValue
+	}
+	value := strings.TrimSpace(p.values[key])
+	return value, value != ""
+}
+
+func (p *parser) get(key, defaultValue string) string {
+	if value, ok := p.lookup(key, defaultValue); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func (p *parser) getInt(key string, defaultValue int) int {
+	value, ok := p.lookup(key, strconv.Itoa(defaultValue))
+	if !ok {
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s must be an integer, got %q", key, value))
+		return defaultValue
+	}
+	return intValue
+}
+
+func (p *parser) getFloat(key string, defaultValue float64) float64 {
+	value, ok := p.lookup(key, strconv.FormatFloat(defaultValue, 'g', -1, 64))
+	if !ok {
+		return defaultValue
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s must be a number, got %q", key, value))
+		return defaultValue
+	}
+	return floatValue
+}
+
+func (p *parser) getBool(key string, defaultValue bool) bool {
+	value, ok := p.lookup(key, strconv.FormatBool(defaultValue))
+	if !ok {
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s must be true or false, got %q", key, value))
+		return defaultValue
+	}
+	return boolValue
+}
+
+func (p *parser) getList(key string) []string {
+	value, _ := p.lookup(key, "")
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// getScopes parses "name=scope,scope;name=scope" into scopes by name
+func (p *parser) getScopes(key string) map[string][]string {
+	value, _ := p.lookup(key, "")
+	scopes := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		name, list, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			continue
+		}
+		scopes[name] = []string{}
+		for _, scope := range strings.Split(list, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes[name] = append(scopes[name], scope)
+			}
+		}
+	}
+	return scopes
+}
+
+// getRateLimits parses "name=rate:burst;name=rate:burst" into limits by name
+func (p *parser) getRateLimits(key, defaultValue string) map[string]RateLimit {
+	value, ok := p.lookup(key, defaultValue)
+	if !ok {
+		value = defaultValue
+	}
+
+	limits := make(map[string]RateLimit)
+	for _, entry := range strings.Split(value, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, spec, _ := strings.Cut(entry, "=")
+		rate, burst, _ := strings.Cut(spec, ":")
+		name = strings.TrimSpace(name)
+
+		rateValue, rateErr := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		burstValue, burstErr := strconv.Atoi(strings.TrimSpace(burst))
+		if name == "" || rateErr != nil || burstErr != nil {
+			p.errs = append(p.errs, fmt.Errorf("%s entries must be name=rate:burst, got %q", key, strings.TrimSpace(entry)))
+			continue
+		}
+		limits[name] = RateLimit{Rate: rateValue, Burst: burstValue}
+	}
+	return limits
+}
@@ -0,0 +1,270 @@
+package curriculum
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrCycle is returned when prerequisites form a cycle
+var ErrCycle = errors.New("prerequisite cycle")
+
+// TopicNode is a topic and the topics that must be mastered before it unlocks.
+// Transfer, when set, is the fraction of knowledge of the topic gained in another
+// jurisdiction that carries over into this one. ExamWeight, when set, is the topic's
+// relative weight in the jurisdiction's exam blueprint.
+type TopicNode struct {
+	Topic         string   `json:"topic"`
+	Prerequisites []string `json:"prerequisites"`
+	Transfer      *float64 `json:"transfer,omitempty"`
+	ExamWeight    *float64 `json:"exam_weight,omitempty"`
+}
+
+// PrerequisiteGraph is a validated, acyclic topic prerequisite graph for one jurisdiction.
+// Topics that are not in the graph have no prerequisites and are always unlocked.
+// A nil graph has no topics.
+type PrerequisiteGraph struct {
+	jurisdiction  string
+	prerequisites map[string][]string
+	depth         map[string]int
+	order         []string // topological order, prerequisites first
+	transfer      map[string]float64
+	examWeights   map[string]float64
+}
+
+// NewPrerequisiteGraph validates the nodes and builds the graph. Every prerequisite
+// must itself be a node, and cycles are rejected with the topics on the cycle.
+func NewPrerequisiteGraph(jurisdiction string, nodes []TopicNode) (*PrerequisiteGraph, error) {
+	g := &PrerequisiteGraph{
+		jurisdiction:  jurisdiction,
+		prerequisites: make(map[string][]string, len(nodes)),
+		depth:         make(map[string]int, len(nodes)),
+		transfer:      make(map[string]float64),
+		examWeights:   make(map[string]float64),
+	}
+
+	for _, node := range nodes {
+		if node.Topic == "" {
+			return nil, fmt.Errorf("topic name is required")
+		}
+		if _, exists := g.prerequisites[node.Topic]; exists {
+			return nil, fmt.Errorf("topic %s is defined more than once", node.Topic)
+		}
+		prerequisites := append([]string(nil), node.Prerequisites...)
+		sort.Strings(prerequisites)
+		g.prerequisites[node.Topic] = prerequisites
+
+		if node.Transfer != nil {
+			if *node.Transfer < 0 || *node.Transfer > 1 {
+				return nil, fmt.Errorf("topic %s has transfer %v outside [0, 1]", node.Topic, *node.Transfer)
+			}
+			g.transfer[node.Topic] = *node.Transfer
+		}
+
+		if node.ExamWeight != nil {
+			if *node.ExamWeight < 0 {
+				return nil, fmt.Errorf("topic %s has negative exam weight %v", node.Topic, *node.ExamWeight)
+			}
+			g.examWeights[node.Topic] = *node.ExamWeight
+		}
+	}
+
+	for topic, prerequisites := range g.prerequisites {
+		for _, prerequisite := range prerequisites {
+			if _, exists := g.prerequisites[prerequisite]; !exists {
+				return nil, fmt.Errorf("topic %s has unknown prerequisite %s", topic, prerequisite)
+			}
+		}
+	}
+
+	if err := g.sortTopologically(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// sortTopologically computes the topological order and depths, failing on cycles
+func (g *PrerequisiteGraph) sortTopologically() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int, len(g.prerequisites))
+	var path []string
+
+	var visit func(topic string) error
+	visit = func(topic string) error {
+		switch marks[topic] {
+		case visited:
+			return nil
+		case visiting:
+			// Report the cycle from its first occurrence on the current path
+			for i, t := range path {
+				if t == topic {
+					return fmt.Errorf("%w: %s", ErrCycle, strings.Join(append(path[i:], topic), " -> "))
+				}
+			}
+		}
+
+		marks[topic] = visiting
+		path = append(path, topic)
+
+		depth := 0
+		for _, prerequisite := range g.prerequisites[topic] {
+			if err := visit(prerequisite); err != nil {
+				return err
+			}
+			if g.depth[prerequisite]+1 > depth {
+				depth = g.depth[prerequisite] + 1
+			}
+		}
+
+		path = path[:len(path)-1]
+		marks[topic] = visited
+		g.depth[topic] = depth
+		g.order = append(g.order, topic)
+		return nil
+	}
+
+	// Visit in name order so the topological order is deterministic
+	topics := make([]string, 0, len(g.prerequisites))
+	for topic := range g.prerequisites {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	for _, topic := range topics {
+		if err := visit(topic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Jurisdiction returns the jurisdiction the graph applies to
+func (g *PrerequisiteGraph) Jurisdiction() string {
+	if g == nil {
+		return ""
+	}
+	return g.jurisdiction
+}
+
+// Topics returns all topics in topological order, prerequisites first
+func (g *PrerequisiteGraph) Topics() []string {
+	if g == nil {
+		return nil
+	}
+	return append([]string(nil), g.order...)
+}
+
+// HasTopic reports whether a topic is part of the graph
+func (g *PrerequisiteGraph) HasTopic(topic string) bool {
+	if g == nil {
+		return false
+	}
+	_, ok := g.prerequisites[topic]
+	return ok
+}
+
+// TransferFactor returns the fraction of knowledge of a topic gained in another
+// jurisdiction that carries over into this one, or fallback when the graph does not set it
+func (g *PrerequisiteGraph) TransferFactor(topic string, fallback float64) float64 {
+	if g == nil {
+		return fallback
+	}
+	if transfer, ok := g.transfer[topic]; ok {
+		return transfer
+	}
+	return fallback
+}
+
+// ExamWeights returns the relative exam weight of each topic the graph weights. It is
+// empty when the graph has no exam blueprint, so every topic weighs the same.
+func (g *PrerequisiteGraph) ExamWeights() map[string]float64 {
+	weights := make(map[string]float64)
+	if g == nil {
+		return weights
+	}
+	for topic, weight := range g.examWeights {
+		weights[topic] = weight
+	}
+	return weights
+}
+
+// Prerequisites returns the direct prerequisites of a topic
+func (g *PrerequisiteGraph) Prerequisites(topic string) []string {
+	if g == nil {
+		return nil
+	}
+	return append([]string(nil), g.prerequisites[topic]...)
+}
+
+// Depth returns the length of the longest prerequisite chain below a topic
+func (g *PrerequisiteGraph) Depth(topic string) int {
+	if g == nil {
+		return 0
+	}
+	return g.depth[topic]
+}
+
+// MissingPrerequisites returns the direct prerequisites of a topic whose mastery is
+// below threshold. A topic is unlocked when none are missing.
+func (g *PrerequisiteGraph) MissingPrerequisites(topic string, mastery map[string]float64, threshold float64) []string {
+	if g == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, prerequisite := range g.prerequisites[topic] {
+		if mastery[prerequisite] < threshold {
+			missing = append(missing, prerequisite)
+		}
+	}
+	return missing
+}
+
+// IsUnlocked reports whether all direct prerequisites of a topic are mastered
+func (g *PrerequisiteGraph) IsUnlocked(topic string, mastery map[string]float64, threshold float64) bool {
+	return len(g.MissingPrerequisites(topic, mastery, threshold)) == 0
+}
+
+// OrderTopics reorders topics so that each comes after any of its direct or indirect
+// prerequisites in the list, otherwise keeping the given order
+func (g *PrerequisiteGraph) OrderTopics(topics []string) []string {
+	if g == nil {
+		return append([]string(nil), topics...)
+	}
+
+	included := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		included[topic] = true
+	}
+
+	ordered := make([]string, 0, len(topics))
+	emitted := make(map[string]bool, len(topics))
+	expanded := make(map[string]bool)
+
+	// The graph is acyclic, so expanding each topic once terminates
+	var emit func(topic string)
+	emit = func(topic string) {
+		if expanded[topic] {
+			return
+		}
+		expanded[topic] = true
+		for _, prerequisite := range g.prerequisites[topic] {
+			emit(prerequisite)
+		}
+		if included[topic] && !emitted[topic] {
+			emitted[topic] = true
+			ordered = append(ordered, topic)
+		}
+	}
+
+	for _, topic := range topics {
+		emit(topic)
+	}
+	return ordered
+}
@@ -0,0 +1,183 @@
+package curriculum
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scheduler-service/internal/config"
+)
+
+func drivingGraph(t *testing.T) *PrerequisiteGraph {
+	t.Helper()
+
+	graph, err := NewPrerequisiteGraph("test", []TopicNode{
+		{Topic: "traffic_signs"},
+		{Topic: "vehicle_operation"},
+		{Topic: "road_rules", Prerequisites: []string{"traffic_signs"}},
+		{Topic: "right_of_way", Prerequisites: []string{"road_rules"}},
+		{Topic: "highway_merging", Prerequisites: []string{"right_of_way", "vehicle_operation"}},
+	})
+	require.NoError(t, err)
+	return graph
+}
+
+func TestNewPrerequisiteGraph_TopologicalOrder(t *testing.T) {
+	graph := drivingGraph(t)
+
+	position := make(map[string]int)
+	for i, topic := range graph.Topics() {
+		position[topic] = i
+	}
+	require.Len(t, position, 5)
+
+	for _, topic := range graph.Topics() {
+		for _, prerequisite := range graph.Prerequisites(topic) {
+			assert.Less(t, position[prerequisite], position[topic], "%s must come before %s", prerequisite, topic)
+		}
+	}
+
+	assert.Equal(t, 0, graph.Depth("traffic_signs"))
+	assert.Equal(t, 2, graph.Depth("right_of_way"))
+	assert.Equal(t, 3, graph.Depth("highway_merging"))
+}
+
+func TestNewPrerequisiteGraph_Invalid(t *testing.T) {
+	outOfRange := 1.5
+	negative := -0.2
+
+	tests := []struct {
+		name  string
+		nodes []TopicNode
+		cycle bool
+	}{
+		{
+			name:  "unknown prerequisite",
+			nodes: []TopicNode{{Topic: "road_rules", Prerequisites: []string{"traffic_signs"}}},
+		},
+		{
+			name:  "duplicate topic",
+			nodes: []TopicNode{{Topic: "road_rules"}, {Topic: "road_rules"}},
+		},
+		{
+			name:  "self loop",
+			nodes: []TopicNode{{Topic: "road_rules", Prerequisites: []string{"road_rules"}}},
+			cycle: true,
+		},
+		{
+			name:  "transfer out of range",
+			nodes: []TopicNode{{Topic: "road_rules", Transfer: &outOfRange}},
+		},
+		{
+			name:  "negative exam weight",
+			nodes: []TopicNode{{Topic: "road_rules", ExamWeight: &negative}},
+		},
+		{
+			name: "indirect cycle",
+			nodes: []TopicNode{
+				{Topic: "a", Prerequisites: []string{"c"}},
+				{Topic: "b", Prerequisites: []string{"a"}},
+				{Topic: "c", Prerequisites: []string{"b"}},
+			},
+			cycle: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPrerequisiteGraph("test", tt.nodes)
+			require.Error(t, err)
+			if tt.cycle {
+				assert.ErrorIs(t, err, ErrCycle)
+			} else {
+				assert.NotErrorIs(t, err, ErrCycle)
+			}
+		})
+	}
+}
+
+func TestPrerequisiteGraph_Unlocking(t *testing.T) {
+	graph := drivingGraph(t)
+	mastery := map[string]float64{"traffic_signs": 0.9, "road_rules": 0.4}
+
+	assert.True(t, graph.IsUnlocked("traffic_signs", mastery, 0.6))
+	assert.True(t, graph.IsUnlocked("road_rules", mastery, 0.6))
+	assert.False(t, graph.IsUnlocked("right_of_way", mastery, 0.6))
+	assert.Equal(t, []string{"right_of_way", "vehicle_operation"}, graph.MissingPrerequisites("highway_merging", mastery, 0.6))
+
+	// Topics outside the graph, and any topic under a nil graph, are unlocked
+	assert.True(t, graph.IsUnlocked("unknown_topic", mastery, 0.6))
+	var empty *PrerequisiteGraph
+	assert.True(t, empty.IsUnlocked("highway_merging", nil, 0.6))
+}
+
+func TestPrerequisiteGraph_OrderTopics(t *testing.T) {
+	graph := drivingGraph(t)
+
+	// Highest priority first, but highway merging depends on right of way
+	ordered := graph.OrderTopics([]string{"highway_merging", "vehicle_operation", "right_of_way", "traffic_signs", "parking"})
+	assert.Equal(t, []string{"traffic_signs", "right_of_way", "vehicle_operation", "highway_merging", "parking"}, ordered)
+
+	var empty *PrerequisiteGraph
+	assert.Equal(t, []string{"b", "a"}, empty.OrderTopics([]string{"b", "a"}))
+}
+
+func TestPrerequisiteGraph_TransferFactor(t *testing.T) {
+	transfer := 0.2
+	graph, err := NewPrerequisiteGraph("test", []TopicNode{
+		{Topic: "traffic_signs", Transfer: &transfer},
+		{Topic: "vehicle_operation"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.2, graph.TransferFactor("traffic_signs", 0.5))
+	assert.Equal(t, 0.5, graph.TransferFactor("vehicle_operation", 0.5))
+	assert.True(t, graph.HasTopic("vehicle_operation"))
+	assert.False(t, graph.HasTopic("parking"))
+
+	var empty *PrerequisiteGraph
+	assert.Equal(t, 0.5, empty.TransferFactor("traffic_signs", 0.5))
+	assert.False(t, empty.HasTopic("traffic_signs"))
+}
+
+func TestPrerequisiteGraph_ExamWeights(t *testing.T) {
+	weight := 0.4
+	graph, err := NewPrerequisiteGraph("test", []TopicNode{
+		{Topic: "traffic_signs", ExamWeight: &weight},
+		{Topic: "vehicle_operation"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{"traffic_signs": 0.4}, graph.ExamWeights())
+
+	// Graphs without an exam blueprint weigh every topic the same
+	unweighted := drivingGraph(t)
+	assert.Empty(t, unweighted.ExamWeights())
+	var empty *PrerequisiteGraph
+	assert.Empty(t, empty.ExamWeights())
+}
+
+func TestRegistry_EmbeddedGraphs(t *testing.T) {
+	registry, err := NewRegistry(&config.CurriculumConfig{UnlockMastery: 0.6})
+	require.NoError(t, err)
+
+	graph := registry.Graph("GB")
+	require.NotNil(t, graph)
+	assert.Equal(t, "GB", graph.Jurisdiction())
+	assert.Contains(t, graph.Prerequisites("roundabouts"), "right_of_way")
+
+	// Jurisdictions without a file use the default graph
+	assert.Equal(t, DefaultJurisdiction, registry.Graph("ZZ").Jurisdiction())
+	assert.Contains(t, registry.Graph("ZZ").Prerequisites("highway_merging"), "right_of_way")
+}
+
+func TestLoadGraphs_RejectsCycles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"g/default.json": {Data: []byte(`{"topics":[{"topic":"a","prerequisites":["b"]},{"topic":"b","prerequisites":["a"]}]}`)},
+	}
+
+	_, err := LoadGraphs(fsys, "g")
+	assert.ErrorIs(t, err, ErrCycle)
+}
@@ -0,0 +1,110 @@
+package curriculum
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"scheduler-service/internal/config"
+)
+
+// DefaultJurisdiction is the graph used for jurisdictions without their own file
+const DefaultJurisdiction = "default"
+
+//go:embed graphs/*.json
+var embeddedGraphs embed.FS
+
+// graphFile is the on-disk format of a prerequisite graph, one file per jurisdiction
+type graphFile struct {
+	Jurisdiction string      `json:"jurisdiction"`
+	Topics       []TopicNode `json:"topics"`
+}
+
+// Registry holds the prerequisite graph of each jurisdiction
+type Registry struct {
+	graphs        map[string]*PrerequisiteGraph
+	unlockMastery float64
+}
+
+// NewRegistry loads prerequisite graphs from cfg.GraphDir, or from the graphs
+// embedded in the binary when no directory is configured
+func NewRegistry(cfg *config.CurriculumConfig) (*Registry, error) {
+	var fsys fs.FS = embeddedGraphs
+	dir := "graphs"
+	if cfg.GraphDir != "" {
+		fsys, dir = os.DirFS(cfg.GraphDir), "."
+	}
+
+	graphs, err := LoadGraphs(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := graphs[DefaultJurisdiction]; !ok {
+		return nil, fmt.Errorf("no %s prerequisite graph", DefaultJurisdiction)
+	}
+
+	return &Registry{graphs: graphs, unlockMastery: cfg.UnlockMastery}, nil
+}
+
+// LoadGraphs reads and validates every *.json prerequisite graph in dir
+func LoadGraphs(fsys fs.FS, dir string) (map[string]*PrerequisiteGraph, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prerequisite graphs: %w", err)
+	}
+
+	graphs := make(map[string]*PrerequisiteGraph)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prerequisite graph %s: %w", entry.Name(), err)
+		}
+
+		var file graphFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse prerequisite graph %s: %w", entry.Name(), err)
+		}
+		if file.Jurisdiction == "" {
+			file.Jurisdiction = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		if _, exists := graphs[file.Jurisdiction]; exists {
+			return nil, fmt.Errorf("duplicate prerequisite graph for jurisdiction %s", file.Jurisdiction)
+		}
+
+		graph, err := NewPrerequisiteGraph(file.Jurisdiction, file.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prerequisite graph %s: %w", entry.Name(), err)
+		}
+		graphs[file.Jurisdiction] = graph
+	}
+
+	return graphs, nil
+}
+
+// Graph returns the graph for a jurisdiction, falling back to the default graph.
+// A nil registry returns a nil graph, under which every topic is unlocked.
+func (r *Registry) Graph(jurisdiction string) *PrerequisiteGraph {
+	if r == nil {
+		return nil
+	}
+	if graph, ok := r.graphs[jurisdiction]; ok {
+		return graph
+	}
+	return r.graphs[DefaultJurisdiction]
+}
+
+// UnlockMastery returns the mastery probability a prerequisite needs before the topics depending on it unlock
+func (r *Registry) UnlockMastery() float64 {
+	if r == nil {
+		return 0
+	}
+	return r.unlockMastery
+}
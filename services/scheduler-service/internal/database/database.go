@@ -9,7 +9,10 @@ import (
 	"scheduler-service/internal/config"
 	applogger "scheduler-service/internal/logger"
 	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/tracing"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -36,7 +39,11 @@ func New(cfg *config.DatabaseConfig, metrics *metrics.Metrics, log *applogger.Lo
 	)
 
 	// Open database connection
-	db, err := gorm.Open(postgres.Open(cfg.URL), &gorm.Config{
+	tracedDB, err := openTracedDB(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: tracedDB}), &gorm.Config{
 		Logger: gormLogger,
 	})
 	if err != nil {
@@ -68,6 +75,17 @@ func New(cfg *config.DatabaseConfig, metrics *metrics.Metrics, log *applogger.Lo
 	}, nil
 }
 
+// openTracedDB opens a database/sql connection pool through the pgx driver that
+// records a span for each query
+func openTracedDB(databaseURL string) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	connConfig.Tracer = tracing.NewQueryTracer()
+	return stdlib.OpenDB(*connConfig), nil
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	sqlDB, err := db.DB.DB()
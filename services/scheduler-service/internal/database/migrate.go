@@ -0,0 +1,357 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	applogger "scheduler-service/internal/logger"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// migrationLockID is the Postgres advisory lock key held while migrations run so
+// that replicas starting together do not apply the same migration twice
+const migrationLockID int64 = 7263541902
+
+// ErrSchemaBehind is returned by CheckSchema when migrations are pending
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// Migration is a versioned schema change loaded from a pair of
+// NNN_name.up.sql / NNN_name.down.sql files
+type Migration struct {
+	Version int
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
+
+// MigrationStatus describes whether a migration has been applied
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// Migrator applies embedded migrations and records them in schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     *applogger.Logger
+}
+
+// NewMigrator creates a migrator for the migrations embedded in the binary
+func NewMigrator(db *DB, log *applogger.Logger) (*Migrator, error) {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	migrations, err := LoadMigrations(embeddedMigrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         sqlDB,
+		migrations: migrations,
+		logger:     log,
+	}, nil
+}
+
+// LoadMigrations reads migration files from dir, ordered by version.
+// Every version must have an up file; down files are optional.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		version, name, direction, err := parseMigrationFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d used by both %s and %s", version, migration.Name, name)
+		}
+
+		if direction == "up" {
+			migration.UpSQL = string(content)
+		} else {
+			migration.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpSQL == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// parseMigrationFilename splits "002_create_sm2_states.up.sql" into its parts
+func parseMigrationFilename(filename string) (int, string, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+
+	var direction string
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+	default:
+		return 0, "", "", fmt.Errorf("migration %s must end in .up.sql or .down.sql", filename)
+	}
+	base = strings.TrimSuffix(base, "."+direction)
+
+	versionPart, name, ok := strings.Cut(base, "_")
+	if !ok || name == "" {
+		return 0, "", "", fmt.Errorf("migration %s must be named NNN_description", filename)
+	}
+
+	version, err := strconv.Atoi(versionPart)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("migration %s has an invalid version", filename)
+	}
+
+	return version, name, direction, nil
+}
+
+// Migrations returns the migrations known to this binary
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies all pending migrations in order. Returns the number applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock(conn)
+
+	applied, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		start := time.Now()
+		err := m.runInTx(ctx, conn, migration.UpSQL,
+			"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+		if err != nil {
+			return count, fmt.Errorf("failed to apply migration %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		m.logger.WithFields(map[string]interface{}{
+			"version":     migration.Version,
+			"name":        migration.Name,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}).Info("Applied migration")
+		count++
+	}
+
+	return count, nil
+}
+
+// Down rolls back the most recently applied migrations, up to steps of them.
+// Returns the number rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, fmt.Errorf("steps must be positive")
+	}
+
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock(conn)
+
+	applied, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.DownSQL == "" {
+			return count, fmt.Errorf("migration %03d_%s has no down file", migration.Version, migration.Name)
+		}
+
+		err := m.runInTx(ctx, conn, migration.DownSQL,
+			"DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		if err != nil {
+			return count, fmt.Errorf("failed to roll back migration %03d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		m.logger.WithFields(map[string]interface{}{
+			"version": migration.Version,
+			"name":    migration.Name,
+		}).Info("Rolled back migration")
+		count++
+	}
+
+	return count, nil
+}
+
+// Status reports every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	applied, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			statuses[i].Applied = true
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+
+	return statuses, nil
+}
+
+// CheckSchema returns ErrSchemaBehind if any embedded migration has not been applied
+func (m *Migrator) CheckSchema(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, fmt.Sprintf("%03d_%s", s.Version, s.Name))
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migration(s): %s", ErrSchemaBehind, len(pending), strings.Join(pending, ", "))
+	}
+
+	return nil
+}
+
+// lock takes a dedicated connection and holds the migration advisory lock on it
+func (m *Migrator) lock(ctx context.Context) (*sql.Conn, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (m *Migrator) unlock(conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+		m.logger.WithError(err).Warn("Failed to release migration lock")
+	}
+	conn.Close()
+}
+
+// appliedVersions ensures schema_migrations exists and returns applied versions
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// runInTx executes a migration script and its bookkeeping statement atomically
+func (m *Migrator) runInTx(ctx context.Context, conn *sql.Conn, script, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Scripts are run without arguments so the driver sends them as a single
+	// multi-statement query
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	migrations, err := LoadMigrations(embeddedMigrations, "migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	// Versions are contiguous so status output has no gaps
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version, "migration %s", migration.Name)
+		assert.NotEmpty(t, migration.UpSQL, "migration %s has no up script", migration.Name)
+		assert.NotEmpty(t, migration.DownSQL, "migration %s has no down script", migration.Name)
+	}
+}
+
+func TestLoadMigrations_OrdersAndPairs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/010_add_index.up.sql":      {Data: []byte("CREATE INDEX a;")},
+		"m/002_create_table.up.sql":   {Data: []byte("CREATE TABLE t;")},
+		"m/002_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"m/README.md":                 {Data: []byte("ignored")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "m")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, 2, migrations[0].Version)
+	assert.Equal(t, "create_table", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE t;", migrations[0].UpSQL)
+	assert.Equal(t, "DROP TABLE t;", migrations[0].DownSQL)
+
+	assert.Equal(t, 10, migrations[1].Version)
+	assert.Empty(t, migrations[1].DownSQL)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{
+			name:  "missing direction",
+			files: fstest.MapFS{"m/001_init.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name:  "missing version",
+			files: fstest.MapFS{"m/init.up.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name:  "down without up",
+			files: fstest.MapFS{"m/001_init.down.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"m/001_init.up.sql":  {Data: []byte("SELECT 1;")},
+				"m/001_other.up.sql": {Data: []byte("SELECT 1;")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.files, "m")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,2 @@
+-- Migration: Enable extensions (rollback)
+-- Description: pgcrypto may be used by other schemas in the same database, so it is left installed
@@ -0,0 +1,4 @@
+-- Migration: Enable extensions
+-- Description: Enables pgcrypto for gen_random_uuid() on PostgreSQL versions before 13
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
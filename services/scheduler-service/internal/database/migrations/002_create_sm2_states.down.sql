@@ -0,0 +1,6 @@
+-- Migration: Create SM-2 states table (rollback)
+
+DROP VIEW IF EXISTS sm2_analytics;
+DROP TRIGGER IF EXISTS trigger_update_sm2_states_updated_at ON sm2_states;
+DROP FUNCTION IF EXISTS update_sm2_states_updated_at();
+DROP TABLE IF EXISTS sm2_states;
@@ -30,12 +30,8 @@ CREATE INDEX IF NOT EXISTS idx_sm2_states_next_due ON sm2_states(next_due);
 CREATE INDEX IF NOT EXISTS idx_sm2_states_user_next_due ON sm2_states(user_id, next_due);
 CREATE INDEX IF NOT EXISTS idx_sm2_states_last_reviewed ON sm2_states(last_reviewed);
 
--- Create partial indexes for common queries
-CREATE INDEX IF NOT EXISTS idx_sm2_states_due_items ON sm2_states(user_id, item_id) 
-    WHERE next_due <= NOW();
-
-CREATE INDEX IF NOT EXISTS idx_sm2_states_overdue_items ON sm2_states(user_id, item_id, next_due) 
-    WHERE next_due < NOW();
+-- Due and overdue lookups use idx_sm2_states_user_next_due; partial indexes on
+-- NOW() are not possible because index predicates must be immutable
 
 -- Create function to automatically update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_sm2_states_updated_at()
@@ -0,0 +1,5 @@
+-- Migration: Create BKT states table (rollback)
+
+DROP TRIGGER IF EXISTS trigger_bkt_states_updated_at ON bkt_states;
+DROP FUNCTION IF EXISTS update_bkt_states_updated_at();
+DROP TABLE IF EXISTS bkt_states;
@@ -0,0 +1,68 @@
+-- Migration: Create BKT states table
+-- Description: Creates table for storing Bayesian Knowledge Tracing states per user and topic
+
+-- Create bkt_states table
+CREATE TABLE IF NOT EXISTS bkt_states (
+    user_id UUID NOT NULL,
+    topic VARCHAR(100) NOT NULL,
+
+    -- BKT model parameters
+    prob_knowledge DECIMAL(5,4) NOT NULL DEFAULT 0.1000,
+    prob_guess DECIMAL(5,4) NOT NULL DEFAULT 0.2500,
+    prob_slip DECIMAL(5,4) NOT NULL DEFAULT 0.1000,
+    prob_learn DECIMAL(5,4) NOT NULL DEFAULT 0.1500,
+
+    -- Evidence
+    attempts_count INTEGER NOT NULL DEFAULT 0,
+    correct_count INTEGER NOT NULL DEFAULT 0,
+    confidence DECIMAL(5,4) NOT NULL DEFAULT 0.1000,
+    last_updated TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, topic),
+
+    -- Constraints
+    CONSTRAINT bkt_states_prob_knowledge_range CHECK (prob_knowledge >= 0.0 AND prob_knowledge <= 1.0),
+    CONSTRAINT bkt_states_prob_guess_range CHECK (prob_guess >= 0.0 AND prob_guess <= 1.0),
+    CONSTRAINT bkt_states_prob_slip_range CHECK (prob_slip >= 0.0 AND prob_slip <= 1.0),
+    CONSTRAINT bkt_states_prob_learn_range CHECK (prob_learn >= 0.0 AND prob_learn <= 1.0),
+    CONSTRAINT bkt_states_confidence_range CHECK (confidence >= 0.0 AND confidence <= 1.0),
+    CONSTRAINT bkt_states_attempts_non_negative CHECK (attempts_count >= 0),
+    CONSTRAINT bkt_states_correct_non_negative CHECK (correct_count >= 0),
+    CONSTRAINT bkt_states_correct_not_exceed_attempts CHECK (correct_count <= attempts_count)
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_bkt_states_user_id ON bkt_states(user_id);
+CREATE INDEX IF NOT EXISTS idx_bkt_states_topic ON bkt_states(topic);
+CREATE INDEX IF NOT EXISTS idx_bkt_states_last_updated ON bkt_states(last_updated);
+CREATE INDEX IF NOT EXISTS idx_bkt_states_user_knowledge ON bkt_states(user_id, prob_knowledge);
+
+-- Add trigger to automatically update updated_at timestamp
+CREATE OR REPLACE FUNCTION update_bkt_states_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_bkt_states_updated_at ON bkt_states;
+CREATE TRIGGER trigger_bkt_states_updated_at
+    BEFORE UPDATE ON bkt_states
+    FOR EACH ROW
+    EXECUTE FUNCTION update_bkt_states_updated_at();
+
+-- Add comments for documentation
+COMMENT ON TABLE bkt_states IS 'Stores Bayesian Knowledge Tracing states for users per topic';
+COMMENT ON COLUMN bkt_states.user_id IS 'Reference to user ID';
+COMMENT ON COLUMN bkt_states.topic IS 'Topic name for which knowledge is tracked';
+COMMENT ON COLUMN bkt_states.prob_knowledge IS 'Probability the user has mastered the topic';
+COMMENT ON COLUMN bkt_states.prob_guess IS 'Probability of a correct answer without knowledge';
+COMMENT ON COLUMN bkt_states.prob_slip IS 'Probability of an incorrect answer despite knowledge';
+COMMENT ON COLUMN bkt_states.prob_learn IS 'Probability of learning the topic on each attempt';
+COMMENT ON COLUMN bkt_states.confidence IS 'Confidence in the knowledge estimate (0-1)';
+COMMENT ON COLUMN bkt_states.last_updated IS 'Timestamp of last state update';
@@ -0,0 +1,5 @@
+-- Migration: Create IRT states table (rollback)
+
+DROP TRIGGER IF EXISTS trigger_irt_states_updated_at ON irt_states;
+DROP FUNCTION IF EXISTS update_irt_states_updated_at();
+DROP TABLE IF EXISTS irt_states;
@@ -45,6 +45,7 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
+DROP TRIGGER IF EXISTS trigger_irt_states_updated_at ON irt_states;
 CREATE TRIGGER trigger_irt_states_updated_at
     BEFORE UPDATE ON irt_states
     FOR EACH ROW
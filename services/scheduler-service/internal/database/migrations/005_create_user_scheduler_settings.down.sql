@@ -0,0 +1,5 @@
+-- Migration: Create user scheduler settings table (rollback)
+
+DROP TRIGGER IF EXISTS trigger_update_user_scheduler_settings_updated_at ON user_scheduler_settings;
+DROP FUNCTION IF EXISTS update_user_scheduler_settings_updated_at();
+DROP TABLE IF EXISTS user_scheduler_settings;
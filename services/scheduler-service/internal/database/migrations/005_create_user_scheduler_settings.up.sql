@@ -0,0 +1,41 @@
+-- Migration: Create user scheduler settings table
+-- Description: Creates table for per-user scheduling settings such as the booked exam date
+
+-- Create user_scheduler_settings table
+CREATE TABLE IF NOT EXISTS user_scheduler_settings (
+    user_id UUID PRIMARY KEY,
+    country_code VARCHAR(10),
+
+    -- Exam-aware scheduling
+    exam_date TIMESTAMPTZ,
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for performance
+CREATE INDEX IF NOT EXISTS idx_user_scheduler_settings_exam_date ON user_scheduler_settings(exam_date)
+    WHERE exam_date IS NOT NULL;
+
+-- Create function to automatically update updated_at timestamp
+CREATE OR REPLACE FUNCTION update_user_scheduler_settings_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Create trigger to automatically update updated_at
+DROP TRIGGER IF EXISTS trigger_update_user_scheduler_settings_updated_at ON user_scheduler_settings;
+CREATE TRIGGER trigger_update_user_scheduler_settings_updated_at
+    BEFORE UPDATE ON user_scheduler_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_user_scheduler_settings_updated_at();
+
+-- Add comments for documentation
+COMMENT ON TABLE user_scheduler_settings IS 'Stores per-user scheduling settings';
+COMMENT ON COLUMN user_scheduler_settings.user_id IS 'Reference to the user';
+COMMENT ON COLUMN user_scheduler_settings.country_code IS 'Jurisdiction the user is preparing for';
+COMMENT ON COLUMN user_scheduler_settings.exam_date IS 'Booked exam date; SM-2 intervals are capped so reviews land before it';
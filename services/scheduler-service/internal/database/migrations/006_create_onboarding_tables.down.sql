@@ -0,0 +1,8 @@
+-- Migration: Create onboarding tables (rollback)
+
+DROP TABLE IF EXISTS onboarding_stage_transitions;
+DROP TABLE IF EXISTS milestone_progress;
+DROP TABLE IF EXISTS learning_paths;
+DROP TABLE IF EXISTS placement_test_sessions;
+DROP TABLE IF EXISTS onboarding_analytics;
+DROP TABLE IF EXISTS user_onboarding;
@@ -10,11 +10,13 @@ CREATE TABLE IF NOT EXISTS user_onboarding (
     onboarding_data JSONB NOT NULL,
     is_active BOOLEAN DEFAULT TRUE,
     created_at TIMESTAMPTZ DEFAULT NOW(),
-    updated_at TIMESTAMPTZ DEFAULT NOW(),
-    
-    CONSTRAINT unique_active_user_onboarding UNIQUE (user_id) WHERE is_active = TRUE
+    updated_at TIMESTAMPTZ DEFAULT NOW()
 );
 
+-- Only one active onboarding record per user
+CREATE UNIQUE INDEX IF NOT EXISTS unique_active_user_onboarding ON user_onboarding(user_id)
+    WHERE is_active = TRUE;
+
 -- Onboarding analytics table
 CREATE TABLE IF NOT EXISTS onboarding_analytics (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -90,12 +92,12 @@ CREATE TABLE IF NOT EXISTS onboarding_stage_transitions (
     to_stage VARCHAR(50) NOT NULL,
     time_spent_seconds INTEGER DEFAULT 0,
     interaction_count INTEGER DEFAULT 0,
-    transition_time TIMESTAMPTZ DEFAULT NOW(),
-    
-    INDEX idx_onboarding_transitions_user (user_id),
-    INDEX idx_onboarding_transitions_time (transition_time)
+    transition_time TIMESTAMPTZ DEFAULT NOW()
 );
 
+CREATE INDEX IF NOT EXISTS idx_onboarding_transitions_user ON onboarding_stage_transitions(user_id);
+CREATE INDEX IF NOT EXISTS idx_onboarding_transitions_time ON onboarding_stage_transitions(transition_time);
+
 -- Create indexes for performance
 CREATE INDEX IF NOT EXISTS idx_user_onboarding_user_id ON user_onboarding(user_id);
 CREATE INDEX IF NOT EXISTS idx_user_onboarding_stage ON user_onboarding(stage);
@@ -0,0 +1,5 @@
+-- Migration: Create study plan tables (rollback)
+
+DROP TABLE IF EXISTS study_plan_history;
+DROP TABLE IF EXISTS study_activity;
+ALTER TABLE learning_paths DROP COLUMN IF EXISTS plan_evaluated_at;
@@ -0,0 +1,41 @@
+-- Migration: Create study plan tables
+-- Description: Creates tables for daily study activity and the revision history of adaptive study plans
+
+-- Daily study activity, aggregated from recorded attempts
+CREATE TABLE IF NOT EXISTS study_activity (
+    user_id UUID NOT NULL,
+    activity_date DATE NOT NULL,
+    study_seconds INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    correct_attempts INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, activity_date)
+);
+
+-- Study plan revisions; revision 1 is the plan generated during onboarding
+CREATE TABLE IF NOT EXISTS study_plan_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    path_id VARCHAR(255) NOT NULL,
+    revision INTEGER NOT NULL,
+    study_plan JSONB NOT NULL,
+    milestones JSONB NOT NULL DEFAULT '[]',
+    reasons JSONB NOT NULL DEFAULT '[]',
+    inputs JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT unique_study_plan_revision UNIQUE (path_id, revision)
+);
+
+-- When the study plan of a learning path was last re-evaluated, whether or not it changed
+ALTER TABLE learning_paths ADD COLUMN IF NOT EXISTS plan_evaluated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_study_activity_date ON study_activity(activity_date);
+CREATE INDEX IF NOT EXISTS idx_study_plan_history_user_created ON study_plan_history(user_id, created_at DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE study_activity IS 'Per-day study time and attempt counts used to re-plan study schedules';
+COMMENT ON TABLE study_plan_history IS 'Every revision of a learning path study plan, so learners can see how it evolved';
+COMMENT ON COLUMN study_plan_history.reasons IS 'Why the plan changed from the previous revision';
+COMMENT ON COLUMN study_plan_history.inputs IS 'Observed study time, velocity and exam date the revision was computed from';
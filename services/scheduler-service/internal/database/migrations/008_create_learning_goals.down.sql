@@ -0,0 +1,4 @@
+-- Migration: Create learning goals (rollback)
+-- learning_goals belongs to the shared schema, so only the index added here is dropped
+
+DROP INDEX IF EXISTS idx_learning_goals_user_active;
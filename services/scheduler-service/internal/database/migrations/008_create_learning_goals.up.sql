@@ -0,0 +1,31 @@
+-- Migration: Create learning goals
+-- Description: Creates the learning_goals table when the service runs without the shared schema
+-- and indexes the active-goal lookups made on every attempt
+
+-- Mirrors learning_goals in the shared schema, minus the foreign key to users
+CREATE TABLE IF NOT EXISTS learning_goals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+
+    -- Goal definition
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    target_topics JSONB NOT NULL,
+    target_mastery FLOAT DEFAULT 0.8,
+    target_date TIMESTAMPTZ,
+
+    -- Progress tracking
+    current_mastery FLOAT DEFAULT 0.0,
+    is_completed BOOLEAN DEFAULT FALSE,
+    completed_at TIMESTAMPTZ,
+
+    -- Audit
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Progress updates and scoring only ever read a user's open goals
+CREATE INDEX IF NOT EXISTS idx_learning_goals_user_active ON learning_goals(user_id)
+    WHERE is_completed = FALSE;
+
+COMMENT ON COLUMN learning_goals.current_mastery IS 'Mean BKT mastery across target_topics, updated after each attempt';
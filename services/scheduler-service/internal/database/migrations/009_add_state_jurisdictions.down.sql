@@ -0,0 +1,22 @@
+-- Migration: Add jurisdictions to learner state (rollback)
+-- Only the states of each user's active jurisdiction are kept
+
+DROP TABLE IF EXISTS user_jurisdictions;
+
+DELETE FROM bkt_states b
+WHERE b.jurisdiction <> COALESCE(
+    (SELECT UPPER(NULLIF(s.country_code, '')) FROM user_scheduler_settings s WHERE s.user_id = b.user_id),
+    'default');
+
+DELETE FROM irt_states i
+WHERE i.jurisdiction <> COALESCE(
+    (SELECT UPPER(NULLIF(s.country_code, '')) FROM user_scheduler_settings s WHERE s.user_id = i.user_id),
+    'default');
+
+ALTER TABLE bkt_states DROP CONSTRAINT IF EXISTS bkt_states_pkey;
+ALTER TABLE bkt_states DROP COLUMN IF EXISTS jurisdiction;
+ALTER TABLE bkt_states ADD PRIMARY KEY (user_id, topic);
+
+ALTER TABLE irt_states DROP CONSTRAINT IF EXISTS irt_states_pkey;
+ALTER TABLE irt_states DROP COLUMN IF EXISTS jurisdiction;
+ALTER TABLE irt_states ADD PRIMARY KEY (user_id, topic);
@@ -0,0 +1,42 @@
+-- Migration: Add jurisdictions to learner state
+-- Description: Keys BKT and IRT states by jurisdiction so learners can hold parallel progress
+-- in several jurisdictions, and stores per-jurisdiction settings such as the exam date
+
+-- Existing states belong to the jurisdiction the user is currently preparing for
+ALTER TABLE bkt_states ADD COLUMN IF NOT EXISTS jurisdiction VARCHAR(10) NOT NULL DEFAULT 'default';
+ALTER TABLE irt_states ADD COLUMN IF NOT EXISTS jurisdiction VARCHAR(10) NOT NULL DEFAULT 'default';
+
+UPDATE bkt_states b SET jurisdiction = UPPER(s.country_code)
+FROM user_scheduler_settings s
+WHERE s.user_id = b.user_id AND COALESCE(s.country_code, '') <> '';
+
+UPDATE irt_states i SET jurisdiction = UPPER(s.country_code)
+FROM user_scheduler_settings s
+WHERE s.user_id = i.user_id AND COALESCE(s.country_code, '') <> '';
+
+ALTER TABLE bkt_states DROP CONSTRAINT IF EXISTS bkt_states_pkey;
+ALTER TABLE bkt_states ADD PRIMARY KEY (user_id, jurisdiction, topic);
+
+ALTER TABLE irt_states DROP CONSTRAINT IF EXISTS irt_states_pkey;
+ALTER TABLE irt_states ADD PRIMARY KEY (user_id, jurisdiction, topic);
+
+-- Jurisdictions a user has studied for; the active one is user_scheduler_settings.country_code
+CREATE TABLE IF NOT EXISTS user_jurisdictions (
+    user_id UUID NOT NULL,
+    jurisdiction VARCHAR(10) NOT NULL,
+
+    -- Exam booked in this jurisdiction, restored when the user switches back to it
+    exam_date TIMESTAMPTZ,
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_active_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, jurisdiction)
+);
+
+-- Add comments for documentation
+COMMENT ON COLUMN bkt_states.jurisdiction IS 'Jurisdiction the knowledge estimate applies to';
+COMMENT ON COLUMN irt_states.jurisdiction IS 'Jurisdiction the ability estimate applies to';
+COMMENT ON TABLE user_jurisdictions IS 'Jurisdictions each user has studied for, with per-jurisdiction settings';
+COMMENT ON COLUMN user_jurisdictions.last_active_at IS 'When the user last switched to or away from this jurisdiction';
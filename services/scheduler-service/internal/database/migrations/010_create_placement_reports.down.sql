@@ -0,0 +1,5 @@
+-- Migration: Create placement reports (rollback)
+
+DROP INDEX IF EXISTS idx_placement_sessions_completed;
+DROP TABLE IF EXISTS placement_item_fit;
+DROP TABLE IF EXISTS placement_reports;
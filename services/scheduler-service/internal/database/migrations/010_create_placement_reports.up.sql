@@ -0,0 +1,46 @@
+-- Migration: Create placement reports
+-- Description: Stores periodic placement test reports per jurisdiction, with the
+-- fit statistics of every item answered in the tests each report covers
+
+CREATE TABLE IF NOT EXISTS placement_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    jurisdiction VARCHAR(10) NOT NULL,
+
+    -- Completed tests covered by the report
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    tests_analyzed INTEGER NOT NULL,
+
+    -- Test-level aggregates
+    mean_test_length FLOAT NOT NULL DEFAULT 0.0,
+    length_distribution JSONB NOT NULL DEFAULT '{}',
+    stopping_reasons JSONB NOT NULL DEFAULT '[]',
+    termination_se JSONB NOT NULL DEFAULT '{}',
+
+    generated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS placement_item_fit (
+    report_id UUID NOT NULL REFERENCES placement_reports(id) ON DELETE CASCADE,
+    item_id VARCHAR(255) NOT NULL,
+    responses INTEGER NOT NULL,
+    proportion_correct FLOAT NOT NULL,
+    expected_correct FLOAT NOT NULL,
+    infit FLOAT NOT NULL,
+    outfit FLOAT NOT NULL,
+    misfit BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (report_id, item_id)
+);
+
+-- Reports are read as the latest per jurisdiction
+CREATE INDEX IF NOT EXISTS idx_placement_reports_latest ON placement_reports(jurisdiction, generated_at DESC);
+
+-- The reporting job scans completed sessions by completion time
+CREATE INDEX IF NOT EXISTS idx_placement_sessions_completed ON placement_test_sessions(completed_at)
+    WHERE status = 'completed';
+
+COMMENT ON TABLE placement_reports IS 'Aggregated placement test statistics for content authors';
+COMMENT ON COLUMN placement_reports.length_distribution IS 'Number of tests by items administered';
+COMMENT ON COLUMN placement_reports.stopping_reasons IS 'Tests, share, mean length and mean final SE per stopping reason';
+COMMENT ON COLUMN placement_reports.termination_se IS 'Mean, median, 90th percentile and maximum SE at test termination';
+COMMENT ON TABLE placement_item_fit IS 'Infit and outfit mean-square statistics of placement items per report';
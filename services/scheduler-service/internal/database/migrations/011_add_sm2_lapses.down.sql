@@ -0,0 +1,4 @@
+-- Migration: Add lapse counts to SM-2 states (rollback)
+
+DROP INDEX IF EXISTS idx_sm2_states_user_lapses;
+ALTER TABLE sm2_states DROP COLUMN IF EXISTS lapses;
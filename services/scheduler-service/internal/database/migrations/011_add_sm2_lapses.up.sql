@@ -0,0 +1,11 @@
+-- Migration: Add lapse counts to SM-2 states
+-- Description: Tracks how often each item was failed after the first attempt, so items a
+-- learner keeps forgetting can be detected as leeches and remediated
+
+ALTER TABLE sm2_states ADD COLUMN IF NOT EXISTS lapses INTEGER NOT NULL DEFAULT 0 CHECK (lapses >= 0);
+
+-- Leech lookups read a user's items with the most lapses
+CREATE INDEX IF NOT EXISTS idx_sm2_states_user_lapses ON sm2_states(user_id, lapses DESC) WHERE lapses > 0;
+
+-- Add comments for documentation
+COMMENT ON COLUMN sm2_states.lapses IS 'Number of failed reviews after the first attempt';
@@ -0,0 +1,4 @@
+-- Migration: Create user data receipts (rollback)
+
+DROP INDEX IF EXISTS idx_user_data_receipts_user;
+DROP TABLE IF EXISTS user_data_receipts;
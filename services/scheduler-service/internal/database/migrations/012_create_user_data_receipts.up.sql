@@ -0,0 +1,24 @@
+-- Migration: Create user data receipts
+-- Description: Keeps the signed receipt of every deletion of a user's learning state,
+-- keyed by the caller's request ID so repeated deletion requests return the same receipt
+
+CREATE TABLE IF NOT EXISTS user_data_receipts (
+    request_id VARCHAR(255) PRIMARY KEY,
+    receipt_id UUID NOT NULL UNIQUE,
+    user_id UUID NOT NULL,
+    operation VARCHAR(20) NOT NULL CHECK (operation IN ('delete')),
+
+    -- Signed receipt, stored exactly as signed
+    payload TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    key_id VARCHAR(64) NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Receipts are looked up per user when auditing erasures
+CREATE INDEX IF NOT EXISTS idx_user_data_receipts_user ON user_data_receipts(user_id, created_at DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE user_data_receipts IS 'Signed receipts of learning state deletions; they hold no learning state and outlive the erasure';
+COMMENT ON COLUMN user_data_receipts.payload IS 'Canonical JSON of the receipt that the signature covers';
@@ -0,0 +1,4 @@
+-- Migration: Create item external IDs (rollback)
+
+DROP INDEX IF EXISTS idx_item_external_ids_item;
+DROP TABLE IF EXISTS item_external_ids;
@@ -0,0 +1,20 @@
+-- Migration: Create item external IDs
+-- Description: Maps the item IDs of other flashcard apps to catalog items, so review
+-- logs exported from them can be imported
+
+CREATE TABLE IF NOT EXISTS item_external_ids (
+    source VARCHAR(50) NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    item_id UUID NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+
+    PRIMARY KEY (source, external_id)
+);
+
+-- Mappings are listed per item when items are merged or retired
+CREATE INDEX IF NOT EXISTS idx_item_external_ids_item ON item_external_ids(item_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE item_external_ids IS 'External item IDs of imported review logs, per source app';
+COMMENT ON COLUMN item_external_ids.source IS 'App the review log was exported from, such as anki';
@@ -0,0 +1,6 @@
+-- Migration: Create replay tables (rollback)
+
+DROP TABLE IF EXISTS irt_states_shadow;
+DROP TABLE IF EXISTS bkt_states_shadow;
+DROP TABLE IF EXISTS sm2_states_shadow;
+DROP TABLE IF EXISTS replay_runs;
@@ -0,0 +1,71 @@
+-- Migration: Create replay tables
+-- Description: Stores runs that rebuild learner state from the shared attempts table, with
+-- the rebuilt SM-2, BKT and IRT states kept in shadow tables until they are swapped in
+
+CREATE TABLE IF NOT EXISTS replay_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('users', 'all')),
+    status VARCHAR(20) NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'replayed', 'failed', 'swapped', 'discarded')),
+
+    -- Progress
+    users_replayed INTEGER NOT NULL DEFAULT 0,
+    attempts_replayed BIGINT NOT NULL DEFAULT 0,
+    users_swapped INTEGER NOT NULL DEFAULT 0,
+    users_skipped INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ,
+    swapped_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS sm2_states_shadow (
+    run_id UUID NOT NULL REFERENCES replay_runs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    item_id UUID NOT NULL,
+    easiness_factor DECIMAL(4,2) NOT NULL,
+    interval_days INTEGER NOT NULL,
+    repetition INTEGER NOT NULL,
+    next_due TIMESTAMPTZ NOT NULL,
+    last_reviewed TIMESTAMPTZ NOT NULL,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (run_id, user_id, item_id)
+);
+
+CREATE TABLE IF NOT EXISTS bkt_states_shadow (
+    run_id UUID NOT NULL REFERENCES replay_runs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    jurisdiction VARCHAR(10) NOT NULL,
+    topic VARCHAR(100) NOT NULL,
+    prob_knowledge DECIMAL(5,4) NOT NULL,
+    prob_guess DECIMAL(5,4) NOT NULL,
+    prob_slip DECIMAL(5,4) NOT NULL,
+    prob_learn DECIMAL(5,4) NOT NULL,
+    attempts_count INTEGER NOT NULL,
+    correct_count INTEGER NOT NULL,
+    confidence DECIMAL(5,4) NOT NULL,
+    last_updated TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (run_id, user_id, jurisdiction, topic)
+);
+
+CREATE TABLE IF NOT EXISTS irt_states_shadow (
+    run_id UUID NOT NULL REFERENCES replay_runs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    jurisdiction VARCHAR(10) NOT NULL,
+    topic VARCHAR(100) NOT NULL,
+    theta DECIMAL(8,4) NOT NULL,
+    theta_variance DECIMAL(8,4) NOT NULL,
+    confidence DECIMAL(5,4) NOT NULL,
+    attempts_count INTEGER NOT NULL,
+    correct_count INTEGER NOT NULL,
+    last_updated TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (run_id, user_id, jurisdiction, topic)
+);
+
+-- Add comments for documentation
+COMMENT ON TABLE replay_runs IS 'Runs rebuilding learner state by replaying the attempts table';
+COMMENT ON COLUMN replay_runs.users_skipped IS 'Users not swapped because they made attempts after the run started';
+COMMENT ON TABLE sm2_states_shadow IS 'SM-2 states rebuilt by a replay run, not yet swapped into sm2_states';
+COMMENT ON TABLE bkt_states_shadow IS 'BKT states rebuilt by a replay run, not yet swapped into bkt_states';
+COMMENT ON TABLE irt_states_shadow IS 'IRT states rebuilt by a replay run, not yet swapped into irt_states';
@@ -0,0 +1,6 @@
+-- Migration: Create config tables (rollback)
+
+DROP INDEX IF EXISTS idx_config_audit_log_key;
+DROP INDEX IF EXISTS idx_config_audit_log_changed;
+DROP TABLE IF EXISTS config_audit_log;
+DROP TABLE IF EXISTS config_values;
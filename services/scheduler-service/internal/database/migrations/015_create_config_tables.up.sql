@@ -0,0 +1,30 @@
+-- Migration: Create config tables
+-- Description: Stores runtime overrides of hot-reloadable settings, applied by every
+-- replica on its next reload, and an audit log of who changed them
+
+CREATE TABLE IF NOT EXISTS config_values (
+    key VARCHAR(100) PRIMARY KEY,
+    value TEXT NOT NULL,
+
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS config_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR(100) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    actor VARCHAR(255) NOT NULL,
+    reason TEXT,
+
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- History is listed newest first, optionally for one setting
+CREATE INDEX IF NOT EXISTS idx_config_audit_log_changed ON config_audit_log(changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_config_audit_log_key ON config_audit_log(key, changed_at DESC);
+
+-- Add comments for documentation
+COMMENT ON TABLE config_values IS 'Runtime overrides of hot-reloadable settings, by environment variable name';
+COMMENT ON TABLE config_audit_log IS 'Changes to config_values; a NULL old_value is a new override, a NULL new_value a removed one';
@@ -0,0 +1,6 @@
+-- Migration: Create tenant tables (rollback)
+
+DROP INDEX IF EXISTS idx_tenant_members_user;
+DROP TABLE IF EXISTS tenant_members;
+DROP TABLE IF EXISTS tenant_items;
+DROP TABLE IF EXISTS tenants;
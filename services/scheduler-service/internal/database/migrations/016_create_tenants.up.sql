@@ -0,0 +1,47 @@
+-- Migration: Create tenant tables
+-- Description: Organizations such as driving schools that manage cohorts of learners,
+-- with their own subset of the item catalog and their own scoring strategy
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+
+    -- Scoring strategy of the tenant's learners; NULL uses the default strategy
+    scoring_strategy VARCHAR(100),
+    -- Weights overriding those of the scoring strategy, as {"urgency", "mastery", "difficulty", "exploration"}
+    scoring_weights JSONB,
+    -- Topics the tenant's learners are scheduled items of; empty allows every topic
+    topics JSONB NOT NULL DEFAULT '[]',
+
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Audit fields
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Items the tenant's learners are scheduled; a tenant without rows allows every item
+CREATE TABLE IF NOT EXISTS tenant_items (
+    tenant_id VARCHAR(64) NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    item_id VARCHAR(255) NOT NULL,
+
+    PRIMARY KEY (tenant_id, item_id)
+);
+
+CREATE TABLE IF NOT EXISTS tenant_members (
+    tenant_id VARCHAR(64) NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'learner' CHECK (role IN ('learner', 'instructor')),
+
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+-- Learners may belong to several tenants
+CREATE INDEX IF NOT EXISTS idx_tenant_members_user ON tenant_members(user_id);
+
+-- Add comments for documentation
+COMMENT ON TABLE tenants IS 'Organizations managing cohorts of learners, with their own content and scoring';
+COMMENT ON TABLE tenant_items IS 'Subset of the item catalog scheduled for a tenant''s learners';
+COMMENT ON TABLE tenant_members IS 'Learners and instructors of each tenant';
@@ -0,0 +1,8 @@
+-- Migration: Create cohort summary tables (rollback)
+
+DROP TABLE IF EXISTS cohort_refreshes;
+DROP INDEX IF EXISTS idx_cohort_item_failures_failures;
+DROP TABLE IF EXISTS cohort_item_failures;
+DROP TABLE IF EXISTS cohort_topic_mastery;
+DROP INDEX IF EXISTS idx_cohort_learner_summaries_risk;
+DROP TABLE IF EXISTS cohort_learner_summaries;
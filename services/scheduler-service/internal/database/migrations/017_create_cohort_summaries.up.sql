@@ -0,0 +1,88 @@
+-- Migration: Create cohort summary tables
+-- Description: Summaries of each tenant's learners for instructor dashboards, refreshed
+-- in the background from the state and attempts tables so reports never scan them
+
+-- One row per learner of a tenant, in their active jurisdiction
+CREATE TABLE IF NOT EXISTS cohort_learner_summaries (
+    tenant_id VARCHAR(64) NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    jurisdiction VARCHAR(10) NOT NULL,
+
+    -- BKT mastery by topic, for heatmap rows
+    topic_mastery JSONB NOT NULL DEFAULT '{}',
+    mean_mastery DOUBLE PRECISION NOT NULL DEFAULT 0,
+    topics_started INTEGER NOT NULL DEFAULT 0,
+    topics_mastered INTEGER NOT NULL DEFAULT 0,
+    weakest_topic VARCHAR(100),
+    weakest_mastery DOUBLE PRECISION,
+
+    -- SM-2 reviews and recent attempts
+    review_items INTEGER NOT NULL DEFAULT 0,
+    due_items INTEGER NOT NULL DEFAULT 0,
+    overdue_items INTEGER NOT NULL DEFAULT 0,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    recent_attempts INTEGER NOT NULL DEFAULT 0,
+    recent_correct INTEGER NOT NULL DEFAULT 0,
+    last_active_at TIMESTAMPTZ,
+    exam_date TIMESTAMPTZ,
+
+    risk_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    risk_reasons JSONB NOT NULL DEFAULT '[]',
+
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+-- Learners at risk are listed by descending risk score
+CREATE INDEX IF NOT EXISTS idx_cohort_learner_summaries_risk ON cohort_learner_summaries(tenant_id, risk_score DESC, user_id);
+
+-- One row per topic practised by a tenant's learners
+CREATE TABLE IF NOT EXISTS cohort_topic_mastery (
+    tenant_id VARCHAR(64) NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    topic VARCHAR(100) NOT NULL,
+
+    learners INTEGER NOT NULL DEFAULT 0,
+    mean_mastery DOUBLE PRECISION NOT NULL DEFAULT 0,
+    mastered INTEGER NOT NULL DEFAULT 0,
+    struggling INTEGER NOT NULL DEFAULT 0,
+    -- Learners per mastery band of 0.2, lowest first
+    distribution JSONB NOT NULL DEFAULT '[]',
+
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, topic)
+);
+
+-- One row per item a tenant's learners failed within the activity window
+CREATE TABLE IF NOT EXISTS cohort_item_failures (
+    tenant_id VARCHAR(64) NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    item_id VARCHAR(255) NOT NULL,
+
+    attempts INTEGER NOT NULL DEFAULT 0,
+    failures INTEGER NOT NULL DEFAULT 0,
+    failure_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    learners INTEGER NOT NULL DEFAULT 0,
+    learners_failed INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ,
+
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, item_id)
+);
+
+-- Items are listed by descending failures
+CREATE INDEX IF NOT EXISTS idx_cohort_item_failures_failures ON cohort_item_failures(tenant_id, failures DESC, item_id);
+
+-- When each tenant's summaries were last refreshed
+CREATE TABLE IF NOT EXISTS cohort_refreshes (
+    tenant_id VARCHAR(64) PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    learners INTEGER NOT NULL DEFAULT 0,
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Add comments for documentation
+COMMENT ON TABLE cohort_learner_summaries IS 'Mastery, review backlog and risk of each learner of a tenant, refreshed in the background';
+COMMENT ON TABLE cohort_topic_mastery IS 'Mastery distribution of a tenant''s learners per topic, refreshed in the background';
+COMMENT ON TABLE cohort_item_failures IS 'Items most failed by a tenant''s learners within the activity window, refreshed in the background';
+COMMENT ON COLUMN cohort_learner_summaries.risk_reasons IS 'Why the learner is at risk: low_mastery, inactive, overdue_reviews, low_accuracy, exam_soon';
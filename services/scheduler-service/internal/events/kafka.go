@@ -0,0 +1,214 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/tracing"
+
+	"github.com/segmentio/kafka-go"
+	otelcodes "go.opentelemetry.io/otel/codes"
+)
+
+// EventType represents the type of event
+type EventType string
+
+const (
+	EventTypeGoalCompleted EventType = "scheduler.goal.completed"
+)
+
+// source identifies this service in event payloads and message headers
+const source = "scheduler-service"
+
+// BaseEvent represents the common fields for all events
+type BaseEvent struct {
+	ID        string    `json:"id"`
+	Type      EventType `json:"type"`
+	Source    string    `json:"source"`
+	UserID    string    `json:"user_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Version   string    `json:"version"`
+}
+
+// GoalCompletedEvent is published once when a learning goal reaches its target mastery
+type GoalCompletedEvent struct {
+	BaseEvent
+	Data GoalCompletedData `json:"data"`
+}
+
+type GoalCompletedData struct {
+	GoalID         string     `json:"goal_id"`
+	Title          string     `json:"title"`
+	TargetTopics   []string   `json:"target_topics"`
+	TargetMastery  float64    `json:"target_mastery"`
+	CurrentMastery float64    `json:"current_mastery"`
+	TargetDate     *time.Time `json:"target_date,omitempty"`
+	CompletedAt    time.Time  `json:"completed_at"`
+}
+
+// EventPublisher interface for publishing events
+type EventPublisher interface {
+	PublishGoalCompleted(ctx context.Context, userID string, data GoalCompletedData) error
+	Close() error
+}
+
+// NewEventPublisher returns a Kafka publisher, or a no-op publisher when no brokers are configured
+func NewEventPublisher(cfg *config.KafkaConfig, log *logger.Logger) EventPublisher {
+	if len(cfg.Brokers) == 0 {
+		return NewNoOpEventPublisher()
+	}
+	return NewKafkaEventPublisher(cfg, log)
+}
+
+// KafkaEventPublisher implements EventPublisher using Kafka
+type KafkaEventPublisher struct {
+	writers map[string]*kafka.Writer
+	config  *config.KafkaConfig
+	logger  *logger.Logger
+}
+
+// NewKafkaEventPublisher creates a new Kafka event publisher
+func NewKafkaEventPublisher(cfg *config.KafkaConfig, log *logger.Logger) *KafkaEventPublisher {
+	writers := make(map[string]*kafka.Writer)
+
+	writers[cfg.EventsTopic] = &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Topic:        cfg.EventsTopic,
+		Balancer:     &kafka.LeastBytes{},
+		RequiredAcks: kafka.RequireOne,
+		Async:        false,
+		Compression:  kafka.Snappy,
+		BatchTimeout: 10 * time.Millisecond,
+		BatchSize:    100,
+	}
+
+	return &KafkaEventPublisher{
+		writers: writers,
+		config:  cfg,
+		logger:  log,
+	}
+}
+
+// PublishGoalCompleted publishes a goal completed event
+func (p *KafkaEventPublisher) PublishGoalCompleted(ctx context.Context, userID string, data GoalCompletedData) error {
+	event := GoalCompletedEvent{
+		BaseEvent: newBaseEvent(EventTypeGoalCompleted, userID),
+		Data:      data,
+	}
+
+	return p.publishEvent(ctx, p.config.EventsTopic, userID, event.ID, event)
+}
+
+// publishEvent publishes an event to Kafka, keyed by user so a user's events stay ordered.
+// The message headers carry the trace context so consumers continue the trace.
+func (p *KafkaEventPublisher) publishEvent(ctx context.Context, topic, key, eventID string, event interface{}) (err error) {
+	ctx, span := tracing.StartProducerSpan(ctx, topic)
+	defer func() {
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	log := p.logger.WithContext(ctx).WithField("topic", topic).WithField("key", key)
+
+	writer, exists := p.writers[topic]
+	if !exists {
+		return fmt.Errorf("no writer configured for topic: %s", topic)
+	}
+
+	// Serialize event to JSON
+	eventData, err := json.Marshal(event)
+	if err != nil {
+		log.WithError(err).Error("Failed to marshal event")
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	// Create Kafka message
+	message := kafka.Message{
+		Key:   []byte(key),
+		Value: eventData,
+		Headers: tracing.InjectKafkaHeaders(ctx, []kafka.Header{
+			{Key: "content-type", Value: []byte("application/json")},
+			{Key: "source", Value: []byte(source)},
+			{Key: "event-id", Value: []byte(eventID)},
+		}),
+		Time: time.Now(),
+	}
+
+	// Publish message with retry logic
+	maxRetries := 3
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		err = writer.WriteMessages(ctx, message)
+		if err == nil {
+			log.WithField("attempt", attempt).Debug("Event published successfully")
+			return nil
+		}
+
+		log.WithError(err).WithField("attempt", attempt).Warn("Failed to publish event")
+
+		if attempt < maxRetries {
+			// Exponential backoff
+			backoff := time.Duration(attempt*attempt) * 100 * time.Millisecond
+			time.Sleep(backoff)
+		}
+	}
+
+	log.WithError(err).Error("Failed to publish event after all retries")
+	return fmt.Errorf("failed to publish event after %d attempts: %w", maxRetries, err)
+}
+
+// Close closes all Kafka writers
+func (p *KafkaEventPublisher) Close() error {
+	var lastErr error
+	for topic, writer := range p.writers {
+		if err := writer.Close(); err != nil {
+			p.logger.WithContext(context.Background()).WithError(err).WithField("topic", topic).Error("Failed to close Kafka writer")
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// newBaseEvent fills in the common event fields
+func newBaseEvent(eventType EventType, userID string) BaseEvent {
+	return BaseEvent{
+		ID:        newEventID(),
+		Type:      eventType,
+		Source:    source,
+		UserID:    userID,
+		Timestamp: time.Now(),
+		Version:   "1.0",
+	}
+}
+
+// newEventID returns a random version 4 UUID
+func newEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// NoOpEventPublisher is a no-op implementation used when Kafka is not configured and in tests
+type NoOpEventPublisher struct{}
+
+func NewNoOpEventPublisher() *NoOpEventPublisher {
+	return &NoOpEventPublisher{}
+}
+
+func (p *NoOpEventPublisher) PublishGoalCompleted(ctx context.Context, userID string, data GoalCompletedData) error {
+	return nil
+}
+
+func (p *NoOpEventPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,386 @@
+package goals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/events"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/state"
+)
+
+var (
+	// ErrGoalNotFound is returned when a goal does not exist or belongs to another user
+	ErrGoalNotFound = errors.New("learning goal not found")
+	// ErrInvalidGoal is returned for a goal definition that fails validation
+	ErrInvalidGoal = errors.New("invalid learning goal")
+	// ErrGoalLimitReached is returned when a user already has the maximum number of open goals
+	ErrGoalLimitReached = errors.New("open learning goal limit reached")
+)
+
+const (
+	// DefaultTargetMastery matches the learning_goals column default
+	DefaultTargetMastery = 0.8
+
+	maxOpenGoals       = 20
+	maxTargetTopics    = 50
+	maxTitleLength     = 255
+	activeGoalsTTL     = 10 * time.Minute
+	progressEpsilon    = 0.001 // Smallest change in current mastery worth writing back
+	urgencyNoDate      = 0.5   // Urgency of a goal without a target date
+	urgencyMin         = 0.25  // Urgency of a goal whose target date is beyond the horizon
+	urgencyHorizonDays = 60
+)
+
+// Goal is a user's learning goal: reach target mastery in a set of topics, optionally by a date
+type Goal struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	TargetTopics   []string   `json:"target_topics"`
+	TargetMastery  float64    `json:"target_mastery"`
+	TargetDate     *time.Time `json:"target_date,omitempty"`
+	CurrentMastery float64    `json:"current_mastery"`
+	IsCompleted    bool       `json:"is_completed"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// GoalDefinition holds the user-editable fields of a goal
+type GoalDefinition struct {
+	Title         string
+	Description   string
+	TargetTopics  []string
+	TargetMastery float64 // DefaultTargetMastery when zero
+	TargetDate    *time.Time
+}
+
+// Service manages learning goals and keeps their progress in step with BKT mastery
+type Service struct {
+	logger     *logger.Logger
+	cache      *cache.RedisClient
+	repository *Repository
+	bktManager *state.BKTStateManager
+	publisher  events.EventPublisher
+
+	masteryGapBoost float64
+}
+
+// NewService creates a new learning goal service
+func NewService(
+	cfg *config.GoalsConfig,
+	logger *logger.Logger,
+	db *database.DB,
+	cache *cache.RedisClient,
+	bktManager *state.BKTStateManager,
+	publisher events.EventPublisher,
+) *Service {
+	return &Service{
+		logger:          logger,
+		cache:           cache,
+		repository:      NewRepository(db),
+		bktManager:      bktManager,
+		publisher:       publisher,
+		masteryGapBoost: cfg.MasteryGapBoost,
+	}
+}
+
+// CreateGoal validates and stores a new goal, then evaluates it against current mastery
+func (s *Service) CreateGoal(ctx context.Context, userID string, definition GoalDefinition) (*Goal, error) {
+	definition, err := normalizeDefinition(definition, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.activeGoals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(open) >= maxOpenGoals {
+		return nil, ErrGoalLimitReached
+	}
+
+	goal, err := s.repository.Create(ctx, userID, definition)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, userID)
+
+	return s.refresh(ctx, goal)
+}
+
+// GetGoal returns one of the user's goals
+func (s *Service) GetGoal(ctx context.Context, userID, goalID string) (*Goal, error) {
+	return s.repository.Get(ctx, userID, goalID)
+}
+
+// ListGoals returns the user's goals, open goals first and soonest target date first
+func (s *Service) ListGoals(ctx context.Context, userID string, includeCompleted bool) ([]Goal, error) {
+	return s.repository.List(ctx, userID, includeCompleted)
+}
+
+// UpdateGoal replaces the definition of a goal. Completion is re-evaluated against the
+// new definition, so raising the target can reopen a completed goal.
+func (s *Service) UpdateGoal(ctx context.Context, userID, goalID string, definition GoalDefinition) (*Goal, error) {
+	definition, err := normalizeDefinition(definition, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.repository.Update(ctx, userID, goalID, definition)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate(ctx, userID)
+
+	return s.refresh(ctx, goal)
+}
+
+// DeleteGoal removes one of the user's goals
+func (s *Service) DeleteGoal(ctx context.Context, userID, goalID string) error {
+	if err := s.repository.Delete(ctx, userID, goalID); err != nil {
+		return err
+	}
+	s.invalidate(ctx, userID)
+	return nil
+}
+
+// UpdateProgress recomputes the user's open goals from their current BKT mastery,
+// marks goals whose topics all reached the target as completed and publishes a
+// completion event for each. It returns the goals completed by this call.
+func (s *Service) UpdateProgress(ctx context.Context, userID string) ([]Goal, error) {
+	open, err := s.activeGoals(ctx, userID)
+	if err != nil || len(open) == 0 {
+		return nil, err
+	}
+
+	mastery, err := s.topicMastery(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var completed []Goal
+	changed := false
+	for _, goal := range open {
+		current, complete := evaluateProgress(goal, mastery)
+		if !complete && math.Abs(current-goal.CurrentMastery) < progressEpsilon {
+			continue
+		}
+
+		justCompleted, err := s.repository.UpdateProgress(ctx, goal.ID, current, complete, now)
+		if err != nil {
+			return completed, err
+		}
+		changed = true
+
+		// Only the writer that flipped is_completed publishes, so concurrent attempts
+		// cannot complete a goal twice
+		if justCompleted {
+			goal.CurrentMastery = current
+			goal.IsCompleted = true
+			goal.CompletedAt = &now
+			completed = append(completed, goal)
+			s.publishCompleted(ctx, goal)
+		}
+	}
+
+	if changed {
+		s.invalidate(ctx, userID)
+	}
+
+	return completed, nil
+}
+
+// TopicWeights returns mastery gap multipliers for the topics of the user's open goals.
+// Goals closer to their target date weigh more; a topic in several goals takes the
+// weight of the most urgent one.
+func (s *Service) TopicWeights(ctx context.Context, userID string) (map[string]float64, error) {
+	open, err := s.activeGoals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return topicWeights(open, s.masteryGapBoost, time.Now()), nil
+}
+
+// refresh evaluates a freshly written goal against current mastery so the caller
+// sees its real progress rather than the stored default
+func (s *Service) refresh(ctx context.Context, goal *Goal) (*Goal, error) {
+	if _, err := s.UpdateProgress(ctx, goal.UserID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("goal_id", goal.ID).Warn("Failed to evaluate goal progress")
+		return goal, nil
+	}
+	return s.repository.Get(ctx, goal.UserID, goal.ID)
+}
+
+// activeGoals returns the user's open goals, cached in Redis
+func (s *Service) activeGoals(ctx context.Context, userID string) ([]Goal, error) {
+	var goals []Goal
+	if err := s.cache.Get(ctx, activeGoalsKey(userID), &goals); err == nil {
+		return goals, nil
+	}
+
+	goals, err := s.repository.List(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, activeGoalsKey(userID), goals, activeGoalsTTL); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to cache active goals")
+	}
+
+	return goals, nil
+}
+
+func (s *Service) invalidate(ctx context.Context, userID string) {
+	if err := s.cache.Delete(ctx, activeGoalsKey(userID)); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to invalidate active goals")
+	}
+}
+
+func (s *Service) topicMastery(ctx context.Context, userID string) (map[string]float64, error) {
+	states, err := s.bktManager.GetUserStates(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mastery for goals: %w", err)
+	}
+
+	mastery := make(map[string]float64, len(states))
+	for topic, bktState := range states {
+		mastery[topic] = bktState.ProbKnowledge
+	}
+	return mastery, nil
+}
+
+func (s *Service) publishCompleted(ctx context.Context, goal Goal) {
+	data := events.GoalCompletedData{
+		GoalID:         goal.ID,
+		Title:          goal.Title,
+		TargetTopics:   goal.TargetTopics,
+		TargetMastery:  goal.TargetMastery,
+		CurrentMastery: goal.CurrentMastery,
+		TargetDate:     goal.TargetDate,
+		CompletedAt:    *goal.CompletedAt,
+	}
+
+	log := s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id": goal.UserID,
+		"goal_id": goal.ID,
+	})
+
+	// The goal is already marked completed, so a lost event is logged rather than retried
+	if err := s.publisher.PublishGoalCompleted(ctx, goal.UserID, data); err != nil {
+		log.WithError(err).Error("Failed to publish goal completed event")
+		return
+	}
+	log.Info("Learning goal completed")
+}
+
+// normalizeDefinition validates a goal definition and fills in defaults
+func normalizeDefinition(definition GoalDefinition, now time.Time) (GoalDefinition, error) {
+	definition.Title = strings.TrimSpace(definition.Title)
+	if definition.Title == "" {
+		return definition, fmt.Errorf("%w: title is required", ErrInvalidGoal)
+	}
+	if len(definition.Title) > maxTitleLength {
+		return definition, fmt.Errorf("%w: title must be at most %d characters", ErrInvalidGoal, maxTitleLength)
+	}
+
+	topics := make([]string, 0, len(definition.TargetTopics))
+	seen := make(map[string]bool, len(definition.TargetTopics))
+	for _, topic := range definition.TargetTopics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" || seen[topic] {
+			continue
+		}
+		seen[topic] = true
+		topics = append(topics, topic)
+	}
+	if len(topics) == 0 {
+		return definition, fmt.Errorf("%w: at least one target topic is required", ErrInvalidGoal)
+	}
+	if len(topics) > maxTargetTopics {
+		return definition, fmt.Errorf("%w: at most %d target topics are allowed", ErrInvalidGoal, maxTargetTopics)
+	}
+	definition.TargetTopics = topics
+
+	if definition.TargetMastery == 0 {
+		definition.TargetMastery = DefaultTargetMastery
+	}
+	if definition.TargetMastery < 0 || definition.TargetMastery > 1 {
+		return definition, fmt.Errorf("%w: target mastery must be between 0 and 1", ErrInvalidGoal)
+	}
+
+	if definition.TargetDate != nil && !definition.TargetDate.After(now) {
+		return definition, fmt.Errorf("%w: target date must be in the future", ErrInvalidGoal)
+	}
+
+	return definition, nil
+}
+
+// evaluateProgress returns the goal's current mastery, the mean over its target topics,
+// and whether every target topic has reached the target mastery. Topics never practiced
+// count as zero mastery.
+func evaluateProgress(goal Goal, mastery map[string]float64) (float64, bool) {
+	if len(goal.TargetTopics) == 0 {
+		return 0, false
+	}
+
+	total := 0.0
+	complete := true
+	for _, topic := range goal.TargetTopics {
+		topicMastery := mastery[topic]
+		total += topicMastery
+		if topicMastery < goal.TargetMastery {
+			complete = false
+		}
+	}
+
+	return total / float64(len(goal.TargetTopics)), complete
+}
+
+// goalUrgency rises from urgencyMin for target dates beyond the horizon to 1.0 on the
+// target date; goals without a date sit in between
+func goalUrgency(goal Goal, now time.Time) float64 {
+	if goal.TargetDate == nil {
+		return urgencyNoDate
+	}
+
+	daysLeft := goal.TargetDate.Sub(now).Hours() / 24.0
+	urgency := 1.0 - (1.0-urgencyMin)*daysLeft/urgencyHorizonDays
+	return math.Max(urgencyMin, math.Min(1.0, urgency))
+}
+
+// topicWeights maps each topic of the open goals to 1 + boost * urgency of its most urgent goal
+func topicWeights(goals []Goal, boost float64, now time.Time) map[string]float64 {
+	weights := make(map[string]float64)
+	if boost <= 0 {
+		return weights
+	}
+
+	for _, goal := range goals {
+		if goal.IsCompleted {
+			continue
+		}
+		weight := 1.0 + boost*goalUrgency(goal, now)
+		for _, topic := range goal.TargetTopics {
+			if weight > weights[topic] {
+				weights[topic] = weight
+			}
+		}
+	}
+
+	return weights
+}
+
+func activeGoalsKey(userID string) string {
+	return fmt.Sprintf("scheduler:goals:%s", userID)
+}
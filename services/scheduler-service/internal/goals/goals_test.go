@@ -0,0 +1,98 @@
+package goals
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalizeDefinition(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		definition GoalDefinition
+		valid      bool
+	}{
+		{"valid", GoalDefinition{Title: "Pass the theory test", TargetTopics: []string{"road_rules"}}, true},
+		{"missing title", GoalDefinition{Title: "  ", TargetTopics: []string{"road_rules"}}, false},
+		{"no topics", GoalDefinition{Title: "Signs", TargetTopics: []string{" ", ""}}, false},
+		{"target mastery above 1", GoalDefinition{Title: "Signs", TargetTopics: []string{"traffic_signs"}, TargetMastery: 1.5}, false},
+		{"target date in the past", GoalDefinition{Title: "Signs", TargetTopics: []string{"traffic_signs"}, TargetDate: &past}, false},
+	}
+
+	for _, test := range tests {
+		_, err := normalizeDefinition(test.definition, now)
+		if test.valid && err != nil {
+			t.Errorf("%s: expected valid definition, got %v", test.name, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidGoal) {
+			t.Errorf("%s: expected ErrInvalidGoal, got %v", test.name, err)
+		}
+	}
+
+	definition, _ := normalizeDefinition(GoalDefinition{
+		Title:        " Signs ",
+		TargetTopics: []string{"traffic_signs", " traffic_signs", "parking"},
+	}, now)
+	if definition.Title != "Signs" || len(definition.TargetTopics) != 2 || definition.TargetMastery != DefaultTargetMastery {
+		t.Errorf("Expected trimmed title, deduplicated topics and default mastery, got %+v", definition)
+	}
+}
+
+func TestEvaluateProgress(t *testing.T) {
+	goal := Goal{TargetTopics: []string{"traffic_signs", "parking"}, TargetMastery: 0.8}
+
+	tests := []struct {
+		name     string
+		mastery  map[string]float64
+		current  float64
+		complete bool
+	}{
+		{"never practiced", nil, 0, false},
+		{"one topic short of target", map[string]float64{"traffic_signs": 0.9, "parking": 0.7}, 0.8, false},
+		{"all topics at target", map[string]float64{"traffic_signs": 0.9, "parking": 0.8}, 0.85, true},
+	}
+
+	for _, test := range tests {
+		current, complete := evaluateProgress(goal, test.mastery)
+		if current < test.current-1e-9 || current > test.current+1e-9 {
+			t.Errorf("%s: expected current mastery %.2f, got %.2f", test.name, test.current, current)
+		}
+		if complete != test.complete {
+			t.Errorf("%s: expected complete=%v, got %v", test.name, test.complete, complete)
+		}
+	}
+}
+
+func TestTopicWeights(t *testing.T) {
+	now := time.Now()
+	soon := now.AddDate(0, 0, 3)
+	distant := now.AddDate(1, 0, 0)
+
+	open := []Goal{
+		{ID: "distant", TargetTopics: []string{"parking", "road_rules"}, TargetDate: &distant},
+		{ID: "soon", TargetTopics: []string{"parking"}, TargetDate: &soon},
+		{ID: "undated", TargetTopics: []string{"traffic_signs"}},
+		{ID: "done", TargetTopics: []string{"highway"}, IsCompleted: true},
+	}
+
+	weights := topicWeights(open, 1.0, now)
+
+	if weights["road_rules"] != 1.0+urgencyMin {
+		t.Errorf("Expected distant goal topic weight %.2f, got %.2f", 1.0+urgencyMin, weights["road_rules"])
+	}
+	if weights["parking"] <= weights["traffic_signs"] || weights["traffic_signs"] <= weights["road_rules"] {
+		t.Errorf("Expected weights to follow urgency, got %v", weights)
+	}
+	if weights["parking"] > 2.0 {
+		t.Errorf("Expected weights capped at 1 + boost, got %.2f", weights["parking"])
+	}
+	if _, exists := weights["highway"]; exists {
+		t.Errorf("Expected completed goals to carry no weight")
+	}
+	if len(topicWeights(open, 0, now)) != 0 {
+		t.Errorf("Expected no weights with goal boosting disabled")
+	}
+}
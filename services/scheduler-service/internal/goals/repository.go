@@ -0,0 +1,185 @@
+package goals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Repository persists learning goals in the learning_goals table
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new learning goal repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create inserts a new goal for the user
+func (r *Repository) Create(ctx context.Context, userID string, definition GoalDefinition) (*Goal, error) {
+	topics, err := json.Marshal(definition.TargetTopics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal target topics: %w", err)
+	}
+
+	model := models.LearningGoalModel{
+		UserID:        userID,
+		Title:         definition.Title,
+		Description:   nullableString(definition.Description),
+		TargetTopics:  topics,
+		TargetMastery: definition.TargetMastery,
+		TargetDate:    definition.TargetDate,
+	}
+	if err := r.db.WithContext(ctx).Create(&model).Error; err != nil {
+		return nil, fmt.Errorf("failed to create learning goal: %w", err)
+	}
+
+	return toGoal(model)
+}
+
+// Get loads one of the user's goals
+func (r *Repository) Get(ctx context.Context, userID, goalID string) (*Goal, error) {
+	var model models.LearningGoalModel
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", goalID, userID).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGoalNotFound
+		}
+		return nil, fmt.Errorf("failed to get learning goal: %w", err)
+	}
+
+	return toGoal(model)
+}
+
+// List returns the user's goals, open goals first and soonest target date first
+func (r *Repository) List(ctx context.Context, userID string, includeCompleted bool) ([]Goal, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if !includeCompleted {
+		query = query.Where("is_completed = ?", false)
+	}
+
+	var rows []models.LearningGoalModel
+	err := query.
+		Order("is_completed ASC, target_date ASC NULLS LAST, created_at ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list learning goals: %w", err)
+	}
+
+	goals := make([]Goal, 0, len(rows))
+	for _, row := range rows {
+		goal, err := toGoal(row)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, *goal)
+	}
+
+	return goals, nil
+}
+
+// Update replaces the definition of one of the user's goals and reopens it
+func (r *Repository) Update(ctx context.Context, userID, goalID string, definition GoalDefinition) (*Goal, error) {
+	topics, err := json.Marshal(definition.TargetTopics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal target topics: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&models.LearningGoalModel{}).
+		Where("id = ? AND user_id = ?", goalID, userID).
+		Updates(map[string]interface{}{
+			"title":          definition.Title,
+			"description":    nullableString(definition.Description),
+			"target_topics":  topics,
+			"target_mastery": definition.TargetMastery,
+			"target_date":    definition.TargetDate,
+			"is_completed":   false,
+			"completed_at":   nil,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update learning goal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrGoalNotFound
+	}
+
+	return r.Get(ctx, userID, goalID)
+}
+
+// Delete removes one of the user's goals
+func (r *Repository) Delete(ctx context.Context, userID, goalID string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", goalID, userID).
+		Delete(&models.LearningGoalModel{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete learning goal: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrGoalNotFound
+	}
+	return nil
+}
+
+// UpdateProgress stores the current mastery of an open goal and, when complete is set,
+// marks it completed. It reports whether this call completed the goal.
+func (r *Repository) UpdateProgress(ctx context.Context, goalID string, currentMastery float64, complete bool, at time.Time) (bool, error) {
+	updates := map[string]interface{}{
+		"current_mastery": currentMastery,
+		"updated_at":      at,
+	}
+	if complete {
+		updates["is_completed"] = true
+		updates["completed_at"] = at
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&models.LearningGoalModel{}).
+		Where("id = ? AND is_completed = ?", goalID, false).
+		Updates(updates)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update learning goal progress: %w", result.Error)
+	}
+
+	return complete && result.RowsAffected == 1, nil
+}
+
+func toGoal(model models.LearningGoalModel) (*Goal, error) {
+	goal := &Goal{
+		ID:             model.ID,
+		UserID:         model.UserID,
+		Title:          model.Title,
+		TargetMastery:  model.TargetMastery,
+		TargetDate:     model.TargetDate,
+		CurrentMastery: model.CurrentMastery,
+		IsCompleted:    model.IsCompleted,
+		CompletedAt:    model.CompletedAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+	if model.Description != nil {
+		goal.Description = *model.Description
+	}
+	if err := json.Unmarshal(model.TargetTopics, &goal.TargetTopics); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal target topics of goal %s: %w", model.ID, err)
+	}
+	return goal, nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,101 @@
+package goals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+)
+
+// newTestRepository connects to SCHEDULER_TEST_DATABASE_URL and applies migrations.
+// Tests using it are skipped when the variable is not set.
+func newTestRepository(t *testing.T) (*Repository, *database.DB) {
+	t.Helper()
+
+	databaseURL := os.Getenv("SCHEDULER_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("SCHEDULER_TEST_DATABASE_URL not set")
+	}
+
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	db, err := database.New(&config.DatabaseConfig{URL: databaseURL, MaxOpenConns: 5, MaxIdleConns: 1, ConnMaxLifetime: time.Minute}, &metrics.Metrics{}, log)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return NewRepository(db), db
+}
+
+func TestRepository_GoalLifecycle(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+
+	userID := fmt.Sprintf("00000000-0000-4000-b000-%012d", time.Now().UnixNano()%1e12)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM learning_goals WHERE user_id = ?", userID)
+	})
+
+	goal, err := repo.Create(ctx, userID, GoalDefinition{
+		Title:         "Master traffic signs",
+		TargetTopics:  []string{"traffic_signs"},
+		TargetMastery: 0.8,
+	})
+	if err != nil {
+		t.Fatalf("failed to create goal: %v", err)
+	}
+
+	if _, err := repo.Get(ctx, "00000000-0000-4000-b000-000000000000", goal.ID); !errors.Is(err, ErrGoalNotFound) {
+		t.Errorf("expected goals to be scoped to their owner, got %v", err)
+	}
+
+	completed, err := repo.UpdateProgress(ctx, goal.ID, 0.85, true, time.Now())
+	if err != nil || !completed {
+		t.Fatalf("expected the first completing update to complete the goal, got %v, %v", completed, err)
+	}
+	completed, err = repo.UpdateProgress(ctx, goal.ID, 0.9, true, time.Now())
+	if err != nil || completed {
+		t.Errorf("expected a completed goal not to complete again, got %v, %v", completed, err)
+	}
+
+	open, err := repo.List(ctx, userID, false)
+	if err != nil || len(open) != 0 {
+		t.Errorf("expected no open goals after completion, got %d (%v)", len(open), err)
+	}
+
+	// Raising the target reopens the goal
+	updated, err := repo.Update(ctx, userID, goal.ID, GoalDefinition{
+		Title:         "Master traffic signs",
+		Description:   "Aim higher",
+		TargetTopics:  []string{"traffic_signs"},
+		TargetMastery: 0.95,
+	})
+	if err != nil {
+		t.Fatalf("failed to update goal: %v", err)
+	}
+	if updated.IsCompleted || updated.CompletedAt != nil || updated.Description != "Aim higher" {
+		t.Errorf("expected an updated, reopened goal, got %+v", updated)
+	}
+
+	if err := repo.Delete(ctx, userID, goal.ID); err != nil {
+		t.Fatalf("failed to delete goal: %v", err)
+	}
+	if err := repo.Delete(ctx, userID, goal.ID); !errors.Is(err, ErrGoalNotFound) {
+		t.Errorf("expected ErrGoalNotFound deleting twice, got %v", err)
+	}
+}
@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 
+	"scheduler-service/internal/auth"
 	"scheduler-service/internal/logger"
 	"scheduler-service/internal/onboarding"
 	"scheduler-service/internal/server"
@@ -17,18 +21,22 @@ type OnboardingHandler struct {
 	logger            *logger.Logger
 	schedulerService  *server.SchedulerService
 	onboardingService *onboarding.OnboardingService
+	verifier          *auth.Verifier
 }
 
-// NewOnboardingHandler creates a new onboarding handler
+// NewOnboardingHandler creates a new onboarding handler. Requests are authenticated
+// with access tokens checked by verifier.
 func NewOnboardingHandler(
 	logger *logger.Logger,
 	schedulerService *server.SchedulerService,
 	onboardingService *onboarding.OnboardingService,
+	verifier *auth.Verifier,
 ) *OnboardingHandler {
 	return &OnboardingHandler{
 		logger:            logger,
 		schedulerService:  schedulerService,
 		onboardingService: onboardingService,
+		verifier:          verifier,
 	}
 }
 
@@ -67,16 +75,33 @@ type LearningPathResponse struct {
 	Error   string                   `json:"error,omitempty"`
 }
 
+// StudyPlanHistoryResponse represents the HTTP response for study plan history
+type StudyPlanHistoryResponse struct {
+	Success bool                           `json:"success"`
+	Message string                         `json:"message,omitempty"`
+	Data    []onboarding.StudyPlanRevision `json:"data,omitempty"`
+	Error   string                         `json:"error,omitempty"`
+}
+
+// PlacementResponseRequest represents the HTTP request for answering the current placement item
+type PlacementResponseRequest struct {
+	ItemID         string `json:"item_id"`
+	Correct        bool   `json:"correct"`
+	ResponseTimeMs int    `json:"response_time_ms"`
+	Confidence     int    `json:"confidence"` // 1-5, 0 if not reported
+}
+
 // CompletionRequest represents the HTTP request for completing onboarding
 type CompletionRequest struct {
 	Satisfaction int    `json:"satisfaction"`
 	Feedback     string `json:"feedback"`
 }
 
-// RegisterRoutes registers HTTP routes for onboarding
+// RegisterRoutes registers HTTP routes for onboarding. All routes require authentication.
 func (h *OnboardingHandler) RegisterRoutes(router *mux.Router) {
 	// Onboarding routes
 	onboardingRouter := router.PathPrefix("/api/v1/onboarding").Subrouter()
+	onboardingRouter.Use(h.AuthMiddleware)
 
 	// Initialize onboarding
 	onboardingRouter.HandleFunc("/initialize", h.InitializeOnboarding).Methods("POST")
@@ -89,10 +114,13 @@ func (h *OnboardingHandler) RegisterRoutes(router *mux.Router) {
 
 	// Placement test routes
 	onboardingRouter.HandleFunc("/{user_id}/placement/start", h.StartPlacementTest).Methods("POST")
+	onboardingRouter.HandleFunc("/{user_id}/placement/responses", h.SubmitPlacementResponse).Methods("POST")
+	onboardingRouter.HandleFunc("/{user_id}/placement/complete", h.CompletePlacementTest).Methods("POST")
 	onboardingRouter.HandleFunc("/{user_id}/placement/skip", h.SkipPlacementTest).Methods("POST")
 
 	// Learning path
 	onboardingRouter.HandleFunc("/{user_id}/learning-path", h.GetLearningPath).Methods("GET")
+	onboardingRouter.HandleFunc("/{user_id}/study-plan/history", h.GetStudyPlanHistory).Methods("GET")
 
 	// Complete onboarding
 	onboardingRouter.HandleFunc("/{user_id}/complete", h.CompleteOnboarding).Methods("POST")
@@ -111,26 +139,28 @@ func (h *OnboardingHandler) InitializeOnboarding(w http.ResponseWriter, r *http.
 		CountryCode string `json:"country_code"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSONBody(w, r, &req); err != nil {
 		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
 
 	// Validate required fields
-	if req.UserID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	if err := validateUserID(req.UserID); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
-	if req.CountryCode == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "country_code is required", nil)
+	if err := validateCountryCode(req.CountryCode); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if !h.authorizeUser(w, r, req.UserID) {
 		return
 	}
 
 	// Initialize onboarding
 	state, err := h.onboardingService.InitializeOnboarding(ctx, req.UserID, req.CountryCode)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to initialize onboarding")
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to initialize onboarding", err)
+		h.writeServiceError(w, r, "Failed to initialize onboarding", err)
 		return
 	}
 
@@ -147,19 +177,15 @@ func (h *OnboardingHandler) InitializeOnboarding(w http.ResponseWriter, r *http.
 // GetOnboardingState handles GET /api/v1/onboarding/{user_id}/state
 func (h *OnboardingHandler) GetOnboardingState(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
-	userID := vars["user_id"]
-
-	if userID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
 		return
 	}
 
 	// Get onboarding state
 	state, err := h.onboardingService.GetOnboardingState(ctx, userID)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to get onboarding state")
-		h.writeErrorResponse(w, http.StatusNotFound, "Onboarding state not found", err)
+		h.writeServiceError(w, r, "Failed to get onboarding state", err)
 		return
 	}
 
@@ -175,20 +201,21 @@ func (h *OnboardingHandler) GetOnboardingState(w http.ResponseWriter, r *http.Re
 // UpdatePreferences handles PUT /api/v1/onboarding/{user_id}/preferences
 func (h *OnboardingHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
-	userID := vars["user_id"]
-
-	if userID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
 		return
 	}
 
 	// Parse request body
 	var req PreferencesRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSONBody(w, r, &req); err != nil {
 		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
+	if err := validatePreferences(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
 
 	// Convert to internal preferences format
 	preferences := onboarding.UserPreferences{
@@ -204,8 +231,7 @@ func (h *OnboardingHandler) UpdatePreferences(w http.ResponseWriter, r *http.Req
 	// Update preferences
 	err := h.onboardingService.UpdatePreferences(ctx, userID, preferences)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to update preferences")
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to update preferences", err)
+		h.writeServiceError(w, r, "Failed to update preferences", err)
 		return
 	}
 
@@ -221,19 +247,15 @@ func (h *OnboardingHandler) UpdatePreferences(w http.ResponseWriter, r *http.Req
 // StartPlacementTest handles POST /api/v1/onboarding/{user_id}/placement/start
 func (h *OnboardingHandler) StartPlacementTest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
-	userID := vars["user_id"]
-
-	if userID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
 		return
 	}
 
 	// Start placement test
 	placementTest, err := h.onboardingService.StartPlacementTest(ctx, userID)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to start placement test")
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to start placement test", err)
+		h.writeServiceError(w, r, "Failed to start placement test", err)
 		return
 	}
 
@@ -247,30 +269,91 @@ func (h *OnboardingHandler) StartPlacementTest(w http.ResponseWriter, r *http.Re
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// SubmitPlacementResponse handles POST /api/v1/onboarding/{user_id}/placement/responses
+func (h *OnboardingHandler) SubmitPlacementResponse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse request body
+	var req PlacementResponseRequest
+	if err := decodeJSONBody(w, r, &req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if err := validatePlacementResponse(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	// Process the response; the test completes itself once a stopping criterion is met
+	placementTest, err := h.onboardingService.SubmitPlacementResponse(ctx, userID, req.ItemID, req.Correct, req.ResponseTimeMs, req.Confidence)
+	if err != nil {
+		h.writeServiceError(w, r, "Failed to submit placement response", err)
+		return
+	}
+
+	message := "Placement response recorded"
+	if placementTest.Status == onboarding.PlacementCompleted {
+		message = "Placement test completed and learning path generated"
+	}
+
+	// Return success response
+	response := PlacementTestResponse{
+		Success: true,
+		Message: message,
+		Data:    placementTest,
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// CompletePlacementTest handles POST /api/v1/onboarding/{user_id}/placement/complete
+func (h *OnboardingHandler) CompletePlacementTest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	// Finish the test with the responses given so far
+	learningPath, err := h.onboardingService.FinishPlacementTest(ctx, userID)
+	if err != nil {
+		h.writeServiceError(w, r, "Failed to complete placement test", err)
+		return
+	}
+
+	// Return success response
+	response := LearningPathResponse{
+		Success: true,
+		Message: "Placement test completed and learning path generated",
+		Data:    learningPath,
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
 // SkipPlacementTest handles POST /api/v1/onboarding/{user_id}/placement/skip
 func (h *OnboardingHandler) SkipPlacementTest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
-	userID := vars["user_id"]
-
-	if userID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
 		return
 	}
 
 	// Skip placement test
 	err := h.onboardingService.SkipPlacementTest(ctx, userID)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to skip placement test")
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to skip placement test", err)
+		h.writeServiceError(w, r, "Failed to skip placement test", err)
 		return
 	}
 
 	// Get the generated learning path
 	learningPath, err := h.onboardingService.GetLearningPath(ctx, userID)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to get learning path")
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to get learning path", err)
+		h.writeServiceError(w, r, "Failed to get learning path", err)
 		return
 	}
 
@@ -287,19 +370,15 @@ func (h *OnboardingHandler) SkipPlacementTest(w http.ResponseWriter, r *http.Req
 // GetLearningPath handles GET /api/v1/onboarding/{user_id}/learning-path
 func (h *OnboardingHandler) GetLearningPath(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
-	userID := vars["user_id"]
-
-	if userID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
 		return
 	}
 
 	// Get learning path
 	learningPath, err := h.onboardingService.GetLearningPath(ctx, userID)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to get learning path")
-		h.writeErrorResponse(w, http.StatusNotFound, "Learning path not found", err)
+		h.writeServiceError(w, r, "Failed to get learning path", err)
 		return
 	}
 
@@ -312,20 +391,47 @@ func (h *OnboardingHandler) GetLearningPath(w http.ResponseWriter, r *http.Reque
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// GetStudyPlanHistory handles GET /api/v1/onboarding/{user_id}/study-plan/history
+func (h *OnboardingHandler) GetStudyPlanHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 20
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 100 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "limit must be between 1 and 100", nil)
+			return
+		}
+		limit = parsed
+	}
+
+	revisions, err := h.onboardingService.GetStudyPlanHistory(ctx, userID, limit)
+	if err != nil {
+		h.writeServiceError(w, r, "Failed to get study plan history", err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, StudyPlanHistoryResponse{
+		Success: true,
+		Data:    revisions,
+	})
+}
+
 // CompleteOnboarding handles POST /api/v1/onboarding/{user_id}/complete
 func (h *OnboardingHandler) CompleteOnboarding(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
-	userID := vars["user_id"]
-
-	if userID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
 		return
 	}
 
 	// Parse request body
 	var req CompletionRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSONBody(w, r, &req); err != nil {
 		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
@@ -339,8 +445,7 @@ func (h *OnboardingHandler) CompleteOnboarding(w http.ResponseWriter, r *http.Re
 	// Complete onboarding
 	err := h.onboardingService.CompleteOnboarding(ctx, userID, req.Satisfaction, req.Feedback)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to complete onboarding")
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to complete onboarding", err)
+		h.writeServiceError(w, r, "Failed to complete onboarding", err)
 		return
 	}
 
@@ -356,11 +461,8 @@ func (h *OnboardingHandler) CompleteOnboarding(w http.ResponseWriter, r *http.Re
 // UpdateProgress handles POST /api/v1/onboarding/{user_id}/progress
 func (h *OnboardingHandler) UpdateProgress(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	vars := mux.Vars(r)
-	userID := vars["user_id"]
-
-	if userID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "user_id is required", nil)
+	userID, ok := h.pathUserID(w, r)
+	if !ok {
 		return
 	}
 
@@ -370,19 +472,26 @@ func (h *OnboardingHandler) UpdateProgress(w http.ResponseWriter, r *http.Reques
 		TimeSpent int    `json:"time_spent"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSONBody(w, r, &req); err != nil {
 		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body", err)
 		return
 	}
 
 	// Convert stage string to onboarding stage
 	stage := onboarding.OnboardingStage(req.Stage)
+	if !stage.IsValid() {
+		h.writeErrorResponse(w, http.StatusBadRequest, "stage is not a valid onboarding stage", nil)
+		return
+	}
+	if req.TimeSpent < 0 || req.TimeSpent > 24*60*60 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "time_spent must be between 0 and 86400 seconds", nil)
+		return
+	}
 
 	// Update progress
 	err := h.onboardingService.UpdateProgress(ctx, userID, stage, req.TimeSpent)
 	if err != nil {
-		h.logger.WithContext(ctx).WithError(err).Error("Failed to update progress")
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to update progress", err)
+		h.writeServiceError(w, r, "Failed to update progress", err)
 		return
 	}
 
@@ -407,6 +516,44 @@ func (h *OnboardingHandler) writeJSONResponse(w http.ResponseWriter, statusCode
 	}
 }
 
+// pathUserID returns the validated user_id path variable, writing an error response if it is invalid
+func (h *OnboardingHandler) pathUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := mux.Vars(r)["user_id"]
+	if err := validateUserID(userID); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error(), nil)
+		return "", false
+	}
+	return userID, true
+}
+
+// authorizeUser checks that the authenticated user may act on userID, writing a 403 if not
+func (h *OnboardingHandler) authorizeUser(w http.ResponseWriter, r *http.Request, userID string) bool {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok || claims.Subject != userID {
+		h.writeErrorResponse(w, http.StatusForbidden, "Not allowed to access this user's onboarding", nil)
+		return false
+	}
+	return true
+}
+
+// writeServiceError maps an onboarding service error to an HTTP status and writes it.
+// Internal error details are logged but not returned to the client.
+func (h *OnboardingHandler) writeServiceError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	switch {
+	case errors.Is(err, onboarding.ErrOnboardingNotFound):
+		h.writeErrorResponse(w, http.StatusNotFound, "Onboarding state not found", nil)
+	case errors.Is(err, onboarding.ErrNoLearningPath):
+		h.writeErrorResponse(w, http.StatusNotFound, "Learning path not found", nil)
+	case errors.Is(err, onboarding.ErrPlacementNotInProgress):
+		h.writeErrorResponse(w, http.StatusConflict, message, err)
+	case errors.Is(err, onboarding.ErrInvalidPlacementResponse):
+		h.writeErrorResponse(w, http.StatusBadRequest, message, err)
+	default:
+		h.logger.WithContext(r.Context()).WithError(err).Error(message)
+		h.writeErrorResponse(w, http.StatusInternalServerError, message, nil)
+	}
+}
+
 // writeErrorResponse writes an error response
 func (h *OnboardingHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string, err error) {
 	response := map[string]interface{}{
@@ -437,6 +584,35 @@ func (h *OnboardingHandler) CORSMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// AuthMiddleware authenticates requests with a bearer access token and checks that
+// routes scoped to a user_id are only used by that user
+func (h *OnboardingHandler) AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(header, "Bearer ")
+		if header == "" || token == header {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			h.writeErrorResponse(w, http.StatusUnauthorized, "Missing bearer token", nil)
+			return
+		}
+
+		claims, err := h.verifier.Verify(token)
+		if err != nil {
+			h.logger.WithContext(r.Context()).WithError(err).Debug("Rejected access token")
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			h.writeErrorResponse(w, http.StatusUnauthorized, "Invalid or expired token", nil)
+			return
+		}
+
+		r = r.WithContext(auth.ContextWithClaims(r.Context(), claims))
+		if userID, ok := mux.Vars(r)["user_id"]; ok && !h.authorizeUser(w, r, userID) {
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // RequestLoggingMiddleware logs incoming requests
 func (h *OnboardingHandler) RequestLoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -444,7 +620,7 @@ func (h *OnboardingHandler) RequestLoggingMiddleware(next http.Handler) http.Han
 
 		next.ServeHTTP(w, r)
 
-		h.logger.WithFields(map[string]interface{}{
+		h.logger.WithContext(r.Context()).WithFields(map[string]interface{}{
 			"method":   r.Method,
 			"path":     r.URL.Path,
 			"duration": time.Since(start).Milliseconds(),
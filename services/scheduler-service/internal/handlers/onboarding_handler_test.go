@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+)
+
+const (
+	testSecret = "test-secret"
+	testUserID = "5f0c7a1e-3b2d-4c8e-9a6f-1d2e3f4a5b6c"
+	otherUser  = "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+)
+
+// newTestRouter mounts the onboarding routes the way main does. The onboarding
+// service is nil, so only requests rejected before reaching it can be exercised.
+func newTestRouter() http.Handler {
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	handler := NewOnboardingHandler(log, nil, nil, auth.NewHMACVerifier(testSecret))
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+	return handler.CORSMiddleware(router)
+}
+
+func testToken(t *testing.T, secret, subject string) string {
+	t.Helper()
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, err := json.Marshal(map[string]interface{}{
+		"sub": subject,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestOnboardingHandler_Authentication(t *testing.T) {
+	router := newTestRouter()
+	path := "/api/v1/onboarding/" + testUserID + "/state"
+
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+	}{
+		{"missing token", "", http.StatusUnauthorized},
+		{"not a bearer token", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"wrong signing secret", "Bearer " + testToken(t, "other-secret", testUserID), http.StatusUnauthorized},
+		{"another user's token", "Bearer " + testToken(t, testSecret, otherUser), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, false, body["success"])
+		})
+	}
+}
+
+func TestOnboardingHandler_PreflightSkipsAuthentication(t *testing.T) {
+	router := newTestRouter()
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/onboarding/"+testUserID+"/placement/responses", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestOnboardingHandler_RequestValidation(t *testing.T) {
+	router := newTestRouter()
+	token := "Bearer " + testToken(t, testSecret, testUserID)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "initialize for another user",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/initialize",
+			body:       `{"user_id":"` + otherUser + `","country_code":"US"}`,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "initialize with invalid country",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/initialize",
+			body:       `{"user_id":"` + testUserID + `","country_code":"usa"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "initialize with unknown field",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/initialize",
+			body:       `{"user_id":"` + testUserID + `","country_code":"US","admin":true}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "path user is not a UUID",
+			method:     http.MethodGet,
+			path:       "/api/v1/onboarding/not-a-uuid/state",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "preferences with zero available time",
+			method:     http.MethodPut,
+			path:       "/api/v1/onboarding/" + testUserID + "/preferences",
+			body:       `{"available_time":0}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "preferences with unknown weekday",
+			method:     http.MethodPut,
+			path:       "/api/v1/onboarding/" + testUserID + "/preferences",
+			body:       `{"available_time":30,"weekly_schedule":{"funday":true}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "placement response without item",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/" + testUserID + "/placement/responses",
+			body:       `{"correct":true,"response_time_ms":1200}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "placement response with invalid confidence",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/" + testUserID + "/placement/responses",
+			body:       `{"item_id":"placement_rules_easy_1","correct":true,"confidence":9}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "completion with satisfaction out of range",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/" + testUserID + "/complete",
+			body:       `{"satisfaction":7}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "study plan history with invalid limit",
+			method:     http.MethodGet,
+			path:       "/api/v1/onboarding/" + testUserID + "/study-plan/history?limit=500",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "progress for unknown stage",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/" + testUserID + "/progress",
+			body:       `{"stage":"checkout","time_spent":10}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "progress with negative time",
+			method:     http.MethodPost,
+			path:       "/api/v1/onboarding/" + testUserID + "/progress",
+			body:       `{"stage":"welcome","time_spent":-5}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", token)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxRequestBodyBytes bounds the size of JSON request bodies
+const maxRequestBodyBytes = 64 * 1024
+
+var (
+	uuidPattern        = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+	validStudyGoals     = []string{"pass_test", "improve_skills", "refresh_knowledge"}
+	validDifficulties   = []string{"easy", "medium", "hard", "adaptive"}
+	validLearningStyles = []string{"visual", "auditory", "kinesthetic", "mixed"}
+	validWeekdays       = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+)
+
+// decodeJSONBody decodes a size-limited JSON body, rejecting unknown fields and trailing data
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return fmt.Errorf("request body must contain a single JSON object")
+	}
+	return nil
+}
+
+// validateUserID checks that a user ID is a UUID, as stored by the onboarding tables
+func validateUserID(userID string) error {
+	if userID == "" {
+		return fmt.Errorf("user_id is required")
+	}
+	if !uuidPattern.MatchString(userID) {
+		return fmt.Errorf("user_id must be a UUID")
+	}
+	return nil
+}
+
+// validateCountryCode checks for an ISO 3166-1 alpha-2 country code
+func validateCountryCode(countryCode string) error {
+	if countryCode == "" {
+		return fmt.Errorf("country_code is required")
+	}
+	if !countryCodePattern.MatchString(countryCode) {
+		return fmt.Errorf("country_code must be a two-letter uppercase ISO code")
+	}
+	return nil
+}
+
+// validatePreferences checks a preferences update; empty optional fields are allowed
+func validatePreferences(req *PreferencesRequest) error {
+	if req.AvailableTime < 1 || req.AvailableTime > 24*60 {
+		return fmt.Errorf("available_time must be between 1 and 1440 minutes")
+	}
+	if req.StudyGoal != "" && !contains(validStudyGoals, req.StudyGoal) {
+		return fmt.Errorf("study_goal must be one of %s", strings.Join(validStudyGoals, ", "))
+	}
+	if req.PreferredDifficulty != "" && !contains(validDifficulties, req.PreferredDifficulty) {
+		return fmt.Errorf("preferred_difficulty must be one of %s", strings.Join(validDifficulties, ", "))
+	}
+	if req.LearningStyle != "" && !contains(validLearningStyles, req.LearningStyle) {
+		return fmt.Errorf("learning_style must be one of %s", strings.Join(validLearningStyles, ", "))
+	}
+	for _, notificationTime := range req.NotificationTimes {
+		if _, err := time.Parse("15:04", notificationTime); err != nil {
+			return fmt.Errorf("notification_times must use HH:MM, got %q", notificationTime)
+		}
+	}
+	for day := range req.WeeklySchedule {
+		if !contains(validWeekdays, day) {
+			return fmt.Errorf("weekly_schedule has unknown day %q", day)
+		}
+	}
+	return nil
+}
+
+// validatePlacementResponse checks a submitted placement response
+func validatePlacementResponse(req *PlacementResponseRequest) error {
+	if req.ItemID == "" {
+		return fmt.Errorf("item_id is required")
+	}
+	if req.ResponseTimeMs < 0 || req.ResponseTimeMs > int(time.Hour/time.Millisecond) {
+		return fmt.Errorf("response_time_ms must be between 0 and 3600000")
+	}
+	if req.Confidence < 0 || req.Confidence > 5 {
+		return fmt.Errorf("confidence must be between 1 and 5, or 0 if not reported")
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
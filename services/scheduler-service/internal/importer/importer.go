@@ -0,0 +1,304 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/catalog"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/replay"
+	"scheduler-service/internal/state"
+)
+
+// maxReportedErrors bounds the row errors listed in a report; all are counted
+const maxReportedErrors = 100
+
+// ErrCatalogEmpty is returned when no item catalog is loaded to map external IDs to
+var ErrCatalogEmpty = errors.New("item catalog is not loaded")
+
+// ItemCatalog is the catalog external item IDs are mapped to
+type ItemCatalog interface {
+	Refresh(ctx context.Context)
+	Len() int
+	Lookup(itemID string) (catalog.Item, bool)
+	LookupSlug(slug string) (catalog.Item, bool)
+}
+
+// Options control an import
+type Options struct {
+	Format     string // csv or jsonl
+	Source     string // App the log was exported from, whose item IDs are mapped in item_external_ids
+	GradeScale string // sm2 (default) or anki
+	DryRun     bool   // Report what would be imported without storing anything
+}
+
+// UnmappedItem is an external item ID that matched no catalog item
+type UnmappedItem struct {
+	ExternalID string `json:"external_id"`
+	Reviews    int    `json:"reviews"`
+}
+
+// Report describes an import, or what an import would do on a dry run
+type Report struct {
+	DryRun          bool           `json:"dry_run"`
+	Rows            int            `json:"rows"`
+	InvalidRows     int            `json:"invalid_rows"`
+	Errors          []RowError     `json:"errors"` // The first rows that could not be imported
+	Reviews         int            `json:"reviews"`
+	UnmappedReviews int            `json:"unmapped_reviews"`
+	Unmapped        []UnmappedItem `json:"unmapped"` // Most reviewed first
+	Users           int            `json:"users"`
+	Items           int            `json:"items"`
+	SM2States       int            `json:"sm2_states"`
+	BKTStates       int            `json:"bkt_states"`
+	IRTStates       int            `json:"irt_states"`
+	StoredStates    int64          `json:"stored_states"`
+	ExistingStates  int64          `json:"existing_states"` // Left untouched because the user already had them
+}
+
+// mappedReview is a review of a catalog item
+type mappedReview struct {
+	Review
+	item catalog.Item
+}
+
+// Importer reconstructs learners' SM-2, BKT and IRT states from the review logs of
+// other flashcard apps, replaying each user's reviews in chronological order. States are
+// only stored for items and topics the user has none for yet, so importing the same log
+// again changes nothing.
+type Importer struct {
+	logger        *logger.Logger
+	repository    *Repository
+	catalog       ItemCatalog
+	jurisdictions state.JurisdictionResolver
+	cache         *cache.RedisClient
+	replayer      *replay.Replayer
+	sm2Manager    *state.SM2StateManager
+	bktManager    *state.BKTStateManager
+	irtManager    *state.IRTManager
+}
+
+// NewImporter creates a new review log importer
+func NewImporter(
+	log *logger.Logger,
+	db *database.DB,
+	cache *cache.RedisClient,
+	itemCatalog ItemCatalog,
+	jurisdictions state.JurisdictionResolver,
+	replayer *replay.Replayer,
+	sm2Manager *state.SM2StateManager,
+	bktManager *state.BKTStateManager,
+	irtManager *state.IRTManager,
+) *Importer {
+	return &Importer{
+		logger:        log,
+		repository:    NewRepository(db),
+		catalog:       itemCatalog,
+		jurisdictions: jurisdictions,
+		cache:         cache,
+		replayer:      replayer,
+		sm2Manager:    sm2Manager,
+		bktManager:    bktManager,
+		irtManager:    irtManager,
+	}
+}
+
+// Import reads a review log, maps its external item IDs to catalog items and replays
+// the mapped reviews. BKT and IRT states are stored in each user's active jurisdiction.
+func (i *Importer) Import(ctx context.Context, r io.Reader, opts Options) (*Report, error) {
+	reviews, rowErrors, rows, err := Parse(r, opts.Format, opts.GradeScale, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	i.catalog.Refresh(ctx)
+	if i.catalog.Len() == 0 {
+		return nil, ErrCatalogEmpty
+	}
+
+	mapped, unmapped, err := i.resolve(ctx, opts.Source, reviews)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		DryRun:      opts.DryRun,
+		Rows:        rows,
+		InvalidRows: len(rowErrors),
+		Errors:      rowErrors,
+		Reviews:     len(mapped),
+		Unmapped:    unmapped,
+	}
+	if len(report.Errors) > maxReportedErrors {
+		report.Errors = report.Errors[:maxReportedErrors]
+	}
+	for _, item := range unmapped {
+		report.UnmappedReviews += item.Reviews
+	}
+
+	users := i.replay(mapped)
+	items := make(map[string]bool)
+	for _, user := range users {
+		for itemID := range user.SM2 {
+			items[itemID] = true
+		}
+		report.SM2States += len(user.SM2)
+		report.BKTStates += len(user.BKT)
+		report.IRTStates += len(user.IRT)
+	}
+	report.Users = len(users)
+	report.Items = len(items)
+
+	if !opts.DryRun {
+		userIDs := make([]string, 0, len(users))
+		for userID := range users {
+			userIDs = append(userIDs, userID)
+		}
+		sort.Strings(userIDs)
+
+		for _, userID := range userIDs {
+			stored, err := i.store(ctx, userID, users[userID])
+			if err != nil {
+				return nil, fmt.Errorf("failed to store imported state of user %s: %w", userID, err)
+			}
+			report.StoredStates += stored
+		}
+		report.ExistingStates = int64(report.SM2States+report.BKTStates+report.IRTStates) - report.StoredStates
+	}
+
+	i.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"source":           opts.Source,
+		"dry_run":          opts.DryRun,
+		"rows":             report.Rows,
+		"invalid_rows":     report.InvalidRows,
+		"reviews":          report.Reviews,
+		"unmapped_reviews": report.UnmappedReviews,
+		"users":            report.Users,
+		"stored_states":    report.StoredStates,
+	}).Info("Imported review log")
+
+	return report, nil
+}
+
+// resolve maps reviews to catalog items, by the source's item_external_ids mapping,
+// then by item ID, then by item slug. Reviews of unpublished items are unmapped.
+func (i *Importer) resolve(ctx context.Context, source string, reviews []Review) ([]mappedReview, []UnmappedItem, error) {
+	reviewCounts := make(map[string]int)
+	var externalIDs []string
+	for _, review := range reviews {
+		if reviewCounts[review.ExternalID] == 0 {
+			externalIDs = append(externalIDs, review.ExternalID)
+		}
+		reviewCounts[review.ExternalID]++
+	}
+
+	mappings := map[string]string{}
+	if source != "" && len(externalIDs) > 0 {
+		var err error
+		mappings, err = i.repository.ItemIDs(ctx, source, externalIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	items := make(map[string]catalog.Item, len(externalIDs))
+	var unmapped []UnmappedItem
+	for _, externalID := range externalIDs {
+		item, ok := lookupItem(i.catalog, mappings, externalID)
+		if !ok {
+			unmapped = append(unmapped, UnmappedItem{ExternalID: externalID, Reviews: reviewCounts[externalID]})
+			continue
+		}
+		items[externalID] = item
+	}
+	sort.Slice(unmapped, func(a, b int) bool {
+		if unmapped[a].Reviews != unmapped[b].Reviews {
+			return unmapped[a].Reviews > unmapped[b].Reviews
+		}
+		return unmapped[a].ExternalID < unmapped[b].ExternalID
+	})
+
+	mapped := make([]mappedReview, 0, len(reviews))
+	for _, review := range reviews {
+		if item, ok := items[review.ExternalID]; ok {
+			mapped = append(mapped, mappedReview{Review: review, item: item})
+		}
+	}
+	return mapped, unmapped, nil
+}
+
+// lookupItem finds the catalog item of an external ID
+func lookupItem(itemCatalog ItemCatalog, mappings map[string]string, externalID string) (catalog.Item, bool) {
+	if itemID, ok := mappings[externalID]; ok {
+		return itemCatalog.Lookup(itemID)
+	}
+	if item, ok := itemCatalog.Lookup(externalID); ok {
+		return item, true
+	}
+	return itemCatalog.LookupSlug(externalID)
+}
+
+// replay applies each user's reviews in chronological order, starting from initial
+// states. Reviews at the same time keep their log order. Imported reviews carry no
+// hints or timings, so each counts as full evidence.
+func (i *Importer) replay(reviews []mappedReview) map[string]*replay.State {
+	sorted := make([]mappedReview, len(reviews))
+	copy(sorted, reviews)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].ReviewedAt.Before(sorted[b].ReviewedAt)
+	})
+
+	users := make(map[string]*replay.State)
+	for _, review := range sorted {
+		user, ok := users[review.UserID]
+		if !ok {
+			user = replay.NewState()
+			users[review.UserID] = user
+		}
+
+		i.replayer.Apply(user, replay.Attempt{
+			Item:           review.item,
+			At:             review.ReviewedAt,
+			Quality:        review.Quality,
+			Correct:        review.Correct(),
+			EvidenceWeight: 1.0,
+		}, time.Time{})
+	}
+
+	return users
+}
+
+// store seeds a user's reconstructed states and returns how many were stored
+func (i *Importer) store(ctx context.Context, userID string, user *replay.State) (int64, error) {
+	jurisdiction, err := i.jurisdictions.ActiveJurisdiction(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve active jurisdiction: %w", err)
+	}
+
+	sm2Stored, err := i.sm2Manager.SeedStates(ctx, userID, user.SM2)
+	if err != nil {
+		return 0, err
+	}
+	bktStored, err := i.bktManager.SeedStates(ctx, userID, jurisdiction, user.BKT)
+	if err != nil {
+		return sm2Stored, err
+	}
+	irtStored, err := i.irtManager.SeedStates(ctx, userID, jurisdiction, user.IRT)
+	if err != nil {
+		return sm2Stored + bktStored, err
+	}
+
+	if i.cache != nil {
+		if err := i.cache.InvalidateUser(ctx, userID); err != nil {
+			i.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to invalidate user cache")
+		}
+	}
+
+	return sm2Stored + bktStored + irtStored, nil
+}
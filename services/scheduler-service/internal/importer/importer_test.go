@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/catalog"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/replay"
+)
+
+// staticCatalog is a fixed item catalog
+type staticCatalog map[string]catalog.Item
+
+func (c staticCatalog) Refresh(ctx context.Context) {}
+
+func (c staticCatalog) Len() int { return len(c) }
+
+func (c staticCatalog) Lookup(itemID string) (catalog.Item, bool) {
+	item, ok := c[itemID]
+	return item, ok
+}
+
+func (c staticCatalog) LookupSlug(slug string) (catalog.Item, bool) {
+	for _, item := range c {
+		if item.Slug == slug {
+			return item, true
+		}
+	}
+	return catalog.Item{}, false
+}
+
+var testCatalog = staticCatalog{
+	"10000000-0000-4000-8000-000000000001": {
+		ID: "10000000-0000-4000-8000-000000000001", Slug: "stop-sign", Topics: []string{"road_signs"},
+		Discrimination: 1.0, Guessing: 0.25,
+	},
+	"10000000-0000-4000-8000-000000000002": {
+		ID: "10000000-0000-4000-8000-000000000002", Slug: "parking-rules",
+		Discrimination: 1.0, Guessing: 0.25,
+	},
+}
+
+func newTestImporter(itemCatalog ItemCatalog) *Importer {
+	return NewImporter(
+		logger.New(&config.LoggingConfig{Level: "error", Format: "text"}), nil, nil, itemCatalog, nil,
+		replay.NewReplayer(algorithms.NewSM2Algorithm(), algorithms.NewBKTAlgorithm(), algorithms.NewIRTAlgorithm()),
+		nil, nil, nil,
+	)
+}
+
+func TestImporter_ReplaysChronologically(t *testing.T) {
+	importer := newTestImporter(testCatalog)
+	stopSign := testCatalog["10000000-0000-4000-8000-000000000001"]
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 9, 0, 0, 0, time.UTC) }
+
+	// Logged out of order: the failure on day 1 comes before both successes
+	users := importer.replay([]mappedReview{
+		{Review: Review{UserID: testUserID, ReviewedAt: day(2), Quality: 4}, item: stopSign},
+		{Review: Review{UserID: testUserID, ReviewedAt: day(8), Quality: 5}, item: stopSign},
+		{Review: Review{UserID: testUserID, ReviewedAt: day(1), Quality: 1}, item: stopSign},
+	})
+
+	user := users[testUserID]
+	if user == nil {
+		t.Fatal("Expected state for the user")
+	}
+	sm2State := user.SM2[stopSign.ID]
+	if sm2State.Repetition != 2 || sm2State.Interval != 6 {
+		t.Errorf("Expected two successful repetitions after the failure, got %+v", sm2State)
+	}
+	if !sm2State.LastReviewed.Equal(day(8)) || !sm2State.NextDue.Equal(day(14)) {
+		t.Errorf("Expected state dated by the last review, got %+v", sm2State)
+	}
+
+	bktState := user.BKT["road_signs"]
+	if bktState == nil || bktState.AttemptsCount != 3 || bktState.CorrectCount != 2 || !bktState.LastUpdated.Equal(day(8)) {
+		t.Errorf("Unexpected BKT state %+v", bktState)
+	}
+	irtState := user.IRT["road_signs"]
+	if irtState == nil || irtState.AttemptsCount != 3 || !irtState.LastUpdated.Equal(day(8)) {
+		t.Errorf("Unexpected IRT state %+v", irtState)
+	}
+}
+
+func TestImporter_DryRunReportsUnmappedItems(t *testing.T) {
+	importer := newTestImporter(testCatalog)
+	log := "user_id,external_id,reviewed_at,grade\n" +
+		testUserID + ",stop-sign,2026-01-01T09:00:00Z,4\n" +
+		testUserID + ",10000000-0000-4000-8000-000000000002,2026-01-01T09:01:00Z,2\n" +
+		testUserID + ",anki-1,2026-01-01T09:02:00Z,4\n" +
+		testUserID + ",anki-2,2026-01-01T09:03:00Z,4\n" +
+		testUserID + ",anki-2,2026-01-02T09:03:00Z,5\n" +
+		testUserID + ",anki-2,2026-01-02T09:03:00Z,9\n"
+
+	report, err := importer.Import(context.Background(), strings.NewReader(log), Options{Format: FormatCSV, DryRun: true})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if !report.DryRun || report.StoredStates != 0 {
+		t.Errorf("Expected nothing stored on a dry run, got %+v", report)
+	}
+	if report.Rows != 6 || report.InvalidRows != 1 || report.Reviews != 2 || report.UnmappedReviews != 3 {
+		t.Errorf("Unexpected counts %+v", report)
+	}
+	if len(report.Unmapped) != 2 || report.Unmapped[0].ExternalID != "anki-2" || report.Unmapped[0].Reviews != 2 {
+		t.Errorf("Expected unmapped items most reviewed first, got %+v", report.Unmapped)
+	}
+	if report.Users != 1 || report.Items != 2 || report.SM2States != 2 {
+		t.Errorf("Expected both catalog items to be mapped, got %+v", report)
+	}
+
+	// Items without topics count towards general knowledge, as for live attempts
+	if report.BKTStates != 2 || report.IRTStates != 2 {
+		t.Errorf("Expected road_signs and general topic states, got %+v", report)
+	}
+}
+
+func TestImporter_RequiresCatalog(t *testing.T) {
+	importer := newTestImporter(staticCatalog{})
+	_, err := importer.Import(context.Background(), strings.NewReader("user_id,external_id,reviewed_at,grade\n"), Options{Format: FormatCSV, DryRun: true})
+	if err != ErrCatalogEmpty {
+		t.Errorf("Expected ErrCatalogEmpty, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Review log formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Grade scales of imported reviews
+const (
+	// GradeScaleSM2 grades reviews 0-5, as SM-2 quality
+	GradeScaleSM2 = "sm2"
+	// GradeScaleAnki grades reviews 1-4: again, hard, good, easy
+	GradeScaleAnki = "anki"
+)
+
+var (
+	// ErrInvalidLog is returned for review logs that cannot be read at all
+	ErrInvalidLog = errors.New("invalid review log")
+	// ErrUnsupportedFormat is returned for review log formats other than csv and jsonl
+	ErrUnsupportedFormat = errors.New("format must be csv or jsonl")
+	// ErrUnsupportedGradeScale is returned for grade scales other than sm2 and anki
+	ErrUnsupportedGradeScale = errors.New("grade_scale must be sm2 or anki")
+)
+
+// Columns of a CSV review log, and fields of a JSONL one
+const (
+	columnUserID     = "user_id"
+	columnExternalID = "external_id"
+	columnReviewedAt = "reviewed_at"
+	columnGrade      = "grade"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ankiQuality maps Anki answer buttons to SM-2 quality
+var ankiQuality = map[int]int{
+	1: 1, // Again
+	2: 3, // Hard
+	3: 4, // Good
+	4: 5, // Easy
+}
+
+// Review is one review of an imported log
+type Review struct {
+	UserID     string
+	ExternalID string
+	ReviewedAt time.Time
+	Quality    int // SM-2 quality, 0-5
+	Line       int
+}
+
+// Correct reports whether the review was answered correctly, which SM-2 takes to
+// be a quality of 3 or more
+func (r Review) Correct() bool {
+	return r.Quality >= 3
+}
+
+// RowError is a row of a review log that could not be imported
+type RowError struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// Parse reads a review log and returns its valid reviews, in log order, with the rows
+// that were skipped and the number of data rows read. Reviews must not be later than now.
+func Parse(r io.Reader, format, gradeScale string, now time.Time) ([]Review, []RowError, int, error) {
+	if gradeScale == "" {
+		gradeScale = GradeScaleSM2
+	}
+	if gradeScale != GradeScaleSM2 && gradeScale != GradeScaleAnki {
+		return nil, nil, 0, ErrUnsupportedGradeScale
+	}
+
+	p := &parser{gradeScale: gradeScale, now: now}
+	var err error
+	switch format {
+	case FormatCSV:
+		err = p.parseCSV(r)
+	case FormatJSONL:
+		err = p.parseJSONL(r)
+	default:
+		return nil, nil, 0, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return p.reviews, p.errors, p.rows, nil
+}
+
+// parser collects the reviews and row errors of a log
+type parser struct {
+	gradeScale string
+	now        time.Time
+
+	reviews []Review
+	errors  []RowError
+	rows    int
+}
+
+// parseCSV reads a CSV log whose header names the columns; other columns are ignored
+func (p *parser) parseCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return fmt.Errorf("%w: missing header", ErrInvalidLog)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLog, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{columnUserID, columnExternalID, columnReviewedAt, columnGrade} {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("%w: missing column %s", ErrInvalidLog, name)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		p.rows++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				p.errors = append(p.errors, RowError{Line: parseErr.Line, Reason: parseErr.Err.Error()})
+				continue
+			}
+			return fmt.Errorf("%w: %v", ErrInvalidLog, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		grade, err := strconv.Atoi(field(columnGrade))
+		if err != nil {
+			p.errors = append(p.errors, RowError{Line: line, Reason: "grade must be an integer"})
+			continue
+		}
+		p.add(line, field(columnUserID), field(columnExternalID), field(columnReviewedAt), grade)
+	}
+}
+
+// jsonlReview is a review of a JSONL log. External IDs may be strings or numbers.
+type jsonlReview struct {
+	UserID     string          `json:"user_id"`
+	ExternalID json.RawMessage `json:"external_id"`
+	ReviewedAt string          `json:"reviewed_at"`
+	Grade      *int            `json:"grade"`
+}
+
+// parseJSONL reads a log of one JSON object per line; blank lines are skipped
+func (p *parser) parseJSONL(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		p.rows++
+
+		var review jsonlReview
+		if err := json.Unmarshal([]byte(text), &review); err != nil {
+			p.errors = append(p.errors, RowError{Line: line, Reason: "invalid JSON"})
+			continue
+		}
+		if review.Grade == nil {
+			p.errors = append(p.errors, RowError{Line: line, Reason: "grade is required"})
+			continue
+		}
+
+		externalID := strings.Trim(string(review.ExternalID), `"`)
+		p.add(line, strings.TrimSpace(review.UserID), strings.TrimSpace(externalID), strings.TrimSpace(review.ReviewedAt), *review.Grade)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLog, err)
+	}
+	return nil
+}
+
+// add validates a row and keeps it as a review, or records why it was skipped
+func (p *parser) add(line int, userID, externalID, reviewedAt string, grade int) {
+	reject := func(reason string) {
+		p.errors = append(p.errors, RowError{Line: line, Reason: reason})
+	}
+
+	if !uuidPattern.MatchString(userID) {
+		reject("user_id must be a UUID")
+		return
+	}
+	if externalID == "" || externalID == "null" {
+		reject("external_id is required")
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, reviewedAt)
+	if err != nil {
+		reject("reviewed_at must be an RFC 3339 timestamp")
+		return
+	}
+	if at.After(p.now) {
+		reject("reviewed_at is in the future")
+		return
+	}
+
+	quality, ok := p.quality(grade)
+	if !ok {
+		reject(fmt.Sprintf("grade %d is outside the %s scale", grade, p.gradeScale))
+		return
+	}
+
+	p.reviews = append(p.reviews, Review{
+		UserID:     strings.ToLower(userID),
+		ExternalID: externalID,
+		ReviewedAt: at.UTC(),
+		Quality:    quality,
+		Line:       line,
+	})
+}
+
+// quality converts a grade of the parser's scale to SM-2 quality
+func (p *parser) quality(grade int) (int, bool) {
+	if p.gradeScale == GradeScaleAnki {
+		quality, ok := ankiQuality[grade]
+		return quality, ok
+	}
+	return grade, grade >= 0 && grade <= 5
+}
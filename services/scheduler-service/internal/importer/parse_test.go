@@ -0,0 +1,100 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testUserID = "00000000-0000-4000-8000-000000000001"
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParse_CSV(t *testing.T) {
+	log := "\ufeffUser_ID,external_id,reviewed_at,grade,deck\n" +
+		testUserID + ",card-1,2026-01-10T08:00:00Z,4,signs\n" +
+		"not-a-uuid,card-1,2026-01-10T08:00:00Z,4,signs\n" +
+		testUserID + ",,2026-01-10T08:00:00Z,4,signs\n" +
+		testUserID + ",card-2,yesterday,4,signs\n" +
+		testUserID + ",card-2,2026-01-11T08:00:00+02:00,six,signs\n" +
+		testUserID + ",card-2,2026-01-11T08:00:00+02:00,6,signs\n" +
+		testUserID + ",card-2,2027-01-01T00:00:00Z,3,signs\n" +
+		testUserID + ",card-2,2026-01-11T08:00:00+02:00,0,signs\n"
+
+	reviews, rowErrors, rows, err := Parse(strings.NewReader(log), FormatCSV, "", testNow)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if rows != 8 {
+		t.Errorf("Expected 8 rows, got %d", rows)
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("Expected 2 reviews, got %d: %+v", len(reviews), rowErrors)
+	}
+	if reviews[0].ExternalID != "card-1" || reviews[0].Quality != 4 || reviews[0].Line != 2 {
+		t.Errorf("Unexpected first review %+v", reviews[0])
+	}
+	if !reviews[1].ReviewedAt.Equal(time.Date(2026, 1, 11, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected reviewed_at in UTC, got %v", reviews[1].ReviewedAt)
+	}
+	if reviews[1].Correct() {
+		t.Errorf("Expected grade 0 to be incorrect")
+	}
+
+	expectedLines := []int{3, 4, 5, 6, 7, 8}
+	if len(rowErrors) != len(expectedLines) {
+		t.Fatalf("Expected %d row errors, got %+v", len(expectedLines), rowErrors)
+	}
+	for i, line := range expectedLines {
+		if rowErrors[i].Line != line {
+			t.Errorf("Expected row error %d on line %d, got %+v", i, line, rowErrors[i])
+		}
+	}
+}
+
+func TestParse_CSVMissingColumn(t *testing.T) {
+	_, _, _, err := Parse(strings.NewReader("user_id,external_id,grade\n"), FormatCSV, "", testNow)
+	if !errors.Is(err, ErrInvalidLog) {
+		t.Errorf("Expected ErrInvalidLog, got %v", err)
+	}
+}
+
+func TestParse_JSONLWithAnkiGrades(t *testing.T) {
+	log := `{"user_id":"` + testUserID + `","external_id":1514219712345,"reviewed_at":"2026-01-10T08:00:00Z","grade":1}
+
+{"user_id":"` + testUserID + `","external_id":"card-2","reviewed_at":"2026-01-10T08:00:00Z","grade":4}
+{"user_id":"` + testUserID + `","external_id":"card-2","reviewed_at":"2026-01-10T08:00:00Z","grade":0}
+{"user_id":"` + testUserID + `","external_id":"card-2","reviewed_at":"2026-01-10T08:00:00Z"}
+{not json}
+`
+
+	reviews, rowErrors, rows, err := Parse(strings.NewReader(log), FormatJSONL, GradeScaleAnki, testNow)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if rows != 5 {
+		t.Errorf("Expected blank lines to be skipped, got %d rows", rows)
+	}
+	if len(reviews) != 2 {
+		t.Fatalf("Expected 2 reviews, got %d: %+v", len(reviews), rowErrors)
+	}
+	if reviews[0].ExternalID != "1514219712345" || reviews[0].Quality != 1 {
+		t.Errorf("Expected numeric external ID and again as quality 1, got %+v", reviews[0])
+	}
+	if reviews[1].Quality != 5 || reviews[1].Line != 3 {
+		t.Errorf("Expected easy as quality 5 on line 3, got %+v", reviews[1])
+	}
+	if len(rowErrors) != 3 || rowErrors[0].Line != 4 || rowErrors[1].Line != 5 || rowErrors[2].Line != 6 {
+		t.Errorf("Unexpected row errors %+v", rowErrors)
+	}
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	if _, _, _, err := Parse(strings.NewReader(""), "xml", "", testNow); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Expected ErrUnsupportedFormat, got %v", err)
+	}
+	if _, _, _, err := Parse(strings.NewReader(""), FormatCSV, "supermemo", testNow); !errors.Is(err, ErrUnsupportedGradeScale) {
+		t.Errorf("Expected ErrUnsupportedGradeScale, got %v", err)
+	}
+}
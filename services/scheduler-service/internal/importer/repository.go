@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"scheduler-service/internal/database"
+)
+
+// lookupBatchSize bounds the external IDs looked up per query
+const lookupBatchSize = 1000
+
+// Repository reads the item_external_ids table mapping other apps' item IDs to
+// catalog items
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new external item ID repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// externalIDRow is a mapping of an external item ID
+type externalIDRow struct {
+	ExternalID string
+	ItemID     string
+}
+
+// ItemIDs returns the catalog item IDs mapped to external IDs of a source app, by
+// external ID. External IDs without a mapping are left out.
+func (r *Repository) ItemIDs(ctx context.Context, source string, externalIDs []string) (map[string]string, error) {
+	itemIDs := make(map[string]string)
+
+	for start := 0; start < len(externalIDs); start += lookupBatchSize {
+		end := start + lookupBatchSize
+		if end > len(externalIDs) {
+			end = len(externalIDs)
+		}
+
+		var rows []externalIDRow
+		err := r.db.WithContext(ctx).Raw(`
+			SELECT external_id, item_id::text AS item_id
+			FROM item_external_ids
+			WHERE source = ? AND external_id IN ?`, source, externalIDs[start:end]).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up external item IDs: %w", err)
+		}
+		for _, row := range rows {
+			itemIDs[row.ExternalID] = row.ItemID
+		}
+	}
+
+	return itemIDs, nil
+}
@@ -0,0 +1,209 @@
+package jurisdiction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/curriculum"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/state"
+)
+
+// ErrInvalidJurisdiction is returned for a jurisdiction that is not a two-letter country code
+var ErrInvalidJurisdiction = errors.New("invalid jurisdiction")
+
+// TopicTransfer describes the knowledge of one topic carried over into a new jurisdiction
+type TopicTransfer struct {
+	Topic              string  `json:"topic"`
+	Factor             float64 `json:"factor"`
+	SourceMastery      float64 `json:"source_mastery"`
+	TransferredMastery float64 `json:"transferred_mastery"`
+}
+
+// SwitchResult is the outcome of switching a user to another jurisdiction
+type SwitchResult struct {
+	PreviousJurisdiction string          `json:"previous_jurisdiction"`
+	Jurisdiction         string          `json:"jurisdiction"`
+	ExamDate             *time.Time      `json:"exam_date,omitempty"`
+	Transfers            []TopicTransfer `json:"transfers"`
+}
+
+// Service switches users between jurisdictions. Each jurisdiction keeps its own BKT and
+// IRT states, so progress made in one is preserved when studying for another.
+type Service struct {
+	logger        *logger.Logger
+	repository    *Repository
+	settings      *state.UserSettingsManager
+	bktManager    *state.BKTStateManager
+	irtManager    *state.IRTManager
+	bktAlgorithm  *algorithms.BKTAlgorithm
+	irtAlgorithm  *algorithms.IRTAlgorithm
+	prerequisites *curriculum.Registry
+
+	defaultTransfer float64
+}
+
+// NewService creates a new jurisdiction service
+func NewService(
+	cfg *config.CurriculumConfig,
+	logger *logger.Logger,
+	db *database.DB,
+	settings *state.UserSettingsManager,
+	bktManager *state.BKTStateManager,
+	irtManager *state.IRTManager,
+	bktAlgorithm *algorithms.BKTAlgorithm,
+	irtAlgorithm *algorithms.IRTAlgorithm,
+	prerequisites *curriculum.Registry,
+) *Service {
+	return &Service{
+		logger:          logger,
+		repository:      NewRepository(db),
+		settings:        settings,
+		bktManager:      bktManager,
+		irtManager:      irtManager,
+		bktAlgorithm:    bktAlgorithm,
+		irtAlgorithm:    irtAlgorithm,
+		prerequisites:   prerequisites,
+		defaultTransfer: cfg.TransferFactor,
+	}
+}
+
+// SwitchJurisdiction makes a jurisdiction the user's active one. Topics the user practised
+// in their previous jurisdiction that are part of the new curriculum, and not yet started
+// there, are seeded with the transferable share of their knowledge. The exam date booked
+// in the previous jurisdiction is kept for when the user switches back, and the one
+// booked in the new jurisdiction, if any, is restored.
+func (s *Service) SwitchJurisdiction(ctx context.Context, userID, countryCode string) (*SwitchResult, error) {
+	target, err := validateJurisdiction(countryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settings.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	source := state.NormalizeJurisdiction(settings.CountryCode)
+
+	result := &SwitchResult{
+		PreviousJurisdiction: source,
+		Jurisdiction:         target,
+		ExamDate:             settings.ExamDate,
+		Transfers:            []TopicTransfer{},
+	}
+	if source == target {
+		return result, nil
+	}
+
+	now := time.Now()
+	transfers, err := s.transfer(ctx, userID, source, target, now)
+	if err != nil {
+		return nil, err
+	}
+	result.Transfers = transfers
+
+	// Exam dates are per jurisdiction
+	if err := s.repository.Leave(ctx, userID, source, settings.ExamDate, now); err != nil {
+		return nil, err
+	}
+	record, err := s.repository.Get(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+	settings.ExamDate = nil
+	if record != nil && record.ExamDate != nil && record.ExamDate.After(now) {
+		settings.ExamDate = record.ExamDate
+	}
+	result.ExamDate = settings.ExamDate
+
+	settings.CountryCode = target
+	if err := s.settings.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Enter(ctx, userID, target, now); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":               userID,
+		"previous_jurisdiction": source,
+		"jurisdiction":          target,
+		"topics_transferred":    len(transfers),
+	}).Info("Switched user jurisdiction")
+
+	return result, nil
+}
+
+// transfer seeds the target jurisdiction's BKT and IRT states from the source jurisdiction
+func (s *Service) transfer(ctx context.Context, userID, source, target string, now time.Time) ([]TopicTransfer, error) {
+	sourceBKT, err := s.bktManager.GetUserStatesIn(ctx, userID, source)
+	if err != nil {
+		return nil, err
+	}
+	targetBKT, err := s.bktManager.GetUserStatesIn(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	factors := planTransfer(sourceBKT, targetBKT, s.prerequisites.Graph(target), s.defaultTransfer)
+	if len(factors) == 0 {
+		return []TopicTransfer{}, nil
+	}
+
+	sourceIRT, err := s.irtManager.GetUserStatesIn(ctx, userID, source)
+	if err != nil {
+		return nil, err
+	}
+	targetIRT, err := s.irtManager.GetUserStatesIn(ctx, userID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]TopicTransfer, 0, len(factors))
+	seededBKT := make(map[string]*algorithms.BKTState, len(factors))
+	seededIRT := make(map[string]*algorithms.IRTState, len(factors))
+	for _, topic := range sortedTopics(factors) {
+		factor := factors[topic]
+
+		bktState := transferBKT(sourceBKT[topic], s.bktAlgorithm.InitializeState(topic), factor, now)
+		seededBKT[topic] = bktState
+		transfers = append(transfers, TopicTransfer{
+			Topic:              topic,
+			Factor:             factor,
+			SourceMastery:      sourceBKT[topic].ProbKnowledge,
+			TransferredMastery: bktState.ProbKnowledge,
+		})
+
+		if sourceState, ok := sourceIRT[topic]; ok {
+			if _, exists := targetIRT[topic]; !exists {
+				seededIRT[topic] = transferIRT(sourceState, s.irtAlgorithm.InitializeState(topic), factor, now)
+			}
+		}
+	}
+
+	if _, err := s.bktManager.SeedStates(ctx, userID, target, seededBKT); err != nil {
+		return nil, fmt.Errorf("failed to transfer knowledge states: %w", err)
+	}
+	if _, err := s.irtManager.SeedStates(ctx, userID, target, seededIRT); err != nil {
+		return nil, fmt.Errorf("failed to transfer ability states: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// validateJurisdiction checks for a two-letter country code, or the default jurisdiction
+func validateJurisdiction(countryCode string) (string, error) {
+	jurisdiction := state.NormalizeJurisdiction(countryCode)
+	if jurisdiction == curriculum.DefaultJurisdiction {
+		return jurisdiction, nil
+	}
+	if len(jurisdiction) != 2 || jurisdiction[0] < 'A' || jurisdiction[0] > 'Z' || jurisdiction[1] < 'A' || jurisdiction[1] > 'Z' {
+		return "", fmt.Errorf("%w: %q is not a two-letter country code", ErrInvalidJurisdiction, countryCode)
+	}
+	return jurisdiction, nil
+}
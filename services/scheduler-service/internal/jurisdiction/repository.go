@@ -0,0 +1,76 @@
+package jurisdiction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository persists the jurisdictions users have studied for in the user_jurisdictions table
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new user jurisdiction repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Get returns the user's record for a jurisdiction, or nil if they never studied for it
+func (r *Repository) Get(ctx context.Context, userID, jurisdiction string) (*models.UserJurisdictionModel, error) {
+	var model models.UserJurisdictionModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND jurisdiction = ?", userID, jurisdiction).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user jurisdiction: %w", err)
+	}
+	return &model, nil
+}
+
+// Leave records the exam date the user had booked in a jurisdiction they are switching away from
+func (r *Repository) Leave(ctx context.Context, userID, jurisdiction string, examDate *time.Time, at time.Time) error {
+	model := models.UserJurisdictionModel{
+		UserID:       userID,
+		Jurisdiction: jurisdiction,
+		ExamDate:     examDate,
+		CreatedAt:    at,
+		LastActiveAt: at,
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "jurisdiction"}},
+		DoUpdates: clause.AssignmentColumns([]string{"exam_date", "last_active_at"}),
+	}).Create(&model).Error
+	if err != nil {
+		return fmt.Errorf("failed to record user jurisdiction %s: %w", jurisdiction, err)
+	}
+	return nil
+}
+
+// Enter records that the user is switching to a jurisdiction, keeping any exam date stored for it
+func (r *Repository) Enter(ctx context.Context, userID, jurisdiction string, at time.Time) error {
+	model := models.UserJurisdictionModel{
+		UserID:       userID,
+		Jurisdiction: jurisdiction,
+		CreatedAt:    at,
+		LastActiveAt: at,
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "jurisdiction"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_active_at"}),
+	}).Create(&model).Error
+	if err != nil {
+		return fmt.Errorf("failed to record user jurisdiction %s: %w", jurisdiction, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package jurisdiction
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+)
+
+// newTestRepository connects to SCHEDULER_TEST_DATABASE_URL and applies migrations.
+// Tests using it are skipped when the variable is not set.
+func newTestRepository(t *testing.T) (*Repository, *database.DB) {
+	t.Helper()
+
+	databaseURL := os.Getenv("SCHEDULER_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("SCHEDULER_TEST_DATABASE_URL not set")
+	}
+
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	db, err := database.New(&config.DatabaseConfig{URL: databaseURL, MaxOpenConns: 5, MaxIdleConns: 1, ConnMaxLifetime: time.Minute}, &metrics.Metrics{}, log)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return NewRepository(db), db
+}
+
+func TestRepository_ExamDatesPerJurisdiction(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+
+	userID := fmt.Sprintf("00000000-0000-4000-c000-%012d", time.Now().UnixNano()%1e12)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM user_jurisdictions WHERE user_id = ?", userID)
+	})
+
+	record, err := repo.Get(ctx, userID, "GB")
+	if err != nil || record != nil {
+		t.Fatalf("expected no record for a new jurisdiction, got %+v (%v)", record, err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	examDate := now.Add(30 * 24 * time.Hour)
+	if err := repo.Leave(ctx, userID, "GB", &examDate, now); err != nil {
+		t.Fatalf("failed to leave jurisdiction: %v", err)
+	}
+
+	// Entering again keeps the stored exam date
+	if err := repo.Enter(ctx, userID, "GB", now.Add(time.Hour)); err != nil {
+		t.Fatalf("failed to enter jurisdiction: %v", err)
+	}
+	record, err = repo.Get(ctx, userID, "GB")
+	if err != nil || record == nil {
+		t.Fatalf("failed to get jurisdiction: %v", err)
+	}
+	if record.ExamDate == nil || !record.ExamDate.Equal(examDate) {
+		t.Errorf("expected exam date %v, got %v", examDate, record.ExamDate)
+	}
+	if !record.LastActiveAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected last active %v, got %v", now.Add(time.Hour), record.LastActiveAt)
+	}
+
+	// Leaving without an exam clears it
+	if err := repo.Leave(ctx, userID, "GB", nil, now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("failed to leave jurisdiction: %v", err)
+	}
+	if record, _ = repo.Get(ctx, userID, "GB"); record == nil || record.ExamDate != nil {
+		t.Errorf("expected exam date to be cleared, got %+v", record)
+	}
+}
@@ -0,0 +1,70 @@
+package jurisdiction
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/curriculum"
+)
+
+// planTransfer returns the transfer factor of each topic that carries over into the target
+// jurisdiction: topics practised in the source that are part of the target curriculum and
+// have no state in the target yet. Progress already made in the target is never overwritten.
+func planTransfer(
+	source map[string]*algorithms.BKTState,
+	target map[string]*algorithms.BKTState,
+	graph *curriculum.PrerequisiteGraph,
+	defaultFactor float64,
+) map[string]float64 {
+	factors := make(map[string]float64)
+	for topic, state := range source {
+		if state.AttemptsCount == 0 || !graph.HasTopic(topic) {
+			continue
+		}
+		if _, exists := target[topic]; exists {
+			continue
+		}
+		if factor := graph.TransferFactor(topic, defaultFactor); factor > 0 {
+			factors[topic] = math.Min(factor, 1)
+		}
+	}
+	return factors
+}
+
+// transferBKT moves the prior knowledge probability of a fresh state towards the source
+// mastery by the transfer factor. Confidence is discounted the same way, and no attempts
+// carry over, so evidence in the new jurisdiction quickly outweighs the transferred estimate.
+func transferBKT(source, prior *algorithms.BKTState, factor float64, at time.Time) *algorithms.BKTState {
+	return &algorithms.BKTState{
+		ProbKnowledge: prior.ProbKnowledge + factor*(source.ProbKnowledge-prior.ProbKnowledge),
+		ProbGuess:     prior.ProbGuess,
+		ProbSlip:      prior.ProbSlip,
+		ProbLearn:     prior.ProbLearn,
+		LastUpdated:   at,
+		Confidence:    math.Max(prior.Confidence, factor*source.Confidence),
+	}
+}
+
+// transferIRT moves the prior ability of a fresh state towards the source ability, and its
+// variance towards the source variance, by the transfer factor
+func transferIRT(source, prior *algorithms.IRTState, factor float64, at time.Time) *algorithms.IRTState {
+	return &algorithms.IRTState{
+		Theta:         prior.Theta + factor*(source.Theta-prior.Theta),
+		ThetaVariance: prior.ThetaVariance + factor*(source.ThetaVariance-prior.ThetaVariance),
+		Confidence:    math.Max(prior.Confidence, factor*source.Confidence),
+		LastUpdated:   at,
+		UpdateHistory: make([]float64, 0),
+	}
+}
+
+// sortedTopics returns the topics of a transfer plan in name order
+func sortedTopics(factors map[string]float64) []string {
+	topics := make([]string, 0, len(factors))
+	for topic := range factors {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
@@ -0,0 +1,122 @@
+package jurisdiction
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/curriculum"
+)
+
+func TestPlanTransfer(t *testing.T) {
+	signsTransfer := 0.2
+	graph, err := curriculum.NewPrerequisiteGraph("GB", []curriculum.TopicNode{
+		{Topic: "traffic_signs", Transfer: &signsTransfer},
+		{Topic: "vehicle_operation"},
+		{Topic: "parking"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build graph: %v", err)
+	}
+
+	source := map[string]*algorithms.BKTState{
+		"traffic_signs":     {ProbKnowledge: 0.9, AttemptsCount: 10},
+		"vehicle_operation": {ProbKnowledge: 0.7, AttemptsCount: 4},
+		"parking":           {ProbKnowledge: 0.8, AttemptsCount: 6},
+		"highway_merging":   {ProbKnowledge: 0.6, AttemptsCount: 3}, // not in the target curriculum
+		"general":           {ProbKnowledge: 0.1},                   // never practised
+	}
+	target := map[string]*algorithms.BKTState{
+		"parking": {ProbKnowledge: 0.4, AttemptsCount: 2}, // already started in the target
+	}
+
+	factors := planTransfer(source, target, graph, 0.5)
+	expected := map[string]float64{"traffic_signs": 0.2, "vehicle_operation": 0.5}
+	if len(factors) != len(expected) {
+		t.Fatalf("Expected transfers %v, got %v", expected, factors)
+	}
+	for topic, factor := range expected {
+		if factors[topic] != factor {
+			t.Errorf("Expected %s to transfer %v, got %v", topic, factor, factors[topic])
+		}
+	}
+
+	if factors := planTransfer(source, target, graph, 0); len(factors) != 1 {
+		t.Errorf("Expected only the topic with its own transfer factor when the default is 0, got %v", factors)
+	}
+}
+
+func TestTransferBKT(t *testing.T) {
+	now := time.Now()
+	prior := algorithms.NewBKTAlgorithm().InitializeState("traffic_signs")
+	source := &algorithms.BKTState{ProbKnowledge: 0.9, Confidence: 0.8, AttemptsCount: 12, CorrectCount: 10}
+
+	transferred := transferBKT(source, prior, 0.5, now)
+
+	expected := prior.ProbKnowledge + 0.5*(0.9-prior.ProbKnowledge)
+	if math.Abs(transferred.ProbKnowledge-expected) > 1e-9 {
+		t.Errorf("Expected knowledge %v, got %v", expected, transferred.ProbKnowledge)
+	}
+	if math.Abs(transferred.Confidence-0.4) > 1e-9 {
+		t.Errorf("Expected confidence discounted to 0.4, got %v", transferred.Confidence)
+	}
+	if transferred.AttemptsCount != 0 || transferred.CorrectCount != 0 {
+		t.Errorf("Expected no attempts to carry over, got %d/%d", transferred.CorrectCount, transferred.AttemptsCount)
+	}
+	if transferred.ProbGuess != prior.ProbGuess || transferred.ProbSlip != prior.ProbSlip {
+		t.Errorf("Expected guess and slip from the target prior")
+	}
+
+	if full := transferBKT(source, prior, 1, now); math.Abs(full.ProbKnowledge-0.9) > 1e-9 {
+		t.Errorf("Expected a full transfer to keep the source mastery, got %v", full.ProbKnowledge)
+	}
+	if none := transferBKT(source, prior, 0, now); none.ProbKnowledge != prior.ProbKnowledge || none.Confidence != prior.Confidence {
+		t.Errorf("Expected no transfer to keep the prior, got %+v", none)
+	}
+}
+
+func TestTransferIRT(t *testing.T) {
+	prior := &algorithms.IRTState{Theta: 0, ThetaVariance: 1, Confidence: 0.1}
+	source := &algorithms.IRTState{Theta: 1.6, ThetaVariance: 0.2, Confidence: 0.9, AttemptsCount: 20}
+
+	transferred := transferIRT(source, prior, 0.25, time.Now())
+
+	if math.Abs(transferred.Theta-0.4) > 1e-9 {
+		t.Errorf("Expected theta 0.4, got %v", transferred.Theta)
+	}
+	if math.Abs(transferred.ThetaVariance-0.8) > 1e-9 {
+		t.Errorf("Expected variance 0.8, got %v", transferred.ThetaVariance)
+	}
+	if math.Abs(transferred.Confidence-0.225) > 1e-9 {
+		t.Errorf("Expected confidence 0.225, got %v", transferred.Confidence)
+	}
+	if transferred.AttemptsCount != 0 {
+		t.Errorf("Expected no attempts to carry over, got %d", transferred.AttemptsCount)
+	}
+}
+
+func TestValidateJurisdiction(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"GB", "GB", true},
+		{" us ", "US", true},
+		{"default", curriculum.DefaultJurisdiction, true},
+		{"GBR", "", false},
+		{"G1", "", false},
+	}
+
+	for _, test := range tests {
+		jurisdiction, err := validateJurisdiction(test.input)
+		if test.valid && (err != nil || jurisdiction != test.expected) {
+			t.Errorf("%q: expected %q, got %q (%v)", test.input, test.expected, jurisdiction, err)
+		}
+		if !test.valid && !errors.Is(err, ErrInvalidJurisdiction) {
+			t.Errorf("%q: expected ErrInvalidJurisdiction, got %v", test.input, err)
+		}
+	}
+}
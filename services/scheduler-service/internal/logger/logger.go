@@ -7,6 +7,7 @@ import (
 	"scheduler-service/internal/config"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Logger struct {
@@ -52,7 +53,12 @@ func New(cfg *config.LoggingConfig) *Logger {
 func (l *Logger) WithContext(ctx context.Context) *logrus.Entry {
 	entry := l.WithFields(logrus.Fields{})
 
-	if traceID := ctx.Value(TraceIDKey); traceID != nil {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		entry = entry.WithFields(logrus.Fields{
+			"trace_id": spanContext.TraceID().String(),
+			"span_id":  spanContext.SpanID().String(),
+		})
+	} else if traceID := ctx.Value(TraceIDKey); traceID != nil {
 		entry = entry.WithField("trace_id", traceID)
 	}
 
@@ -73,8 +79,11 @@ func WithUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, UserIDKey, userID)
 }
 
-// GetTraceID retrieves trace ID from context
+// GetTraceID retrieves trace ID from context, preferring the trace of the current span
 func GetTraceID(ctx context.Context) string {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		return spanContext.TraceID().String()
+	}
 	if traceID := ctx.Value(TraceIDKey); traceID != nil {
 		return traceID.(string)
 	}
@@ -22,19 +22,39 @@ type Metrics struct {
 	ItemSelectionTime prometheus.Histogram
 
 	// Cache metrics
-	CacheHits   *prometheus.CounterVec
-	CacheMisses *prometheus.CounterVec
+	CacheHits     *prometheus.CounterVec
+	CacheMisses   *prometheus.CounterVec
+	CacheErrors   *prometheus.CounterVec
+	CacheDuration *prometheus.HistogramVec
+	CacheHitRatio prometheus.Gauge
 
 	// Database metrics
 	DBConnections prometheus.Gauge
 	DBQueries     *prometheus.CounterVec
 	DBDuration    *prometheus.HistogramVec
+	DBSlowQueries *prometheus.CounterVec
 
 	// Business metrics
 	ActiveUsers      prometheus.Gauge
 	SessionsStarted  prometheus.Counter
 	ItemsRecommended prometheus.Counter
 	MasteryUpdates   prometheus.Counter
+
+	// Background worker metrics
+	WorkerLeader         prometheus.Gauge
+	WorkerJobRuns        *prometheus.CounterVec
+	WorkerJobDuration    *prometheus.HistogramVec
+	WorkerJobRunning     *prometheus.GaugeVec
+	WorkerJobLastRun     *prometheus.GaugeVec
+	WorkerJobLastSuccess *prometheus.GaugeVec
+	WorkerJobProcessed   *prometheus.GaugeVec
+
+	// Rate limiting metrics
+	RateLimited *prometheus.CounterVec
+
+	// Tenant metrics
+	TenantRequestDuration *prometheus.HistogramVec
+	TenantRequestTotal    *prometheus.CounterVec
 }
 
 // New creates a new metrics instance
@@ -108,6 +128,27 @@ func New() *Metrics {
 			},
 			[]string{"cache_type"},
 		),
+		CacheErrors: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "scheduler_cache_errors_total",
+				Help: "Total number of cache errors",
+			},
+			[]string{"cache_type"},
+		),
+		CacheDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "scheduler_cache_duration_seconds",
+				Help:    "Duration of cache operations",
+				Buckets: []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1},
+			},
+			[]string{"operation"},
+		),
+		CacheHitRatio: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "scheduler_cache_hit_ratio",
+				Help: "Ratio of cache hits to lookups on the optimized Redis client",
+			},
+		),
 		DBConnections: promauto.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "scheduler_db_connections",
@@ -129,6 +170,13 @@ func New() *Metrics {
 			},
 			[]string{"operation"},
 		),
+		DBSlowQueries: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "scheduler_db_slow_queries_total",
+				Help: "Total number of database operations slower than one second",
+			},
+			[]string{"operation"},
+		),
 		ActiveUsers: promauto.NewGauge(
 			prometheus.GaugeOpts{
 				Name: "scheduler_active_users",
@@ -153,6 +201,77 @@ func New() *Metrics {
 				Help: "Total number of mastery level updates",
 			},
 		),
+		WorkerLeader: promauto.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "scheduler_worker_leader",
+				Help: "Whether this instance holds the maintenance leader lock (1) or not (0)",
+			},
+		),
+		WorkerJobRuns: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "scheduler_worker_job_runs_total",
+				Help: "Total number of background job runs",
+			},
+			[]string{"job", "status"},
+		),
+		WorkerJobDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "scheduler_worker_job_duration_seconds",
+				Help:    "Duration of background job runs",
+				Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300},
+			},
+			[]string{"job"},
+		),
+		WorkerJobRunning: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "scheduler_worker_job_running",
+				Help: "Whether a background job is currently running",
+			},
+			[]string{"job"},
+		),
+		WorkerJobLastRun: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "scheduler_worker_job_last_run_timestamp_seconds",
+				Help: "Unix time of the last background job run",
+			},
+			[]string{"job"},
+		),
+		WorkerJobLastSuccess: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "scheduler_worker_job_last_success_timestamp_seconds",
+				Help: "Unix time of the last successful background job run",
+			},
+			[]string{"job"},
+		),
+		WorkerJobProcessed: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "scheduler_worker_job_last_processed",
+				Help: "Number of records processed by the last background job run",
+			},
+			[]string{"job"},
+		),
+		RateLimited: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "scheduler_rate_limited_requests_total",
+				Help: "Total number of gRPC requests rejected by rate limits",
+			},
+			[]string{"method", "scope"},
+		),
+		TenantRequestDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "scheduler_tenant_request_duration_seconds",
+				Help:    "Duration of gRPC requests made within a tenant",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"tenant", "method"},
+		),
+		TenantRequestTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "scheduler_tenant_requests_total",
+				Help: "Total number of gRPC requests made within a tenant",
+			},
+			[]string{"tenant", "method", "status"},
+		),
 	}
 }
 
@@ -177,12 +296,67 @@ func (m *Metrics) RecordCacheMiss(cacheType string) {
 	m.CacheMisses.WithLabelValues(cacheType).Inc()
 }
 
+// RecordCacheError records cache error
+func (m *Metrics) RecordCacheError(cacheType string) {
+	m.CacheErrors.WithLabelValues(cacheType).Inc()
+}
+
+// RecordCacheOperation records cache operation latency
+func (m *Metrics) RecordCacheOperation(operation string, duration time.Duration) {
+	m.CacheDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// RecordSlowQuery records a slow database operation
+func (m *Metrics) RecordSlowQuery(operation string) {
+	m.DBSlowQueries.WithLabelValues(operation).Inc()
+}
+
 // RecordDBOperation records database operation metrics
 func (m *Metrics) RecordDBOperation(operation, status string, duration time.Duration) {
 	m.DBQueries.WithLabelValues(operation, status).Inc()
 	m.DBDuration.WithLabelValues(operation).Observe(duration.Seconds())
 }
 
+// RecordJobRun records the outcome of a background job run
+func (m *Metrics) RecordJobRun(job, status string, processed int, startedAt time.Time, duration time.Duration) {
+	m.WorkerJobRuns.WithLabelValues(job, status).Inc()
+	m.WorkerJobDuration.WithLabelValues(job).Observe(duration.Seconds())
+	m.WorkerJobLastRun.WithLabelValues(job).Set(float64(startedAt.Unix()))
+	m.WorkerJobProcessed.WithLabelValues(job).Set(float64(processed))
+	if status == "success" {
+		m.WorkerJobLastSuccess.WithLabelValues(job).Set(float64(startedAt.Add(duration).Unix()))
+	}
+}
+
+// SetJobRunning marks a background job as running or idle
+func (m *Metrics) SetJobRunning(job string, running bool) {
+	value := 0.0
+	if running {
+		value = 1.0
+	}
+	m.WorkerJobRunning.WithLabelValues(job).Set(value)
+}
+
+// SetWorkerLeader records whether this instance is the maintenance leader
+func (m *Metrics) SetWorkerLeader(leader bool) {
+	value := 0.0
+	if leader {
+		value = 1.0
+	}
+	m.WorkerLeader.Set(value)
+}
+
+// RecordRateLimited records a request rejected by the rate limit of a scope
+func (m *Metrics) RecordRateLimited(method, scope string) {
+	m.RateLimited.WithLabelValues(method, scope).Inc()
+}
+
+// RecordTenantRequest records a request made within a tenant
+func (m *Metrics) RecordTenantRequest(tenant, method, status string, duration time.Duration) {
+	m.TenantRequestDuration.WithLabelValues(tenant, method).Observe(duration.Seconds())
+	m.TenantRequestTotal.WithLabelValues(tenant, method, status).Inc()
+}
+
 // Timer helps measure operation duration
 type Timer struct {
 	start time.Time
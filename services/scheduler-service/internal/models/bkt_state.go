@@ -9,6 +9,7 @@ import (
 // BKTStateModel represents the Bayesian Knowledge Tracing state in the database using GORM
 type BKTStateModel struct {
 	UserID        string    `gorm:"primaryKey;column:user_id;type:uuid" json:"user_id"`
+	Jurisdiction  string    `gorm:"primaryKey;column:jurisdiction;type:varchar(10)" json:"jurisdiction"`
 	Topic         string    `gorm:"primaryKey;column:topic;type:varchar(100)" json:"topic"`
 	ProbKnowledge float64   `gorm:"column:prob_knowledge;type:decimal(5,4);not null;default:0.1000" json:"prob_knowledge"`
 	ProbGuess     float64   `gorm:"column:prob_guess;type:decimal(5,4);not null;default:0.2500" json:"prob_guess"`
@@ -10,6 +10,7 @@ import (
 // IRTStateModel represents the IRT ability state in the database using GORM
 type IRTStateModel struct {
 	UserID        string    `gorm:"primaryKey;column:user_id;type:uuid" json:"user_id"`
+	Jurisdiction  string    `gorm:"primaryKey;column:jurisdiction;type:varchar(10)" json:"jurisdiction"`
 	Topic         string    `gorm:"primaryKey;column:topic;type:varchar(100)" json:"topic"`
 	Theta         float64   `gorm:"column:theta;type:decimal(8,4);not null;default:0.0000" json:"theta"`
 	ThetaVariance float64   `gorm:"column:theta_variance;type:decimal(8,4);not null;default:1.0000" json:"theta_variance"`
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LearningGoalModel stores a user-defined learning goal in the shared learning_goals table
+type LearningGoalModel struct {
+	ID             string     `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID         string     `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	Title          string     `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	Description    *string    `gorm:"column:description" json:"description,omitempty"`
+	TargetTopics   []byte     `gorm:"column:target_topics;type:jsonb;not null" json:"target_topics"`
+	TargetMastery  float64    `gorm:"column:target_mastery;default:0.8" json:"target_mastery"`
+	TargetDate     *time.Time `gorm:"column:target_date" json:"target_date,omitempty"`
+	CurrentMastery float64    `gorm:"column:current_mastery;default:0" json:"current_mastery"`
+	IsCompleted    bool       `gorm:"column:is_completed;default:false" json:"is_completed"`
+	CompletedAt    *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"column:created_at;default:now()" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LearningGoalModel) TableName() string {
+	return "learning_goals"
+}
+
+// BeforeUpdate sets updated_at before updating a record
+func (g *LearningGoalModel) BeforeUpdate(tx *gorm.DB) error {
+	g.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserOnboardingModel stores a user's onboarding state in the database using GORM.
+// The full state is kept as JSONB; stage and country are duplicated for querying.
+type UserOnboardingModel struct {
+	ID             string    `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID         string    `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	CountryCode    string    `gorm:"column:country_code;type:varchar(2);not null" json:"country_code"`
+	Stage          string    `gorm:"column:stage;type:varchar(50);not null" json:"stage"`
+	OnboardingData []byte    `gorm:"column:onboarding_data;type:jsonb;not null" json:"onboarding_data"`
+	IsActive       bool      `gorm:"column:is_active;default:true" json:"is_active"`
+	CreatedAt      time.Time `gorm:"column:created_at;default:now()" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserOnboardingModel) TableName() string {
+	return "user_onboarding"
+}
+
+// BeforeUpdate sets updated_at before updating a record
+func (u *UserOnboardingModel) BeforeUpdate(tx *gorm.DB) error {
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// OnboardingStageTransitionModel records a user moving between onboarding stages
+type OnboardingStageTransitionModel struct {
+	ID               string    `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID           string    `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	FromStage        *string   `gorm:"column:from_stage;type:varchar(50)" json:"from_stage,omitempty"`
+	ToStage          string    `gorm:"column:to_stage;type:varchar(50);not null" json:"to_stage"`
+	TimeSpentSeconds int       `gorm:"column:time_spent_seconds;default:0" json:"time_spent_seconds"`
+	InteractionCount int       `gorm:"column:interaction_count;default:0" json:"interaction_count"`
+	TransitionTime   time.Time `gorm:"column:transition_time;default:now()" json:"transition_time"`
+}
+
+// TableName specifies the table name for GORM
+func (OnboardingStageTransitionModel) TableName() string {
+	return "onboarding_stage_transitions"
+}
+
+// LearningPathModel stores a personalized learning path generated during onboarding
+type LearningPathModel struct {
+	ID                    string     `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	PathID                string     `gorm:"column:path_id;type:varchar(255);not null;uniqueIndex" json:"path_id"`
+	UserID                string     `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	RecommendedLevel      string     `gorm:"column:recommended_level;type:varchar(50);not null" json:"recommended_level"`
+	FocusTopics           []byte     `gorm:"column:focus_topics;type:jsonb;not null" json:"focus_topics"`
+	StudyPlan             []byte     `gorm:"column:study_plan;type:jsonb;not null" json:"study_plan"`
+	Milestones            []byte     `gorm:"column:milestones;type:jsonb;not null" json:"milestones"`
+	EstimatedDurationDays int        `gorm:"column:estimated_duration_days;not null" json:"estimated_duration_days"`
+	IsActive              bool       `gorm:"column:is_active;default:true" json:"is_active"`
+	PlanEvaluatedAt       *time.Time `gorm:"column:plan_evaluated_at" json:"plan_evaluated_at,omitempty"`
+	CreatedAt             time.Time  `gorm:"column:created_at;default:now()" json:"created_at"`
+	UpdatedAt             time.Time  `gorm:"column:updated_at;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LearningPathModel) TableName() string {
+	return "learning_paths"
+}
+
+// MilestoneProgressModel tracks a user's progress towards a learning path milestone
+type MilestoneProgressModel struct {
+	ID              string     `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID          string     `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	MilestoneID     string     `gorm:"column:milestone_id;type:varchar(255);not null" json:"milestone_id"`
+	LearningPathID  string     `gorm:"column:learning_path_id;type:uuid" json:"learning_path_id"`
+	Title           string     `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	TargetAccuracy  float64    `gorm:"column:target_accuracy;not null" json:"target_accuracy"`
+	CurrentAccuracy float64    `gorm:"column:current_accuracy;default:0" json:"current_accuracy"`
+	RequiredTopics  []byte     `gorm:"column:required_topics;type:jsonb;not null" json:"required_topics"`
+	IsCompleted     bool       `gorm:"column:is_completed" json:"is_completed"`
+	CompletedAt     *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	EstimatedDate   time.Time  `gorm:"column:estimated_date;not null" json:"estimated_date"`
+	CreatedAt       time.Time  `gorm:"column:created_at;default:now()" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (MilestoneProgressModel) TableName() string {
+	return "milestone_progress"
+}
+
+// OnboardingAnalyticsModel stores completion analytics and feedback for a finished onboarding
+type OnboardingAnalyticsModel struct {
+	ID                    string    `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID                string    `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	CountryCode           string    `gorm:"column:country_code;type:varchar(2);not null" json:"country_code"`
+	CompletionTimeSeconds int       `gorm:"column:completion_time_seconds;not null" json:"completion_time_seconds"`
+	SatisfactionScore     *int      `gorm:"column:satisfaction_score" json:"satisfaction_score,omitempty"` // 1-5, nil if not given
+	Feedback              string    `gorm:"column:feedback" json:"feedback"`
+	PlacementCompleted    bool      `gorm:"column:placement_completed" json:"placement_completed"`
+	RecommendedLevel      string    `gorm:"column:recommended_level;type:varchar(50)" json:"recommended_level"`
+	CreatedAt             time.Time `gorm:"column:created_at;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (OnboardingAnalyticsModel) TableName() string {
+	return "onboarding_analytics"
+}
+
+// StudyActivityModel aggregates a user's study time and attempts for one day
+type StudyActivityModel struct {
+	UserID          string    `gorm:"primaryKey;column:user_id;type:uuid" json:"user_id"`
+	ActivityDate    time.Time `gorm:"primaryKey;column:activity_date;type:date" json:"activity_date"`
+	StudySeconds    int       `gorm:"column:study_seconds;not null;default:0" json:"study_seconds"`
+	Attempts        int       `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	CorrectAttempts int       `gorm:"column:correct_attempts;not null;default:0" json:"correct_attempts"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (StudyActivityModel) TableName() string {
+	return "study_activity"
+}
+
+// StudyPlanHistoryModel stores one revision of a learning path's study plan
+type StudyPlanHistoryModel struct {
+	ID         string    `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID     string    `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	PathID     string    `gorm:"column:path_id;type:varchar(255);not null" json:"path_id"`
+	Revision   int       `gorm:"column:revision;not null" json:"revision"`
+	StudyPlan  []byte    `gorm:"column:study_plan;type:jsonb;not null" json:"study_plan"`
+	Milestones []byte    `gorm:"column:milestones;type:jsonb;not null" json:"milestones"`
+	Reasons    []byte    `gorm:"column:reasons;type:jsonb;not null" json:"reasons"`
+	Inputs     []byte    `gorm:"column:inputs;type:jsonb;not null" json:"inputs"`
+	CreatedAt  time.Time `gorm:"column:created_at;default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (StudyPlanHistoryModel) TableName() string {
+	return "study_plan_history"
+}
+
+// PlacementTestSessionModel stores a placement test session. Completed sessions keep
+// the full algorithm state, including items and responses, for reporting.
+type PlacementTestSessionModel struct {
+	ID                string     `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	SessionID         string     `gorm:"column:session_id;type:varchar(255);not null;uniqueIndex" json:"session_id"`
+	UserID            string     `gorm:"column:user_id;type:uuid;not null" json:"user_id"`
+	CountryCode       string     `gorm:"column:country_code;type:varchar(2);not null" json:"country_code"`
+	Status            string     `gorm:"column:status;type:varchar(50);not null" json:"status"`
+	ItemsAdministered int        `gorm:"column:items_administered;default:0" json:"items_administered"`
+	OverallAbility    float64    `gorm:"column:overall_ability;default:0" json:"overall_ability"`
+	OverallSE         float64    `gorm:"column:overall_se;default:1" json:"overall_se"`
+	TopicAbilities    []byte     `gorm:"column:topic_abilities;type:jsonb" json:"topic_abilities"`
+	TopicConfidence   []byte     `gorm:"column:topic_confidence;type:jsonb" json:"topic_confidence"`
+	PlacementData     []byte     `gorm:"column:placement_data;type:jsonb" json:"placement_data"`
+	Results           []byte     `gorm:"column:results;type:jsonb" json:"results"`
+	StartedAt         time.Time  `gorm:"column:started_at;default:now()" json:"started_at"`
+	CompletedAt       *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CreatedAt         time.Time  `gorm:"column:created_at;default:now()" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PlacementTestSessionModel) TableName() string {
+	return "placement_test_sessions"
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// PlacementReportModel stores the aggregated statistics of the placement tests completed
+// in one jurisdiction over a reporting window in the database using GORM
+type PlacementReportModel struct {
+	ID                 string    `gorm:"primaryKey;column:id;type:uuid;default:gen_random_uuid()" json:"id"`
+	Jurisdiction       string    `gorm:"column:jurisdiction;type:varchar(10);not null" json:"jurisdiction"`
+	WindowStart        time.Time `gorm:"column:window_start;not null" json:"window_start"`
+	WindowEnd          time.Time `gorm:"column:window_end;not null" json:"window_end"`
+	TestsAnalyzed      int       `gorm:"column:tests_analyzed;not null" json:"tests_analyzed"`
+	MeanTestLength     float64   `gorm:"column:mean_test_length;not null" json:"mean_test_length"`
+	LengthDistribution []byte    `gorm:"column:length_distribution;type:jsonb;not null" json:"length_distribution"`
+	StoppingReasons    []byte    `gorm:"column:stopping_reasons;type:jsonb;not null" json:"stopping_reasons"`
+	TerminationSE      []byte    `gorm:"column:termination_se;type:jsonb;not null" json:"termination_se"`
+	GeneratedAt        time.Time `gorm:"column:generated_at;default:now()" json:"generated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PlacementReportModel) TableName() string {
+	return "placement_reports"
+}
+
+// PlacementItemFitModel stores the fit statistics of one placement item within a report
+type PlacementItemFitModel struct {
+	ReportID          string  `gorm:"primaryKey;column:report_id;type:uuid" json:"report_id"`
+	ItemID            string  `gorm:"primaryKey;column:item_id;type:varchar(255)" json:"item_id"`
+	Responses         int     `gorm:"column:responses;not null" json:"responses"`
+	ProportionCorrect float64 `gorm:"column:proportion_correct;not null" json:"proportion_correct"`
+	ExpectedCorrect   float64 `gorm:"column:expected_correct;not null" json:"expected_correct"`
+	Infit             float64 `gorm:"column:infit;not null" json:"infit"`
+	Outfit            float64 `gorm:"column:outfit;not null" json:"outfit"`
+	Misfit            bool    `gorm:"column:misfit;not null" json:"misfit"`
+}
+
+// TableName specifies the table name for GORM
+func (PlacementItemFitModel) TableName() string {
+	return "placement_item_fit"
+}
@@ -15,6 +15,7 @@ type SM2StateModel struct {
 	Repetition     int       `gorm:"column:repetition;not null;default:0" json:"repetition"`
 	NextDue        time.Time `gorm:"column:next_due;not null" json:"next_due"`
 	LastReviewed   time.Time `gorm:"column:last_reviewed;not null" json:"last_reviewed"`
+	Lapses         int       `gorm:"column:lapses;not null;default:0" json:"lapses"`
 	CreatedAt      time.Time `gorm:"column:created_at;not null;default:now()" json:"created_at"`
 	UpdatedAt      time.Time `gorm:"column:updated_at;not null;default:now()" json:"updated_at"`
 }
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// UserJurisdictionModel records a jurisdiction a user has studied for in the database using GORM
+type UserJurisdictionModel struct {
+	UserID       string     `gorm:"primaryKey;column:user_id;type:uuid" json:"user_id"`
+	Jurisdiction string     `gorm:"primaryKey;column:jurisdiction;type:varchar(10)" json:"jurisdiction"`
+	ExamDate     *time.Time `gorm:"column:exam_date" json:"exam_date,omitempty"`
+	CreatedAt    time.Time  `gorm:"column:created_at;not null;default:now()" json:"created_at"`
+	LastActiveAt time.Time  `gorm:"column:last_active_at;not null;default:now()" json:"last_active_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserJurisdictionModel) TableName() string {
+	return "user_jurisdictions"
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserSchedulerSettingsModel represents per-user scheduling settings in the database using GORM
+type UserSchedulerSettingsModel struct {
+	UserID      string     `gorm:"primaryKey;column:user_id;type:uuid" json:"user_id"`
+	CountryCode string     `gorm:"column:country_code;type:varchar(10)" json:"country_code"`
+	ExamDate    *time.Time `gorm:"column:exam_date" json:"exam_date,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at;not null;default:now()" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;not null;default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserSchedulerSettingsModel) TableName() string {
+	return "user_scheduler_settings"
+}
+
+// BeforeUpdate sets updated_at before updating a record
+func (u *UserSchedulerSettingsModel) BeforeUpdate(tx *gorm.DB) error {
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// HasUpcomingExam reports whether the user has an exam booked after the given time
+func (u *UserSchedulerSettingsModel) HasUpcomingExam(currentTime time.Time) bool {
+	return u.ExamDate != nil && u.ExamDate.After(currentTime)
+}
+
+// GetDaysUntilExam returns the number of days until the exam, or -1 if no exam is booked
+func (u *UserSchedulerSettingsModel) GetDaysUntilExam(currentTime time.Time) float64 {
+	if u.ExamDate == nil {
+		return -1
+	}
+	return u.ExamDate.Sub(currentTime).Hours() / 24.0
+}
@@ -2,27 +2,45 @@ package onboarding
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"time"
 
 	"scheduler-service/internal/algorithms"
 	"scheduler-service/internal/cache"
+	"scheduler-service/internal/curriculum"
 	"scheduler-service/internal/database"
 	"scheduler-service/internal/logger"
+	"scheduler-service/internal/models"
 	"scheduler-service/internal/state"
 )
 
+var (
+	// ErrPlacementNotInProgress is returned when a placement action needs a running test
+	ErrPlacementNotInProgress = errors.New("no placement test in progress")
+	// ErrInvalidPlacementResponse is returned for a response that does not match the current item
+	ErrInvalidPlacementResponse = errors.New("invalid placement response")
+	// ErrNoLearningPath is returned when the user has not been given a learning path yet
+	ErrNoLearningPath = errors.New("no learning path available for user")
+)
+
+// PlacementItemSource returns the calibrated placement items available for a country
+type PlacementItemSource func(ctx context.Context, countryCode string) []algorithms.PlacementItem
+
 // OnboardingService handles user onboarding and initialization
 type OnboardingService struct {
 	logger             *logger.Logger
 	db                 *database.DB
 	cache              *cache.RedisClient
+	repository         *Repository
 	placementAlgorithm *algorithms.PlacementTestAlgorithm
 	sm2Manager         *state.SM2StateManager
 	bktManager         *state.BKTStateManager
 	irtManager         *state.IRTManager
+	settingsManager    *state.UserSettingsManager
+	prerequisites      *curriculum.Registry
+	placementItems     PlacementItemSource
 }
 
 // NewOnboardingService creates a new onboarding service instance
@@ -34,18 +52,29 @@ func NewOnboardingService(
 	sm2Manager *state.SM2StateManager,
 	bktManager *state.BKTStateManager,
 	irtManager *state.IRTManager,
+	settingsManager *state.UserSettingsManager,
+	prerequisites *curriculum.Registry,
 ) *OnboardingService {
 	return &OnboardingService{
 		logger:             logger,
 		db:                 db,
 		cache:              cache,
+		repository:         NewRepository(db),
 		placementAlgorithm: placementAlgorithm,
 		sm2Manager:         sm2Manager,
 		bktManager:         bktManager,
 		irtManager:         irtManager,
+		settingsManager:    settingsManager,
+		prerequisites:      prerequisites,
 	}
 }
 
+// SetPlacementItemSource sets where adaptive placement tests draw their items from.
+// Without a source, placement tests start without items and cannot take responses.
+func (o *OnboardingService) SetPlacementItemSource(source PlacementItemSource) {
+	o.placementItems = source
+}
+
 // OnboardingState represents the current state of user onboarding
 type OnboardingState struct {
 	UserID        string              `json:"user_id"`
@@ -73,6 +102,15 @@ const (
 	StageCompleted       OnboardingStage = "completed"
 )
 
+// IsValid reports whether the stage is a known onboarding stage
+func (s OnboardingStage) IsValid() bool {
+	switch s {
+	case StageWelcome, StagePreferences, StagePlacementTest, StageLearningPath, StageInitialPractice, StageCompleted:
+		return true
+	}
+	return false
+}
+
 // OnboardingProgress tracks progress through onboarding stages
 type OnboardingProgress struct {
 	CurrentStage           OnboardingStage   `json:"current_stage"`
@@ -102,6 +140,7 @@ type PlacementTestState struct {
 	CurrentAbility float64                     `json:"current_ability"`
 	Confidence     float64                     `json:"confidence"`
 	TopicAbilities map[string]float64          `json:"topic_abilities"`
+	CurrentItem    *algorithms.PlacementItem   `json:"current_item,omitempty"` // item awaiting a response
 	Results        *algorithms.PlacementResult `json:"results,omitempty"`
 	StartedAt      time.Time                   `json:"started_at"`
 	CompletedAt    *time.Time                  `json:"completed_at,omitempty"`
@@ -126,15 +165,18 @@ type LearningPath struct {
 	Milestones        []LearningMilestone   `json:"milestones"`
 	EstimatedDuration int                   `json:"estimated_duration_days"`
 	CreatedAt         time.Time             `json:"created_at"`
+	PlanRevision      int                   `json:"plan_revision"` // bumped each time the study plan is re-planned
 }
 
 // TopicRecommendation represents a recommended topic with priority
 type TopicRecommendation struct {
-	Topic         string  `json:"topic"`
-	Priority      string  `json:"priority"`   // "high", "medium", "low"
-	Reason        string  `json:"reason"`     // why this topic is recommended
-	Difficulty    float64 `json:"difficulty"` // recommended starting difficulty
-	EstimatedTime int     `json:"estimated_time_hours"`
+	Topic         string   `json:"topic"`
+	Priority      string   `json:"priority"`   // "high", "medium", "low"
+	Reason        string   `json:"reason"`     // why this topic is recommended
+	Difficulty    float64  `json:"difficulty"` // recommended starting difficulty
+	EstimatedTime int      `json:"estimated_time_hours"`
+	Prerequisites []string `json:"prerequisites,omitempty"` // topics to master before this one
+	Locked        bool     `json:"locked"`                  // prerequisites not yet mastered
 }
 
 // StudyPlan represents the recommended study schedule
@@ -181,6 +223,9 @@ func (o *OnboardingService) InitializeOnboarding(ctx context.Context, userID, co
 		o.logger.WithContext(ctx).WithField("user_id", userID).Info("User already has onboarding state")
 		return existingState, nil
 	}
+	if err != nil && !errors.Is(err, ErrOnboardingNotFound) {
+		return nil, fmt.Errorf("failed to get onboarding state: %w", err)
+	}
 
 	// Create new onboarding state
 	state := &OnboardingState{
@@ -224,6 +269,11 @@ func (o *OnboardingService) InitializeOnboarding(ctx context.Context, userID, co
 		return nil, fmt.Errorf("failed to store onboarding state: %w", err)
 	}
 
+	// New users start studying for the jurisdiction they onboard in
+	if err := o.settingsManager.AdoptJurisdiction(ctx, userID, countryCode); err != nil {
+		return nil, fmt.Errorf("failed to set user jurisdiction: %w", err)
+	}
+
 	o.logger.WithContext(ctx).WithField("user_id", userID).Info("Onboarding initialized successfully")
 	return state, nil
 }
@@ -287,6 +337,15 @@ func (o *OnboardingService) StartPlacementTest(ctx context.Context, userID strin
 		StartedAt:      time.Now(),
 	}
 
+	// Administer the first item; the rest are selected adaptively as responses arrive
+	if o.placementItems != nil {
+		item, err := o.administerNextItem(ctx, placementState)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select first placement item: %w", err)
+		}
+		testState.CurrentItem = item
+	}
+
 	// Update onboarding state
 	state.PlacementTest = testState
 	state.Stage = StagePlacementTest
@@ -298,10 +357,10 @@ func (o *OnboardingService) StartPlacementTest(ctx context.Context, userID strin
 		return nil, fmt.Errorf("failed to store onboarding state: %w", err)
 	}
 
-	// Store placement test state in cache
+	// Responses are processed against the cached adaptive state
 	err = o.storePlacementState(ctx, placementState.SessionID, placementState)
 	if err != nil {
-		o.logger.WithContext(ctx).WithError(err).Warn("Failed to store placement state in cache")
+		return nil, err
 	}
 
 	o.logger.WithContext(ctx).WithFields(map[string]interface{}{
@@ -326,12 +385,13 @@ func (o *OnboardingService) CompletePlacementTest(ctx context.Context, userID st
 	}
 
 	if state.PlacementTest == nil {
-		return fmt.Errorf("no placement test in progress")
+		return ErrPlacementNotInProgress
 	}
 
 	// Update placement test state
 	completedAt := time.Now()
 	state.PlacementTest.Status = PlacementCompleted
+	state.PlacementTest.CurrentItem = nil
 	state.PlacementTest.Results = results
 	state.PlacementTest.CompletedAt = &completedAt
 	state.PlacementTest.ItemsCompleted = results.ItemsAdministered
@@ -371,6 +431,79 @@ func (o *OnboardingService) CompletePlacementTest(ctx context.Context, userID st
 	return nil
 }
 
+// SubmitPlacementResponse records the user's answer to the current placement item.
+// The test is finished and the learning path generated once a stopping criterion is
+// met; otherwise the next item is selected adaptively and returned as CurrentItem.
+func (o *OnboardingService) SubmitPlacementResponse(ctx context.Context, userID, itemID string, correct bool, responseTimeMs, confidence int) (*PlacementTestState, error) {
+	state, placementState, err := o.getActivePlacementTest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.placementAlgorithm.ProcessResponse(ctx, placementState, itemID, correct, responseTimeMs, confidence)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPlacementResponse, err)
+	}
+
+	state.PlacementTest.ItemsCompleted = len(placementState.Responses)
+	state.PlacementTest.CurrentAbility = placementState.OverallAbility
+	state.PlacementTest.Confidence = placementState.OverallConfidence
+	state.PlacementTest.TopicAbilities = placementState.TopicAbilities
+	state.PlacementTest.CurrentItem = nil
+
+	shouldStop, reason := o.placementAlgorithm.CheckStoppingCriteria(ctx, placementState)
+	if !shouldStop {
+		item, err := o.administerNextItem(ctx, placementState)
+		if err != nil {
+			o.logger.WithContext(ctx).WithError(err).Warn("No further placement items, finishing test")
+			shouldStop, reason = true, "item_bank_exhausted"
+		} else {
+			state.PlacementTest.CurrentItem = item
+		}
+	}
+
+	if shouldStop {
+		if err := o.finishPlacementTest(ctx, userID, placementState, reason); err != nil {
+			return nil, err
+		}
+		completed, err := o.getOnboardingState(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get onboarding state: %w", err)
+		}
+		return completed.PlacementTest, nil
+	}
+
+	if err := o.storePlacementState(ctx, placementState.SessionID, placementState); err != nil {
+		return nil, err
+	}
+
+	state.UpdatedAt = time.Now()
+	if err := o.storeOnboardingState(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to store onboarding state: %w", err)
+	}
+
+	return state.PlacementTest, nil
+}
+
+// FinishPlacementTest ends the user's placement test early, scoring the responses
+// given so far, and generates the learning path
+func (o *OnboardingService) FinishPlacementTest(ctx context.Context, userID string) (*LearningPath, error) {
+	_, placementState, err := o.getActivePlacementTest(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(placementState.Responses) == 0 {
+		return nil, fmt.Errorf("%w: at least one response is required", ErrInvalidPlacementResponse)
+	}
+
+	if err := o.finishPlacementTest(ctx, userID, placementState, "user_finished"); err != nil {
+		return nil, err
+	}
+
+	return o.GetLearningPath(ctx, userID)
+}
+
 // SkipPlacementTest allows users to skip the placement test
 func (o *OnboardingService) SkipPlacementTest(ctx context.Context, userID string) error {
 	o.logger.WithContext(ctx).WithField("user_id", userID).Info("Skipping placement test")
@@ -485,7 +618,7 @@ func (o *OnboardingService) GetLearningPath(ctx context.Context, userID string)
 	}
 
 	if state.LearningPath == nil {
-		return nil, fmt.Errorf("no learning path available for user")
+		return nil, ErrNoLearningPath
 	}
 
 	return state.LearningPath, nil
@@ -521,6 +654,57 @@ func (o *OnboardingService) UpdateProgress(ctx context.Context, userID string, s
 
 // Helper methods
 
+// getActivePlacementTest loads the onboarding state and the adaptive state of its in-progress placement test
+func (o *OnboardingService) getActivePlacementTest(ctx context.Context, userID string) (*OnboardingState, *algorithms.PlacementTestState, error) {
+	state, err := o.getOnboardingState(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get onboarding state: %w", err)
+	}
+
+	if state.PlacementTest == nil || state.PlacementTest.Status != PlacementInProgress {
+		return nil, nil, ErrPlacementNotInProgress
+	}
+
+	placementState, err := o.getPlacementState(ctx, state.PlacementTest.SessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return state, placementState, nil
+}
+
+// administerNextItem selects the most informative remaining item and adds it to the test
+func (o *OnboardingService) administerNextItem(ctx context.Context, placementState *algorithms.PlacementTestState) (*algorithms.PlacementItem, error) {
+	if o.placementItems == nil {
+		return nil, fmt.Errorf("no placement item source configured")
+	}
+
+	item, err := o.placementAlgorithm.SelectNextItem(ctx, placementState, o.placementItems(ctx, placementState.CountryCode))
+	if err != nil {
+		return nil, err
+	}
+
+	o.placementAlgorithm.AddItemToTest(placementState, item)
+	return item, nil
+}
+
+// finishPlacementTest scores the placement test and completes it
+func (o *OnboardingService) finishPlacementTest(ctx context.Context, userID string, placementState *algorithms.PlacementTestState, reason string) error {
+	results, err := o.placementAlgorithm.FinalizePlacementTest(ctx, placementState, reason)
+	if err != nil {
+		return fmt.Errorf("failed to finalize placement test: %w", err)
+	}
+
+	if err := o.storePlacementState(ctx, placementState.SessionID, placementState); err != nil {
+		o.logger.WithContext(ctx).WithError(err).Warn("Failed to store finalized placement state")
+	}
+	if err := o.repository.SaveCompletedPlacement(ctx, placementState, results); err != nil {
+		o.logger.WithContext(ctx).WithError(err).Warn("Failed to record completed placement session")
+	}
+
+	return o.CompletePlacementTest(ctx, userID, results)
+}
+
 // advanceStage advances the onboarding to the next stage
 func (o *OnboardingService) advanceStage(ctx context.Context, state *OnboardingState, nextStage OnboardingStage) error {
 	// Add current stage to completed stages if not already there
@@ -558,12 +742,14 @@ func (o *OnboardingService) advanceStage(ctx context.Context, state *OnboardingS
 	return nil
 }
 
-// initializeSchedulerState initializes the scheduler state with placement results
+// initializeSchedulerState initializes the scheduler state of the onboarding jurisdiction with placement results
 func (o *OnboardingService) initializeSchedulerState(ctx context.Context, userID, countryCode string, results *algorithms.PlacementResult) error {
+	jurisdiction := state.NormalizeJurisdiction(countryCode)
+
 	// Initialize BKT states for each topic by getting existing state (which creates default if not exists)
 	for topic := range results.TopicAbilities {
 		// Get state (this will create default state if not exists)
-		_, err := o.bktManager.GetState(ctx, userID, topic)
+		_, err := o.bktManager.GetStateIn(ctx, userID, jurisdiction, topic)
 		if err != nil {
 			o.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Error("Failed to initialize BKT state")
 			return fmt.Errorf("failed to initialize BKT state for topic %s: %w", topic, err)
@@ -573,7 +759,7 @@ func (o *OnboardingService) initializeSchedulerState(ctx context.Context, userID
 	// Initialize IRT states for each topic by getting existing state (which creates default if not exists)
 	for topic := range results.TopicAbilities {
 		// Get state (this will create default state if not exists)
-		_, err := o.irtManager.GetState(ctx, userID, topic)
+		_, err := o.irtManager.GetStateIn(ctx, userID, jurisdiction, topic)
 		if err != nil {
 			o.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Error("Failed to initialize IRT state")
 			return fmt.Errorf("failed to initialize IRT state for topic %s: %w", topic, err)
@@ -589,14 +775,16 @@ func (o *OnboardingService) initializeSchedulerState(ctx context.Context, userID
 	return nil
 }
 
-// initializeSchedulerStateDefault initializes scheduler state with default values
+// initializeSchedulerStateDefault initializes scheduler state of the onboarding jurisdiction with default values
 func (o *OnboardingService) initializeSchedulerStateDefault(ctx context.Context, userID, countryCode string) error {
+	jurisdiction := state.NormalizeJurisdiction(countryCode)
+
 	// Default topics for driving test
 	topics := []string{"traffic_signs", "road_rules", "vehicle_operation", "safety_procedures"}
 
 	// Initialize BKT states by getting existing state (which creates default if not exists)
 	for _, topic := range topics {
-		_, err := o.bktManager.GetState(ctx, userID, topic)
+		_, err := o.bktManager.GetStateIn(ctx, userID, jurisdiction, topic)
 		if err != nil {
 			return fmt.Errorf("failed to initialize default BKT state for topic %s: %w", topic, err)
 		}
@@ -604,7 +792,7 @@ func (o *OnboardingService) initializeSchedulerStateDefault(ctx context.Context,
 
 	// Initialize IRT states by getting existing state (which creates default if not exists)
 	for _, topic := range topics {
-		_, err := o.irtManager.GetState(ctx, userID, topic)
+		_, err := o.irtManager.GetStateIn(ctx, userID, jurisdiction, topic)
 		if err != nil {
 			return fmt.Errorf("failed to initialize default IRT state for topic %s: %w", topic, err)
 		}
@@ -646,8 +834,12 @@ func (o *OnboardingService) generateLearningPath(ctx context.Context, state *Onb
 
 	pathID := fmt.Sprintf("path_%s_%d", state.UserID, time.Now().Unix())
 
-	// Analyze placement results to determine focus areas
-	focusTopics := o.analyzePlacementResults(results, state.Preferences)
+	// Analyze placement results to determine focus areas, then gate them on prerequisites
+	mastery := make(map[string]float64, len(results.TopicAbilities))
+	for topic, ability := range results.TopicAbilities {
+		mastery[topic] = o.abilityToBKTProbability(ability, results.TopicConfidence[topic])
+	}
+	focusTopics := o.applyPrerequisites(state.CountryCode, o.analyzePlacementResults(results, state.Preferences), mastery)
 
 	// Generate study plan based on user preferences
 	studyPlan := o.generateStudyPlan(state.Preferences)
@@ -666,6 +858,7 @@ func (o *OnboardingService) generateLearningPath(ctx context.Context, state *Onb
 		Milestones:        milestones,
 		EstimatedDuration: estimatedDuration,
 		CreatedAt:         time.Now(),
+		PlanRevision:      1,
 	}
 
 	o.logger.WithContext(ctx).WithFields(map[string]interface{}{
@@ -717,6 +910,9 @@ func (o *OnboardingService) generateDefaultLearningPath(ctx context.Context, sta
 		},
 	}
 
+	// Nothing is mastered yet, so only topics without prerequisites start unlocked
+	focusTopics = o.applyPrerequisites(state.CountryCode, focusTopics, nil)
+
 	// Generate study plan based on user preferences
 	studyPlan := o.generateStudyPlan(state.Preferences)
 
@@ -734,6 +930,7 @@ func (o *OnboardingService) generateDefaultLearningPath(ctx context.Context, sta
 		Milestones:        milestones,
 		EstimatedDuration: estimatedDuration,
 		CreatedAt:         time.Now(),
+		PlanRevision:      1,
 	}
 
 	o.logger.WithContext(ctx).WithFields(map[string]interface{}{
@@ -900,6 +1097,30 @@ func (o *OnboardingService) sortRecommendationsByPriority(recommendations []Topi
 	}
 }
 
+// applyPrerequisites reorders recommendations so that each topic follows its prerequisites
+// in the jurisdiction's topic graph, and locks topics whose prerequisites are not mastered
+func (o *OnboardingService) applyPrerequisites(countryCode string, recommendations []TopicRecommendation, mastery map[string]float64) []TopicRecommendation {
+	graph := o.prerequisites.Graph(countryCode)
+	threshold := o.prerequisites.UnlockMastery()
+
+	byTopic := make(map[string]TopicRecommendation, len(recommendations))
+	topics := make([]string, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		byTopic[recommendation.Topic] = recommendation
+		topics = append(topics, recommendation.Topic)
+	}
+
+	ordered := make([]TopicRecommendation, 0, len(recommendations))
+	for _, topic := range graph.OrderTopics(topics) {
+		recommendation := byTopic[topic]
+		recommendation.Prerequisites = graph.Prerequisites(topic)
+		recommendation.Locked = !graph.IsUnlocked(topic, mastery, threshold)
+		ordered = append(ordered, recommendation)
+	}
+
+	return ordered
+}
+
 // priorityToInt converts priority string to int for sorting
 func (o *OnboardingService) priorityToInt(priority string) int {
 	switch priority {
@@ -1150,32 +1371,29 @@ func (o *OnboardingService) getOnboardingState(ctx context.Context, userID strin
 		return &state, nil
 	}
 
-	// Try database - for now, return not found since we don't have the table structure
-	// TODO: Implement proper database retrieval once tables are created
-	return nil, fmt.Errorf("onboarding state not found")
+	// Fallback to database
+	statePtr, err := o.repository.GetActiveState(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Cache for future use
-	stateBytes, _ := json.Marshal(state)
-	_ = o.cache.Set(ctx, cacheKey, string(stateBytes), 30*time.Minute)
+	if err := o.cache.Set(ctx, cacheKey, statePtr, 30*time.Minute); err != nil {
+		o.logger.WithContext(ctx).WithError(err).Warn("Failed to cache onboarding state")
+	}
 
-	return &state, nil
+	return statePtr, nil
 }
 
-// storeOnboardingState stores onboarding state in cache and database
+// storeOnboardingState stores onboarding state in database and cache
 func (o *OnboardingService) storeOnboardingState(ctx context.Context, state *OnboardingState) error {
-	stateBytes, err := json.Marshal(state)
-	if err != nil {
-		return fmt.Errorf("failed to marshal onboarding state: %w", err)
+	if err := o.repository.SaveState(ctx, state); err != nil {
+		return err
 	}
 
-	// Store in database - for now, just log since we don't have the table structure
-	// TODO: Implement proper database storage once tables are created
-	o.logger.WithContext(ctx).WithField("user_id", state.UserID).Debug("Onboarding state stored (cache only)")
-
 	// Store in cache
 	cacheKey := fmt.Sprintf("onboarding:%s", state.UserID)
-	err = o.cache.Set(ctx, cacheKey, string(stateBytes), 30*time.Minute)
-	if err != nil {
+	if err := o.cache.Set(ctx, cacheKey, state, 30*time.Minute); err != nil {
 		o.logger.WithContext(ctx).WithError(err).Warn("Failed to cache onboarding state")
 	}
 
@@ -1184,13 +1402,8 @@ func (o *OnboardingService) storeOnboardingState(ctx context.Context, state *Onb
 
 // storePlacementState stores placement test state in cache
 func (o *OnboardingService) storePlacementState(ctx context.Context, sessionID string, state *algorithms.PlacementTestState) error {
-	stateBytes, err := json.Marshal(state)
-	if err != nil {
-		return fmt.Errorf("failed to marshal placement state: %w", err)
-	}
-
 	cacheKey := fmt.Sprintf("placement:%s", sessionID)
-	err = o.cache.Set(ctx, cacheKey, string(stateBytes), 2*time.Hour)
+	err := o.cache.Set(ctx, cacheKey, state, 2*time.Hour)
 	if err != nil {
 		return fmt.Errorf("failed to cache placement state: %w", err)
 	}
@@ -1198,6 +1411,17 @@ func (o *OnboardingService) storePlacementState(ctx context.Context, sessionID s
 	return nil
 }
 
+// getPlacementState retrieves placement test state from cache
+func (o *OnboardingService) getPlacementState(ctx context.Context, sessionID string) (*algorithms.PlacementTestState, error) {
+	cacheKey := fmt.Sprintf("placement:%s", sessionID)
+	var state algorithms.PlacementTestState
+	if err := o.cache.Get(ctx, cacheKey, &state); err != nil {
+		return nil, fmt.Errorf("%w: placement session %s has expired", ErrPlacementNotInProgress, sessionID)
+	}
+
+	return &state, nil
+}
+
 // trackOnboardingCompletion tracks analytics for completed onboarding
 func (o *OnboardingService) trackOnboardingCompletion(ctx context.Context, state *OnboardingState) error {
 	// Calculate total time spent
@@ -1206,14 +1430,28 @@ func (o *OnboardingService) trackOnboardingCompletion(ctx context.Context, state
 		totalTime += timeSpent
 	}
 
-	// Store completion analytics - for now, just log since we don't have the table structure
-	// TODO: Implement proper analytics storage once tables are created
 	placementCompleted := state.PlacementTest != nil && state.PlacementTest.Status == PlacementCompleted
 	recommendedLevel := ""
 	if state.LearningPath != nil {
 		recommendedLevel = state.LearningPath.RecommendedLevel
 	}
 
+	analytics := &models.OnboardingAnalyticsModel{
+		UserID:                state.UserID,
+		CountryCode:           state.CountryCode,
+		CompletionTimeSeconds: totalTime,
+		Feedback:              state.Analytics.FeedbackComments,
+		PlacementCompleted:    placementCompleted,
+		RecommendedLevel:      recommendedLevel,
+	}
+	if satisfaction := state.Analytics.UserSatisfaction; satisfaction >= 1 && satisfaction <= 5 {
+		analytics.SatisfactionScore = &satisfaction
+	}
+
+	if err := o.repository.SaveCompletionAnalytics(ctx, analytics); err != nil {
+		return err
+	}
+
 	o.logger.WithContext(ctx).WithFields(map[string]interface{}{
 		"user_id":             state.UserID,
 		"total_time_seconds":  totalTime,
@@ -1,9 +1,13 @@
 package onboarding
 
 import (
+	"context"
 	"testing"
 
 	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/curriculum"
+	"scheduler-service/internal/logger"
 )
 
 func TestDeterminePriority(t *testing.T) {
@@ -200,3 +204,94 @@ func TestGenerateStudyPlan(t *testing.T) {
 	t.Logf("Generated study plan: %d daily minutes, %d active days, %v session types",
 		studyPlan.DailyMinutes, activeDays, studyPlan.SessionTypes)
 }
+
+func TestOnboardingStageIsValid(t *testing.T) {
+	for _, stage := range []OnboardingStage{StageWelcome, StagePreferences, StagePlacementTest, StageLearningPath, StageInitialPractice, StageCompleted} {
+		if !stage.IsValid() {
+			t.Errorf("expected stage %s to be valid", stage)
+		}
+	}
+	if OnboardingStage("checkout").IsValid() {
+		t.Error("expected unknown stage to be invalid")
+	}
+}
+
+func TestAdministerNextItem(t *testing.T) {
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	placementAlgorithm := algorithms.NewPlacementTestAlgorithm(algorithms.NewIRTAlgorithm(), log)
+	service := &OnboardingService{logger: log, placementAlgorithm: placementAlgorithm}
+
+	placementState, err := placementAlgorithm.InitializePlacementTest(context.Background(), "user-1", "session-1", "US")
+	if err != nil {
+		t.Fatalf("failed to initialize placement test: %v", err)
+	}
+
+	if _, err := service.administerNextItem(context.Background(), placementState); err == nil {
+		t.Error("expected an error without a placement item source")
+	}
+
+	items := []algorithms.PlacementItem{
+		{ItemID: "item-1", Topics: []string{"traffic_signs"}, Difficulty: 0.0, Discrimination: 1.2, Guessing: 0.25},
+		{ItemID: "item-2", Topics: []string{"road_rules"}, Difficulty: 0.5, Discrimination: 1.4, Guessing: 0.25},
+	}
+	service.SetPlacementItemSource(func(ctx context.Context, countryCode string) []algorithms.PlacementItem {
+		return items
+	})
+
+	seen := make(map[string]bool)
+	for i := 0; i < len(items); i++ {
+		item, err := service.administerNextItem(context.Background(), placementState)
+		if err != nil {
+			t.Fatalf("failed to administer item %d: %v", i, err)
+		}
+		if seen[item.ItemID] {
+			t.Errorf("item %s administered twice", item.ItemID)
+		}
+		seen[item.ItemID] = true
+	}
+
+	if len(placementState.ItemsAdministered) != len(items) {
+		t.Errorf("expected %d administered items, got %d", len(items), len(placementState.ItemsAdministered))
+	}
+	if _, err := service.administerNextItem(context.Background(), placementState); err == nil {
+		t.Error("expected an error once the item bank is exhausted")
+	}
+}
+
+func TestApplyPrerequisites(t *testing.T) {
+	registry, err := curriculum.NewRegistry(&config.CurriculumConfig{UnlockMastery: 0.6})
+	if err != nil {
+		t.Fatalf("failed to load prerequisite graphs: %v", err)
+	}
+	service := &OnboardingService{prerequisites: registry}
+
+	// Sorted by priority alone, highway merging would come before right of way
+	recommendations := []TopicRecommendation{
+		{Topic: "highway_merging", Priority: "high"},
+		{Topic: "right_of_way", Priority: "medium"},
+		{Topic: "road_rules", Priority: "low"},
+	}
+	mastery := map[string]float64{"traffic_signs": 0.9, "road_rules": 0.8, "right_of_way": 0.3}
+
+	ordered := service.applyPrerequisites("US", recommendations, mastery)
+
+	expected := []string{"road_rules", "right_of_way", "highway_merging"}
+	if len(ordered) != len(expected) {
+		t.Fatalf("expected %d recommendations, got %d", len(expected), len(ordered))
+	}
+	for i, topic := range expected {
+		if ordered[i].Topic != topic {
+			t.Errorf("position %d: expected %s, got %s", i, topic, ordered[i].Topic)
+		}
+	}
+
+	if ordered[0].Locked || ordered[1].Locked {
+		t.Error("expected road_rules and right_of_way to be unlocked")
+	}
+	if !ordered[2].Locked {
+		t.Error("expected highway_merging to be locked until right_of_way is mastered")
+	}
+	if len(ordered[2].Prerequisites) == 0 {
+		t.Error("expected highway_merging to list its prerequisites")
+	}
+}
@@ -0,0 +1,498 @@
+package onboarding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrOnboardingNotFound is returned when a user has no active onboarding state
+var ErrOnboardingNotFound = errors.New("onboarding state not found")
+
+// Repository persists onboarding state, stage transitions, learning paths and
+// milestone progress in the onboarding tables
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new onboarding repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// GetActiveState loads the user's active onboarding state
+func (r *Repository) GetActiveState(ctx context.Context, userID string) (*OnboardingState, error) {
+	var model models.UserOnboardingModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOnboardingNotFound
+		}
+		return nil, fmt.Errorf("failed to get onboarding state: %w", err)
+	}
+
+	var state OnboardingState
+	if err := json.Unmarshal(model.OnboardingData, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal onboarding state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// SaveState upserts the user's active onboarding state. A stage change is recorded
+// in onboarding_stage_transitions and the learning path, if any, is written with
+// its milestones, all in one transaction.
+func (r *Repository) SaveState(ctx context.Context, state *OnboardingState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal onboarding state: %w", err)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.saveState(tx, state, data)
+	})
+}
+
+// saveState writes the onboarding state and its learning path within tx
+func (r *Repository) saveState(tx *gorm.DB, state *OnboardingState, data []byte) error {
+	var current models.UserOnboardingModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND is_active = ?", state.UserID, true).
+		First(&current).Error
+
+	var fromStage *string
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		current = models.UserOnboardingModel{
+			UserID:         state.UserID,
+			CountryCode:    state.CountryCode,
+			Stage:          string(state.Stage),
+			OnboardingData: data,
+			IsActive:       true,
+		}
+		if err := tx.Create(&current).Error; err != nil {
+			return fmt.Errorf("failed to create onboarding state: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to lock onboarding state: %w", err)
+	default:
+		previous := current.Stage
+		fromStage = &previous
+
+		err := tx.Model(&current).Updates(map[string]interface{}{
+			"country_code":    state.CountryCode,
+			"stage":           string(state.Stage),
+			"onboarding_data": data,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update onboarding state: %w", err)
+		}
+	}
+
+	if fromStage == nil || *fromStage != string(state.Stage) {
+		if err := r.recordTransition(tx, state, fromStage); err != nil {
+			return err
+		}
+	}
+
+	if state.LearningPath != nil {
+		if err := r.saveLearningPath(tx, state.UserID, state.LearningPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveCompletionAnalytics records the analytics for a finished onboarding
+func (r *Repository) SaveCompletionAnalytics(ctx context.Context, analytics *models.OnboardingAnalyticsModel) error {
+	if err := r.db.WithContext(ctx).Create(analytics).Error; err != nil {
+		return fmt.Errorf("failed to save onboarding analytics: %w", err)
+	}
+	return nil
+}
+
+// SaveCompletedPlacement records a finished placement test session with its full
+// state and results, replacing any earlier record of the session
+func (r *Repository) SaveCompletedPlacement(ctx context.Context, state *algorithms.PlacementTestState, results *algorithms.PlacementResult) error {
+	placementData, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal placement state: %w", err)
+	}
+	resultData, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to marshal placement results: %w", err)
+	}
+	topicAbilities, err := json.Marshal(results.TopicAbilities)
+	if err != nil {
+		return fmt.Errorf("failed to marshal topic abilities: %w", err)
+	}
+	topicConfidence, err := json.Marshal(results.TopicConfidence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal topic confidence: %w", err)
+	}
+
+	completedAt := results.CompletedAt
+	session := models.PlacementTestSessionModel{
+		SessionID:         state.SessionID,
+		UserID:            state.UserID,
+		CountryCode:       state.CountryCode,
+		Status:            string(PlacementCompleted),
+		ItemsAdministered: results.ItemsAdministered,
+		OverallAbility:    results.OverallAbility,
+		OverallSE:         results.FinalSE,
+		TopicAbilities:    topicAbilities,
+		TopicConfidence:   topicConfidence,
+		PlacementData:     placementData,
+		Results:           resultData,
+		StartedAt:         state.StartTime,
+		CompletedAt:       &completedAt,
+	}
+
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "session_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"status", "items_administered", "overall_ability", "overall_se", "topic_abilities",
+			"topic_confidence", "placement_data", "results", "completed_at", "updated_at",
+		}),
+	}).Create(&session).Error
+	if err != nil {
+		return fmt.Errorf("failed to save placement session: %w", err)
+	}
+	return nil
+}
+
+// GetStageTransitions returns the user's stage transitions in order
+func (r *Repository) GetStageTransitions(ctx context.Context, userID string) ([]models.OnboardingStageTransitionModel, error) {
+	var transitions []models.OnboardingStageTransitionModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("transition_time ASC").
+		Find(&transitions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get onboarding stage transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// GetMilestoneProgress returns progress for the milestones of the user's active learning path
+func (r *Repository) GetMilestoneProgress(ctx context.Context, userID string) ([]models.MilestoneProgressModel, error) {
+	var progress []models.MilestoneProgressModel
+	err := r.db.WithContext(ctx).
+		Joins("JOIN learning_paths ON learning_paths.id = milestone_progress.learning_path_id").
+		Where("milestone_progress.user_id = ? AND learning_paths.is_active = ?", userID, true).
+		Order("milestone_progress.estimated_date ASC").
+		Find(&progress).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestone progress: %w", err)
+	}
+	return progress, nil
+}
+
+// RecordStudyActivity adds one attempt and its study time to the user's activity for the day of at (UTC)
+func (r *Repository) RecordStudyActivity(ctx context.Context, userID string, at time.Time, studySeconds int, correct bool) error {
+	activity := models.StudyActivityModel{
+		UserID:       userID,
+		ActivityDate: at.UTC().Truncate(24 * time.Hour),
+		StudySeconds: studySeconds,
+		Attempts:     1,
+	}
+	if correct {
+		activity.CorrectAttempts = 1
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "activity_date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"study_seconds":    gorm.Expr("study_activity.study_seconds + EXCLUDED.study_seconds"),
+			"attempts":         gorm.Expr("study_activity.attempts + EXCLUDED.attempts"),
+			"correct_attempts": gorm.Expr("study_activity.correct_attempts + EXCLUDED.correct_attempts"),
+			"updated_at":       gorm.Expr("NOW()"),
+		}),
+	}).Create(&activity).Error
+	if err != nil {
+		return fmt.Errorf("failed to record study activity: %w", err)
+	}
+	return nil
+}
+
+// GetStudyActivitySummary totals the user's study activity on or after since
+func (r *Repository) GetStudyActivitySummary(ctx context.Context, userID string, since time.Time) (StudyActivitySummary, error) {
+	var summary StudyActivitySummary
+	err := r.db.WithContext(ctx).
+		Model(&models.StudyActivityModel{}).
+		Select("COUNT(*) AS active_days, COALESCE(SUM(study_seconds), 0) AS study_seconds, "+
+			"COALESCE(SUM(attempts), 0) AS attempts, COALESCE(SUM(correct_attempts), 0) AS correct_attempts").
+		Where("user_id = ? AND activity_date >= ?", userID, since.UTC().Truncate(24*time.Hour)).
+		Scan(&summary).Error
+	if err != nil {
+		return StudyActivitySummary{}, fmt.Errorf("failed to get study activity: %w", err)
+	}
+	return summary, nil
+}
+
+// SaveStudyPlanRevision saves the onboarding state carrying a re-planned learning path
+// and appends the revision to the plan history, in one transaction. The revision
+// number is assigned here and set on both the revision and the learning path.
+func (r *Repository) SaveStudyPlanRevision(ctx context.Context, state *OnboardingState, revision *StudyPlanRevision) error {
+	studyPlan, err := json.Marshal(revision.StudyPlan)
+	if err != nil {
+		return fmt.Errorf("failed to marshal study plan: %w", err)
+	}
+	milestones, err := json.Marshal(revision.Milestones)
+	if err != nil {
+		return fmt.Errorf("failed to marshal milestones: %w", err)
+	}
+	reasons, err := json.Marshal(revision.Reasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan change reasons: %w", err)
+	}
+	inputs, err := json.Marshal(revision.Inputs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan inputs: %w", err)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialize revisions of the same path on its row
+		var path models.LearningPathModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("path_id = ?", revision.PathID).
+			First(&path).Error
+		if err != nil {
+			return fmt.Errorf("failed to lock learning path: %w", err)
+		}
+
+		var latest int
+		err = tx.Model(&models.StudyPlanHistoryModel{}).
+			Select("COALESCE(MAX(revision), 0)").
+			Where("path_id = ?", revision.PathID).
+			Scan(&latest).Error
+		if err != nil {
+			return fmt.Errorf("failed to get latest study plan revision: %w", err)
+		}
+		revision.Revision = latest + 1
+		state.LearningPath.PlanRevision = revision.Revision
+
+		data, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal onboarding state: %w", err)
+		}
+		if err := r.saveState(tx, state, data); err != nil {
+			return err
+		}
+
+		history := models.StudyPlanHistoryModel{
+			UserID:     state.UserID,
+			PathID:     revision.PathID,
+			Revision:   revision.Revision,
+			StudyPlan:  studyPlan,
+			Milestones: milestones,
+			Reasons:    reasons,
+			Inputs:     inputs,
+			CreatedAt:  revision.CreatedAt,
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return fmt.Errorf("failed to record study plan revision: %w", err)
+		}
+
+		return markPlanEvaluated(tx, revision.PathID, revision.CreatedAt)
+	})
+}
+
+// MarkPlanEvaluated records that a learning path's study plan was re-evaluated at
+func (r *Repository) MarkPlanEvaluated(ctx context.Context, pathID string, at time.Time) error {
+	return markPlanEvaluated(r.db.WithContext(ctx), pathID, at)
+}
+
+func markPlanEvaluated(tx *gorm.DB, pathID string, at time.Time) error {
+	err := tx.Model(&models.LearningPathModel{}).
+		Where("path_id = ?", pathID).
+		Update("plan_evaluated_at", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark study plan evaluated: %w", err)
+	}
+	return nil
+}
+
+// GetUsersDueForReplan returns users whose active learning path has not been
+// re-evaluated, or created, since before
+func (r *Repository) GetUsersDueForReplan(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).
+		Model(&models.LearningPathModel{}).
+		Where("is_active = ? AND COALESCE(plan_evaluated_at, created_at) < ?", true, before).
+		Order("COALESCE(plan_evaluated_at, created_at) ASC").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users due for re-planning: %w", err)
+	}
+	return userIDs, nil
+}
+
+// GetStudyPlanHistory returns up to limit revisions of the user's study plans, newest first
+func (r *Repository) GetStudyPlanHistory(ctx context.Context, userID string, limit int) ([]StudyPlanRevision, error) {
+	var rows []models.StudyPlanHistoryModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, revision DESC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get study plan history: %w", err)
+	}
+
+	revisions := make([]StudyPlanRevision, 0, len(rows))
+	for _, row := range rows {
+		revision := StudyPlanRevision{
+			PathID:    row.PathID,
+			Revision:  row.Revision,
+			CreatedAt: row.CreatedAt,
+		}
+		if err := json.Unmarshal(row.StudyPlan, &revision.StudyPlan); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal study plan revision %d: %w", row.Revision, err)
+		}
+		if err := json.Unmarshal(row.Milestones, &revision.Milestones); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal milestones of revision %d: %w", row.Revision, err)
+		}
+		if err := json.Unmarshal(row.Reasons, &revision.Reasons); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal reasons of revision %d: %w", row.Revision, err)
+		}
+		if err := json.Unmarshal(row.Inputs, &revision.Inputs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inputs of revision %d: %w", row.Revision, err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// recordTransition stores a move into the state's current stage. Time spent and
+// interactions are those accumulated in the stage being left.
+func (r *Repository) recordTransition(tx *gorm.DB, state *OnboardingState, fromStage *string) error {
+	transition := models.OnboardingStageTransitionModel{
+		UserID:         state.UserID,
+		FromStage:      fromStage,
+		ToStage:        string(state.Stage),
+		TransitionTime: time.Now(),
+	}
+	if fromStage != nil {
+		transition.TimeSpentSeconds = state.Analytics.TimeSpent[OnboardingStage(*fromStage)]
+		transition.InteractionCount = state.Analytics.InteractionCount[OnboardingStage(*fromStage)]
+	}
+
+	if err := tx.Create(&transition).Error; err != nil {
+		return fmt.Errorf("failed to record onboarding stage transition: %w", err)
+	}
+	return nil
+}
+
+// saveLearningPath upserts the learning path, deactivates the user's other paths and
+// upserts its milestones. Milestone accuracy tracked elsewhere is left untouched.
+func (r *Repository) saveLearningPath(tx *gorm.DB, userID string, path *LearningPath) error {
+	focusTopics, err := json.Marshal(path.FocusTopics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal focus topics: %w", err)
+	}
+	studyPlan, err := json.Marshal(path.StudyPlan)
+	if err != nil {
+		return fmt.Errorf("failed to marshal study plan: %w", err)
+	}
+	milestones, err := json.Marshal(path.Milestones)
+	if err != nil {
+		return fmt.Errorf("failed to marshal milestones: %w", err)
+	}
+
+	model := models.LearningPathModel{
+		PathID:                path.PathID,
+		UserID:                userID,
+		RecommendedLevel:      path.RecommendedLevel,
+		FocusTopics:           focusTopics,
+		StudyPlan:             studyPlan,
+		Milestones:            milestones,
+		EstimatedDurationDays: path.EstimatedDuration,
+		IsActive:              true,
+	}
+	err = tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "path_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"recommended_level", "focus_topics", "study_plan", "milestones",
+			"estimated_duration_days", "is_active", "updated_at",
+		}),
+	}).Create(&model).Error
+	if err != nil {
+		return fmt.Errorf("failed to save learning path: %w", err)
+	}
+
+	// The upsert does not return the id of an existing row
+	if err := tx.Where("path_id = ?", path.PathID).First(&model).Error; err != nil {
+		return fmt.Errorf("failed to load learning path: %w", err)
+	}
+
+	// The plan generated during onboarding is the first revision of the plan history
+	initial := models.StudyPlanHistoryModel{
+		UserID:     userID,
+		PathID:     path.PathID,
+		Revision:   1,
+		StudyPlan:  studyPlan,
+		Milestones: milestones,
+		Reasons:    []byte(`["` + ReasonInitialPlan + `"]`),
+		Inputs:     []byte(`{}`),
+	}
+	err = tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "path_id"}, {Name: "revision"}},
+		DoNothing: true,
+	}).Create(&initial).Error
+	if err != nil {
+		return fmt.Errorf("failed to record initial study plan: %w", err)
+	}
+
+	err = tx.Model(&models.LearningPathModel{}).
+		Where("user_id = ? AND path_id <> ? AND is_active = ?", userID, path.PathID, true).
+		Update("is_active", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to deactivate previous learning paths: %w", err)
+	}
+
+	for _, milestone := range path.Milestones {
+		requiredTopics, err := json.Marshal(milestone.RequiredTopics)
+		if err != nil {
+			return fmt.Errorf("failed to marshal milestone topics: %w", err)
+		}
+
+		progress := models.MilestoneProgressModel{
+			UserID:         userID,
+			MilestoneID:    milestone.ID,
+			LearningPathID: model.ID,
+			Title:          milestone.Title,
+			TargetAccuracy: milestone.TargetAccuracy,
+			RequiredTopics: requiredTopics,
+			IsCompleted:    milestone.IsCompleted,
+			CompletedAt:    milestone.CompletedAt,
+			EstimatedDate:  milestone.EstimatedDate,
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "milestone_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"learning_path_id", "title", "target_accuracy", "required_topics",
+				"is_completed", "completed_at", "estimated_date", "updated_at",
+			}),
+		}).Create(&progress).Error
+		if err != nil {
+			return fmt.Errorf("failed to save milestone %s: %w", milestone.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+)
+
+// newTestRepository connects to SCHEDULER_TEST_DATABASE_URL and applies migrations.
+// Tests using it are skipped when the variable is not set.
+func newTestRepository(t *testing.T) (*Repository, *database.DB) {
+	t.Helper()
+
+	databaseURL := os.Getenv("SCHEDULER_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("SCHEDULER_TEST_DATABASE_URL not set")
+	}
+
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	db, err := database.New(&config.DatabaseConfig{URL: databaseURL, MaxOpenConns: 5, MaxIdleConns: 1, ConnMaxLifetime: time.Minute}, &metrics.Metrics{}, log)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return NewRepository(db), db
+}
+
+func TestRepository_SaveAndResumeOnboarding(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+
+	userID := fmt.Sprintf("00000000-0000-4000-a000-%012d", time.Now().UnixNano()%1e12)
+	t.Cleanup(func() {
+		for _, table := range []string{"study_plan_history", "milestone_progress", "learning_paths", "onboarding_stage_transitions", "user_onboarding"} {
+			db.Exec("DELETE FROM "+table+" WHERE user_id = ?", userID)
+		}
+	})
+
+	if _, err := repo.GetActiveState(ctx, userID); !errors.Is(err, ErrOnboardingNotFound) {
+		t.Fatalf("expected ErrOnboardingNotFound for a new user, got %v", err)
+	}
+
+	state := &OnboardingState{
+		UserID:      userID,
+		CountryCode: "US",
+		Stage:       StageWelcome,
+		Analytics: OnboardingAnalytics{
+			TimeSpent:        map[OnboardingStage]int{StageWelcome: 40},
+			InteractionCount: map[OnboardingStage]int{StageWelcome: 3},
+		},
+	}
+	if err := repo.SaveState(ctx, state); err != nil {
+		t.Fatalf("failed to save initial state: %v", err)
+	}
+
+	// Saving again without a stage change must not add a transition
+	if err := repo.SaveState(ctx, state); err != nil {
+		t.Fatalf("failed to re-save state: %v", err)
+	}
+
+	state.Stage = StageLearningPath
+	state.LearningPath = &LearningPath{
+		PathID:           "path_" + userID,
+		RecommendedLevel: "beginner",
+		Milestones: []LearningMilestone{
+			{ID: "foundation", Title: "Foundation", TargetAccuracy: 0.7, RequiredTopics: []string{"traffic_signs"}, EstimatedDate: time.Now().AddDate(0, 0, 7)},
+			{ID: "intermediate", Title: "Intermediate", TargetAccuracy: 0.8, RequiredTopics: []string{"road_rules"}, EstimatedDate: time.Now().AddDate(0, 0, 14)},
+		},
+		EstimatedDuration: 30,
+	}
+	if err := repo.SaveState(ctx, state); err != nil {
+		t.Fatalf("failed to save learning path state: %v", err)
+	}
+
+	resumed, err := repo.GetActiveState(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to resume onboarding: %v", err)
+	}
+	if resumed.Stage != StageLearningPath {
+		t.Errorf("expected resumed stage %s, got %s", StageLearningPath, resumed.Stage)
+	}
+	if resumed.LearningPath == nil || resumed.LearningPath.PathID != state.LearningPath.PathID {
+		t.Errorf("expected resumed learning path %s", state.LearningPath.PathID)
+	}
+
+	transitions, err := repo.GetStageTransitions(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get transitions: %v", err)
+	}
+	if len(transitions) != 2 {
+		t.Fatalf("expected 2 transitions, got %d", len(transitions))
+	}
+	if transitions[1].FromStage == nil || *transitions[1].FromStage != string(StageWelcome) {
+		t.Errorf("expected second transition to leave %s", StageWelcome)
+	}
+	if transitions[1].TimeSpentSeconds != 40 || transitions[1].InteractionCount != 3 {
+		t.Errorf("expected time spent 40 and 3 interactions, got %d and %d",
+			transitions[1].TimeSpentSeconds, transitions[1].InteractionCount)
+	}
+
+	progress, err := repo.GetMilestoneProgress(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get milestone progress: %v", err)
+	}
+	if len(progress) != 2 {
+		t.Errorf("expected 2 milestones, got %d", len(progress))
+	}
+}
@@ -0,0 +1,470 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"scheduler-service/internal/state"
+)
+
+// Study plan re-planning bounds
+const (
+	minDailyMinutes = 10
+	maxDailyMinutes = 180
+
+	// defaultAttemptsPerMinute is assumed until the learner has timed attempts
+	defaultAttemptsPerMinute = 1.5
+	// defaultMasteryVelocity is the mastery gain per attempt assumed for topics without history
+	defaultMasteryVelocity = 0.02
+	// masteryTarget is the mastery a topic is planned to reach before a milestone is due
+	masteryTarget = 0.85
+
+	// activityWindowDays is how far back study time is observed when re-planning
+	activityWindowDays = 14
+	// maxAttemptSeconds caps the study time credited to one attempt, so an idle
+	// screen does not count as studying
+	maxAttemptSeconds = 600
+)
+
+// StudyPlanChangedChannel is the Redis pub/sub channel study plan changes are published on
+const StudyPlanChangedChannel = "scheduler:events:study_plan_changed"
+
+// StudyPlanChangedEvent is published when re-planning changes a learner's study plan
+type StudyPlanChangedEvent struct {
+	Type       string              `json:"type"` // always "study_plan_changed"
+	UserID     string              `json:"user_id"`
+	PathID     string              `json:"path_id"`
+	Revision   int                 `json:"revision"`
+	Previous   StudyPlan           `json:"previous_plan"`
+	Current    StudyPlan           `json:"current_plan"`
+	Milestones []LearningMilestone `json:"milestones"`
+	Reasons    []string            `json:"reasons"`
+	OccurredAt time.Time           `json:"occurred_at"`
+}
+
+// Reasons recorded when a study plan revision differs from the previous one
+const (
+	ReasonInitialPlan           = "initial_plan"
+	ReasonStudyTimeBelowPlan    = "study_time_below_plan"
+	ReasonStudyTimeAbovePlan    = "study_time_above_plan"
+	ReasonExamPressure          = "exam_pressure"
+	ReasonExamApproaching       = "exam_approaching"
+	ReasonMilestoneCompleted    = "milestone_completed"
+	ReasonMilestonesRescheduled = "milestones_rescheduled"
+)
+
+// StudyActivitySummary aggregates a learner's recorded study over an observation window
+type StudyActivitySummary struct {
+	WindowDays      int `json:"window_days"`
+	ActiveDays      int `json:"active_days"`
+	StudySeconds    int `json:"study_seconds"`
+	Attempts        int `json:"attempts"`
+	CorrectAttempts int `json:"correct_attempts"`
+}
+
+// PlanInputs are the observations a study plan revision was computed from
+type PlanInputs struct {
+	Activity          StudyActivitySummary `json:"activity"`
+	ObservedMinutes   float64              `json:"observed_daily_minutes"` // per scheduled study day
+	AttemptsPerMinute float64              `json:"attempts_per_minute"`
+	MasteryVelocity   float64              `json:"mastery_velocity"` // mean mastery gain per attempt
+	AverageMastery    float64              `json:"average_mastery"`
+	RequiredMinutes   float64              `json:"required_daily_minutes,omitempty"` // to master every topic by the exam
+	ExamDate          *time.Time           `json:"exam_date,omitempty"`
+}
+
+// StudyPlanRevision is one version of a learning path's study plan
+type StudyPlanRevision struct {
+	PathID     string              `json:"path_id"`
+	Revision   int                 `json:"revision"`
+	StudyPlan  StudyPlan           `json:"study_plan"`
+	Milestones []LearningMilestone `json:"milestones"`
+	Reasons    []string            `json:"reasons"`
+	Inputs     PlanInputs          `json:"inputs"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+// RecordStudyActivity adds an attempt to the learner's daily study activity
+func (o *OnboardingService) RecordStudyActivity(ctx context.Context, userID string, timeTaken time.Duration, correct bool) error {
+	seconds := int(timeTaken / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+	if seconds > maxAttemptSeconds {
+		seconds = maxAttemptSeconds
+	}
+
+	return o.repository.RecordStudyActivity(ctx, userID, time.Now(), seconds, correct)
+}
+
+// ReplanStudyPlan re-computes the user's study plan and milestone dates from their
+// recent study time, learning velocity and exam date. When the plan changes, the new
+// revision is saved to the plan history and a StudyPlanChangedEvent is published.
+// Returns nil when the plan did not change.
+func (o *OnboardingService) ReplanStudyPlan(ctx context.Context, userID string, examDate *time.Time) (*StudyPlanRevision, error) {
+	state, err := o.getOnboardingState(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get onboarding state: %w", err)
+	}
+	path := state.LearningPath
+	if path == nil {
+		return nil, ErrNoLearningPath
+	}
+
+	now := time.Now()
+
+	// Observe at most the window, and no further back than the path itself
+	windowDays := activityWindowDays
+	if pathDays := int(math.Ceil(now.Sub(path.CreatedAt).Hours() / 24)); pathDays > 0 && pathDays < windowDays {
+		windowDays = pathDays
+	}
+	activity, err := o.repository.GetStudyActivitySummary(ctx, userID, now.AddDate(0, 0, -windowDays))
+	if err != nil {
+		return nil, err
+	}
+	activity.WindowDays = windowDays
+
+	progress, err := o.bktManager.GetLearningProgress(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get learning progress: %w", err)
+	}
+
+	plan, milestones, inputs, reasons := o.replan(path, state.Preferences, activity, progress, examDate, now)
+	if len(reasons) == 0 {
+		if err := o.repository.MarkPlanEvaluated(ctx, path.PathID, now); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	previous := path.StudyPlan
+	path.StudyPlan = plan
+	path.Milestones = milestones
+	state.UpdatedAt = now
+
+	revision := &StudyPlanRevision{
+		PathID:     path.PathID,
+		StudyPlan:  plan,
+		Milestones: milestones,
+		Reasons:    reasons,
+		Inputs:     inputs,
+		CreatedAt:  now,
+	}
+	if err := o.repository.SaveStudyPlanRevision(ctx, state, revision); err != nil {
+		return nil, err
+	}
+
+	cacheKey := fmt.Sprintf("onboarding:%s", userID)
+	if err := o.cache.Set(ctx, cacheKey, state, 30*time.Minute); err != nil {
+		o.logger.WithContext(ctx).WithError(err).Warn("Failed to cache onboarding state")
+	}
+
+	event := StudyPlanChangedEvent{
+		Type:       "study_plan_changed",
+		UserID:     userID,
+		PathID:     path.PathID,
+		Revision:   revision.Revision,
+		Previous:   previous,
+		Current:    plan,
+		Milestones: milestones,
+		Reasons:    reasons,
+		OccurredAt: now,
+	}
+	if err := o.cache.Publish(ctx, StudyPlanChangedChannel, event); err != nil {
+		o.logger.WithContext(ctx).WithError(err).Warn("Failed to publish study plan change")
+	}
+
+	o.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":       userID,
+		"path_id":       path.PathID,
+		"revision":      revision.Revision,
+		"daily_minutes": plan.DailyMinutes,
+		"reasons":       reasons,
+	}).Info("Study plan re-planned")
+
+	return revision, nil
+}
+
+// GetStudyPlanHistory returns up to limit revisions of the user's study plans, newest first
+func (o *OnboardingService) GetStudyPlanHistory(ctx context.Context, userID string, limit int) ([]StudyPlanRevision, error) {
+	return o.repository.GetStudyPlanHistory(ctx, userID, limit)
+}
+
+// GetUsersDueForReplan returns users whose study plan has not been re-evaluated within replanAfter
+func (o *OnboardingService) GetUsersDueForReplan(ctx context.Context, replanAfter time.Duration, limit int) ([]string, error) {
+	return o.repository.GetUsersDueForReplan(ctx, time.Now().Add(-replanAfter), limit)
+}
+
+// replan recomputes the study plan and milestone dates of a learning path from observed
+// study time, mastery velocity and the exam date. It returns the new plan, milestones and
+// the reasons they differ from the current ones; no reasons means nothing changed.
+func (o *OnboardingService) replan(
+	path *LearningPath,
+	preferences UserPreferences,
+	activity StudyActivitySummary,
+	progress map[string]state.TopicProgress,
+	examDate *time.Time,
+	now time.Time,
+) (StudyPlan, []LearningMilestone, PlanInputs, []string) {
+	inputs := PlanInputs{Activity: activity, ExamDate: examDate}
+	var reasons []string
+
+	studyDaysPerWeek := scheduledDaysPerWeek(preferences)
+
+	// Observed minutes per scheduled study day over the window
+	if activity.WindowDays > 0 {
+		scheduledDays := float64(activity.WindowDays) * float64(studyDaysPerWeek) / 7
+		inputs.ObservedMinutes = float64(activity.StudySeconds) / 60 / math.Max(1, scheduledDays)
+	}
+	inputs.AttemptsPerMinute = defaultAttemptsPerMinute
+	if activity.StudySeconds >= 60 && activity.Attempts > 0 {
+		inputs.AttemptsPerMinute = float64(activity.Attempts) / (float64(activity.StudySeconds) / 60)
+	}
+	inputs.MasteryVelocity, inputs.AverageMastery = summarizeProgress(progress)
+
+	current := path.StudyPlan
+	dailyMinutes := float64(current.DailyMinutes)
+	if dailyMinutes <= 0 {
+		dailyMinutes = float64(preferences.AvailableTime)
+	}
+
+	// Move halfway towards the observed study time, so one unusual week does not
+	// swing the plan. Without any activity there is nothing to adapt to.
+	if activity.Attempts > 0 {
+		switch {
+		case inputs.ObservedMinutes < 0.7*dailyMinutes:
+			dailyMinutes = (dailyMinutes + inputs.ObservedMinutes) / 2
+			reasons = append(reasons, ReasonStudyTimeBelowPlan)
+		case inputs.ObservedMinutes > 1.3*dailyMinutes:
+			dailyMinutes = (dailyMinutes + inputs.ObservedMinutes) / 2
+			reasons = append(reasons, ReasonStudyTimeAbovePlan)
+		}
+	}
+
+	// With an exam booked, never plan less than what mastering every topic in time takes
+	daysUntilExam := -1
+	if examDate != nil && examDate.After(now) {
+		daysUntilExam = int(math.Ceil(examDate.Sub(now).Hours() / 24))
+		studyDaysLeft := math.Max(1, float64(daysUntilExam)*float64(studyDaysPerWeek)/7)
+		attemptsNeeded := attemptsToMaster(path.topics(), progress, inputs.MasteryVelocity)
+		inputs.RequiredMinutes = attemptsNeeded / inputs.AttemptsPerMinute / studyDaysLeft
+		if inputs.RequiredMinutes > dailyMinutes {
+			dailyMinutes = inputs.RequiredMinutes
+			reasons = append(reasons, ReasonExamPressure)
+		}
+	}
+
+	plan := o.generateStudyPlan(UserPreferences{
+		StudyGoal:      preferences.StudyGoal,
+		AvailableTime:  roundDailyMinutes(dailyMinutes),
+		WeeklySchedule: preferences.WeeklySchedule,
+	})
+	if plan.DailyMinutes == current.DailyMinutes {
+		// Rounding absorbed the adjustment
+		reasons = removeReasons(reasons, ReasonStudyTimeBelowPlan, ReasonStudyTimeAbovePlan, ReasonExamPressure)
+	}
+
+	// Shift towards mock tests and frequent reviews as the exam approaches
+	switch {
+	case daysUntilExam >= 0 && daysUntilExam <= 14:
+		plan.SessionTypes = []string{"mock_test", "review", "practice"}
+		plan.ReviewFrequency = 1
+		plan.TestFrequency = 2
+	case daysUntilExam >= 0 && daysUntilExam <= 42:
+		plan.SessionTypes = []string{"practice", "review", "mock_test"}
+		plan.ReviewFrequency = 2
+		plan.TestFrequency = 5
+	}
+	if daysUntilExam >= 0 && (!reflect.DeepEqual(plan.SessionTypes, current.SessionTypes) ||
+		plan.ReviewFrequency != current.ReviewFrequency || plan.TestFrequency != current.TestFrequency) {
+		reasons = append(reasons, ReasonExamApproaching)
+	}
+
+	if len(reasons) == 0 {
+		// Nothing observed moved the plan, so keep it as the learner knows it
+		plan = current
+	}
+
+	milestones, milestoneReasons := rescheduleMilestones(path.Milestones, progress, inputs, plan, studyDaysPerWeek, now)
+	reasons = append(reasons, milestoneReasons...)
+
+	return plan, milestones, inputs, reasons
+}
+
+// rescheduleMilestones marks milestones whose topics are all mastered as completed and
+// re-estimates the dates of the rest from the planned attempts per study day
+func rescheduleMilestones(
+	milestones []LearningMilestone,
+	progress map[string]state.TopicProgress,
+	inputs PlanInputs,
+	plan StudyPlan,
+	studyDaysPerWeek int,
+	now time.Time,
+) ([]LearningMilestone, []string) {
+	updated := make([]LearningMilestone, len(milestones))
+	copy(updated, milestones)
+
+	attemptsPerStudyDay := float64(plan.DailyMinutes) * inputs.AttemptsPerMinute
+	hasHistory := inputs.Activity.Attempts > 0
+
+	var reasons []string
+	completed, rescheduled := false, false
+	earliest := now
+
+	for i := range updated {
+		milestone := &updated[i]
+		if milestone.IsCompleted {
+			continue
+		}
+
+		if len(milestone.RequiredTopics) > 0 && allMastered(milestone.RequiredTopics, progress) {
+			completedAt := now
+			milestone.IsCompleted = true
+			milestone.CompletedAt = &completedAt
+			completed = true
+			continue
+		}
+
+		estimated := milestone.EstimatedDate
+		if hasHistory && attemptsPerStudyDay > 0 {
+			studyDays := attemptsToMaster(milestone.RequiredTopics, progress, inputs.MasteryVelocity) / attemptsPerStudyDay
+			calendarDays := int(math.Ceil(studyDays * 7 / float64(studyDaysPerWeek)))
+			estimated = now.AddDate(0, 0, calendarDays)
+		} else if estimated.Before(now) {
+			// Overdue without data to estimate from: give it another week
+			estimated = now.AddDate(0, 0, 7)
+		}
+
+		// Later milestones build on earlier ones, so keep dates in order
+		if !estimated.After(earliest) {
+			estimated = earliest.AddDate(0, 0, 1)
+		}
+
+		// Ignore drift of less than two days so dates do not wobble week to week
+		if math.Abs(estimated.Sub(milestone.EstimatedDate).Hours()) >= 48 || !milestone.EstimatedDate.After(earliest) {
+			milestone.EstimatedDate = estimated
+			rescheduled = true
+		}
+		earliest = milestone.EstimatedDate
+	}
+
+	if completed {
+		reasons = append(reasons, ReasonMilestoneCompleted)
+	}
+	if rescheduled {
+		reasons = append(reasons, ReasonMilestonesRescheduled)
+	}
+	return updated, reasons
+}
+
+// attemptsToMaster estimates the attempts needed to bring every topic to the mastery target.
+// Topics without a measured velocity use fallbackVelocity, or the default when that is zero.
+func attemptsToMaster(topics []string, progress map[string]state.TopicProgress, fallbackVelocity float64) float64 {
+	if fallbackVelocity <= 0 {
+		fallbackVelocity = defaultMasteryVelocity
+	}
+
+	attempts := 0.0
+	for _, topic := range topics {
+		p := progress[topic]
+		if p.Mastered || p.Mastery >= masteryTarget {
+			continue
+		}
+		velocity := p.Velocity
+		if velocity <= 0 {
+			velocity = fallbackVelocity
+		}
+		attempts += (masteryTarget - p.Mastery) / velocity
+	}
+	return attempts
+}
+
+// summarizeProgress returns the mean learning velocity over topics with a measured
+// velocity and the mean mastery over all topics
+func summarizeProgress(progress map[string]state.TopicProgress) (velocity, mastery float64) {
+	measured := 0
+	for _, p := range progress {
+		mastery += p.Mastery
+		if p.Velocity > 0 {
+			velocity += p.Velocity
+			measured++
+		}
+	}
+	if measured > 0 {
+		velocity /= float64(measured)
+	}
+	if len(progress) > 0 {
+		mastery /= float64(len(progress))
+	}
+	return velocity, mastery
+}
+
+// topics returns the distinct topics the learning path covers, in name order
+func (p *LearningPath) topics() []string {
+	seen := make(map[string]bool)
+	for _, recommendation := range p.FocusTopics {
+		seen[recommendation.Topic] = true
+	}
+	for _, milestone := range p.Milestones {
+		for _, topic := range milestone.RequiredTopics {
+			seen[topic] = true
+		}
+	}
+
+	topics := make([]string, 0, len(seen))
+	for topic := range seen {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// scheduledDaysPerWeek counts the days the learner studies on, assuming every day when unset
+func scheduledDaysPerWeek(preferences UserPreferences) int {
+	days := 0
+	for _, active := range preferences.WeeklySchedule {
+		if active {
+			days++
+		}
+	}
+	if days == 0 {
+		return 7
+	}
+	return days
+}
+
+// roundDailyMinutes rounds to the nearest 5 minutes within the planning bounds
+func roundDailyMinutes(minutes float64) int {
+	rounded := int(math.Round(minutes/5) * 5)
+	if rounded < minDailyMinutes {
+		return minDailyMinutes
+	}
+	if rounded > maxDailyMinutes {
+		return maxDailyMinutes
+	}
+	return rounded
+}
+
+func allMastered(topics []string, progress map[string]state.TopicProgress) bool {
+	for _, topic := range topics {
+		if !progress[topic].Mastered {
+			return false
+		}
+	}
+	return true
+}
+
+func removeReasons(reasons []string, remove ...string) []string {
+	var kept []string
+	for _, reason := range reasons {
+		if !slices.Contains(remove, reason) {
+			kept = append(kept, reason)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,177 @@
+package onboarding
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/state"
+)
+
+func testLearningPath(now time.Time) *LearningPath {
+	service := &OnboardingService{}
+	preferences := UserPreferences{StudyGoal: "improve_skills", AvailableTime: 30}
+
+	return &LearningPath{
+		PathID:    "path_test",
+		StudyPlan: service.generateStudyPlan(preferences),
+		Milestones: []LearningMilestone{
+			{ID: "foundation", RequiredTopics: []string{"traffic_signs"}, EstimatedDate: now.AddDate(0, 0, 14)},
+			{ID: "intermediate", RequiredTopics: []string{"traffic_signs", "road_rules"}, EstimatedDate: now.AddDate(0, 0, 28)},
+		},
+		CreatedAt: now.AddDate(0, 0, -14),
+	}
+}
+
+func TestReplan_NoActivityKeepsPlan(t *testing.T) {
+	service := &OnboardingService{}
+	now := time.Now()
+	path := testLearningPath(now)
+	preferences := UserPreferences{StudyGoal: "improve_skills", AvailableTime: 30}
+
+	plan, milestones, _, reasons := service.replan(path, preferences, StudyActivitySummary{WindowDays: 14}, nil, nil, now)
+
+	if len(reasons) != 0 {
+		t.Errorf("Expected no changes without activity, got reasons %v", reasons)
+	}
+	if plan.DailyMinutes != path.StudyPlan.DailyMinutes {
+		t.Errorf("Expected daily minutes to stay %d, got %d", path.StudyPlan.DailyMinutes, plan.DailyMinutes)
+	}
+	for i, milestone := range milestones {
+		if !milestone.EstimatedDate.Equal(path.Milestones[i].EstimatedDate) {
+			t.Errorf("Expected milestone %s to keep its date", milestone.ID)
+		}
+	}
+}
+
+func TestReplan_AdaptsToObservedStudyTime(t *testing.T) {
+	service := &OnboardingService{}
+	now := time.Now()
+	preferences := UserPreferences{StudyGoal: "improve_skills", AvailableTime: 30}
+
+	tests := []struct {
+		name         string
+		studyMinutes int // over 14 days, studying every day
+		minMinutes   int
+		maxMinutes   int
+		reason       string
+	}{
+		{"studying far less than planned", 14 * 10, 15, 25, ReasonStudyTimeBelowPlan},
+		{"studying far more than planned", 14 * 60, 40, 50, ReasonStudyTimeAbovePlan},
+		{"studying about as planned", 14 * 30, 30, 30, ""},
+	}
+
+	for _, test := range tests {
+		path := testLearningPath(now)
+		activity := StudyActivitySummary{WindowDays: 14, ActiveDays: 14, StudySeconds: test.studyMinutes * 60, Attempts: test.studyMinutes}
+
+		plan, _, inputs, reasons := service.replan(path, preferences, activity, nil, nil, now)
+
+		if plan.DailyMinutes < test.minMinutes || plan.DailyMinutes > test.maxMinutes {
+			t.Errorf("%s: expected daily minutes between %d and %d, got %d", test.name, test.minMinutes, test.maxMinutes, plan.DailyMinutes)
+		}
+		if plan.DailyMinutes%5 != 0 {
+			t.Errorf("%s: expected daily minutes rounded to 5, got %d", test.name, plan.DailyMinutes)
+		}
+		if test.reason != "" && !slices.Contains(reasons, test.reason) {
+			t.Errorf("%s: expected reason %s, got %v", test.name, test.reason, reasons)
+		}
+		if test.reason == "" && (slices.Contains(reasons, ReasonStudyTimeBelowPlan) || slices.Contains(reasons, ReasonStudyTimeAbovePlan)) {
+			t.Errorf("%s: expected no study time change, got %v", test.name, reasons)
+		}
+		if inputs.AttemptsPerMinute != 1 {
+			t.Errorf("%s: expected 1 attempt per minute observed, got %.2f", test.name, inputs.AttemptsPerMinute)
+		}
+	}
+}
+
+func TestReplan_ExamDate(t *testing.T) {
+	service := &OnboardingService{}
+	now := time.Now()
+	path := testLearningPath(now)
+	preferences := UserPreferences{StudyGoal: "improve_skills", AvailableTime: 30}
+	examDate := now.AddDate(0, 0, 10)
+
+	// Nothing mastered and slow progress, so ten days needs more than 30 minutes a day
+	progress := map[string]state.TopicProgress{
+		"traffic_signs": {Mastery: 0.2, Velocity: 0.002, Attempts: 40},
+		"road_rules":    {Mastery: 0.1, Velocity: 0.002, Attempts: 40},
+	}
+
+	plan, _, inputs, reasons := service.replan(path, preferences, StudyActivitySummary{WindowDays: 14}, progress, &examDate, now)
+
+	if !slices.Contains(reasons, ReasonExamPressure) {
+		t.Errorf("Expected exam pressure to raise the plan, got %v", reasons)
+	}
+	if plan.DailyMinutes <= 30 || float64(plan.DailyMinutes) < inputs.RequiredMinutes-2.5 {
+		t.Errorf("Expected at least the %.1f required minutes, got %d", inputs.RequiredMinutes, plan.DailyMinutes)
+	}
+	if !slices.Contains(reasons, ReasonExamApproaching) || plan.SessionTypes[0] != "mock_test" || plan.TestFrequency != 2 {
+		t.Errorf("Expected mock tests to lead within two weeks of the exam, got %v every %d days", plan.SessionTypes, plan.TestFrequency)
+	}
+}
+
+func TestRescheduleMilestones(t *testing.T) {
+	now := time.Now()
+	milestones := testLearningPath(now).Milestones
+	plan := StudyPlan{DailyMinutes: 20}
+	inputs := PlanInputs{Activity: StudyActivitySummary{Attempts: 100}, AttemptsPerMinute: 1}
+
+	progress := map[string]state.TopicProgress{
+		"traffic_signs": {Mastery: 0.9, Mastered: true},
+		"road_rules":    {Mastery: 0.05, Velocity: 0.01},
+	}
+
+	updated, reasons := rescheduleMilestones(milestones, progress, inputs, plan, 7, now)
+
+	if !updated[0].IsCompleted || updated[0].CompletedAt == nil {
+		t.Errorf("Expected foundation milestone to be completed once its topics are mastered")
+	}
+	// 80 attempts at 20 a day is 4 days out, well before the original 28
+	expected := now.AddDate(0, 0, 4)
+	if !updated[1].EstimatedDate.Equal(expected) {
+		t.Errorf("Expected intermediate milestone on %v, got %v", expected, updated[1].EstimatedDate)
+	}
+	if !slices.Contains(reasons, ReasonMilestoneCompleted) || !slices.Contains(reasons, ReasonMilestonesRescheduled) {
+		t.Errorf("Expected completion and reschedule reasons, got %v", reasons)
+	}
+	if milestones[0].IsCompleted {
+		t.Errorf("Expected the input milestones to be left untouched")
+	}
+}
+
+func TestRescheduleMilestones_KeepsOrder(t *testing.T) {
+	now := time.Now()
+	milestones := []LearningMilestone{
+		{ID: "first", RequiredTopics: []string{"road_rules"}, EstimatedDate: now.AddDate(0, 0, -3)},
+		{ID: "second", RequiredTopics: []string{"parking"}, EstimatedDate: now.AddDate(0, 0, -1)},
+	}
+
+	// No attempts yet, so overdue milestones slip rather than being re-estimated
+	updated, _ := rescheduleMilestones(milestones, nil, PlanInputs{AttemptsPerMinute: 1}, StudyPlan{DailyMinutes: 20}, 7, now)
+
+	if !updated[0].EstimatedDate.After(now) {
+		t.Errorf("Expected overdue milestone to move into the future, got %v", updated[0].EstimatedDate)
+	}
+	if !updated[1].EstimatedDate.After(updated[0].EstimatedDate) {
+		t.Errorf("Expected milestones to stay in order, got %v then %v", updated[0].EstimatedDate, updated[1].EstimatedDate)
+	}
+}
+
+func TestRoundDailyMinutes(t *testing.T) {
+	tests := []struct {
+		minutes  float64
+		expected int
+	}{
+		{2, minDailyMinutes},
+		{22.4, 20},
+		{22.6, 25},
+		{600, maxDailyMinutes},
+	}
+
+	for _, test := range tests {
+		if result := roundDailyMinutes(test.minutes); result != test.expected {
+			t.Errorf("For %.1f minutes: expected %d, got %d", test.minutes, test.expected, result)
+		}
+	}
+}
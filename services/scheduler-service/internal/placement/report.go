@@ -0,0 +1,120 @@
+package placement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+)
+
+// ErrReportNotFound is returned when no placement report has been generated for a jurisdiction
+var ErrReportNotFound = errors.New("placement report not found")
+
+// Report is a persisted placement report of one jurisdiction
+type Report struct {
+	ID           string    `json:"id"`
+	Jurisdiction string    `json:"jurisdiction"`
+	WindowStart  time.Time `json:"window_start"`
+	WindowEnd    time.Time `json:"window_end"`
+	GeneratedAt  time.Time `json:"generated_at"`
+
+	algorithms.PlacementReport
+}
+
+// Service aggregates completed placement tests into per-jurisdiction reports for
+// content authors: how long tests run, why they stop, how precise they are when they
+// do, and which items misfit the IRT model
+type Service struct {
+	logger     *logger.Logger
+	repository *Repository
+	algorithm  *algorithms.PlacementTestAlgorithm
+
+	window   time.Duration
+	maxTests int
+}
+
+// NewService creates a new placement report service
+func NewService(cfg *config.WorkerConfig, logger *logger.Logger, db *database.DB, algorithm *algorithms.PlacementTestAlgorithm) *Service {
+	return &Service{
+		logger:     logger,
+		repository: NewRepository(db),
+		algorithm:  algorithm,
+		window:     cfg.ReportWindow,
+		maxTests:   cfg.ReportMaxTests,
+	}
+}
+
+// GenerateReports builds and stores a report for every jurisdiction with placement
+// tests completed within the reporting window before now, and returns how many
+// reports were stored
+func (s *Service) GenerateReports(ctx context.Context, now time.Time) (int, error) {
+	since := now.Add(-s.window)
+
+	jurisdictions, err := s.repository.CompletedJurisdictions(ctx, since, now)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, jurisdiction := range jurisdictions {
+		if ctx.Err() != nil {
+			return generated, ctx.Err()
+		}
+		if _, err := s.GenerateReport(ctx, jurisdiction, since, now); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("jurisdiction", jurisdiction).Warn("Failed to generate placement report")
+			continue
+		}
+		generated++
+	}
+
+	return generated, nil
+}
+
+// GenerateReport builds and stores the report of the placement tests completed in a
+// jurisdiction in [since, until)
+func (s *Service) GenerateReport(ctx context.Context, jurisdiction string, since, until time.Time) (*Report, error) {
+	tests, err := s.repository.CompletedTests(ctx, jurisdiction, since, until, s.maxTests)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Jurisdiction:    jurisdiction,
+		WindowStart:     since,
+		WindowEnd:       until,
+		GeneratedAt:     time.Now(),
+		PlacementReport: *s.algorithm.BuildPlacementReport(tests),
+	}
+
+	if err := s.repository.SaveReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	misfits := 0
+	for _, item := range report.ItemFit {
+		if item.Misfit {
+			misfits++
+		}
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"jurisdiction":     jurisdiction,
+		"tests_analyzed":   report.TestsAnalyzed,
+		"mean_test_length": report.MeanTestLength,
+		"termination_se":   report.TerminationSE.Mean,
+		"items":            len(report.ItemFit),
+		"misfit_items":     misfits,
+	}).Info("Generated placement report")
+
+	return report, nil
+}
+
+// LatestReport returns the most recent report of a jurisdiction. Only misfitting
+// items are included when misfitOnly is set.
+func (s *Service) LatestReport(ctx context.Context, jurisdiction string, misfitOnly bool) (*Report, error) {
+	return s.repository.LatestReport(ctx, jurisdiction, misfitOnly)
+}
@@ -0,0 +1,213 @@
+package placement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Repository reads completed placement test sessions and persists placement reports
+// in the placement_reports and placement_item_fit tables
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new placement report repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// CompletedJurisdictions returns the jurisdictions with placement tests completed in [since, until)
+func (r *Repository) CompletedJurisdictions(ctx context.Context, since, until time.Time) ([]string, error) {
+	var jurisdictions []string
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT UPPER(country_code)
+		FROM placement_test_sessions
+		WHERE status = ? AND completed_at >= ? AND completed_at < ?
+		ORDER BY 1`, "completed", since, until).
+		Scan(&jurisdictions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement jurisdictions: %w", err)
+	}
+	return jurisdictions, nil
+}
+
+// CompletedTests returns up to limit placement tests completed in a jurisdiction in
+// [since, until), most recent first
+func (r *Repository) CompletedTests(ctx context.Context, jurisdiction string, since, until time.Time, limit int) ([]algorithms.CompletedPlacementTest, error) {
+	var rows []models.PlacementTestSessionModel
+	err := r.db.WithContext(ctx).
+		Select("session_id", "placement_data", "results").
+		Where("status = ? AND UPPER(country_code) = ? AND completed_at >= ? AND completed_at < ?",
+			"completed", jurisdiction, since, until).
+		Where("placement_data IS NOT NULL AND results IS NOT NULL").
+		Order("completed_at DESC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed placement tests: %w", err)
+	}
+
+	tests := make([]algorithms.CompletedPlacementTest, 0, len(rows))
+	for _, row := range rows {
+		var state algorithms.PlacementTestState
+		if err := json.Unmarshal(row.PlacementData, &state); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal placement state of session %s: %w", row.SessionID, err)
+		}
+		test := algorithms.CompletedPlacementTest{
+			Items:     state.ItemsAdministered,
+			Responses: state.Responses,
+		}
+		if err := json.Unmarshal(row.Results, &test.Result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal placement results of session %s: %w", row.SessionID, err)
+		}
+		tests = append(tests, test)
+	}
+
+	return tests, nil
+}
+
+// SaveReport stores a report with its item fit statistics in one transaction and
+// sets the report's ID
+func (r *Repository) SaveReport(ctx context.Context, report *Report) error {
+	distribution := make(map[string]int, len(report.LengthDistribution))
+	for length, tests := range report.LengthDistribution {
+		distribution[strconv.Itoa(length)] = tests
+	}
+	lengths, err := json.Marshal(distribution)
+	if err != nil {
+		return fmt.Errorf("failed to marshal test length distribution: %w", err)
+	}
+	reasons, err := json.Marshal(report.StoppingReasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stopping reasons: %w", err)
+	}
+	terminationSE, err := json.Marshal(report.TerminationSE)
+	if err != nil {
+		return fmt.Errorf("failed to marshal termination SE: %w", err)
+	}
+
+	model := models.PlacementReportModel{
+		Jurisdiction:       report.Jurisdiction,
+		WindowStart:        report.WindowStart,
+		WindowEnd:          report.WindowEnd,
+		TestsAnalyzed:      report.TestsAnalyzed,
+		MeanTestLength:     report.MeanTestLength,
+		LengthDistribution: lengths,
+		StoppingReasons:    reasons,
+		TerminationSE:      terminationSE,
+		GeneratedAt:        report.GeneratedAt,
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return fmt.Errorf("failed to save placement report: %w", err)
+		}
+
+		if len(report.ItemFit) > 0 {
+			fit := make([]models.PlacementItemFitModel, 0, len(report.ItemFit))
+			for _, item := range report.ItemFit {
+				fit = append(fit, models.PlacementItemFitModel{
+					ReportID:          model.ID,
+					ItemID:            item.ItemID,
+					Responses:         item.Responses,
+					ProportionCorrect: item.ProportionCorrect,
+					ExpectedCorrect:   item.ExpectedCorrect,
+					Infit:             item.Infit,
+					Outfit:            item.Outfit,
+					Misfit:            item.Misfit,
+				})
+			}
+			if err := tx.CreateInBatches(fit, 500).Error; err != nil {
+				return fmt.Errorf("failed to save placement item fit: %w", err)
+			}
+		}
+
+		report.ID = model.ID
+		return nil
+	})
+}
+
+// LatestReport loads the most recent report of a jurisdiction with its item fit
+// statistics, worst fitting first. Only misfitting items are loaded when misfitOnly is set.
+func (r *Repository) LatestReport(ctx context.Context, jurisdiction string, misfitOnly bool) (*Report, error) {
+	var model models.PlacementReportModel
+	err := r.db.WithContext(ctx).
+		Where("jurisdiction = ?", jurisdiction).
+		Order("generated_at DESC").
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get placement report: %w", err)
+	}
+
+	report := &Report{
+		ID:           model.ID,
+		Jurisdiction: model.Jurisdiction,
+		WindowStart:  model.WindowStart,
+		WindowEnd:    model.WindowEnd,
+		GeneratedAt:  model.GeneratedAt,
+		PlacementReport: algorithms.PlacementReport{
+			TestsAnalyzed:      model.TestsAnalyzed,
+			MeanTestLength:     model.MeanTestLength,
+			LengthDistribution: make(map[int]int),
+		},
+	}
+
+	var distribution map[string]int
+	if err := json.Unmarshal(model.LengthDistribution, &distribution); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal test length distribution: %w", err)
+	}
+	for length, tests := range distribution {
+		items, err := strconv.Atoi(length)
+		if err != nil {
+			return nil, fmt.Errorf("invalid test length %q in placement report: %w", length, err)
+		}
+		report.LengthDistribution[items] = tests
+	}
+	if err := json.Unmarshal(model.StoppingReasons, &report.StoppingReasons); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stopping reasons: %w", err)
+	}
+	if err := json.Unmarshal(model.TerminationSE, &report.TerminationSE); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal termination SE: %w", err)
+	}
+
+	query := r.db.WithContext(ctx).Where("report_id = ?", model.ID)
+	if misfitOnly {
+		query = query.Where("misfit = ?", true)
+	}
+
+	var fit []models.PlacementItemFitModel
+	err = query.
+		Order("misfit DESC, GREATEST(ABS(infit - 1), ABS(outfit - 1)) DESC, item_id ASC").
+		Find(&fit).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement item fit: %w", err)
+	}
+
+	report.ItemFit = make([]algorithms.ItemFitStatistics, 0, len(fit))
+	for _, item := range fit {
+		report.ItemFit = append(report.ItemFit, algorithms.ItemFitStatistics{
+			ItemID:            item.ItemID,
+			Responses:         item.Responses,
+			ProportionCorrect: item.ProportionCorrect,
+			ExpectedCorrect:   item.ExpectedCorrect,
+			Infit:             item.Infit,
+			Outfit:            item.Outfit,
+			Misfit:            item.Misfit,
+		})
+	}
+
+	return report, nil
+}
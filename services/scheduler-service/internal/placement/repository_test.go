@@ -0,0 +1,150 @@
+package placement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/models"
+)
+
+// newTestRepository connects to SCHEDULER_TEST_DATABASE_URL and applies migrations.
+// Tests using it are skipped when the variable is not set.
+func newTestRepository(t *testing.T) (*Repository, *database.DB) {
+	t.Helper()
+
+	databaseURL := os.Getenv("SCHEDULER_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("SCHEDULER_TEST_DATABASE_URL not set")
+	}
+
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	db, err := database.New(&config.DatabaseConfig{URL: databaseURL, MaxOpenConns: 5, MaxIdleConns: 1, ConnMaxLifetime: time.Minute}, &metrics.Metrics{}, log)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return NewRepository(db), db
+}
+
+func TestRepository_CompletedTestsAndReports(t *testing.T) {
+	repo, db := newTestRepository(t)
+	ctx := context.Background()
+
+	// A jurisdiction of its own keeps the test independent of other sessions
+	suffix := time.Now().UnixNano() % 1e12
+	jurisdiction := "ZZ"
+	userID := fmt.Sprintf("00000000-0000-4000-d000-%012d", suffix)
+	sessionID := fmt.Sprintf("placement_test_%d", suffix)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM placement_test_sessions WHERE session_id = ?", sessionID)
+		db.Exec("DELETE FROM placement_reports WHERE jurisdiction = ?", jurisdiction)
+	})
+
+	now := time.Now().Truncate(time.Second)
+	state := algorithms.PlacementTestState{
+		ItemsAdministered: []algorithms.PlacementItem{{ItemID: "item_1", Discrimination: 1.0}},
+		Responses:         []algorithms.PlacementResponse{{ItemID: "item_1", Correct: true}},
+	}
+	result := algorithms.PlacementResult{ItemsAdministered: 1, FinalSE: 0.4, StoppingReason: "user_finished"}
+	placementData, _ := json.Marshal(state)
+	resultData, _ := json.Marshal(result)
+
+	completedAt := now.Add(-time.Hour)
+	err := db.Create(&models.PlacementTestSessionModel{
+		SessionID:     sessionID,
+		UserID:        userID,
+		CountryCode:   "zz",
+		Status:        "completed",
+		PlacementData: placementData,
+		Results:       resultData,
+		CompletedAt:   &completedAt,
+	}).Error
+	if err != nil {
+		t.Fatalf("failed to create placement session: %v", err)
+	}
+
+	jurisdictions, err := repo.CompletedJurisdictions(ctx, now.Add(-2*time.Hour), now)
+	if err != nil {
+		t.Fatalf("failed to get jurisdictions: %v", err)
+	}
+	found := false
+	for _, j := range jurisdictions {
+		found = found || j == jurisdiction
+	}
+	if !found {
+		t.Errorf("expected %s among jurisdictions with completed tests, got %v", jurisdiction, jurisdictions)
+	}
+
+	tests, err := repo.CompletedTests(ctx, jurisdiction, now.Add(-2*time.Hour), now, 10)
+	if err != nil {
+		t.Fatalf("failed to get completed tests: %v", err)
+	}
+	if len(tests) != 1 || len(tests[0].Responses) != 1 || tests[0].Result.StoppingReason != "user_finished" {
+		t.Fatalf("expected the completed test with its responses, got %+v", tests)
+	}
+
+	if _, err := repo.LatestReport(ctx, jurisdiction, false); err != ErrReportNotFound {
+		t.Fatalf("expected ErrReportNotFound before a report is generated, got %v", err)
+	}
+
+	report := &Report{
+		Jurisdiction: jurisdiction,
+		WindowStart:  now.Add(-2 * time.Hour),
+		WindowEnd:    now,
+		GeneratedAt:  now,
+		PlacementReport: algorithms.PlacementReport{
+			TestsAnalyzed:      1,
+			MeanTestLength:     1,
+			LengthDistribution: map[int]int{1: 1},
+			StoppingReasons:    []algorithms.StoppingReasonStats{{Reason: "user_finished", Tests: 1, Share: 1}},
+			TerminationSE:      algorithms.SEDistribution{Mean: 0.4, Median: 0.4, P90: 0.4, Max: 0.4},
+			ItemFit: []algorithms.ItemFitStatistics{
+				{ItemID: "item_1", Responses: 1, Infit: 1.1, Outfit: 1.2},
+				{ItemID: "item_2", Responses: 12, Infit: 1.6, Outfit: 2.0, Misfit: true},
+			},
+		},
+	}
+	if err := repo.SaveReport(ctx, report); err != nil {
+		t.Fatalf("failed to save report: %v", err)
+	}
+	if report.ID == "" {
+		t.Error("expected the saved report to have an ID")
+	}
+
+	latest, err := repo.LatestReport(ctx, jurisdiction, false)
+	if err != nil {
+		t.Fatalf("failed to get latest report: %v", err)
+	}
+	if latest.ID != report.ID || latest.LengthDistribution[1] != 1 || len(latest.StoppingReasons) != 1 {
+		t.Errorf("unexpected latest report %+v", latest)
+	}
+	if len(latest.ItemFit) != 2 || latest.ItemFit[0].ItemID != "item_2" {
+		t.Errorf("expected misfitting items first, got %+v", latest.ItemFit)
+	}
+
+	misfits, err := repo.LatestReport(ctx, jurisdiction, true)
+	if err != nil {
+		t.Fatalf("failed to get misfitting items: %v", err)
+	}
+	if len(misfits.ItemFit) != 1 || !misfits.ItemFit[0].Misfit {
+		t.Errorf("expected only the misfitting item, got %+v", misfits.ItemFit)
+	}
+}
@@ -0,0 +1,139 @@
+package quality
+
+import (
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"scheduler-service/internal/config"
+)
+
+const (
+	// SourceInferred derives quality from the attempt server-side
+	SourceInferred = "inferred"
+	// SourceClient uses the quality supplied with the attempt, with full BKT evidence
+	SourceClient = "client"
+
+	minQuality        = 0
+	maxQuality        = 5
+	passQuality       = 3 // Lowest quality SM-2 treats as successful recall
+	minHintedQuality  = 2 // Floor for correct answers reached with hints, which reset SM-2 repetitions
+	incorrectQuality  = 1
+	confidentlyWrong  = 0
+	normalQuality     = 4
+	fastQuality       = 5
+	unknownConfidence = 0
+)
+
+// Attempt is the evidence an attempt gives about the learner's recall of an item
+type Attempt struct {
+	Correct       bool
+	ClientQuality int           // 0-5 as supplied with the attempt
+	Confidence    int           // 1-5 self-reported, 0 when not given
+	TimeTaken     time.Duration // 0 when not measured
+	ExpectedTime  time.Duration // The item's estimated answer time, 0 when unknown
+	HintsUsed     int
+}
+
+// Assessment is the quality an attempt is scored with
+type Assessment struct {
+	Quality        int     `json:"quality"`         // SM-2 quality 0-5
+	EvidenceWeight float64 `json:"evidence_weight"` // Weight of the attempt as BKT evidence, in [0, 1]
+	Inferred       bool    `json:"inferred"`        // Whether quality was derived server-side
+}
+
+// Inferrer derives SM-2 quality and BKT evidence weight from attempts. Correct answers
+// are rated by speed relative to the item's expected time and marked down for hints
+// and low confidence; wrong answers given with high confidence are rated lowest, as
+// they point to a misconception rather than a lapse.
+type Inferrer struct {
+	cfg atomic.Pointer[config.QualityConfig]
+}
+
+// NewInferrer creates a new quality inferrer
+func NewInferrer(cfg *config.QualityConfig) *Inferrer {
+	i := &Inferrer{}
+	i.Configure(cfg)
+	return i
+}
+
+// Configure changes how attempts are scored from the next attempt on
+func (i *Inferrer) Configure(cfg *config.QualityConfig) {
+	copied := *cfg
+	i.cfg.Store(&copied)
+}
+
+// Assess scores an attempt
+func (i *Inferrer) Assess(attempt Attempt) Assessment {
+	cfg := i.cfg.Load()
+	if strings.EqualFold(cfg.Source, SourceClient) {
+		return Assessment{
+			Quality:        clampQuality(attempt.ClientQuality),
+			EvidenceWeight: 1.0,
+		}
+	}
+
+	return Assessment{
+		Quality:        inferQuality(cfg, attempt),
+		EvidenceWeight: evidenceWeight(cfg, attempt),
+		Inferred:       true,
+	}
+}
+
+// inferQuality maps an attempt onto the SM-2 quality scale
+func inferQuality(cfg *config.QualityConfig, attempt Attempt) int {
+	hints := max(attempt.HintsUsed, 0)
+
+	if !attempt.Correct {
+		if attempt.Confidence != unknownConfidence && attempt.Confidence >= cfg.HighConfidence {
+			return confidentlyWrong
+		}
+		return incorrectQuality
+	}
+
+	quality := normalQuality
+	if attempt.TimeTaken > 0 && attempt.ExpectedTime > 0 {
+		ratio := attempt.TimeTaken.Seconds() / attempt.ExpectedTime.Seconds()
+		switch {
+		case ratio <= cfg.FastRatio:
+			quality = fastQuality
+		case ratio > cfg.SlowRatio:
+			quality = passQuality
+		}
+	}
+
+	// An unsure correct answer may have been a guess
+	if attempt.Confidence != unknownConfidence && attempt.Confidence <= cfg.LowConfidence {
+		quality = min(quality, passQuality)
+	}
+
+	if hints > 0 {
+		quality = max(quality-hints*cfg.HintPenalty, minHintedQuality)
+	}
+
+	return clampQuality(quality)
+}
+
+// evidenceWeight is how strongly an attempt counts as evidence of knowledge. Wrong
+// answers count in full; correct answers count less when hinted or guessed.
+func evidenceWeight(cfg *config.QualityConfig, attempt Attempt) float64 {
+	if !attempt.Correct {
+		return 1.0
+	}
+
+	weight := 1.0
+	if attempt.Confidence != unknownConfidence && attempt.Confidence <= cfg.LowConfidence {
+		weight *= cfg.GuessEvidenceWeight
+	}
+	if attempt.HintsUsed > 0 {
+		weight *= math.Pow(cfg.HintEvidenceDecay, float64(attempt.HintsUsed))
+	}
+
+	return math.Max(cfg.MinEvidenceWeight, math.Min(1.0, weight))
+}
+
+// clampQuality keeps a quality on the SM-2 scale
+func clampQuality(quality int) int {
+	return max(minQuality, min(maxQuality, quality))
+}
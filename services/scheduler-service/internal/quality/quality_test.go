@@ -0,0 +1,103 @@
+package quality
+
+import (
+	"testing"
+	"time"
+
+	"scheduler-service/internal/config"
+)
+
+func testConfig() *config.QualityConfig {
+	return &config.QualityConfig{
+		Source:              SourceInferred,
+		FastRatio:           0.5,
+		SlowRatio:           1.5,
+		HintPenalty:         1,
+		LowConfidence:       2,
+		HighConfidence:      4,
+		GuessEvidenceWeight: 0.5,
+		HintEvidenceDecay:   0.5,
+		MinEvidenceWeight:   0.1,
+	}
+}
+
+func TestAssess_ClientSourceKeepsCurrentBehavior(t *testing.T) {
+	cfg := testConfig()
+	cfg.Source = SourceClient
+	inferrer := NewInferrer(cfg)
+
+	for clientQuality := 0; clientQuality <= 5; clientQuality++ {
+		// Speed, confidence and hints are ignored, as before inference existed
+		assessment := inferrer.Assess(Attempt{
+			Correct:       clientQuality >= 3,
+			ClientQuality: clientQuality,
+			Confidence:    1,
+			TimeTaken:     time.Minute,
+			ExpectedTime:  10 * time.Second,
+			HintsUsed:     3,
+		})
+		if assessment.Quality != clientQuality || assessment.EvidenceWeight != 1.0 || assessment.Inferred {
+			t.Errorf("Expected client quality %d with full evidence, got %+v", clientQuality, assessment)
+		}
+	}
+}
+
+func TestAssess_InferredQuality(t *testing.T) {
+	inferrer := NewInferrer(testConfig())
+	expected := 60 * time.Second
+
+	tests := []struct {
+		name    string
+		attempt Attempt
+		quality int
+		weight  float64
+	}{
+		{"correct at expected pace", Attempt{Correct: true, TimeTaken: 50 * time.Second, ExpectedTime: expected}, 4, 1.0},
+		{"correct and fast", Attempt{Correct: true, TimeTaken: 20 * time.Second, ExpectedTime: expected}, 5, 1.0},
+		{"correct and slow", Attempt{Correct: true, TimeTaken: 2 * time.Minute, ExpectedTime: expected}, 3, 1.0},
+		{"correct without timing", Attempt{Correct: true}, 4, 1.0},
+		{"correct but unsure", Attempt{Correct: true, Confidence: 2, TimeTaken: 20 * time.Second, ExpectedTime: expected}, 3, 0.5},
+		{"correct and confident", Attempt{Correct: true, Confidence: 5, TimeTaken: 50 * time.Second, ExpectedTime: expected}, 4, 1.0},
+		{"correct with one hint", Attempt{Correct: true, HintsUsed: 1, TimeTaken: 50 * time.Second, ExpectedTime: expected}, 3, 0.5},
+		{"correct with many hints", Attempt{Correct: true, HintsUsed: 5}, 2, 0.1},
+		{"wrong", Attempt{Correct: false, TimeTaken: 50 * time.Second, ExpectedTime: expected}, 1, 1.0},
+		{"wrong with hints", Attempt{Correct: false, HintsUsed: 2}, 1, 1.0},
+		{"wrong but confident", Attempt{Correct: false, Confidence: 4}, 0, 1.0},
+		{"wrong and unsure", Attempt{Correct: false, Confidence: 1}, 1, 1.0},
+	}
+
+	for _, tt := range tests {
+		assessment := inferrer.Assess(tt.attempt)
+		if assessment.Quality != tt.quality {
+			t.Errorf("%s: expected quality %d, got %d", tt.name, tt.quality, assessment.Quality)
+		}
+		if assessment.EvidenceWeight != tt.weight {
+			t.Errorf("%s: expected evidence weight %f, got %f", tt.name, tt.weight, assessment.EvidenceWeight)
+		}
+		if !assessment.Inferred {
+			t.Errorf("%s: expected quality to be inferred", tt.name)
+		}
+	}
+}
+
+func TestAssess_PassAndFailMatchCorrectness(t *testing.T) {
+	inferrer := NewInferrer(testConfig())
+
+	// Whatever the speed, confidence or hints, SM-2 only counts unhinted correct answers as recall
+	for _, correct := range []bool{true, false} {
+		for confidence := 0; confidence <= 5; confidence++ {
+			for _, taken := range []time.Duration{0, 5 * time.Second, time.Minute, 10 * time.Minute} {
+				assessment := inferrer.Assess(Attempt{
+					Correct:      correct,
+					Confidence:   confidence,
+					TimeTaken:    taken,
+					ExpectedTime: time.Minute,
+				})
+				if passed := assessment.Quality >= passQuality; passed != correct {
+					t.Errorf("correct=%v confidence=%d taken=%s: quality %d disagrees with correctness",
+						correct, confidence, taken, assessment.Quality)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+)
+
+// Scopes of the buckets a request is charged to
+const (
+	ScopeUser    = "user"
+	ScopeService = "service"
+	ScopeTenant  = "tenant"
+	ScopeMethod  = "method"
+)
+
+// Bucket is a token bucket a request takes a token from
+type Bucket struct {
+	Scope string
+	Key   string // Identifies the bucket within its scope
+	Limit config.RateLimit
+}
+
+// Decision is the outcome of taking a token from every bucket of a request
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration // When the request would be allowed, if denied
+	Scope      string        // Scope of the bucket that denied the request
+}
+
+// Store keeps token buckets. Take takes a token from every bucket if each has one, and
+// none otherwise, so a denied request costs nothing.
+type Store interface {
+	Take(ctx context.Context, buckets []Bucket) (Decision, error)
+}
+
+// Request identifies who a gRPC request is charged to
+type Request struct {
+	Method  string // Method name without the service, e.g. GetNextItems
+	UserID  string // User whose learning state the request acts on, if any
+	Service string // Calling service account, if any
+	Tenant  string // Tenant the request is made within, if any
+	Caller  string // Subject or address of the caller, for per-method limits
+}
+
+// Limiter limits requests per user, per calling service, per tenant and per method and caller.
+// Buckets are kept in a shared store; when it fails, requests are limited per replica
+// by a local store instead of being denied.
+type Limiter struct {
+	store    Store
+	fallback *LocalStore
+	logger   *logger.Logger
+	cfg      atomic.Pointer[config.RateLimitConfig]
+}
+
+// NewLimiter creates a limiter keeping buckets in store. The store may be nil to keep
+// them in process only.
+func NewLimiter(cfg *config.RateLimitConfig, store Store, log *logger.Logger) *Limiter {
+	l := &Limiter{
+		store:    store,
+		fallback: NewLocalStore(),
+		logger:   log,
+	}
+	l.Configure(cfg)
+	return l
+}
+
+// Configure changes the limits from the next request on. Buckets keep their tokens.
+func (l *Limiter) Configure(cfg *config.RateLimitConfig) {
+	copied := *cfg
+	l.cfg.Store(&copied)
+}
+
+// Allow takes a token for a request from each of its buckets
+func (l *Limiter) Allow(ctx context.Context, req Request) Decision {
+	buckets := l.buckets(req)
+	if len(buckets) == 0 {
+		return Decision{Allowed: true}
+	}
+
+	if l.store != nil {
+		decision, err := l.store.Take(ctx, buckets)
+		if err == nil {
+			return decision
+		}
+		l.logger.WithContext(ctx).WithError(err).Warn("Rate limit store unavailable, limiting per replica")
+	}
+
+	decision, _ := l.fallback.Take(ctx, buckets)
+	return decision
+}
+
+// buckets returns the buckets a request is charged to; disabled limits have none
+func (l *Limiter) buckets(req Request) []Bucket {
+	cfg := l.cfg.Load()
+	var buckets []Bucket
+
+	if req.UserID != "" && cfg.User.Rate > 0 {
+		buckets = append(buckets, Bucket{Scope: ScopeUser, Key: req.UserID, Limit: cfg.User})
+	}
+
+	if req.Service != "" {
+		limit, ok := cfg.Services[req.Service]
+		if !ok {
+			limit = cfg.Service
+		}
+		if limit.Rate > 0 {
+			buckets = append(buckets, Bucket{Scope: ScopeService, Key: req.Service, Limit: limit})
+		}
+	}
+
+	if req.Tenant != "" && cfg.Tenant.Rate > 0 {
+		buckets = append(buckets, Bucket{Scope: ScopeTenant, Key: req.Tenant, Limit: cfg.Tenant})
+	}
+
+	if limit, ok := cfg.Methods[req.Method]; ok && limit.Rate > 0 && req.Caller != "" {
+		buckets = append(buckets, Bucket{Scope: ScopeMethod, Key: req.Method + ":" + req.Caller, Limit: limit})
+	}
+
+	return buckets
+}
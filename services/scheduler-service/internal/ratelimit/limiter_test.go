@@ -0,0 +1,148 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+)
+
+func newTestLocalStore(now *time.Time) *LocalStore {
+	store := NewLocalStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestLocalStore_RefillsAtRate(t *testing.T) {
+	now := time.Now()
+	store := newTestLocalStore(&now)
+	buckets := []Bucket{{Scope: ScopeUser, Key: "user-1", Limit: config.RateLimit{Rate: 2, Burst: 2}}}
+
+	for i := 0; i < 2; i++ {
+		if decision, _ := store.Take(context.Background(), buckets); !decision.Allowed {
+			t.Fatalf("Expected request %d within the burst to be allowed", i+1)
+		}
+	}
+
+	decision, _ := store.Take(context.Background(), buckets)
+	if decision.Allowed {
+		t.Fatal("Expected request beyond the burst to be denied")
+	}
+	if decision.Scope != ScopeUser || decision.RetryAfter != 500*time.Millisecond {
+		t.Errorf("Expected user scope and 500ms retry, got %+v", decision)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if decision, _ := store.Take(context.Background(), buckets); !decision.Allowed {
+		t.Error("Expected a token to be refilled after 500ms")
+	}
+}
+
+func TestLocalStore_DeniedRequestsCostNothing(t *testing.T) {
+	now := time.Now()
+	store := newTestLocalStore(&now)
+	user := Bucket{Scope: ScopeUser, Key: "user-1", Limit: config.RateLimit{Rate: 1, Burst: 5}}
+	method := Bucket{Scope: ScopeMethod, Key: "GetNextItems:user-1", Limit: config.RateLimit{Rate: 1, Burst: 1}}
+
+	store.Take(context.Background(), []Bucket{user, method})
+	decision, _ := store.Take(context.Background(), []Bucket{user, method})
+	if decision.Allowed || decision.Scope != ScopeMethod {
+		t.Fatalf("Expected the method bucket to deny, got %+v", decision)
+	}
+
+	// The user bucket was only charged for the allowed request
+	for i := 0; i < 4; i++ {
+		if decision, _ := store.Take(context.Background(), []Bucket{user}); !decision.Allowed {
+			t.Fatalf("Expected user request %d to be allowed", i+1)
+		}
+	}
+}
+
+func TestLimiter_Buckets(t *testing.T) {
+	cfg := &config.RateLimitConfig{
+		User:     config.RateLimit{Rate: 10, Burst: 20},
+		Service:  config.RateLimit{Rate: 100, Burst: 200},
+		Tenant:   config.RateLimit{Rate: 50, Burst: 100},
+		Services: map[string]config.RateLimit{"batch": {Rate: 0}},
+		Methods:  map[string]config.RateLimit{"GetNextItems": {Rate: 1, Burst: 5}},
+	}
+	l := NewLimiter(cfg, nil, logger.New(&config.LoggingConfig{Level: "error", Format: "text"}))
+
+	tests := []struct {
+		name   string
+		req    Request
+		scopes []string
+	}{
+		{"learner", Request{Method: "GetNextItems", UserID: "user-1", Caller: "user-1"}, []string{ScopeUser, ScopeMethod}},
+		{"service on a user", Request{Method: "RecordAttempt", UserID: "user-1", Service: "gateway", Caller: "gateway"}, []string{ScopeUser, ScopeService}},
+		{"learner in a tenant", Request{Method: "RecordAttempt", UserID: "user-1", Tenant: "school-1", Caller: "user-1"}, []string{ScopeUser, ScopeTenant}},
+		{"unlimited service", Request{Method: "ImportReviewLog", Service: "batch", Caller: "batch"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := l.buckets(tt.req)
+			if len(buckets) != len(tt.scopes) {
+				t.Fatalf("Expected scopes %v, got %+v", tt.scopes, buckets)
+			}
+			for i, scope := range tt.scopes {
+				if buckets[i].Scope != scope {
+					t.Errorf("Expected scopes %v, got %+v", tt.scopes, buckets)
+				}
+			}
+		})
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, buckets []Bucket) (Decision, error) {
+	return Decision{}, errors.New("connection refused")
+}
+
+func TestLimiter_FallsBackToLocalStore(t *testing.T) {
+	cfg := &config.RateLimitConfig{User: config.RateLimit{Rate: 1, Burst: 1}}
+	l := NewLimiter(cfg, failingStore{}, logger.New(&config.LoggingConfig{Level: "error", Format: "text"}))
+	req := Request{Method: "GetUserState", UserID: "user-1"}
+
+	if !l.Allow(context.Background(), req).Allowed {
+		t.Fatal("Expected the first request to be allowed by the local store")
+	}
+	if l.Allow(context.Background(), req).Allowed {
+		t.Error("Expected the local store to keep limiting while the shared store fails")
+	}
+}
+
+func TestLimiter_Configure(t *testing.T) {
+	l := NewLimiter(&config.RateLimitConfig{User: config.RateLimit{Rate: 1, Burst: 1}}, nil,
+		logger.New(&config.LoggingConfig{Level: "error", Format: "text"}))
+	req := Request{Method: "GetUserState", UserID: "user-1"}
+
+	l.Allow(context.Background(), req)
+	l.Configure(&config.RateLimitConfig{})
+	if !l.Allow(context.Background(), req).Allowed {
+		t.Error("Expected requests to be allowed once the user limit is disabled")
+	}
+}
+
+func TestBucketKey_SharesHashTag(t *testing.T) {
+	buckets := []Bucket{
+		{Scope: ScopeUser, Key: "user-1"},
+		{Scope: ScopeService, Key: "content-service"},
+		{Scope: ScopeTenant, Key: "tenant-{1}"},
+		{Scope: ScopeMethod, Key: "GetNextItems:spiffe://learner"},
+	}
+
+	for _, bucket := range buckets {
+		key := bucketKey(bucket)
+		start := strings.Index(key, "{")
+		end := strings.Index(key[start+1:], "}")
+		if start < 0 || end < 0 || key[start:start+end+2] != "{buckets}" {
+			t.Errorf("Expected key %s to be hashed on {buckets}", key)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// localBucketIdle is how long an unused bucket is kept; a bucket idle this long has
+// refilled under any limit the service is configured with
+const localBucketIdle = 10 * time.Minute
+
+// localBucket is the state of a token bucket
+type localBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// LocalStore keeps token buckets in process, limiting each replica on its own
+type LocalStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*localBucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewLocalStore creates an in-process token bucket store
+func NewLocalStore() *LocalStore {
+	return &LocalStore{
+		buckets: make(map[string]*localBucket),
+		now:     time.Now,
+	}
+}
+
+// Take takes a token from every bucket if each has one
+func (s *LocalStore) Take(ctx context.Context, buckets []Bucket) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	states := make([]*localBucket, len(buckets))
+	decision := Decision{Allowed: true}
+	for i, bucket := range buckets {
+		key := bucket.Scope + ":" + bucket.Key
+		state, ok := s.buckets[key]
+		if !ok {
+			state = &localBucket{tokens: float64(bucket.Limit.Burst), updated: now}
+			s.buckets[key] = state
+		}
+
+		// Refill for the time since the bucket was last used
+		elapsed := now.Sub(state.updated).Seconds()
+		state.tokens = math.Min(float64(bucket.Limit.Burst), state.tokens+elapsed*bucket.Limit.Rate)
+		state.updated = now
+		states[i] = state
+
+		if state.tokens < 1 {
+			retryAfter := time.Duration((1 - state.tokens) / bucket.Limit.Rate * float64(time.Second))
+			if !decision.Allowed && retryAfter <= decision.RetryAfter {
+				continue
+			}
+			decision = Decision{Allowed: false, RetryAfter: retryAfter, Scope: bucket.Scope}
+		}
+	}
+
+	if decision.Allowed {
+		for _, state := range states {
+			state.tokens--
+		}
+	}
+	return decision, nil
+}
+
+// sweep drops idle buckets, at most once per idle period
+func (s *LocalStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < localBucketIdle {
+		return
+	}
+	s.lastSweep = now
+
+	for key, state := range s.buckets {
+		if now.Sub(state.updated) > localBucketIdle {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+)
+
+// Layers a setting can come from, in increasing precedence
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceRemote  = "remote"
+)
+
+var (
+	// ErrUnknownSetting is returned when overriding a setting that does not exist
+	ErrUnknownSetting = errors.New("unknown setting")
+	// ErrNotHotReloadable is returned when overriding a setting that takes a restart
+	ErrNotHotReloadable = errors.New("setting cannot be changed at runtime")
+	// ErrInvalidConfig is returned when overrides would make the configuration invalid
+	ErrInvalidConfig = errors.New("invalid configuration")
+	// ErrNoRepository is returned when changing or listing overrides without a database
+	ErrNoRepository = errors.New("config overrides need a database")
+)
+
+// Change is a setting whose value changed on a reload
+type Change struct {
+	Key    string `json:"key"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+	Source string `json:"source"` // Layer the new value comes from
+}
+
+// Setting is the effective value of a setting and the layer it comes from
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Hot    bool   `json:"hot"`
+	Secret bool   `json:"secret"`
+}
+
+// Reloader layers settings from the config file, the environment and the config_values
+// table, in increasing precedence. On every reload the file and table are read again and
+// changed hot-reloadable settings are applied; other changes are logged and take effect
+// on the next restart. Overrides in config_values are limited to hot-reloadable settings.
+type Reloader struct {
+	logger     *logger.Logger
+	repository *Repository
+	file       string
+	interval   time.Duration
+
+	// Serializes reloads
+	mu       sync.Mutex
+	applied  config.Values // Values the current configuration was parsed from
+	seen     config.Values // Values read on the last reload
+	rejected config.Values // Hot values of the last reload that failed validation
+	current  atomic.Pointer[config.Config]
+	appliers []func(cfg *config.Config)
+}
+
+// NewReloader creates a reloader of a configuration parsed from values. Without a
+// database only the file and the environment are layered.
+func NewReloader(log *logger.Logger, db *database.DB, cfg *config.Config, values config.Values) *Reloader {
+	r := &Reloader{
+		logger:   log,
+		file:     cfg.Reload.File,
+		interval: cfg.Reload.Interval,
+		applied:  values,
+		seen:     values,
+	}
+	if db != nil {
+		r.repository = NewRepository(db)
+	}
+	r.current.Store(cfg)
+	return r
+}
+
+// OnReload registers a function applying the hot-reloadable settings of a reloaded
+// configuration. Functions are called in registration order.
+func (r *Reloader) OnReload(apply func(cfg *config.Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.appliers = append(r.appliers, apply)
+}
+
+// Current returns the configuration in effect
+func (r *Reloader) Current() *config.Config {
+	return r.current.Load()
+}
+
+// Run reloads the configuration every interval until ctx is done
+func (r *Reloader) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Reload(ctx)
+		}
+	}
+}
+
+// Reload reads the file and overrides again and applies the hot-reloadable settings
+// that changed. An invalid configuration is logged and leaves the current one in effect.
+func (r *Reloader) Reload(ctx context.Context) ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	layers, err := r.layers(ctx)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error("Failed to reload configuration")
+		return nil, err
+	}
+	next := config.Merge(layers.file, layers.env, layers.remote)
+
+	for _, key := range changedKeys(r.seen, next) {
+		if !config.HotReloadable(key) {
+			r.logger.WithContext(ctx).WithFields(map[string]interface{}{
+				"key":    key,
+				"source": layers.source(key),
+			}).Warn("Configuration setting changed; it takes effect on restart")
+		}
+	}
+	r.seen = next
+
+	// Hot settings are taken from the new layers, all others stay as started
+	effective := config.Merge(r.applied)
+	for _, setting := range config.Schema() {
+		if !setting.Hot {
+			continue
+		}
+		if value, ok := next[setting.Key]; ok {
+			effective[setting.Key] = value
+		} else {
+			delete(effective, setting.Key)
+		}
+	}
+
+	changed := changedKeys(r.applied, effective)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	cfg, err := config.Parse(effective)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		if len(changedKeys(r.rejected, hotValues(effective))) > 0 {
+			r.logger.WithContext(ctx).WithError(err).Error("Reloaded configuration is invalid, keeping the current one")
+		}
+		r.rejected = hotValues(effective)
+		return nil, err
+	}
+	r.rejected = nil
+
+	changes := make([]Change, 0, len(changed))
+	for _, key := range changed {
+		changes = append(changes, Change{Key: key, Old: r.applied[key], New: effective[key], Source: layers.source(key)})
+	}
+
+	r.applied = effective
+	r.current.Store(cfg)
+	for _, apply := range r.appliers {
+		apply(cfg)
+	}
+
+	for _, change := range changes {
+		r.logger.WithContext(ctx).WithFields(map[string]interface{}{
+			"key":    change.Key,
+			"old":    change.Old,
+			"new":    change.New,
+			"source": change.Source,
+		}).Info("Applied configuration change")
+	}
+
+	return changes, nil
+}
+
+// Set overrides hot-reloadable settings in config_values, or removes the overrides of
+// those without a value, and reloads. The change is recorded in the audit log under
+// actor; other replicas apply it on their next reload.
+func (r *Reloader) Set(ctx context.Context, changes map[string]*string, actor, reason string) ([]AuditEntry, error) {
+	if r.repository == nil {
+		return nil, ErrNoRepository
+	}
+	if actor == "" {
+		return nil, errors.New("actor is required")
+	}
+	for key := range changes {
+		if !config.Known(key) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSetting, key)
+		}
+		if !config.HotReloadable(key) {
+			return nil, fmt.Errorf("%w: %s", ErrNotHotReloadable, key)
+		}
+	}
+
+	// Reject overrides that would not apply here rather than storing them
+	layers, err := r.layers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(layers.remote, key)
+		} else {
+			layers.remote[key] = *value
+		}
+	}
+	if _, err := config.Parse(config.Merge(layers.file, layers.env, layers.remote)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	entries, err := r.repository.Apply(ctx, changes, actor, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		r.logger.WithContext(ctx).WithFields(map[string]interface{}{
+			"key":    entry.Key,
+			"actor":  entry.Actor,
+			"reason": entry.Reason,
+		}).Info("Changed configuration override")
+	}
+
+	if _, err := r.Reload(ctx); err != nil {
+		return entries, err
+	}
+	return entries, nil
+}
+
+// History returns the latest changes to overrides, of one setting or all of them
+func (r *Reloader) History(ctx context.Context, key string, limit int) ([]AuditEntry, error) {
+	if r.repository == nil {
+		return nil, ErrNoRepository
+	}
+	return r.repository.History(ctx, key, limit)
+}
+
+// Settings returns the value every setting would have on a reload and where it comes from
+func (r *Reloader) Settings(ctx context.Context) ([]Setting, error) {
+	layers, err := r.layers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	next := config.Merge(layers.file, layers.env, layers.remote)
+
+	schema := config.Schema()
+	settings := make([]Setting, 0, len(schema))
+	for _, setting := range schema {
+		value, ok := next[setting.Key]
+		if !ok {
+			value = setting.Default
+		}
+		settings = append(settings, Setting{
+			Key:    setting.Key,
+			Value:  value,
+			Source: layers.source(setting.Key),
+			Hot:    setting.Hot,
+			Secret: setting.Secret,
+		})
+	}
+	return settings, nil
+}
+
+// layerValues are the values of each configuration layer
+type layerValues struct {
+	file   config.Values
+	env    config.Values
+	remote config.Values
+}
+
+// source returns the layer a setting's value comes from
+func (l layerValues) source(key string) string {
+	switch {
+	case hasValue(l.remote, key):
+		return SourceRemote
+	case hasValue(l.env, key):
+		return SourceEnv
+	case hasValue(l.file, key):
+		return SourceFile
+	default:
+		return SourceDefault
+	}
+}
+
+// layers reads the configuration layers. Overrides of settings that cannot change at
+// runtime, which Set never stores, are ignored.
+func (r *Reloader) layers(ctx context.Context) (layerValues, error) {
+	fileValues, err := config.ReadFile(r.file)
+	if err != nil {
+		return layerValues{}, err
+	}
+
+	layers := layerValues{file: fileValues, env: config.EnvValues(), remote: config.Values{}}
+	if r.repository == nil {
+		return layers, nil
+	}
+
+	overrides, err := r.repository.Overrides(ctx)
+	if err != nil {
+		return layerValues{}, err
+	}
+	for _, override := range overrides {
+		if !config.HotReloadable(override.Key) {
+			r.logger.WithContext(ctx).WithField("key", override.Key).Warn("Ignoring config override of a setting that cannot change at runtime")
+			continue
+		}
+		layers.remote[override.Key] = override.Value
+	}
+	return layers, nil
+}
+
+// changedKeys returns the keys whose values differ between a and b, sorted
+func changedKeys(a, b config.Values) []string {
+	var keys []string
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hotValues returns the hot-reloadable settings of values
+func hotValues(values config.Values) config.Values {
+	hot := make(config.Values)
+	for key, value := range values {
+		if config.HotReloadable(key) {
+			hot[key] = value
+		}
+	}
+	return hot
+}
+
+// hasValue reports whether a layer sets a key; empty values count as unset
+func hasValue(values config.Values, key string) bool {
+	return values[key] != ""
+}
@@ -0,0 +1,130 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+)
+
+// newFileReloader returns a reloader of a configuration file without a database, and
+// a function rewriting the file
+func newFileReloader(t *testing.T, initial string) (*Reloader, func(data string)) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scheduler.yaml")
+	write := func(data string) {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(initial)
+
+	values, err := config.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	values["CONFIG_FILE"] = path
+	cfg, err := config.Parse(values)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	return NewReloader(log, nil, cfg, values), write
+}
+
+func TestReload_AppliesHotSettings(t *testing.T) {
+	r, write := newFileReloader(t, "BKT_LEARN_PROBABILITY: 0.15\n")
+
+	var applied *config.Config
+	r.OnReload(func(cfg *config.Config) { applied = cfg })
+
+	write("BKT_LEARN_PROBABILITY: 0.25\nWEIGHT_URGENCY: 0.25\nWEIGHT_MASTERY: 0.4\n")
+	changes, err := r.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %+v", changes)
+	}
+	if changes[0].Key != "BKT_LEARN_PROBABILITY" || changes[0].Old != "0.15" || changes[0].New != "0.25" || changes[0].Source != SourceFile {
+		t.Errorf("Unexpected change %+v", changes[0])
+	}
+	if applied == nil || applied.BKT.LearnProbability != 0.25 || applied.Scoring.WeightMastery != 0.4 {
+		t.Errorf("Expected reloaded configuration to be applied, got %+v", applied)
+	}
+	if r.Current() != applied {
+		t.Errorf("Expected the applied configuration to be current")
+	}
+}
+
+func TestReload_KeepsSettingsThatNeedRestart(t *testing.T) {
+	r, write := newFileReloader(t, "WORKER_BATCH_SIZE: 500\n")
+
+	calls := 0
+	r.OnReload(func(cfg *config.Config) { calls++ })
+
+	write("WORKER_BATCH_SIZE: 100\n")
+	changes, err := r.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if len(changes) != 0 || calls != 0 {
+		t.Errorf("Expected no changes to be applied, got %+v and %d calls", changes, calls)
+	}
+	if r.Current().Worker.BatchSize != 500 {
+		t.Errorf("Expected batch size to stay 500 until restart, got %d", r.Current().Worker.BatchSize)
+	}
+}
+
+func TestReload_RejectsInvalidConfiguration(t *testing.T) {
+	r, write := newFileReloader(t, "WEIGHT_URGENCY: 0.30\n")
+	before := r.Current()
+
+	write("WEIGHT_URGENCY: 0.9\n")
+	_, err := r.Reload(context.Background())
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	if r.Current() != before {
+		t.Errorf("Expected the current configuration to stay in effect")
+	}
+
+	// Fixing the file applies it on the next reload
+	write("WEIGHT_URGENCY: 0.25\nWEIGHT_MASTERY: 0.4\n")
+	if _, err := r.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if r.Current().Scoring.WeightUrgency != 0.25 {
+		t.Errorf("Expected fixed configuration to be applied, got %v", r.Current().Scoring.WeightUrgency)
+	}
+}
+
+func TestSet_NeedsDatabase(t *testing.T) {
+	r, _ := newFileReloader(t, "")
+	value := "0.2"
+
+	_, err := r.Set(context.Background(), map[string]*string{"BKT_LEARN_PROBABILITY": &value}, "admin", "")
+	if !errors.Is(err, ErrNoRepository) {
+		t.Errorf("Expected ErrNoRepository, got %v", err)
+	}
+}
+
+func TestChangedKeys(t *testing.T) {
+	keys := changedKeys(config.Values{"A": "1", "B": "2", "C": "3"}, config.Values{"A": "1", "B": "4", "D": "5"})
+	want := []string{"B", "C", "D"}
+	if len(keys) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, keys)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, keys)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"scheduler-service/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// Override is a setting overridden at runtime in the config_values table
+type Override struct {
+	Key       string    `json:"key"`
+	Value     string    `json:"value"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AuditEntry is a change to an override. OldValue is nil for a new override and
+// NewValue is nil for a removed one.
+type AuditEntry struct {
+	ID        int64     `json:"id"`
+	Key       string    `json:"key"`
+	OldValue  *string   `json:"old_value"`
+	NewValue  *string   `json:"new_value"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// Repository stores runtime overrides of settings and the audit log of their changes
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new config override repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Overrides returns the settings overridden at runtime, by key
+func (r *Repository) Overrides(ctx context.Context) ([]Override, error) {
+	var overrides []Override
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT key, value, updated_by, updated_at
+		FROM config_values
+		ORDER BY key`).
+		Scan(&overrides).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+// Apply sets the overrides of changes with a value and removes those without one,
+// recording each actual change in the audit log in the same transaction
+func (r *Repository) Apply(ctx context.Context, changes map[string]*string, actor, reason string) ([]AuditEntry, error) {
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var entries []AuditEntry
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, key := range keys {
+			var current []string
+			if err := tx.Raw(`SELECT value FROM config_values WHERE key = ? FOR UPDATE`, key).
+				Scan(&current).Error; err != nil {
+				return err
+			}
+			var oldValue *string
+			if len(current) > 0 {
+				oldValue = &current[0]
+			}
+
+			newValue := changes[key]
+			if equalValues(oldValue, newValue) {
+				continue
+			}
+
+			if newValue == nil {
+				if err := tx.Exec(`DELETE FROM config_values WHERE key = ?`, key).Error; err != nil {
+					return err
+				}
+			} else if err := tx.Exec(`
+				INSERT INTO config_values (key, value, updated_by, updated_at)
+				VALUES (?, ?, ?, NOW())
+				ON CONFLICT (key) DO UPDATE
+				SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at`,
+				key, *newValue, actor).Error; err != nil {
+				return err
+			}
+
+			var entry AuditEntry
+			if err := tx.Raw(`
+				INSERT INTO config_audit_log (key, old_value, new_value, actor, reason, changed_at)
+				VALUES (?, ?, ?, ?, NULLIF(?, ''), NOW())
+				RETURNING id, key, old_value, new_value, actor, COALESCE(reason, '') AS reason, changed_at`,
+				key, oldValue, newValue, actor, reason).
+				Scan(&entry).Error; err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to change config overrides: %w", err)
+	}
+	return entries, nil
+}
+
+// History returns the latest changes to overrides, newest first, of one setting or of
+// all of them when key is empty
+func (r *Repository) History(ctx context.Context, key string, limit int) ([]AuditEntry, error) {
+	query := `
+		SELECT id, key, old_value, new_value, actor, COALESCE(reason, '') AS reason, changed_at
+		FROM config_audit_log`
+	args := []interface{}{}
+	if key != "" {
+		query += ` WHERE key = ?`
+		args = append(args, key)
+	}
+	query += ` ORDER BY changed_at DESC, id DESC LIMIT ?`
+	args = append(args, limit)
+
+	var entries []AuditEntry
+	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to read config audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// equalValues reports whether two optional values are the same
+func equalValues(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
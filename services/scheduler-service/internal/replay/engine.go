@@ -0,0 +1,348 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/catalog"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/quality"
+)
+
+// userBatchSize bounds the users read per page when replaying or swapping everyone
+const userBatchSize = 500
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var (
+	// ErrInvalidUserID is returned for user IDs that are not UUIDs
+	ErrInvalidUserID = errors.New("user_id must be a UUID")
+	// ErrNoUsers is returned for runs scoped to users without any user IDs
+	ErrNoUsers = errors.New("no users to replay")
+	// ErrRunNotSwappable is returned when swapping a run that is not fully replayed
+	ErrRunNotSwappable = errors.New("only replayed runs can be swapped")
+	// ErrRunSwapped is returned when discarding a run that was already swapped
+	ErrRunSwapped = errors.New("run was already swapped")
+	// ErrCatalogEmpty is returned when no item catalog is loaded to score attempts against
+	ErrCatalogEmpty = errors.New("item catalog is not loaded")
+)
+
+// ItemCatalog is the catalog attempts are scored against
+type ItemCatalog interface {
+	Refresh(ctx context.Context)
+	Len() int
+	Lookup(itemID string) (catalog.Item, bool)
+}
+
+// UserSettings resolves where and for when a user's states are rebuilt
+type UserSettings interface {
+	ActiveJurisdiction(ctx context.Context, userID string) (string, error)
+	GetExamDate(ctx context.Context, userID string) (*time.Time, error)
+}
+
+// Scope selects the users a run replays: the given users, or everyone with attempts
+type Scope struct {
+	UserIDs []string
+	All     bool
+}
+
+// Diff compares a run's rebuilt states with the live states they would replace
+type Diff struct {
+	Run    *Run        `json:"run"`
+	Tables []TableDiff `json:"tables"`
+}
+
+// Engine rebuilds SM-2, BKT and IRT states by replaying the shared attempts table with
+// the current algorithm parameters. A run writes the rebuilt states to shadow tables;
+// they can be diffed against the live states before they are swapped in or discarded.
+type Engine struct {
+	logger     *logger.Logger
+	repository *Repository
+	cache      *cache.RedisClient
+	catalog    ItemCatalog
+	settings   UserSettings
+	inferrer   *quality.Inferrer
+	replayer   *Replayer
+}
+
+// NewEngine creates a new replay engine
+func NewEngine(
+	log *logger.Logger,
+	db *database.DB,
+	cache *cache.RedisClient,
+	itemCatalog ItemCatalog,
+	settings UserSettings,
+	inferrer *quality.Inferrer,
+	replayer *Replayer,
+) *Engine {
+	return &Engine{
+		logger:     log,
+		repository: NewRepository(db),
+		cache:      cache,
+		catalog:    itemCatalog,
+		settings:   settings,
+		inferrer:   inferrer,
+		replayer:   replayer,
+	}
+}
+
+// Run replays the attempts of the users in scope, made before the run started, into
+// the shadow tables. BKT and IRT states are rebuilt in each user's active jurisdiction.
+func (e *Engine) Run(ctx context.Context, scope Scope) (*Run, error) {
+	if !scope.All && len(scope.UserIDs) == 0 {
+		return nil, ErrNoUsers
+	}
+	for _, userID := range scope.UserIDs {
+		if !uuidPattern.MatchString(userID) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidUserID, userID)
+		}
+	}
+
+	// Without the catalog every attempt would count towards the general topic
+	e.catalog.Refresh(ctx)
+	if e.catalog.Len() == 0 {
+		return nil, ErrCatalogEmpty
+	}
+
+	scopeName := ScopeUsers
+	if scope.All {
+		scopeName = ScopeAll
+	}
+	run, err := e.repository.CreateRun(ctx, scopeName)
+	if err != nil {
+		return nil, err
+	}
+
+	e.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"run_id": run.ID,
+		"scope":  scopeName,
+		"users":  len(scope.UserIDs),
+	}).Info("Starting replay run")
+
+	usersReplayed := 0
+	var attemptsReplayed int64
+	replayUsers := func(userIDs []string) error {
+		for _, userID := range userIDs {
+			attempts, err := e.replayUser(ctx, run, userID)
+			if err != nil {
+				return fmt.Errorf("failed to replay user %s: %w", userID, err)
+			}
+			usersReplayed++
+			attemptsReplayed += int64(attempts)
+		}
+		return nil
+	}
+
+	if scope.All {
+		err = forEachPage(func(after string) ([]string, error) {
+			return e.repository.UsersWithAttempts(ctx, after, userBatchSize)
+		}, replayUsers)
+	} else {
+		err = replayUsers(scope.UserIDs)
+	}
+
+	status := StatusReplayed
+	if err != nil {
+		status = StatusFailed
+	}
+	if finishErr := e.repository.FinishRun(ctx, run.ID, status, usersReplayed, attemptsReplayed, err); finishErr != nil {
+		return nil, finishErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"run_id":            run.ID,
+		"users_replayed":    usersReplayed,
+		"attempts_replayed": attemptsReplayed,
+	}).Info("Finished replay run")
+
+	return e.repository.GetRun(ctx, run.ID)
+}
+
+// replayUser rebuilds a user's states into the shadow tables and returns the number
+// of attempts replayed
+func (e *Engine) replayUser(ctx context.Context, run *Run, userID string) (int, error) {
+	attempts, err := e.repository.Attempts(ctx, userID, run.StartedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	jurisdiction, err := e.settings.ActiveJurisdiction(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve active jurisdiction: %w", err)
+	}
+	var examDate time.Time
+	if date, err := e.settings.GetExamDate(ctx, userID); err != nil {
+		return 0, fmt.Errorf("failed to get exam date: %w", err)
+	} else if date != nil {
+		examDate = *date
+	}
+
+	state := NewState()
+	for _, row := range attempts {
+		e.replayer.Apply(state, e.scoreAttempt(row), examDate)
+	}
+
+	if err := e.repository.SaveShadowStates(ctx, run.ID, userID, jurisdiction, state); err != nil {
+		return 0, err
+	}
+	return len(attempts), nil
+}
+
+// scoreAttempt scores an attempt as RecordAttempt does. Items no longer in the catalog
+// get the placeholder parameters and topic of items outside it.
+func (e *Engine) scoreAttempt(row AttemptRow) Attempt {
+	item, ok := e.catalog.Lookup(row.ItemID)
+	if !ok {
+		item = catalog.Item{ID: row.ItemID, Discrimination: 1.0}
+	}
+
+	attempt := quality.Attempt{
+		Correct:      row.Correct,
+		TimeTaken:    time.Duration(row.TimeTakenMs) * time.Millisecond,
+		ExpectedTime: item.EstimatedTime,
+	}
+	if row.Quality != nil {
+		attempt.ClientQuality = *row.Quality
+	}
+	if row.Confidence != nil {
+		attempt.Confidence = *row.Confidence
+	}
+	if row.HintsUsed != nil {
+		attempt.HintsUsed = *row.HintsUsed
+	}
+	assessment := e.inferrer.Assess(attempt)
+
+	return Attempt{
+		Item:           item,
+		At:             row.Timestamp,
+		Quality:        assessment.Quality,
+		Correct:        row.Correct,
+		EvidenceWeight: assessment.EvidenceWeight,
+	}
+}
+
+// Diff compares a run's shadow states with the live states, per table
+func (e *Engine) Diff(ctx context.Context, runID string) (*Diff, error) {
+	run, err := e.repository.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := e.repository.Diff(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return &Diff{Run: run, Tables: tables}, nil
+}
+
+// Swap replaces the live states of a replayed run's users with the rebuilt ones, user
+// by user. Users who made attempts after the run started are skipped, so no attempt is
+// lost; replay them again in a new run.
+func (e *Engine) Swap(ctx context.Context, runID string) (*Run, error) {
+	run, err := e.repository.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != StatusReplayed {
+		return nil, ErrRunNotSwappable
+	}
+
+	swapped, skipped := 0, 0
+	err = forEachPage(func(after string) ([]string, error) {
+		return e.repository.ShadowUsers(ctx, runID, after, userBatchSize)
+	}, func(userIDs []string) error {
+		for _, userID := range userIDs {
+			ok, err := e.repository.SwapUser(ctx, runID, userID, run.StartedAt)
+			if err != nil {
+				return fmt.Errorf("failed to swap user %s: %w", userID, err)
+			}
+			if !ok {
+				skipped++
+				continue
+			}
+			swapped++
+			e.invalidateUserCache(ctx, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		// Users swapped so far keep their rebuilt states; swapping again upserts them again
+		return nil, err
+	}
+
+	if err := e.repository.FinishSwap(ctx, runID, swapped, skipped); err != nil {
+		return nil, err
+	}
+
+	e.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"run_id":        runID,
+		"users_swapped": swapped,
+		"users_skipped": skipped,
+	}).Info("Swapped replayed states")
+
+	return e.repository.GetRun(ctx, runID)
+}
+
+// Discard drops a run's shadow states without swapping them in
+func (e *Engine) Discard(ctx context.Context, runID string) error {
+	run, err := e.repository.GetRun(ctx, runID)
+	if err != nil {
+		return err
+	}
+	if run.Status == StatusSwapped {
+		return ErrRunSwapped
+	}
+	return e.repository.DiscardRun(ctx, runID)
+}
+
+// Runs returns the latest runs, newest first
+func (e *Engine) Runs(ctx context.Context, limit int) ([]Run, error) {
+	return e.repository.ListRuns(ctx, limit)
+}
+
+// invalidateUserCache drops a user's cached SM-2, BKT and IRT states after a swap
+func (e *Engine) invalidateUserCache(ctx context.Context, userID string) {
+	if e.cache == nil {
+		return
+	}
+
+	patterns := []string{
+		"sm2:" + userID + ":*",
+		"sm2:user:" + userID + ":*",
+		"bkt:" + userID + ":*",
+		"irt_state:" + userID + ":*",
+	}
+	for _, pattern := range patterns {
+		if _, err := e.cache.DeletePattern(ctx, pattern); err != nil {
+			e.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to invalidate replayed states in cache")
+		}
+	}
+	if err := e.cache.InvalidateUser(ctx, userID); err != nil {
+		e.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to invalidate user cache")
+	}
+}
+
+// forEachPage pages through user IDs in ascending order until a page comes back empty
+func forEachPage(page func(after string) ([]string, error), visit func(userIDs []string) error) error {
+	after := ""
+	for {
+		userIDs, err := page(after)
+		if err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+		if err := visit(userIDs); err != nil {
+			return err
+		}
+		after = userIDs[len(userIDs)-1]
+	}
+}
@@ -0,0 +1,134 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/catalog"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/quality"
+)
+
+// staticCatalog is a fixed item catalog
+type staticCatalog map[string]catalog.Item
+
+func (c staticCatalog) Refresh(ctx context.Context) {}
+
+func (c staticCatalog) Len() int { return len(c) }
+
+func (c staticCatalog) Lookup(itemID string) (catalog.Item, bool) {
+	item, ok := c[itemID]
+	return item, ok
+}
+
+func newTestEngine(itemCatalog ItemCatalog) *Engine {
+	inferrer := quality.NewInferrer(&config.QualityConfig{
+		Source:              quality.SourceInferred,
+		FastRatio:           0.5,
+		SlowRatio:           1.5,
+		HintPenalty:         1,
+		LowConfidence:       2,
+		HighConfidence:      4,
+		GuessEvidenceWeight: 0.5,
+		HintEvidenceDecay:   0.5,
+		MinEvidenceWeight:   0.1,
+	})
+	return NewEngine(logger.New(&config.LoggingConfig{Level: "error", Format: "text"}),
+		nil, nil, itemCatalog, nil, inferrer, newTestReplayer())
+}
+
+func TestEngine_ScoresAttemptsAsRecorded(t *testing.T) {
+	item := roadSign
+	item.EstimatedTime = 20 * time.Second
+	engine := newTestEngine(staticCatalog{item.ID: item})
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	lowConfidence := 1
+	hints := 1
+
+	fast := engine.scoreAttempt(AttemptRow{ItemID: item.ID, Correct: true, TimeTakenMs: 5000, Timestamp: at})
+	if fast.Quality != 5 || fast.EvidenceWeight != 1 {
+		t.Errorf("expected a fast answer to score 5 at full weight, got %d at %.2f", fast.Quality, fast.EvidenceWeight)
+	}
+	if !fast.At.Equal(at) || fast.Item.ID != item.ID {
+		t.Errorf("expected the attempt of %s at %v, got %s at %v", item.ID, at, fast.Item.ID, fast.At)
+	}
+
+	guessed := engine.scoreAttempt(AttemptRow{
+		ItemID: item.ID, Correct: true, TimeTakenMs: 15000, Confidence: &lowConfidence, HintsUsed: &hints, Timestamp: at,
+	})
+	if guessed.Quality >= fast.Quality {
+		t.Errorf("expected a hinted guess to score below %d, got %d", fast.Quality, guessed.Quality)
+	}
+	if guessed.EvidenceWeight >= 1 {
+		t.Errorf("expected a hinted guess to count as partial evidence, got %.2f", guessed.EvidenceWeight)
+	}
+}
+
+func TestEngine_ScoresRemovedItemsAsUncatalogued(t *testing.T) {
+	engine := newTestEngine(staticCatalog{roadSign.ID: roadSign})
+
+	attempt := engine.scoreAttempt(AttemptRow{ItemID: "10000000-0000-4000-8000-000000000009", Correct: false})
+	if attempt.Item.Discrimination != 1.0 || len(attempt.Item.Topics) != 0 {
+		t.Errorf("expected placeholder parameters for a removed item, got %+v", attempt.Item)
+	}
+	if attempt.Quality > 2 {
+		t.Errorf("expected a wrong answer to score at most 2, got %d", attempt.Quality)
+	}
+}
+
+func TestEngine_RunValidatesScope(t *testing.T) {
+	ctx := context.Background()
+
+	engine := newTestEngine(staticCatalog{roadSign.ID: roadSign})
+	if _, err := engine.Run(ctx, Scope{}); !errors.Is(err, ErrNoUsers) {
+		t.Errorf("expected ErrNoUsers, got %v", err)
+	}
+	if _, err := engine.Run(ctx, Scope{UserIDs: []string{"not-a-uuid"}}); !errors.Is(err, ErrInvalidUserID) {
+		t.Errorf("expected ErrInvalidUserID, got %v", err)
+	}
+
+	empty := newTestEngine(staticCatalog{})
+	if _, err := empty.Run(ctx, Scope{All: true}); !errors.Is(err, ErrCatalogEmpty) {
+		t.Errorf("expected ErrCatalogEmpty, got %v", err)
+	}
+}
+
+func TestForEachPage(t *testing.T) {
+	pages := map[string][]string{
+		"":  {"a", "b"},
+		"b": {"c"},
+		"c": nil,
+	}
+	var afters, visited []string
+
+	err := forEachPage(func(after string) ([]string, error) {
+		afters = append(afters, after)
+		return pages[after], nil
+	}, func(userIDs []string) error {
+		visited = append(visited, userIDs...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(visited, []string{"a", "b", "c"}) {
+		t.Errorf("expected to visit a, b, c, got %v", visited)
+	}
+	if !reflect.DeepEqual(afters, []string{"", "b", "c"}) {
+		t.Errorf("expected pages after \"\", b, c, got %v", afters)
+	}
+
+	visitErr := errors.New("visit failed")
+	err = forEachPage(func(after string) ([]string, error) {
+		return pages[after], nil
+	}, func(userIDs []string) error {
+		return visitErr
+	})
+	if !errors.Is(err, visitErr) {
+		t.Errorf("expected the visit error, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package replay
+
+import (
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/catalog"
+)
+
+// generalTopic is the topic of items without topics, as for live attempts
+const generalTopic = "general"
+
+// Attempt is an answer to replay, already scored
+type Attempt struct {
+	Item           catalog.Item
+	At             time.Time
+	Quality        int // SM-2 quality, 0-5
+	Correct        bool
+	EvidenceWeight float64 // Weight of the attempt as BKT evidence, in [0, 1]
+}
+
+// State is a user's SM-2, BKT and IRT states rebuilt from their attempts
+type State struct {
+	SM2 map[string]*algorithms.SM2State // By item ID
+	BKT map[string]*algorithms.BKTState // By topic
+	IRT map[string]*algorithms.IRTState // By topic
+}
+
+// NewState creates the state of a user without attempts
+func NewState() *State {
+	return &State{
+		SM2: make(map[string]*algorithms.SM2State),
+		BKT: make(map[string]*algorithms.BKTState),
+		IRT: make(map[string]*algorithms.IRTState),
+	}
+}
+
+// Replayer applies past attempts with the updates RecordAttempt makes, dating the
+// states by the attempts rather than the time of the replay
+type Replayer struct {
+	sm2Algorithm *algorithms.SM2Algorithm
+	bktAlgorithm *algorithms.BKTAlgorithm
+	irtAlgorithm *algorithms.IRTAlgorithm
+}
+
+// NewReplayer creates a new attempt replayer
+func NewReplayer(sm2Algorithm *algorithms.SM2Algorithm, bktAlgorithm *algorithms.BKTAlgorithm, irtAlgorithm *algorithms.IRTAlgorithm) *Replayer {
+	return &Replayer{
+		sm2Algorithm: sm2Algorithm,
+		bktAlgorithm: bktAlgorithm,
+		irtAlgorithm: irtAlgorithm,
+	}
+}
+
+// Apply updates a user's states with an attempt. Attempts must be applied in
+// chronological order. SM-2 intervals are capped for the exam date unless it is zero.
+func (r *Replayer) Apply(state *State, attempt Attempt, examDate time.Time) {
+	sm2State, ok := state.SM2[attempt.Item.ID]
+	if !ok {
+		sm2State = r.sm2Algorithm.InitializeState()
+	}
+	sm2State = r.sm2Algorithm.UpdateState(sm2State, attempt.Quality)
+	sm2State.LastReviewed = attempt.At
+	sm2State.NextDue = attempt.At.AddDate(0, 0, sm2State.Interval)
+	state.SM2[attempt.Item.ID] = r.sm2Algorithm.CapIntervalForExam(sm2State, examDate)
+
+	itemParams := &algorithms.ItemParameters{
+		Difficulty:     attempt.Item.Difficulty,
+		Discrimination: attempt.Item.Discrimination,
+		Guessing:       attempt.Item.Guessing,
+	}
+	topics := attempt.Item.Topics
+	if len(topics) == 0 {
+		topics = []string{generalTopic}
+	}
+	for _, topic := range topics {
+		bktState, ok := state.BKT[topic]
+		if !ok {
+			bktState = r.bktAlgorithm.InitializeState(topic)
+		}
+		bktState = r.bktAlgorithm.UpdateStateWeighted(bktState, attempt.Correct, attempt.EvidenceWeight)
+		bktState.LastUpdated = attempt.At
+		state.BKT[topic] = bktState
+
+		irtState, ok := state.IRT[topic]
+		if !ok {
+			irtState = r.irtAlgorithm.InitializeState(topic)
+		}
+		irtState = r.irtAlgorithm.UpdateAbility(irtState, itemParams, attempt.Correct)
+		irtState.LastUpdated = attempt.At
+		state.IRT[topic] = irtState
+	}
+}
@@ -0,0 +1,104 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/catalog"
+)
+
+func newTestReplayer() *Replayer {
+	return NewReplayer(algorithms.NewSM2Algorithm(), algorithms.NewBKTAlgorithm(), algorithms.NewIRTAlgorithm())
+}
+
+var roadSign = catalog.Item{
+	ID: "10000000-0000-4000-8000-000000000001", Topics: []string{"road_signs", "priority"},
+	Discrimination: 1.0, Guessing: 0.25,
+}
+
+func TestReplayer_DatesStatesByAttempts(t *testing.T) {
+	replayer := newTestReplayer()
+	state := NewState()
+	first := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 1)
+
+	replayer.Apply(state, Attempt{Item: roadSign, At: first, Quality: 5, Correct: true, EvidenceWeight: 1}, time.Time{})
+	replayer.Apply(state, Attempt{Item: roadSign, At: second, Quality: 5, Correct: true, EvidenceWeight: 1}, time.Time{})
+
+	sm2 := state.SM2[roadSign.ID]
+	if sm2 == nil {
+		t.Fatal("expected an SM-2 state for the item")
+	}
+	if sm2.Repetition != 2 {
+		t.Errorf("expected 2 repetitions, got %d", sm2.Repetition)
+	}
+	if !sm2.LastReviewed.Equal(second) {
+		t.Errorf("expected last review at %v, got %v", second, sm2.LastReviewed)
+	}
+	if want := second.AddDate(0, 0, sm2.Interval); !sm2.NextDue.Equal(want) {
+		t.Errorf("expected next due %v, got %v", want, sm2.NextDue)
+	}
+
+	for _, topic := range roadSign.Topics {
+		if state.BKT[topic] == nil || !state.BKT[topic].LastUpdated.Equal(second) {
+			t.Errorf("expected BKT state of %s updated at %v", topic, second)
+		}
+		if state.IRT[topic] == nil || !state.IRT[topic].LastUpdated.Equal(second) {
+			t.Errorf("expected IRT state of %s updated at %v", topic, second)
+		}
+	}
+}
+
+func TestReplayer_ItemsWithoutTopicsCountTowardsGeneral(t *testing.T) {
+	replayer := newTestReplayer()
+	state := NewState()
+
+	replayer.Apply(state, Attempt{
+		Item: catalog.Item{ID: "10000000-0000-4000-8000-000000000002", Discrimination: 1.0},
+		At:   time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), Quality: 4, Correct: true, EvidenceWeight: 1,
+	}, time.Time{})
+
+	if _, ok := state.BKT[generalTopic]; !ok {
+		t.Errorf("expected a BKT state for the %s topic", generalTopic)
+	}
+	if _, ok := state.IRT[generalTopic]; !ok {
+		t.Errorf("expected an IRT state for the %s topic", generalTopic)
+	}
+}
+
+func TestReplayer_CapsIntervalsForExam(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	examDate := start.AddDate(0, 0, 10)
+
+	uncapped, capped := NewState(), NewState()
+	replayer := newTestReplayer()
+	for day := 0; day < 4; day++ {
+		attempt := Attempt{Item: roadSign, At: start.AddDate(0, 0, day), Quality: 5, Correct: true, EvidenceWeight: 1}
+		replayer.Apply(uncapped, attempt, time.Time{})
+		replayer.Apply(capped, attempt, examDate)
+	}
+
+	if capped.SM2[roadSign.ID].NextDue.After(examDate) {
+		t.Errorf("expected next review before the exam on %v, got %v", examDate, capped.SM2[roadSign.ID].NextDue)
+	}
+	if capped.SM2[roadSign.ID].Interval >= uncapped.SM2[roadSign.ID].Interval {
+		t.Errorf("expected the exam to shorten the interval of %d days, got %d",
+			uncapped.SM2[roadSign.ID].Interval, capped.SM2[roadSign.ID].Interval)
+	}
+}
+
+func TestReplayer_WeighsBKTEvidence(t *testing.T) {
+	replayer := newTestReplayer()
+	full, weak := NewState(), NewState()
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	replayer.Apply(full, Attempt{Item: roadSign, At: at, Quality: 4, Correct: true, EvidenceWeight: 1}, time.Time{})
+	replayer.Apply(weak, Attempt{Item: roadSign, At: at, Quality: 4, Correct: true, EvidenceWeight: 0.25}, time.Time{})
+
+	topic := roadSign.Topics[0]
+	if weak.BKT[topic].ProbKnowledge >= full.BKT[topic].ProbKnowledge {
+		t.Errorf("expected weak evidence to raise mastery less: %.3f vs %.3f",
+			weak.BKT[topic].ProbKnowledge, full.BKT[topic].ProbKnowledge)
+	}
+}
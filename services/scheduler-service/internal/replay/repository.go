@@ -0,0 +1,388 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// ErrRunNotFound is returned for unknown replay run IDs
+var ErrRunNotFound = errors.New("replay run not found")
+
+// Run statuses
+const (
+	StatusRunning   = "running"
+	StatusReplayed  = "replayed"
+	StatusFailed    = "failed"
+	StatusSwapped   = "swapped"
+	StatusDiscarded = "discarded"
+)
+
+// Run scopes
+const (
+	ScopeUsers = "users"
+	ScopeAll   = "all"
+)
+
+// Run is a replay of the attempts table into the shadow tables
+type Run struct {
+	ID               string     `json:"id"`
+	Scope            string     `json:"scope"`
+	Status           string     `json:"status"`
+	UsersReplayed    int        `json:"users_replayed"`
+	AttemptsReplayed int64      `json:"attempts_replayed"`
+	UsersSwapped     int        `json:"users_swapped"`
+	UsersSkipped     int        `json:"users_skipped"`
+	Error            *string    `json:"error,omitempty"`
+	StartedAt        time.Time  `json:"started_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+	SwappedAt        *time.Time `json:"swapped_at,omitempty"`
+}
+
+// AttemptRow is an attempt of the shared attempts table as replayed
+type AttemptRow struct {
+	ItemID      string
+	Correct     bool
+	Quality     *int
+	Confidence  *int
+	TimeTakenMs int
+	HintsUsed   *int
+	Timestamp   time.Time
+}
+
+// Repository reads the shared attempts table and stores replay runs and the states
+// they rebuild in the shadow tables
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new replay repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateRun records the start of a replay run
+func (r *Repository) CreateRun(ctx context.Context, scope string) (*Run, error) {
+	var run Run
+	err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO replay_runs (scope, status, started_at)
+		VALUES (?, ?, NOW())
+		RETURNING id::text AS id, scope, status, started_at`, scope, StatusRunning).
+		Scan(&run).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replay run: %w", err)
+	}
+	return &run, nil
+}
+
+// GetRun returns a replay run, or ErrRunNotFound
+func (r *Repository) GetRun(ctx context.Context, runID string) (*Run, error) {
+	var runs []Run
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT id::text AS id, scope, status, users_replayed, attempts_replayed, users_swapped,
+			users_skipped, error, started_at, finished_at, swapped_at
+		FROM replay_runs
+		WHERE id::text = ?`, runID).
+		Scan(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replay run: %w", err)
+	}
+	if len(runs) == 0 {
+		return nil, ErrRunNotFound
+	}
+	return &runs[0], nil
+}
+
+// ListRuns returns the latest replay runs, newest first
+func (r *Repository) ListRuns(ctx context.Context, limit int) ([]Run, error) {
+	var runs []Run
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT id::text AS id, scope, status, users_replayed, attempts_replayed, users_swapped,
+			users_skipped, error, started_at, finished_at, swapped_at
+		FROM replay_runs
+		ORDER BY started_at DESC
+		LIMIT ?`, limit).
+		Scan(&runs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replay runs: %w", err)
+	}
+	return runs, nil
+}
+
+// FinishRun records the outcome of the replay phase of a run
+func (r *Repository) FinishRun(ctx context.Context, runID, status string, usersReplayed int, attemptsReplayed int64, runErr error) error {
+	var message *string
+	if runErr != nil {
+		text := runErr.Error()
+		message = &text
+	}
+
+	err := r.db.WithContext(ctx).Exec(`
+		UPDATE replay_runs
+		SET status = ?, users_replayed = ?, attempts_replayed = ?, error = ?, finished_at = NOW()
+		WHERE id::text = ?`, status, usersReplayed, attemptsReplayed, message, runID).Error
+	if err != nil {
+		return fmt.Errorf("failed to finish replay run: %w", err)
+	}
+	return nil
+}
+
+// UsersWithAttempts returns up to limit users having attempts, ordered by ID and
+// starting after a user ID, to page through everyone
+func (r *Repository) UsersWithAttempts(ctx context.Context, after string, limit int) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT user_id::text
+		FROM attempts
+		WHERE user_id::text > ?
+		ORDER BY user_id::text
+		LIMIT ?`, after, limit).
+		Scan(&userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users with attempts: %w", err)
+	}
+	return userIDs, nil
+}
+
+// Attempts returns a user's attempts made up to a time, oldest first
+func (r *Repository) Attempts(ctx context.Context, userID string, until time.Time) ([]AttemptRow, error) {
+	var attempts []AttemptRow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT item_id::text AS item_id, correct, quality, confidence, time_taken_ms, hints_used,
+			COALESCE(timestamp, created_at) AS timestamp
+		FROM attempts
+		WHERE user_id = ? AND created_at <= ?
+		ORDER BY COALESCE(timestamp, created_at), created_at, id`, userID, until).
+		Scan(&attempts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// SaveShadowStates replaces a user's rebuilt states of a run in the shadow tables.
+// BKT and IRT states are stored in the given jurisdiction.
+func (r *Repository) SaveShadowStates(ctx context.Context, runID, userID, jurisdiction string, state *State) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"sm2_states_shadow", "bkt_states_shadow", "irt_states_shadow"} {
+			if err := tx.Exec(`DELETE FROM `+table+` WHERE run_id::text = ? AND user_id = ?`, runID, userID).Error; err != nil {
+				return fmt.Errorf("failed to clear %s: %w", table, err)
+			}
+		}
+
+		for itemID, s := range state.SM2 {
+			err := tx.Exec(`
+				INSERT INTO sm2_states_shadow (run_id, user_id, item_id, easiness_factor, interval_days, repetition, next_due, last_reviewed, lapses)
+				VALUES (?::uuid, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				runID, userID, itemID, s.EasinessFactor, s.Interval, s.Repetition, s.NextDue, s.LastReviewed, s.Lapses).Error
+			if err != nil {
+				return fmt.Errorf("failed to save shadow SM-2 state: %w", err)
+			}
+		}
+		for topic, s := range state.BKT {
+			err := tx.Exec(`
+				INSERT INTO bkt_states_shadow (run_id, user_id, jurisdiction, topic, prob_knowledge, prob_guess, prob_slip, prob_learn, attempts_count, correct_count, confidence, last_updated)
+				VALUES (?::uuid, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				runID, userID, jurisdiction, topic, s.ProbKnowledge, s.ProbGuess, s.ProbSlip, s.ProbLearn, s.AttemptsCount, s.CorrectCount, s.Confidence, s.LastUpdated).Error
+			if err != nil {
+				return fmt.Errorf("failed to save shadow BKT state: %w", err)
+			}
+		}
+		for topic, s := range state.IRT {
+			err := tx.Exec(`
+				INSERT INTO irt_states_shadow (run_id, user_id, jurisdiction, topic, theta, theta_variance, confidence, attempts_count, correct_count, last_updated)
+				VALUES (?::uuid, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				runID, userID, jurisdiction, topic, s.Theta, s.ThetaVariance, s.Confidence, s.AttemptsCount, s.CorrectCount, s.LastUpdated).Error
+			if err != nil {
+				return fmt.Errorf("failed to save shadow IRT state: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// TableDiff compares the states of one shadow table with the live states they would replace
+type TableDiff struct {
+	Table        string  `json:"table"`
+	Metric       string  `json:"metric"` // Column the deltas are measured on
+	Rows         int64   `json:"rows"`
+	Added        int64   `json:"added"`     // States the user has no live state for
+	Changed      int64   `json:"changed"`   // Live states that differ from the rebuilt ones
+	Unchanged    int64   `json:"unchanged"` // Live states equal to the rebuilt ones
+	MeanAbsDelta float64 `json:"mean_abs_delta"`
+	MaxAbsDelta  float64 `json:"max_abs_delta"`
+}
+
+// diffQueries compare each shadow table with its live table on the state columns.
+// Deltas are measured on the metric column, over states the user already has.
+var diffQueries = []struct {
+	table  string
+	metric string
+	query  string
+}{
+	{"sm2_states", "easiness_factor", `
+		SELECT COUNT(*) AS rows,
+			COUNT(*) FILTER (WHERE l.user_id IS NULL) AS added,
+			COUNT(*) FILTER (WHERE l.user_id IS NOT NULL AND (l.easiness_factor, l.interval_days, l.repetition, l.next_due, l.lapses)
+				IS DISTINCT FROM (s.easiness_factor, s.interval_days, s.repetition, s.next_due, s.lapses)) AS changed,
+			COALESCE(AVG(ABS(s.easiness_factor - l.easiness_factor)), 0) AS mean_abs_delta,
+			COALESCE(MAX(ABS(s.easiness_factor - l.easiness_factor)), 0) AS max_abs_delta
+		FROM sm2_states_shadow s
+		LEFT JOIN sm2_states l ON l.user_id = s.user_id AND l.item_id = s.item_id
+		WHERE s.run_id::text = ?`},
+	{"bkt_states", "prob_knowledge", `
+		SELECT COUNT(*) AS rows,
+			COUNT(*) FILTER (WHERE l.user_id IS NULL) AS added,
+			COUNT(*) FILTER (WHERE l.user_id IS NOT NULL AND (l.prob_knowledge, l.attempts_count, l.correct_count, l.confidence)
+				IS DISTINCT FROM (s.prob_knowledge, s.attempts_count, s.correct_count, s.confidence)) AS changed,
+			COALESCE(AVG(ABS(s.prob_knowledge - l.prob_knowledge)), 0) AS mean_abs_delta,
+			COALESCE(MAX(ABS(s.prob_knowledge - l.prob_knowledge)), 0) AS max_abs_delta
+		FROM bkt_states_shadow s
+		LEFT JOIN bkt_states l ON l.user_id = s.user_id AND l.jurisdiction = s.jurisdiction AND l.topic = s.topic
+		WHERE s.run_id::text = ?`},
+	{"irt_states", "theta", `
+		SELECT COUNT(*) AS rows,
+			COUNT(*) FILTER (WHERE l.user_id IS NULL) AS added,
+			COUNT(*) FILTER (WHERE l.user_id IS NOT NULL AND (l.theta, l.theta_variance, l.attempts_count, l.correct_count)
+				IS DISTINCT FROM (s.theta, s.theta_variance, s.attempts_count, s.correct_count)) AS changed,
+			COALESCE(AVG(ABS(s.theta - l.theta)), 0) AS mean_abs_delta,
+			COALESCE(MAX(ABS(s.theta - l.theta)), 0) AS max_abs_delta
+		FROM irt_states_shadow s
+		LEFT JOIN irt_states l ON l.user_id = s.user_id AND l.jurisdiction = s.jurisdiction AND l.topic = s.topic
+		WHERE s.run_id::text = ?`},
+}
+
+// Diff compares a run's shadow states with the live states, per table
+func (r *Repository) Diff(ctx context.Context, runID string) ([]TableDiff, error) {
+	diffs := make([]TableDiff, 0, len(diffQueries))
+	for _, q := range diffQueries {
+		diff := TableDiff{Table: q.table, Metric: q.metric}
+		err := r.db.WithContext(ctx).Raw(q.query, runID).
+			Row().Scan(&diff.Rows, &diff.Added, &diff.Changed, &diff.MeanAbsDelta, &diff.MaxAbsDelta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s: %w", q.table, err)
+		}
+		diff.Unchanged = diff.Rows - diff.Added - diff.Changed
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// ShadowUsers returns up to limit users with shadow states in a run, ordered by ID and
+// starting after a user ID
+func (r *Repository) ShadowUsers(ctx context.Context, runID, after string, limit int) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT user_id::text FROM (
+			SELECT user_id FROM sm2_states_shadow WHERE run_id::text = ?
+			UNION
+			SELECT user_id FROM bkt_states_shadow WHERE run_id::text = ?
+		) u
+		WHERE user_id::text > ?
+		ORDER BY user_id::text
+		LIMIT ?`, runID, runID, after, limit).
+		Scan(&userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shadow users: %w", err)
+	}
+	return userIDs, nil
+}
+
+// SwapUser replaces a user's live states with their shadow states of a run, unless the
+// user made attempts after the run started, and reports whether it did. Live states
+// the run did not rebuild are kept.
+func (r *Repository) SwapUser(ctx context.Context, runID, userID string, startedAt time.Time) (bool, error) {
+	swapped := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var newer int64
+		err := tx.Raw(`SELECT COUNT(*) FROM attempts WHERE user_id = ? AND created_at > ?`, userID, startedAt).
+			Row().Scan(&newer)
+		if err != nil {
+			return fmt.Errorf("failed to check for newer attempts: %w", err)
+		}
+		if newer > 0 {
+			return nil
+		}
+
+		statements := []string{`
+			INSERT INTO sm2_states (user_id, item_id, easiness_factor, interval_days, repetition, next_due, last_reviewed, lapses)
+			SELECT user_id, item_id, easiness_factor, interval_days, repetition, next_due, last_reviewed, lapses
+			FROM sm2_states_shadow WHERE run_id::text = ? AND user_id = ?
+			ON CONFLICT (user_id, item_id) DO UPDATE SET
+				easiness_factor = EXCLUDED.easiness_factor, interval_days = EXCLUDED.interval_days,
+				repetition = EXCLUDED.repetition, next_due = EXCLUDED.next_due,
+				last_reviewed = EXCLUDED.last_reviewed, lapses = EXCLUDED.lapses`, `
+			INSERT INTO bkt_states (user_id, jurisdiction, topic, prob_knowledge, prob_guess, prob_slip, prob_learn, attempts_count, correct_count, confidence, last_updated)
+			SELECT user_id, jurisdiction, topic, prob_knowledge, prob_guess, prob_slip, prob_learn, attempts_count, correct_count, confidence, last_updated
+			FROM bkt_states_shadow WHERE run_id::text = ? AND user_id = ?
+			ON CONFLICT (user_id, jurisdiction, topic) DO UPDATE SET
+				prob_knowledge = EXCLUDED.prob_knowledge, prob_guess = EXCLUDED.prob_guess,
+				prob_slip = EXCLUDED.prob_slip, prob_learn = EXCLUDED.prob_learn,
+				attempts_count = EXCLUDED.attempts_count, correct_count = EXCLUDED.correct_count,
+				confidence = EXCLUDED.confidence, last_updated = EXCLUDED.last_updated`, `
+			INSERT INTO irt_states (user_id, jurisdiction, topic, theta, theta_variance, confidence, attempts_count, correct_count, last_updated)
+			SELECT user_id, jurisdiction, topic, theta, theta_variance, confidence, attempts_count, correct_count, last_updated
+			FROM irt_states_shadow WHERE run_id::text = ? AND user_id = ?
+			ON CONFLICT (user_id, jurisdiction, topic) DO UPDATE SET
+				theta = EXCLUDED.theta, theta_variance = EXCLUDED.theta_variance,
+				confidence = EXCLUDED.confidence, attempts_count = EXCLUDED.attempts_count,
+				correct_count = EXCLUDED.correct_count, last_updated = EXCLUDED.last_updated`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement, runID, userID).Error; err != nil {
+				return fmt.Errorf("failed to swap in shadow states: %w", err)
+			}
+		}
+
+		swapped = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return swapped, nil
+}
+
+// FinishSwap marks a run swapped and drops its shadow states
+func (r *Repository) FinishSwap(ctx context.Context, runID string, usersSwapped, usersSkipped int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.dropShadowStates(tx, runID); err != nil {
+			return err
+		}
+		err := tx.Exec(`
+			UPDATE replay_runs
+			SET status = ?, users_swapped = ?, users_skipped = ?, swapped_at = NOW()
+			WHERE id::text = ?`, StatusSwapped, usersSwapped, usersSkipped, runID).Error
+		if err != nil {
+			return fmt.Errorf("failed to finish replay swap: %w", err)
+		}
+		return nil
+	})
+}
+
+// DiscardRun marks a run discarded and drops its shadow states
+func (r *Repository) DiscardRun(ctx context.Context, runID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.dropShadowStates(tx, runID); err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE replay_runs SET status = ? WHERE id::text = ?`, StatusDiscarded, runID).Error; err != nil {
+			return fmt.Errorf("failed to discard replay run: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *Repository) dropShadowStates(tx *gorm.DB, runID string) error {
+	for _, table := range []string{"sm2_states_shadow", "bkt_states_shadow", "irt_states_shadow"} {
+		if err := tx.Exec(`DELETE FROM `+table+` WHERE run_id::text = ?`, runID).Error; err != nil {
+			return fmt.Errorf("failed to drop %s: %w", table, err)
+		}
+	}
+	return nil
+}
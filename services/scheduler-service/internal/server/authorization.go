@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/logger"
+	pb "scheduler-service/proto"
+)
+
+// publicMethods are served without authentication, for load balancer health checks
+var publicMethods = map[string]bool{
+	pb.SchedulerService_Health_FullMethodName:  true,
+	grpc_health_v1.Health_Check_FullMethodName: true,
+	grpc_health_v1.Health_Watch_FullMethodName: true,
+}
+
+// unscopedMethodScopes are the scopes service accounts need for methods whose requests
+// carry no user_id. An empty scope lets any authenticated caller, learners included,
+// use the method. Other methods without a user_id need auth.ScopeAdmin.
+var unscopedMethodScopes = map[string]string{
+	pb.SchedulerService_GetItemDifficulty_FullMethodName:      "",
+	pb.SchedulerService_GetAvailableStrategies_FullMethodName: "",
+	pb.SchedulerService_GetBanditMetrics_FullMethodName:       auth.ScopeReportsRead,
+	pb.SchedulerService_GetPlacementReport_FullMethodName:     auth.ScopeReportsRead,
+}
+
+// serviceOnlyMethodScopes are the scopes service accounts need for methods on a user
+// that learners cannot call themselves, overriding learners:read and learners:write
+var serviceOnlyMethodScopes = map[string]string{
+	pb.SchedulerService_DeleteUserLearningState_FullMethodName: auth.ScopeLearnersErase,
+}
+
+// tenantReportMethodScopes are the scopes service accounts need for reports on a
+// tenant's learners. Other callers must be instructors of the tenant, which the
+// handlers check.
+var tenantReportMethodScopes = map[string]string{
+	pb.SchedulerService_GetCohortMastery_FullMethodName:         auth.ScopeReportsRead,
+	pb.SchedulerService_GetLearnersAtRisk_FullMethodName:        auth.ScopeReportsRead,
+	pb.SchedulerService_GetFrequentlyFailedItems_FullMethodName: auth.ScopeReportsRead,
+}
+
+// userScopedRequest is implemented by requests that act on one user's learning state
+type userScopedRequest interface {
+	GetUserId() string
+}
+
+// authInterceptor authenticates every request except public ones and checks that the
+// caller may use the method on the requested user
+func authInterceptor(authenticator *auth.Authenticator, log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] || strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+			return handler(ctx, req)
+		}
+
+		principal, err := authenticator.Authenticate(ctx)
+		if err != nil {
+			log.WithContext(ctx).WithError(err).WithField("method", info.FullMethod).Debug("Rejected unauthenticated request")
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, status.Error(codes.Unauthenticated, "missing credentials")
+			}
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		}
+
+		if err := authorize(principal, info.FullMethod, req); err != nil {
+			log.WithContext(ctx).WithFields(map[string]interface{}{
+				"method":    info.FullMethod,
+				"principal": principal.Subject,
+				"service":   principal.Service,
+			}).Warn("Rejected unauthorized request")
+			return nil, err
+		}
+
+		return handler(auth.ContextWithPrincipal(ctx, principal), req)
+	}
+}
+
+// authorize checks that a principal may call a method with a request. Learners may only
+// act on their own user_id. Service accounts need learners:read for Get, List and
+// Export methods on a user and learners:write for the others, unless the method has
+// a service-only scope. Instructors' access to tenant reports is checked by the handlers.
+func authorize(principal *auth.Principal, fullMethod string, req interface{}) error {
+	if userReq, ok := req.(userScopedRequest); ok {
+		if scope, ok := serviceOnlyMethodScopes[fullMethod]; ok {
+			if !principal.Service || !principal.HasScope(scope) {
+				return status.Errorf(codes.PermissionDenied, "method requires scope %s", scope)
+			}
+			return nil
+		}
+
+		if !principal.Service {
+			if userReq.GetUserId() != principal.Subject {
+				return status.Error(codes.PermissionDenied, "not allowed to access another user's learning state")
+			}
+			return nil
+		}
+
+		scope := auth.ScopeLearnersWrite
+		if isReadMethod(fullMethod) {
+			scope = auth.ScopeLearnersRead
+		}
+		if !principal.HasScope(scope) {
+			return status.Errorf(codes.PermissionDenied, "service account lacks scope %s", scope)
+		}
+		return nil
+	}
+
+	if scope, ok := tenantReportMethodScopes[fullMethod]; ok {
+		if principal.Service && !principal.HasScope(scope) {
+			return status.Errorf(codes.PermissionDenied, "method requires scope %s", scope)
+		}
+		return nil
+	}
+
+	scope, ok := unscopedMethodScopes[fullMethod]
+	if !ok {
+		scope = auth.ScopeAdmin
+	}
+	if scope == "" {
+		return nil
+	}
+	if !principal.Service || !principal.HasScope(scope) {
+		return status.Errorf(codes.PermissionDenied, "method requires scope %s", scope)
+	}
+	return nil
+}
+
+// isReadMethod reports whether a method only reads state, by its name
+func isReadMethod(fullMethod string) bool {
+	name := path.Base(fullMethod)
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List") || strings.HasPrefix(name, "Export")
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"scheduler-service/internal/auth"
+	pb "scheduler-service/proto"
+)
+
+func TestAuthorize(t *testing.T) {
+	learner := &auth.Principal{Subject: "user-1"}
+	gateway := &auth.Principal{Subject: "gateway", Service: true, Scopes: []string{auth.ScopeLearnersRead}}
+	reporting := &auth.Principal{Subject: "content-service", Service: true, Scopes: []string{auth.ScopeReportsRead}}
+	privacy := &auth.Principal{Subject: "user-service", Service: true, Scopes: []string{auth.ScopeLearnersRead, auth.ScopeLearnersErase}}
+	admin := &auth.Principal{Subject: "admin-cli", Service: true, Scopes: []string{auth.ScopeAdmin}}
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		method    string
+		req       interface{}
+		allowed   bool
+	}{
+		{"learner on own state", learner, pb.SchedulerService_RecordAttempt_FullMethodName, &pb.AttemptRequest{UserId: "user-1"}, true},
+		{"learner on another user", learner, pb.SchedulerService_GetUserState_FullMethodName, &pb.GetUserStateRequest{UserId: "user-2"}, false},
+		{"learner reading item difficulty", learner, pb.SchedulerService_GetItemDifficulty_FullMethodName, &pb.GetItemDifficultyRequest{}, true},
+		{"learner reading placement reports", learner, pb.SchedulerService_GetPlacementReport_FullMethodName, &pb.GetPlacementReportRequest{}, false},
+		{"service reading any user", gateway, pb.SchedulerService_GetUserState_FullMethodName, &pb.GetUserStateRequest{UserId: "user-2"}, true},
+		{"service listing goals", gateway, pb.SchedulerService_ListLearningGoals_FullMethodName, &pb.ListLearningGoalsRequest{UserId: "user-2"}, true},
+		{"service writing without scope", gateway, pb.SchedulerService_RecordAttempt_FullMethodName, &pb.AttemptRequest{UserId: "user-2"}, false},
+		{"service reading reports", reporting, pb.SchedulerService_GetPlacementReport_FullMethodName, &pb.GetPlacementReportRequest{}, true},
+		{"service reading reports without scope", gateway, pb.SchedulerService_GetBanditMetrics_FullMethodName, &pb.GetBanditMetricsRequest{}, false},
+		{"learner exporting own state", learner, pb.SchedulerService_ExportUserLearningState_FullMethodName, &pb.ExportUserLearningStateRequest{UserId: "user-1"}, true},
+		{"service exporting any user", gateway, pb.SchedulerService_ExportUserLearningState_FullMethodName, &pb.ExportUserLearningStateRequest{UserId: "user-2"}, true},
+		{"learner deleting own state", learner, pb.SchedulerService_DeleteUserLearningState_FullMethodName, &pb.DeleteUserLearningStateRequest{UserId: "user-1"}, false},
+		{"service deleting without erase scope", gateway, pb.SchedulerService_DeleteUserLearningState_FullMethodName, &pb.DeleteUserLearningStateRequest{UserId: "user-2"}, false},
+		{"service deleting with erase scope", privacy, pb.SchedulerService_DeleteUserLearningState_FullMethodName, &pb.DeleteUserLearningStateRequest{UserId: "user-2"}, true},
+		{"learner importing review log", learner, pb.SchedulerService_ImportReviewLog_FullMethodName, &pb.ImportReviewLogRequest{}, false},
+		{"service importing without admin scope", privacy, pb.SchedulerService_ImportReviewLog_FullMethodName, &pb.ImportReviewLogRequest{}, false},
+		{"admin importing review log", admin, pb.SchedulerService_ImportReviewLog_FullMethodName, &pb.ImportReviewLogRequest{}, true},
+		{"learner upserting tenant", learner, pb.SchedulerService_UpsertTenant_FullMethodName, &pb.UpsertTenantRequest{}, false},
+		{"admin updating tenant members", admin, pb.SchedulerService_UpdateTenantMembers_FullMethodName, &pb.UpdateTenantMembersRequest{}, true},
+		{"instructor reading cohort mastery", learner, pb.SchedulerService_GetCohortMastery_FullMethodName, &pb.GetCohortMasteryRequest{TenantId: "school"}, true},
+		{"service reading learners at risk", reporting, pb.SchedulerService_GetLearnersAtRisk_FullMethodName, &pb.GetLearnersAtRiskRequest{TenantId: "school"}, true},
+		{"service reading failed items without scope", gateway, pb.SchedulerService_GetFrequentlyFailedItems_FullMethodName, &pb.GetFrequentlyFailedItemsRequest{TenantId: "school"}, false},
+		{"unlisted method without user", reporting, "/scheduler.SchedulerService/Unlisted", &pb.HealthRequest{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorize(tt.principal, tt.method, tt.req)
+			if tt.allowed && err != nil {
+				t.Errorf("Expected request to be allowed, got %v", err)
+			}
+			if !tt.allowed && status.Code(err) != codes.PermissionDenied {
+				t.Errorf("Expected PermissionDenied, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIsReadMethod(t *testing.T) {
+	if !isReadMethod(pb.SchedulerService_GetNextItems_FullMethodName) {
+		t.Error("Expected GetNextItems to be a read method")
+	}
+	if !isReadMethod(pb.SchedulerService_ListLearningGoals_FullMethodName) {
+		t.Error("Expected ListLearningGoals to be a read method")
+	}
+	if isReadMethod(pb.SchedulerService_SwitchJurisdiction_FullMethodName) {
+		t.Error("Expected SwitchJurisdiction not to be a read method")
+	}
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/cohort"
+	"scheduler-service/internal/tenant"
+	pb "scheduler-service/proto"
+)
+
+// GetCohortMastery returns the mastery heatmap of an organization: each topic's
+// mastery distribution and a page of its learners' mastery by topic
+func (s *SchedulerService) GetCohortMastery(ctx context.Context, req *pb.GetCohortMasteryRequest) (*pb.GetCohortMasteryResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"tenant":    req.TenantId,
+		"page_size": req.PageSize,
+	}).Info("Getting cohort mastery")
+
+	tenantID, err := s.cohortTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.cohorts.Mastery(ctx, tenantID, cohort.Page{Size: int(req.PageSize), Token: req.PageToken})
+	if err != nil {
+		return nil, s.cohortReportError(ctx, err, "failed to get cohort mastery")
+	}
+
+	resp := &pb.GetCohortMasteryResponse{
+		Topics:        make([]*pb.CohortTopicMastery, 0, len(report.Topics)),
+		Learners:      make([]*pb.CohortLearnerMastery, 0, len(report.Learners)),
+		TotalLearners: int32(report.TotalLearners),
+		NextPageToken: report.NextPageToken,
+		RefreshedAt:   timestamppb.New(report.RefreshedAt),
+	}
+	for _, topic := range report.Topics {
+		distribution := make([]int32, len(topic.Distribution))
+		for i, learners := range topic.Distribution {
+			distribution[i] = int32(learners)
+		}
+		resp.Topics = append(resp.Topics, &pb.CohortTopicMastery{
+			Topic:        topic.Topic,
+			Learners:     int32(topic.Learners),
+			MeanMastery:  topic.MeanMastery,
+			Mastered:     int32(topic.Mastered),
+			Struggling:   int32(topic.Struggling),
+			Distribution: distribution,
+		})
+	}
+	for _, learner := range report.Learners {
+		resp.Learners = append(resp.Learners, &pb.CohortLearnerMastery{
+			UserId:       learner.UserID,
+			MeanMastery:  learner.MeanMastery,
+			TopicMastery: learner.TopicMastery,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetLearnersAtRisk returns a page of an organization's learners at risk of falling
+// behind, riskiest first, with the reasons they are at risk
+func (s *SchedulerService) GetLearnersAtRisk(ctx context.Context, req *pb.GetLearnersAtRiskRequest) (*pb.GetLearnersAtRiskResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"tenant":         req.TenantId,
+		"page_size":      req.PageSize,
+		"min_risk_score": req.MinRiskScore,
+	}).Info("Getting learners at risk")
+
+	// Validate request
+	if req.MinRiskScore < 0 || req.MinRiskScore > 1 {
+		return nil, status.Error(codes.InvalidArgument, "min_risk_score must be between 0 and 1")
+	}
+
+	tenantID, err := s.cohortTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.cohorts.AtRisk(ctx, tenantID, req.MinRiskScore, cohort.Page{Size: int(req.PageSize), Token: req.PageToken})
+	if err != nil {
+		return nil, s.cohortReportError(ctx, err, "failed to get learners at risk")
+	}
+
+	resp := &pb.GetLearnersAtRiskResponse{
+		Learners:      make([]*pb.LearnerRisk, 0, len(report.Learners)),
+		NextPageToken: report.NextPageToken,
+		RefreshedAt:   timestamppb.New(report.RefreshedAt),
+	}
+	for _, learner := range report.Learners {
+		resp.Learners = append(resp.Learners, &pb.LearnerRisk{
+			UserId:         learner.UserID,
+			RiskScore:      learner.RiskScore,
+			Reasons:        learner.RiskReasons,
+			MeanMastery:    learner.MeanMastery,
+			WeakestTopic:   learner.WeakestTopic,
+			WeakestMastery: learner.WeakestMastery,
+			OverdueItems:   int32(learner.OverdueItems),
+			RecentAccuracy: learner.RecentAccuracy(),
+			LastActiveAt:   optionalTimestamp(learner.LastActiveAt),
+			ExamDate:       optionalTimestamp(learner.ExamDate),
+		})
+	}
+
+	return resp, nil
+}
+
+// GetFrequentlyFailedItems returns a page of the items an organization's learners
+// failed within the activity window, most failures first
+func (s *SchedulerService) GetFrequentlyFailedItems(ctx context.Context, req *pb.GetFrequentlyFailedItemsRequest) (*pb.GetFrequentlyFailedItemsResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"tenant":    req.TenantId,
+		"page_size": req.PageSize,
+	}).Info("Getting frequently failed items")
+
+	tenantID, err := s.cohortTenantID(ctx, req.TenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.cohorts.FailedItems(ctx, tenantID, cohort.Page{Size: int(req.PageSize), Token: req.PageToken})
+	if err != nil {
+		return nil, s.cohortReportError(ctx, err, "failed to get frequently failed items")
+	}
+
+	resp := &pb.GetFrequentlyFailedItemsResponse{
+		Items:         make([]*pb.FailedItem, 0, len(report.Items)),
+		NextPageToken: report.NextPageToken,
+		RefreshedAt:   timestamppb.New(report.RefreshedAt),
+	}
+	for _, item := range report.Items {
+		resp.Items = append(resp.Items, &pb.FailedItem{
+			ItemId:         item.ItemID,
+			Attempts:       int32(item.Attempts),
+			Failures:       int32(item.Failures),
+			FailureRate:    item.FailureRate,
+			Learners:       int32(item.Learners),
+			LearnersFailed: int32(item.LearnersFailed),
+			LastFailedAt:   optionalTimestamp(item.LastFailedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+// cohortTenantID returns the tenant a cohort report is requested for, from the request
+// or else the tenant the request is made within, and checks that the caller may view
+// it. Service accounts are authorized by scope; other callers must be instructors of
+// the tenant. Unauthenticated callers are rejected even when authentication is off.
+func (s *SchedulerService) cohortTenantID(ctx context.Context, requested string) (string, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "cohort reports require an authenticated caller")
+	}
+
+	tenantID, err := requestTenantID(ctx)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case requested == "" && tenantID == "":
+		return "", status.Error(codes.InvalidArgument, "tenant_id is required")
+	case requested == "":
+		requested = tenantID
+	case tenantID != "" && requested != tenantID:
+		return "", status.Error(codes.PermissionDenied, "tenant_id does not match the tenant of the request")
+	}
+
+	if _, err := s.tenants.Get(ctx, requested); err != nil {
+		return "", status.Error(codes.NotFound, "unknown tenant")
+	}
+
+	if principal.Service {
+		return requested, nil
+	}
+	member, err := s.tenants.Member(ctx, requested, principal.Subject)
+	if err != nil && !errors.Is(err, tenant.ErrNotMember) {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to check tenant membership")
+		return "", status.Error(codes.Internal, "failed to check tenant membership")
+	}
+	if member == nil || member.Role != tenant.RoleInstructor {
+		s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+			"tenant":    requested,
+			"principal": principal.Subject,
+		}).Warn("Rejected cohort report for a caller who is not an instructor of the tenant")
+		return "", status.Error(codes.PermissionDenied, "only instructors of the tenant can view cohort reports")
+	}
+	return requested, nil
+}
+
+// cohortReportError maps an error of a cohort report to a gRPC status
+func (s *SchedulerService) cohortReportError(ctx context.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, cohort.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, "invalid page_token")
+	case errors.Is(err, cohort.ErrNotRefreshed):
+		return status.Error(codes.FailedPrecondition, "cohort summaries have not been refreshed yet")
+	}
+	s.logger.WithContext(ctx).WithError(err).Error("Failed to get cohort report")
+	return status.Error(codes.Internal, message)
+}
@@ -0,0 +1,17 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCohortTenantID_RequiresPrincipal(t *testing.T) {
+	s := &SchedulerService{}
+	_, err := s.cohortTenantID(context.Background(), "school-1")
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a principal, got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/events"
+	"scheduler-service/internal/logger"
+)
+
+// newTestSchedulerService builds a scheduler service with its real state managers over
+// an in-memory database and Redis, so request paths run without Postgres or Redis
+func newTestSchedulerService(t *testing.T, tables map[string]*fakeTable) *SchedulerService {
+	t.Helper()
+
+	redisURL := startFakeRedis(t)
+	cfg, err := config.Parse(config.Values{
+		"DATABASE_URL":           "postgres://fake",
+		"REDIS_URL":              redisURL,
+		"REDIS_LOCAL_CACHE_SIZE": "0",
+		"LOG_LEVEL":              "error",
+		"LOG_FORMAT":             "text",
+	})
+	if err != nil {
+		t.Fatalf("invalid configuration: %v", err)
+	}
+	log := logger.New(&cfg.Logging)
+	metricsInstance := sharedBenchMetrics()
+
+	redisClient, err := cache.New(&cfg.Redis, metricsInstance, log)
+	if err != nil {
+		t.Fatalf("failed to connect to fake Redis: %v", err)
+	}
+	t.Cleanup(func() { redisClient.Close() })
+
+	return NewSchedulerService(cfg, log, metricsInstance, newFakeDB(t, tables), redisClient, nil, events.NewNoOpEventPublisher(), nil)
+}
+
+// newFakeDB opens a database answering queries on the given tables from their content
+func newFakeDB(t *testing.T, tables map[string]*fakeTable) *database.DB {
+	t.Helper()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(&fakeConnector{tables: tables})}), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	return &database.DB{DB: gormDB}
+}
+
+// fakeTable is the content of a table the fake database answers queries on it with,
+// and the updates made to it
+type fakeTable struct {
+	columns []string
+	rows    [][]driver.Value
+
+	mu      sync.Mutex
+	updates []string
+}
+
+// Updates returns the UPDATE statements run on the table
+func (t *fakeTable) Updates() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.updates...)
+}
+
+// fakeConnector is a database/sql connector answering queries on a table from its
+// fake content and every other query with no rows
+type fakeConnector struct {
+	tables map[string]*fakeTable
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{c.tables}, nil
+}
+func (c *fakeConnector) Driver() driver.Driver            { return c }
+func (c *fakeConnector) Open(string) (driver.Conn, error) { return &fakeConn{c.tables}, nil }
+
+type fakeConn struct {
+	tables map[string]*fakeTable
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+func (c *fakeConn) query(query string) driver.Rows {
+	for name, table := range c.tables {
+		if strings.Contains(query, name) {
+			return &fakeRows{columns: table.columns, rows: table.rows}
+		}
+	}
+	return &fakeRows{}
+}
+
+func (c *fakeConn) exec(query string) driver.Result {
+	if strings.HasPrefix(strings.TrimSpace(query), "UPDATE") {
+		for name, table := range c.tables {
+			if strings.Contains(query, name) {
+				table.mu.Lock()
+				table.updates = append(table.updates, query)
+				table.mu.Unlock()
+			}
+		}
+	}
+	return driver.RowsAffected(0)
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error                               { return nil }
+func (s *fakeStmt) NumInput() int                              { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) { return s.conn.exec(s.query), nil }
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return s.conn.query(s.query), nil }
+func (s *fakeStmt) CheckNamedValue(*driver.NamedValue) error   { return nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+// startFakeRedis serves the Redis commands of the cache from memory and returns its URL
+func startFakeRedis(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen for fake Redis: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	store := &fakeRedis{values: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go store.serve(conn)
+		}
+	}()
+
+	return "redis://" + listener.Addr().String()
+}
+
+type fakeRedis struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, f.execute(args)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) execute(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		value, ok := f.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		f.values[args[1]] = args[2]
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := f.values[key]; ok {
+				delete(f.values, key)
+				deleted++
+			}
+		}
+		return ":" + strconv.Itoa(deleted) + "\r\n"
+	case "PUBLISH":
+		return ":0\r\n"
+	}
+	return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+// readCommand reads a command sent as a RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "*")))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid command header %q", header)
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk string header %q", line)
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"scheduler-service/internal/goals"
+	pb "scheduler-service/proto"
+)
+
+// CreateLearningGoal creates a learning goal and evaluates it against the user's current mastery
+func (s *SchedulerService) CreateLearningGoal(ctx context.Context, req *pb.CreateLearningGoalRequest) (*pb.CreateLearningGoalResponse, error) {
+	s.logger.WithContext(ctx).WithField("user_id", req.UserId).Info("Creating learning goal")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Goal == nil {
+		return nil, status.Error(codes.InvalidArgument, "goal is required")
+	}
+
+	goal, err := s.goalsService.CreateGoal(ctx, req.UserId, convertGoalDefinitionFromProto(req.Goal))
+	if err != nil {
+		return nil, s.goalError(ctx, err, "failed to create learning goal")
+	}
+
+	return &pb.CreateLearningGoalResponse{Goal: convertLearningGoalToProto(goal)}, nil
+}
+
+// GetLearningGoal returns one of the user's learning goals
+func (s *SchedulerService) GetLearningGoal(ctx context.Context, req *pb.GetLearningGoalRequest) (*pb.GetLearningGoalResponse, error) {
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.GoalId == "" {
+		return nil, status.Error(codes.InvalidArgument, "goal_id is required")
+	}
+
+	goal, err := s.goalsService.GetGoal(ctx, req.UserId, req.GoalId)
+	if err != nil {
+		return nil, s.goalError(ctx, err, "failed to get learning goal")
+	}
+
+	return &pb.GetLearningGoalResponse{Goal: convertLearningGoalToProto(goal)}, nil
+}
+
+// ListLearningGoals returns the user's learning goals, open goals first
+func (s *SchedulerService) ListLearningGoals(ctx context.Context, req *pb.ListLearningGoalsRequest) (*pb.ListLearningGoalsResponse, error) {
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	userGoals, err := s.goalsService.ListGoals(ctx, req.UserId, req.IncludeCompleted)
+	if err != nil {
+		return nil, s.goalError(ctx, err, "failed to list learning goals")
+	}
+
+	pbGoals := make([]*pb.LearningGoal, 0, len(userGoals))
+	for i := range userGoals {
+		pbGoals = append(pbGoals, convertLearningGoalToProto(&userGoals[i]))
+	}
+
+	return &pb.ListLearningGoalsResponse{Goals: pbGoals}, nil
+}
+
+// UpdateLearningGoal replaces the definition of a learning goal and re-evaluates its progress
+func (s *SchedulerService) UpdateLearningGoal(ctx context.Context, req *pb.UpdateLearningGoalRequest) (*pb.UpdateLearningGoalResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id": req.UserId,
+		"goal_id": req.GoalId,
+	}).Info("Updating learning goal")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.GoalId == "" {
+		return nil, status.Error(codes.InvalidArgument, "goal_id is required")
+	}
+	if req.Goal == nil {
+		return nil, status.Error(codes.InvalidArgument, "goal is required")
+	}
+
+	goal, err := s.goalsService.UpdateGoal(ctx, req.UserId, req.GoalId, convertGoalDefinitionFromProto(req.Goal))
+	if err != nil {
+		return nil, s.goalError(ctx, err, "failed to update learning goal")
+	}
+
+	return &pb.UpdateLearningGoalResponse{Goal: convertLearningGoalToProto(goal)}, nil
+}
+
+// DeleteLearningGoal deletes one of the user's learning goals
+func (s *SchedulerService) DeleteLearningGoal(ctx context.Context, req *pb.DeleteLearningGoalRequest) (*pb.DeleteLearningGoalResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id": req.UserId,
+		"goal_id": req.GoalId,
+	}).Info("Deleting learning goal")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.GoalId == "" {
+		return nil, status.Error(codes.InvalidArgument, "goal_id is required")
+	}
+
+	if err := s.goalsService.DeleteGoal(ctx, req.UserId, req.GoalId); err != nil {
+		return nil, s.goalError(ctx, err, "failed to delete learning goal")
+	}
+
+	return &pb.DeleteLearningGoalResponse{Success: true}, nil
+}
+
+// goalError maps learning goal errors to gRPC status codes
+func (s *SchedulerService) goalError(ctx context.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, goals.ErrInvalidGoal):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, goals.ErrGoalNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, goals.ErrGoalLimitReached):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	s.logger.WithContext(ctx).WithError(err).Error(message)
+	return status.Error(codes.Internal, message)
+}
+
+// convertGoalDefinitionFromProto converts a protobuf goal definition to its internal form
+func convertGoalDefinitionFromProto(definition *pb.LearningGoalDefinition) goals.GoalDefinition {
+	result := goals.GoalDefinition{
+		Title:         definition.Title,
+		Description:   definition.Description,
+		TargetTopics:  definition.TargetTopics,
+		TargetMastery: definition.TargetMastery,
+	}
+	if definition.TargetDate != nil {
+		targetDate := definition.TargetDate.AsTime()
+		result.TargetDate = &targetDate
+	}
+	return result
+}
+
+// convertLearningGoalToProto converts an internal learning goal to protobuf format
+func convertLearningGoalToProto(goal *goals.Goal) *pb.LearningGoal {
+	return &pb.LearningGoal{
+		Id:             goal.ID,
+		UserId:         goal.UserID,
+		Title:          goal.Title,
+		Description:    goal.Description,
+		TargetTopics:   goal.TargetTopics,
+		TargetMastery:  goal.TargetMastery,
+		TargetDate:     optionalTimestamp(goal.TargetDate),
+		CurrentMastery: goal.CurrentMastery,
+		IsCompleted:    goal.IsCompleted,
+		CompletedAt:    optionalTimestamp(goal.CompletedAt),
+		CreatedAt:      timestamppb.New(goal.CreatedAt),
+		UpdatedAt:      timestamppb.New(goal.UpdatedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -2,20 +2,26 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
+	"os"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
+	"scheduler-service/internal/auth"
 	"scheduler-service/internal/config"
 	"scheduler-service/internal/logger"
 	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/ratelimit"
+	"scheduler-service/internal/tracing"
 	pb "scheduler-service/proto"
 )
 
@@ -27,6 +33,8 @@ type GRPCServer struct {
 	logger   *logger.Logger
 	metrics  *metrics.Metrics
 	service  *SchedulerService
+	health   *HealthMonitor
+	limiter  *ratelimit.Limiter
 }
 
 // NewGRPCServer creates a new gRPC server
@@ -42,22 +50,68 @@ func NewGRPCServer(
 		return nil, fmt.Errorf("failed to listen on port %s: %w", cfg.Server.GRPCPort, err)
 	}
 
+	interceptors := []grpc.UnaryServerInterceptor{
+		tracing.UnaryServerInterceptor(),
+		loggingInterceptor(log),
+		metricsInterceptor(metrics),
+		recoveryInterceptor(log),
+	}
+	var options []grpc.ServerOption
+
+	// Serve TLS, verifying client certificates of service accounts when a CA is configured
+	if cfg.Auth.ClientCAFile != "" && cfg.Auth.TLSCertFile == "" {
+		listener.Close()
+		return nil, fmt.Errorf("client certificate authentication requires a gRPC server certificate")
+	}
+	if cfg.Auth.TLSCertFile != "" {
+		tlsConfig, err := serverTLSConfig(&cfg.Auth)
+		if err != nil {
+			listener.Close()
+			return nil, err
+		}
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	// Authenticate callers by access token or client certificate
+	verifier, err := auth.NewVerifier(&cfg.Auth)
+	if err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to load access token keys: %w", err)
+	}
+	if verifier != nil || cfg.Auth.ClientCAFile != "" {
+		interceptors = append(interceptors, authInterceptor(auth.NewAuthenticator(&cfg.Auth, verifier), log))
+	} else {
+		log.Warn("No access token keys or client CA configured, gRPC requests are not authenticated")
+	}
+
+	// Resolve the tenant of requests, after callers are identified
+	if service.tenants != nil {
+		interceptors = append(interceptors, tenantInterceptor(service.tenants, metrics, log))
+	}
+
+	// Limit requests per user, calling service, tenant and method, after callers and
+	// tenants are identified
+	var limiter *ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		var store ratelimit.Store
+		if cfg.RateLimit.Backend == "redis" && service.cache != nil {
+			store = ratelimit.NewRedisStore(service.cache)
+		}
+		limiter = ratelimit.NewLimiter(&cfg.RateLimit, store, log)
+		interceptors = append(interceptors, rateLimitInterceptor(limiter, metrics, log))
+	}
+
 	// Create gRPC server with interceptors
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(chainUnaryInterceptors(
-			loggingInterceptor(log),
-			metricsInterceptor(metrics),
-			recoveryInterceptor(log),
-		)),
-	)
+	options = append(options, grpc.UnaryInterceptor(chainUnaryInterceptors(interceptors...)))
+	server := grpc.NewServer(options...)
 
 	// Register services
 	pb.RegisterSchedulerServiceServer(server, service)
 
-	// Register health service
-	healthServer := health.NewServer()
-	healthServer.SetServingStatus("scheduler", grpc_health_v1.HealthCheckResponse_SERVING)
-	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	// Register the health service, reporting the status of each dependency
+	healthMonitor := NewHealthMonitor(&cfg.Health, log, service.dependencyChecks())
+	grpc_health_v1.RegisterHealthServer(server, healthMonitor.Server())
+	service.health = healthMonitor
 
 	// Enable reflection for development
 	if cfg.Server.Env == "development" {
@@ -71,19 +125,70 @@ func NewGRPCServer(
 		logger:   log,
 		metrics:  metrics,
 		service:  service,
+		health:   healthMonitor,
+		limiter:  limiter,
 	}, nil
 }
 
+// serverTLSConfig loads the server certificate and, when configured, the CA bundle that
+// client certificates are verified against. Client certificates are optional since
+// learners authenticate with access tokens.
+func serverTLSConfig(cfg *config.AuthConfig) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gRPC server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		caBundle, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
+
+// Health returns the health monitor of the server
+func (s *GRPCServer) Health() *HealthMonitor {
+	return s.health
+}
+
+// RateLimiter returns the rate limiter of the server, or nil when rate limiting is disabled
+func (s *GRPCServer) RateLimiter() *ratelimit.Limiter {
+	return s.limiter
+}
+
 // Start starts the gRPC server
 func (s *GRPCServer) Start() error {
 	s.logger.Infof("Starting gRPC server on port %s", s.config.Server.GRPCPort)
+	s.health.Start()
 	return s.server.Serve(s.listener)
 }
 
-// Stop gracefully stops the gRPC server
+// Stop reports the server as NOT_SERVING, waits for the drain delay so load balancers
+// stop sending new requests, and then gracefully stops the gRPC server
 func (s *GRPCServer) Stop() {
+	s.health.Drain()
+	if s.config.Health.DrainDelay > 0 {
+		s.logger.Infof("Draining gRPC server for %s", s.config.Health.DrainDelay)
+		time.Sleep(s.config.Health.DrainDelay)
+	}
+
 	s.logger.Info("Stopping gRPC server...")
 	s.server.GracefulStop()
+	s.health.Stop()
 }
 
 // Interceptor chain helper
@@ -106,13 +211,6 @@ func loggingInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		start := time.Now()
 
-		// Add trace ID to context if not present
-		traceID := logger.GetTraceID(ctx)
-		if traceID == "" {
-			traceID = generateTraceID()
-			ctx = logger.WithTraceID(ctx, traceID)
-		}
-
 		log.WithContext(ctx).WithField("method", info.FullMethod).Info("gRPC request started")
 
 		resp, err := handler(ctx, req)
@@ -169,8 +267,3 @@ func recoveryInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
-
-// Generate a simple trace ID (in production, use proper distributed tracing)
-func generateTraceID() string {
-	return fmt.Sprintf("%d", time.Now().UnixNano())
-}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+	pb "scheduler-service/proto"
+)
+
+// Subsystems reported by the health service. Each is registered as a service name of
+// grpc.health.v1 so orchestrators can watch it on its own.
+const (
+	subsystemPostgres = "postgres"
+	subsystemRedis    = "redis"
+	subsystemML       = "ml"
+)
+
+// dependencyCheck checks one subsystem the service depends on. The service is only ready
+// while its critical dependencies are healthy.
+type dependencyCheck struct {
+	name     string
+	critical bool
+	check    func(ctx context.Context) error
+}
+
+// dependencyChecks returns the checks of the dependencies of the scheduler. The ML
+// service is optional for scheduling, so it is reported but does not affect readiness.
+// Bandit state is kept in memory and has no persistence to check.
+func (s *SchedulerService) dependencyChecks() []dependencyCheck {
+	checks := []dependencyCheck{
+		{
+			name:     subsystemPostgres,
+			critical: true,
+			check: func(ctx context.Context) error {
+				if s.db == nil {
+					return fmt.Errorf("not configured")
+				}
+				return s.db.Health(ctx)
+			},
+		},
+		{
+			name:     subsystemRedis,
+			critical: true,
+			check: func(ctx context.Context) error {
+				if s.cache == nil {
+					return fmt.Errorf("not configured")
+				}
+				return s.cache.Health(ctx)
+			},
+		},
+	}
+
+	if s.config != nil && s.config.ML.ServiceURL != "" {
+		healthURL := strings.TrimSuffix(s.config.ML.ServiceURL, "/") + "/api/v1/health"
+		client := &http.Client{Timeout: s.config.ML.Timeout}
+		checks = append(checks, dependencyCheck{
+			name:     subsystemML,
+			critical: false,
+			check: func(ctx context.Context) error {
+				return checkHTTPHealth(ctx, client, healthURL)
+			},
+		})
+	}
+
+	return checks
+}
+
+// checkHTTPHealth fails unless the health endpoint at url answers with a 2xx status
+func checkHTTPHealth(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// checkResult is the outcome of the latest run of a dependency check
+type checkResult struct {
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	Critical bool      `json:"critical"`
+	Checked  time.Time `json:"checked_at"`
+}
+
+// HealthMonitor checks the dependencies of the service periodically and serves the
+// results through the standard grpc.health.v1 service. The overall status, reported
+// for "" and the scheduler service name, is SERVING while every critical dependency is
+// healthy and the server is not draining.
+type HealthMonitor struct {
+	server   *health.Server
+	checks   []dependencyCheck
+	interval time.Duration
+	timeout  time.Duration
+	logger   *logger.Logger
+
+	mu       sync.RWMutex
+	results  map[string]checkResult
+	ready    bool
+	draining bool
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// NewHealthMonitor creates a health monitor for the given dependency checks. The
+// service reports NOT_SERVING until the first round of checks has passed.
+func NewHealthMonitor(cfg *config.HealthConfig, log *logger.Logger, checks []dependencyCheck) *HealthMonitor {
+	m := &HealthMonitor{
+		server:   health.NewServer(),
+		checks:   checks,
+		interval: cfg.CheckInterval,
+		timeout:  cfg.CheckTimeout,
+		logger:   log,
+		results:  make(map[string]checkResult),
+		stop:     make(chan struct{}),
+	}
+
+	m.setOverallStatus(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	for _, check := range checks {
+		m.server.SetServingStatus(check.name, grpc_health_v1.HealthCheckResponse_UNKNOWN)
+	}
+
+	return m
+}
+
+// Server returns the grpc.health.v1 server to register with the gRPC server
+func (m *HealthMonitor) Server() grpc_health_v1.HealthServer {
+	return m.server
+}
+
+// Start runs the dependency checks immediately and then every check interval until
+// the monitor is stopped
+func (m *HealthMonitor) Start() {
+	go func() {
+		m.runChecks(context.Background())
+
+		interval := m.interval
+		if interval <= 0 {
+			interval = 10 * time.Second
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				m.runChecks(context.Background())
+			}
+		}
+	}()
+}
+
+// Drain reports the service as NOT_SERVING so load balancers stop routing new
+// requests to it before the server stops. Statuses stay NOT_SERVING from then on.
+func (m *HealthMonitor) Drain() {
+	m.mu.Lock()
+	m.draining = true
+	m.ready = false
+	m.mu.Unlock()
+
+	m.logger.Info("Draining, readiness is NOT_SERVING")
+	m.server.Shutdown()
+}
+
+// Stop stops the periodic dependency checks
+func (m *HealthMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// Draining reports whether the server is draining for shutdown
+func (m *HealthMonitor) Draining() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.draining
+}
+
+// Ready reports whether the critical dependencies were healthy on the latest check
+// and the server is not draining
+func (m *HealthMonitor) Ready() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ready
+}
+
+// runChecks runs every dependency check in parallel and updates the reported statuses
+func (m *HealthMonitor) runChecks(ctx context.Context) {
+	results := make([]checkResult, len(m.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range m.checks {
+		wg.Add(1)
+		go func(i int, check dependencyCheck) {
+			defer wg.Done()
+			results[i] = runDependencyCheck(ctx, check, m.timeout)
+		}(i, check)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	if m.draining {
+		m.mu.Unlock()
+		return
+	}
+
+	ready := true
+	for i, check := range m.checks {
+		result := results[i]
+		previous, checked := m.results[check.name]
+		m.results[check.name] = result
+
+		if result.Status != "healthy" && check.critical {
+			ready = false
+		}
+
+		if !checked || previous.Status != result.Status {
+			entry := m.logger.WithFields(map[string]interface{}{
+				"subsystem": check.name,
+				"status":    result.Status,
+				"critical":  check.critical,
+			})
+			if result.Error != "" {
+				entry.WithField("error", result.Error).Warn("Dependency is unhealthy")
+			} else {
+				entry.Info("Dependency is healthy")
+			}
+		}
+
+		servingStatus := grpc_health_v1.HealthCheckResponse_SERVING
+		if result.Status != "healthy" {
+			servingStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		m.server.SetServingStatus(check.name, servingStatus)
+	}
+
+	wasReady := m.ready
+	m.ready = ready
+	m.mu.Unlock()
+
+	if ready {
+		m.setOverallStatus(grpc_health_v1.HealthCheckResponse_SERVING)
+	} else {
+		m.setOverallStatus(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+	if wasReady != ready {
+		m.logger.WithField("ready", ready).Info("Readiness changed")
+	}
+}
+
+// runDependencyCheck runs a single dependency check, bounded by timeout when positive
+func runDependencyCheck(ctx context.Context, check dependencyCheck, timeout time.Duration) checkResult {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result := checkResult{
+		Status:   "healthy",
+		Critical: check.critical,
+		Checked:  time.Now(),
+	}
+	if err := check.check(ctx); err != nil {
+		result.Status = "unhealthy"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// setOverallStatus sets the status of the whole server and of the scheduler service
+func (m *HealthMonitor) setOverallStatus(servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	m.server.SetServingStatus("", servingStatus)
+	m.server.SetServingStatus(pb.SchedulerService_ServiceDesc.ServiceName, servingStatus)
+}
+
+// LivenessHandler reports that the process is up. It does not check dependencies so
+// that an outage of Postgres or Redis does not get the service restarted.
+func (m *HealthMonitor) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// ReadinessHandler reports whether the service can take traffic along with the
+// latest result of each dependency check. It answers 503 while not ready.
+func (m *HealthMonitor) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	m.mu.RLock()
+	status := "ready"
+	if m.draining {
+		status = "draining"
+	} else if !m.ready {
+		status = "not_ready"
+	}
+	checks := make(map[string]checkResult, len(m.results))
+	for name, result := range m.results {
+		checks[name] = result
+	}
+	m.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if status != "ready" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": status,
+		"checks": checks,
+	})
+}
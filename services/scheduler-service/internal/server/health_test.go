@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+	pb "scheduler-service/proto"
+)
+
+func newTestHealthMonitor(checks ...dependencyCheck) *HealthMonitor {
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	return NewHealthMonitor(&config.HealthConfig{}, log, checks)
+}
+
+func healthyCheck(name string, critical bool) dependencyCheck {
+	return dependencyCheck{name: name, critical: critical, check: func(ctx context.Context) error { return nil }}
+}
+
+func failingCheck(name string, critical bool) dependencyCheck {
+	return dependencyCheck{name: name, critical: critical, check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}}
+}
+
+func servingStatus(t *testing.T, m *HealthMonitor, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := m.Server().Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Health check of %q failed: %v", service, err)
+	}
+	return resp.GetStatus()
+}
+
+func TestHealthMonitor_NotServingBeforeFirstCheck(t *testing.T) {
+	m := newTestHealthMonitor(healthyCheck(subsystemPostgres, true))
+
+	if status := servingStatus(t, m, ""); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING before dependencies are checked, got %s", status)
+	}
+	if m.Ready() {
+		t.Errorf("Expected not ready before dependencies are checked")
+	}
+}
+
+func TestHealthMonitor_ReportsSubsystems(t *testing.T) {
+	m := newTestHealthMonitor(
+		healthyCheck(subsystemPostgres, true),
+		failingCheck(subsystemRedis, true),
+	)
+	m.runChecks(context.Background())
+
+	if status := servingStatus(t, m, subsystemPostgres); status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("Expected postgres SERVING, got %s", status)
+	}
+	if status := servingStatus(t, m, subsystemRedis); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected redis NOT_SERVING, got %s", status)
+	}
+	if status := servingStatus(t, m, pb.SchedulerService_ServiceDesc.ServiceName); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected the scheduler NOT_SERVING while a critical dependency fails, got %s", status)
+	}
+	if m.Ready() {
+		t.Errorf("Expected not ready while a critical dependency fails")
+	}
+}
+
+func TestHealthMonitor_NonCriticalFailureKeepsReadiness(t *testing.T) {
+	m := newTestHealthMonitor(
+		healthyCheck(subsystemPostgres, true),
+		healthyCheck(subsystemRedis, true),
+		failingCheck(subsystemML, false),
+	)
+	m.runChecks(context.Background())
+
+	if !m.Ready() {
+		t.Errorf("Expected ready when only a non-critical dependency fails")
+	}
+	if status := servingStatus(t, m, ""); status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING, got %s", status)
+	}
+	if status := servingStatus(t, m, subsystemML); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected ml NOT_SERVING, got %s", status)
+	}
+}
+
+func TestHealthMonitor_DrainFlipsReadiness(t *testing.T) {
+	m := newTestHealthMonitor(healthyCheck(subsystemPostgres, true))
+	m.runChecks(context.Background())
+	if !m.Ready() {
+		t.Fatalf("Expected ready after healthy checks")
+	}
+
+	m.Drain()
+	m.runChecks(context.Background())
+
+	if m.Ready() {
+		t.Errorf("Expected not ready while draining, even after healthy checks")
+	}
+	if status := servingStatus(t, m, ""); status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING while draining, got %s", status)
+	}
+
+	recorder := httptest.NewRecorder()
+	m.ReadinessHandler(recorder, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected readiness 503 while draining, got %d", recorder.Code)
+	}
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode readiness response: %v", err)
+	}
+	if body.Status != "draining" {
+		t.Errorf("Expected draining status, got %s", body.Status)
+	}
+
+	recorder = httptest.NewRecorder()
+	m.LivenessHandler(recorder, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected liveness 200 while draining, got %d", recorder.Code)
+	}
+}
+
+func TestHealthMonitor_ReadinessHandlerReady(t *testing.T) {
+	m := newTestHealthMonitor(healthyCheck(subsystemPostgres, true), failingCheck(subsystemML, false))
+	m.runChecks(context.Background())
+
+	recorder := httptest.NewRecorder()
+	m.ReadinessHandler(recorder, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected readiness 200, got %d", recorder.Code)
+	}
+
+	var body struct {
+		Status string                 `json:"status"`
+		Checks map[string]checkResult `json:"checks"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode readiness response: %v", err)
+	}
+	if body.Checks[subsystemML].Error == "" {
+		t.Errorf("Expected the ml check error to be reported")
+	}
+	if body.Checks[subsystemPostgres].Status != "healthy" {
+		t.Errorf("Expected postgres healthy, got %s", body.Checks[subsystemPostgres].Status)
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"scheduler-service/internal/jurisdiction"
+	pb "scheduler-service/proto"
+)
+
+// SwitchJurisdiction makes another jurisdiction the user's active one, seeding shared
+// topics they have not started there with the knowledge that carries over
+func (s *SchedulerService) SwitchJurisdiction(ctx context.Context, req *pb.SwitchJurisdictionRequest) (*pb.SwitchJurisdictionResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":      req.UserId,
+		"country_code": req.CountryCode,
+	}).Info("Switching jurisdiction")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.CountryCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "country_code is required")
+	}
+
+	result, err := s.jurisdictions.SwitchJurisdiction(ctx, req.UserId, req.CountryCode)
+	if err != nil {
+		if errors.Is(err, jurisdiction.ErrInvalidJurisdiction) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to switch jurisdiction")
+		return nil, status.Error(codes.Internal, "failed to switch jurisdiction")
+	}
+
+	// Other replicas may hold the previous settings in their in-process tier
+	s.invalidateUserCache(ctx, req.UserId)
+
+	transfers := make([]*pb.TopicTransfer, 0, len(result.Transfers))
+	for _, transfer := range result.Transfers {
+		transfers = append(transfers, &pb.TopicTransfer{
+			Topic:              transfer.Topic,
+			Factor:             transfer.Factor,
+			SourceMastery:      transfer.SourceMastery,
+			TransferredMastery: transfer.TransferredMastery,
+		})
+	}
+
+	return &pb.SwitchJurisdictionResponse{
+		PreviousJurisdiction: result.PreviousJurisdiction,
+		Jurisdiction:         result.Jurisdiction,
+		ExamDate:             optionalTimestamp(result.ExamDate),
+		Transfers:            transfers,
+	}, nil
+}
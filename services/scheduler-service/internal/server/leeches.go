@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/catalog"
+	"scheduler-service/internal/models"
+	"scheduler-service/internal/state"
+	pb "scheduler-service/proto"
+)
+
+const (
+	// remediationEasierSibling serves an easier item of the leech's sibling group in its place
+	remediationEasierSibling = "easier_sibling"
+	// remediationExplanation serves the leech with its explanation shown before answering
+	remediationExplanation = "explanation"
+)
+
+// GetLeeches returns the items the user keeps failing, most lapses first, with how
+// GetNextItems remediates each of them
+func (s *SchedulerService) GetLeeches(ctx context.Context, req *pb.GetLeechesRequest) (*pb.GetLeechesResponse, error) {
+	s.logger.WithContext(ctx).WithField("user_id", req.UserId).Info("Getting leeches")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	leeches, err := s.sm2Manager.GetLeeches(ctx, req.UserId, int(req.Limit))
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get leeches")
+		return nil, status.Error(codes.Internal, "failed to get leeches")
+	}
+
+	response := &pb.GetLeechesResponse{
+		Leeches:        make([]*pb.LeechItem, 0, len(leeches)),
+		LeechThreshold: int32(s.sm2Algorithm.Parameters().LeechThreshold),
+	}
+	if len(leeches) == 0 {
+		return response, nil
+	}
+
+	// Remediation depends on the siblings published in the user's jurisdiction and on
+	// whether those siblings are leeches themselves
+	settings, err := s.settingsManager.GetSettings(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get user settings, using the default jurisdiction")
+		settings = &models.UserSchedulerSettingsModel{UserID: req.UserId}
+	}
+	sm2States, err := s.sm2Manager.GetUserStates(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get SM-2 states, remediating without sibling states")
+		sm2States = make(map[string]*algorithms.SM2State)
+	}
+	s.itemCatalog.Refresh(ctx)
+	jurisdiction := state.NormalizeJurisdiction(settings.CountryCode)
+
+	for _, leech := range leeches {
+		pbLeech := &pb.LeechItem{
+			ItemId:         leech.ItemID,
+			Lapses:         int32(leech.State.Lapses),
+			EasinessFactor: leech.State.EasinessFactor,
+			IntervalDays:   int32(leech.State.Interval),
+			LastReviewed:   timestamppb.New(leech.State.LastReviewed),
+			NextDue:        timestamppb.New(leech.State.NextDue),
+			Topics:         s.getItemTopicsFromID(leech.ItemID),
+			Remediation:    remediationExplanation,
+		}
+		if sibling := s.easierSibling(leech.ItemID, sm2States, nil, jurisdiction); sibling != nil {
+			pbLeech.Remediation = remediationEasierSibling
+			pbLeech.RemediationItemId = sibling.ID
+		}
+		response.Leeches = append(response.Leeches, pbLeech)
+	}
+
+	return response, nil
+}
+
+// remediateLeech turns a recommendation of a leech into a remediation: an easier item
+// of its sibling group when one is available, otherwise the leech itself with its
+// explanation shown first
+func (s *SchedulerService) remediateLeech(
+	item *pb.RecommendedItem,
+	sm2States map[string]*algorithms.SM2State,
+	excludedItems map[string]bool,
+	jurisdiction string,
+	currentTime time.Time,
+) {
+	leechID := item.ItemId
+	item.LeechItemId = leechID
+
+	sibling := s.easierSibling(leechID, sm2States, excludedItems, jurisdiction)
+	if sibling == nil {
+		item.Remediation = remediationExplanation
+		item.Reason = "Leech - review the explanation before answering"
+		return
+	}
+
+	siblingState, ok := sm2States[sibling.ID]
+	if !ok {
+		siblingState = s.sm2Algorithm.InitializeState()
+	}
+
+	item.ItemId = sibling.ID
+	item.Remediation = remediationEasierSibling
+	item.Reason = fmt.Sprintf("Easier sibling of leech item %s", leechID)
+	item.Topics = s.getItemTopicsFromID(sibling.ID)
+	item.Difficulty = sibling.Difficulty
+	item.PredictedCorrectness = s.sm2Algorithm.GetRetentionProbability(siblingState, currentTime)
+}
+
+// easierSibling returns the easiest sibling of a leech that is easier than the leech,
+// published in the jurisdiction, not excluded and not a leech itself, or nil when
+// there is none
+func (s *SchedulerService) easierSibling(
+	leechID string,
+	sm2States map[string]*algorithms.SM2State,
+	excludedItems map[string]bool,
+	jurisdiction string,
+) *catalog.Item {
+	leech, ok := s.itemCatalog.Lookup(leechID)
+	if !ok {
+		return nil
+	}
+
+	for _, sibling := range s.itemCatalog.Siblings(leechID) {
+		// Siblings come easiest first
+		if sibling.Difficulty >= leech.Difficulty {
+			return nil
+		}
+		if excludedItems[sibling.ID] || !sibling.AvailableIn(jurisdiction) {
+			continue
+		}
+		if siblingState, ok := sm2States[sibling.ID]; ok && s.sm2Algorithm.IsLeech(siblingState) {
+			continue
+		}
+		return &sibling
+	}
+
+	return nil
+}
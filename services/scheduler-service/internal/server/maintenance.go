@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/worker"
+)
+
+// MaintenanceJobs returns the background jobs that keep learner state fresh
+func (s *SchedulerService) MaintenanceJobs(cfg *config.WorkerConfig) []worker.Job {
+	return []worker.Job{
+		{
+			Name:     "decay",
+			Interval: cfg.DecayInterval,
+			Timeout:  cfg.JobTimeout,
+			Run: func(ctx context.Context) (int, error) {
+				return s.RunDecayJob(ctx, cfg.StaleAfter, cfg.BatchSize)
+			},
+		},
+		{
+			Name:     "recalibration",
+			Interval: cfg.RecalibrationInterval,
+			Timeout:  cfg.JobTimeout,
+			Run: func(ctx context.Context) (int, error) {
+				return s.RunRecalibrationJob(ctx, cfg.ActiveWindow, cfg.BatchSize)
+			},
+		},
+		{
+			Name:     "cache_warmup",
+			Interval: cfg.CacheWarmupInterval,
+			Timeout:  cfg.JobTimeout,
+			Run: func(ctx context.Context) (int, error) {
+				return s.RunCacheWarmupJob(ctx, cfg.ActiveWindow, cfg.BatchSize)
+			},
+		},
+		{
+			Name:     "study_plan",
+			Interval: cfg.ReplanInterval,
+			Timeout:  cfg.JobTimeout,
+			Run: func(ctx context.Context) (int, error) {
+				return s.RunStudyPlanJob(ctx, cfg.ReplanAfter, cfg.BatchSize)
+			},
+		},
+		{
+			Name:     "placement_report",
+			Interval: cfg.ReportInterval,
+			Timeout:  cfg.JobTimeout,
+			Run:      s.RunPlacementReportJob,
+		},
+		{
+			Name:     "cohort_summaries",
+			Interval: cfg.CohortInterval,
+			Timeout:  cfg.JobTimeout,
+			Run:      s.RunCohortSummaryJob,
+		},
+	}
+}
+
+// RunDecayJob persists time decay for IRT abilities and BKT knowledge of users
+// whose states have not been updated within staleAfter
+func (s *SchedulerService) RunDecayJob(ctx context.Context, staleAfter time.Duration, batchSize int) (int, error) {
+	olderThan := time.Now().Add(-staleAfter)
+	processed := 0
+
+	irtUsers, err := s.irtManager.GetUsersWithStaleStates(ctx, olderThan, batchSize)
+	if err != nil {
+		return processed, err
+	}
+
+	for _, userID := range irtUsers {
+		if ctx.Err() != nil {
+			return processed, ctx.Err()
+		}
+		if err := s.ApplyTimeDecayToAbilities(ctx, userID, staleAfter); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to decay IRT abilities")
+			continue
+		}
+		s.invalidateUserCache(ctx, userID)
+		processed++
+	}
+
+	bktUsers, err := s.bktManager.GetUsersWithStaleStates(ctx, olderThan, batchSize)
+	if err != nil {
+		return processed, err
+	}
+
+	for _, userID := range bktUsers {
+		if ctx.Err() != nil {
+			return processed, ctx.Err()
+		}
+		if err := s.bktManager.ApplyTimeDecay(ctx, userID, staleAfter); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to decay BKT knowledge")
+			continue
+		}
+		s.invalidateUserCache(ctx, userID)
+		processed++
+	}
+
+	return processed, nil
+}
+
+// RunRecalibrationJob recalibrates BKT parameters from users who attempted items within
+// activeWindow; users whose states were only decayed are not active
+func (s *SchedulerService) RunRecalibrationJob(ctx context.Context, activeWindow time.Duration, batchSize int) (int, error) {
+	userIDs, err := s.bktManager.GetRecentlyActiveUsers(ctx, time.Now().Add(-activeWindow), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return processed, ctx.Err()
+		}
+		if err := s.bktManager.CalibrateParameters(ctx, userID); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to calibrate BKT parameters")
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// RunCacheWarmupJob loads the states of users active within activeWindow into the cache
+// so their next session does not hit the database
+func (s *SchedulerService) RunCacheWarmupJob(ctx context.Context, activeWindow time.Duration, batchSize int) (int, error) {
+	userIDs, err := s.sm2Manager.GetRecentlyActiveUsers(ctx, time.Now().Add(-activeWindow), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return processed, ctx.Err()
+		}
+		if err := s.warmUserCache(ctx, userID); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to warm user cache")
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// RunStudyPlanJob re-plans the study plans of users whose plan has not been
+// re-evaluated within replanAfter, against their current exam date
+func (s *SchedulerService) RunStudyPlanJob(ctx context.Context, replanAfter time.Duration, batchSize int) (int, error) {
+	userIDs, err := s.onboardingService.GetUsersDueForReplan(ctx, replanAfter, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return processed, ctx.Err()
+		}
+
+		examDate, err := s.settingsManager.GetExamDate(ctx, userID)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to get exam date, re-planning without deadline")
+			examDate = nil
+		}
+
+		if _, err := s.onboardingService.ReplanStudyPlan(ctx, userID, examDate); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to re-plan study plan")
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// RunPlacementReportJob generates a placement report for every jurisdiction with
+// placement tests completed within the reporting window
+func (s *SchedulerService) RunPlacementReportJob(ctx context.Context) (int, error) {
+	return s.placementReports.GenerateReports(ctx, time.Now())
+}
+
+// RunCohortSummaryJob refreshes the cohort summaries instructors' reports are served
+// from for every active tenant
+func (s *SchedulerService) RunCohortSummaryJob(ctx context.Context) (int, error) {
+	return s.cohorts.RefreshAll(ctx, time.Now())
+}
+
+// warmUserCache reads a user's SM-2 states and settings through their managers, which cache on read
+func (s *SchedulerService) warmUserCache(ctx context.Context, userID string) error {
+	if _, err := s.sm2Manager.GetUserStates(ctx, userID); err != nil {
+		return fmt.Errorf("failed to warm SM-2 states: %w", err)
+	}
+	if _, err := s.settingsManager.GetSettings(ctx, userID); err != nil {
+		return fmt.Errorf("failed to warm scheduler settings: %w", err)
+	}
+
+	return nil
+}
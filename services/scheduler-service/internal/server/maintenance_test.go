@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyTimeDecayToAbilities_AdvancesUnchangedStates(t *testing.T) {
+	// An IRT state stale after an hour, already as uncertain as it gets, so decay leaves it unchanged
+	now := time.Now()
+	irtStates := &fakeTable{
+		columns: []string{"user_id", "jurisdiction", "topic", "theta", "theta_variance", "confidence", "attempts_count", "correct_count", "last_updated", "created_at", "updated_at"},
+		rows: [][]driver.Value{
+			{"test-user", "default", "traffic_signs", 0.5, 0.0, 0.1, int64(3), int64(2), now.Add(-2 * time.Hour), now, now},
+		},
+	}
+	service := newTestSchedulerService(t, map[string]*fakeTable{"irt_states": irtStates})
+
+	if err := service.ApplyTimeDecayToAbilities(context.Background(), "test-user", time.Hour); err != nil {
+		t.Fatalf("Unexpected error decaying abilities: %v", err)
+	}
+
+	updates := irtStates.Updates()
+	if len(updates) != 1 {
+		t.Fatalf("Expected the stale state to be written once, got %d updates", len(updates))
+	}
+	if !strings.Contains(updates[0], "last_updated") {
+		t.Errorf("Expected last_updated to move forward so the state is not selected again, got %s", updates[0])
+	}
+}
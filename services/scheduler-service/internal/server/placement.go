@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"scheduler-service/internal/placement"
+	"scheduler-service/internal/state"
+	pb "scheduler-service/proto"
+)
+
+// GetPlacementReport returns the latest placement test report of a jurisdiction:
+// test lengths, stopping reasons, SE at termination and item fit
+func (s *SchedulerService) GetPlacementReport(ctx context.Context, req *pb.GetPlacementReportRequest) (*pb.GetPlacementReportResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"country_code": req.CountryCode,
+		"misfit_only":  req.MisfitOnly,
+	}).Info("Getting placement report")
+
+	// Validate request
+	if req.CountryCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "country_code is required")
+	}
+
+	report, err := s.placementReports.LatestReport(ctx, state.NormalizeJurisdiction(req.CountryCode), req.MisfitOnly)
+	if err != nil {
+		if errors.Is(err, placement.ErrReportNotFound) {
+			return nil, status.Error(codes.NotFound, "no placement report for jurisdiction")
+		}
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get placement report")
+		return nil, status.Error(codes.Internal, "failed to get placement report")
+	}
+
+	return &pb.GetPlacementReportResponse{Report: placementReportToProto(report)}, nil
+}
+
+// placementReportToProto converts a stored placement report to its protobuf form
+func placementReportToProto(report *placement.Report) *pb.PlacementReport {
+	lengths := make([]*pb.TestLengthCount, 0, len(report.LengthDistribution))
+	for items, tests := range report.LengthDistribution {
+		lengths = append(lengths, &pb.TestLengthCount{
+			ItemsAdministered: int32(items),
+			Tests:             int32(tests),
+		})
+	}
+	sort.Slice(lengths, func(i, j int) bool {
+		return lengths[i].ItemsAdministered < lengths[j].ItemsAdministered
+	})
+
+	reasons := make([]*pb.StoppingReasonStats, 0, len(report.StoppingReasons))
+	for _, reason := range report.StoppingReasons {
+		reasons = append(reasons, &pb.StoppingReasonStats{
+			Reason:         reason.Reason,
+			Tests:          int32(reason.Tests),
+			Share:          reason.Share,
+			MeanTestLength: reason.MeanTestLength,
+			MeanFinalSe:    reason.MeanFinalSE,
+		})
+	}
+
+	fit := make([]*pb.ItemFit, 0, len(report.ItemFit))
+	for _, item := range report.ItemFit {
+		fit = append(fit, &pb.ItemFit{
+			ItemId:            item.ItemID,
+			Responses:         int32(item.Responses),
+			ProportionCorrect: item.ProportionCorrect,
+			ExpectedCorrect:   item.ExpectedCorrect,
+			Infit:             item.Infit,
+			Outfit:            item.Outfit,
+			Misfit:            item.Misfit,
+		})
+	}
+
+	return &pb.PlacementReport{
+		ReportId:        report.ID,
+		Jurisdiction:    report.Jurisdiction,
+		WindowStart:     timestamppb.New(report.WindowStart),
+		WindowEnd:       timestamppb.New(report.WindowEnd),
+		GeneratedAt:     timestamppb.New(report.GeneratedAt),
+		TestsAnalyzed:   int32(report.TestsAnalyzed),
+		MeanTestLength:  report.MeanTestLength,
+		TestLengths:     lengths,
+		StoppingReasons: reasons,
+		TerminationSe: &pb.TerminationSE{
+			Mean:   report.TerminationSE.Mean,
+			Median: report.TerminationSE.Median,
+			P90:    report.TerminationSE.P90,
+			Max:    report.TerminationSE.Max,
+		},
+		ItemFit: fit,
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/ratelimit"
+	"scheduler-service/internal/tenant"
+)
+
+// retryAfterHeader is the response header telling rate limited callers how many
+// seconds to wait before retrying
+const retryAfterHeader = "retry-after"
+
+// rateLimitInterceptor charges every request except public ones to the buckets of its
+// user, its calling service, its tenant and its method, and rejects it with
+// ResourceExhausted when any of them is empty. It runs after authentication and tenant
+// resolution so callers and tenants are known.
+func rateLimitInterceptor(limiter *ratelimit.Limiter, metrics *metrics.Metrics, log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] || strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+			return handler(ctx, req)
+		}
+
+		limitReq := rateLimitRequest(ctx, info.FullMethod, req)
+		decision := limiter.Allow(ctx, limitReq)
+		if decision.Allowed {
+			return handler(ctx, req)
+		}
+
+		if metrics != nil {
+			metrics.RecordRateLimited(limitReq.Method, decision.Scope)
+		}
+		log.WithContext(ctx).WithFields(map[string]interface{}{
+			"method":      info.FullMethod,
+			"scope":       decision.Scope,
+			"user_id":     limitReq.UserID,
+			"service":     limitReq.Service,
+			"retry_after": decision.RetryAfter.String(),
+		}).Debug("Rejected rate limited request")
+
+		return nil, rateLimitedError(ctx, decision)
+	}
+}
+
+// rateLimitRequest identifies who a request is charged to. Requests on a user count
+// against that user whoever makes them; service accounts are also charged for their
+// own calls.
+func rateLimitRequest(ctx context.Context, fullMethod string, req interface{}) ratelimit.Request {
+	limitReq := ratelimit.Request{Method: fullMethod[strings.LastIndex(fullMethod, "/")+1:]}
+
+	if userReq, ok := req.(userScopedRequest); ok {
+		limitReq.UserID = userReq.GetUserId()
+	}
+
+	if t, ok := tenant.FromContext(ctx); ok {
+		limitReq.Tenant = t.ID
+	}
+
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		limitReq.Caller = principal.Subject
+		if principal.Service {
+			limitReq.Service = principal.Subject
+		} else if limitReq.UserID == "" {
+			limitReq.UserID = principal.Subject
+		}
+	} else if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		// Without authentication, callers are told apart by address
+		limitReq.Caller = p.Addr.String()
+		if host, _, err := net.SplitHostPort(limitReq.Caller); err == nil {
+			limitReq.Caller = host
+		}
+	}
+
+	return limitReq
+}
+
+// rateLimitedError returns a ResourceExhausted status carrying when to retry, both as
+// a RetryInfo detail and, for callers that do not decode details, a retry-after header
+// in whole seconds
+func rateLimitedError(ctx context.Context, decision ratelimit.Decision) error {
+	retryAfter := max(decision.RetryAfter, time.Millisecond)
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, strconv.Itoa(seconds)))
+
+	st := status.Newf(codes.ResourceExhausted, "rate limit exceeded, retry after %ds", seconds)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
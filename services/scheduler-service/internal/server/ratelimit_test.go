@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/ratelimit"
+	pb "scheduler-service/proto"
+)
+
+func TestRateLimitRequest(t *testing.T) {
+	learner := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "user-1"})
+	gateway := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "gateway", Service: true})
+
+	req := rateLimitRequest(learner, pb.SchedulerService_GetNextItems_FullMethodName, &pb.NextItemsRequest{UserId: "user-1"})
+	if req.Method != "GetNextItems" || req.UserID != "user-1" || req.Service != "" || req.Caller != "user-1" {
+		t.Errorf("Unexpected learner request %+v", req)
+	}
+
+	req = rateLimitRequest(learner, pb.SchedulerService_GetAvailableStrategies_FullMethodName, &pb.GetAvailableStrategiesRequest{})
+	if req.UserID != "user-1" {
+		t.Errorf("Expected learner requests without a user_id to count against the learner, got %+v", req)
+	}
+
+	req = rateLimitRequest(gateway, pb.SchedulerService_RecordAttempt_FullMethodName, &pb.AttemptRequest{UserId: "user-2"})
+	if req.UserID != "user-2" || req.Service != "gateway" || req.Caller != "gateway" {
+		t.Errorf("Unexpected service request %+v", req)
+	}
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	limiter := ratelimit.NewLimiter(&config.RateLimitConfig{User: config.RateLimit{Rate: 0.5, Burst: 1}}, nil, log)
+	interceptor := rateLimitInterceptor(limiter, nil, log)
+
+	ctx := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "user-1"})
+	info := &grpc.UnaryServerInfo{FullMethod: pb.SchedulerService_GetUserState_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	req := &pb.GetUserStateRequest{UserId: "user-1"}
+
+	if _, err := interceptor(ctx, req, info, handler); err != nil {
+		t.Fatalf("Expected first request to be allowed, got %v", err)
+	}
+
+	_, err := interceptor(ctx, req, info, handler)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted, got %v", err)
+	}
+
+	var retryDelay time.Duration
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryDelay = info.RetryDelay.AsDuration()
+		}
+	}
+	if retryDelay <= 0 || retryDelay > 2*time.Second {
+		t.Errorf("Expected a retry delay of up to 2s, got %v", retryDelay)
+	}
+
+	// Health checks are never limited
+	healthInfo := &grpc.UnaryServerInfo{FullMethod: pb.SchedulerService_Health_FullMethodName}
+	if _, err := interceptor(ctx, &pb.HealthRequest{}, healthInfo, handler); err != nil {
+		t.Errorf("Expected health checks to bypass rate limits, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/reload"
+	pb "scheduler-service/proto"
+)
+
+// defaultConfigHistoryLimit is how many audit log entries GetConfigHistory returns by default
+const defaultConfigHistoryLimit = 50
+
+// ApplyConfig applies the hot-reloadable settings of cfg: SM-2 and BKT parameters of
+// new states, scoring weights, quality inference and cache TTLs. It runs at startup and
+// whenever a reload changes any of them; states already stored keep their parameters.
+func (s *SchedulerService) ApplyConfig(cfg *config.Config) {
+	s.sm2Algorithm.SetParameters(algorithms.SM2Parameters{
+		InitialEasinessFactor: cfg.SM2.InitialEasiness,
+		MinEasinessFactor:     cfg.SM2.MinEasiness,
+		LeechThreshold:        cfg.SM2.LeechThreshold,
+	})
+
+	s.bktAlgorithm.SetParameters(algorithms.BKTParameters{
+		ProbInit:  cfg.BKT.InitialKnowledge,
+		ProbGuess: cfg.BKT.GuessProbability,
+		ProbSlip:  cfg.BKT.SlipProbability,
+		ProbLearn: cfg.BKT.LearnProbability,
+	})
+
+	err := s.unifiedScoring.UpdateWeights(algorithms.ScoringWeights{
+		Urgency:     cfg.Scoring.WeightUrgency,
+		Mastery:     cfg.Scoring.WeightMastery,
+		Difficulty:  cfg.Scoring.WeightDifficulty,
+		Exploration: cfg.Scoring.WeightExploration,
+	})
+	if err != nil {
+		// Validated configurations always sum to 1
+		s.logger.WithError(err).Error("Failed to apply scoring weights")
+	}
+
+	s.qualityInferrer.Configure(&cfg.Quality)
+
+	if s.cache != nil {
+		s.cache.SetTTLs(cfg.Redis.StateTTL, cfg.Redis.LocalCacheTTL)
+	}
+}
+
+// UseReloader serves the configuration RPCs from reloader and applies the
+// configurations it reloads
+func (s *SchedulerService) UseReloader(reloader *reload.Reloader) {
+	s.reloader = reloader
+	reloader.OnReload(s.ApplyConfig)
+}
+
+// GetConfig returns the value every setting would have on the next reload and the
+// layer it comes from. Values of secrets are left out.
+func (s *SchedulerService) GetConfig(ctx context.Context, req *pb.GetConfigRequest) (*pb.GetConfigResponse, error) {
+	if s.reloader == nil {
+		return nil, status.Error(codes.Unavailable, "configuration reloading is not enabled")
+	}
+
+	settings, err := s.reloader.Settings(ctx)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to read configuration")
+		return nil, status.Error(codes.Internal, "failed to read configuration")
+	}
+
+	resp := &pb.GetConfigResponse{Settings: make([]*pb.ConfigSetting, 0, len(settings))}
+	for _, setting := range settings {
+		value := setting.Value
+		if setting.Secret {
+			value = ""
+		}
+		resp.Settings = append(resp.Settings, &pb.ConfigSetting{
+			Key:           setting.Key,
+			Value:         value,
+			Source:        setting.Source,
+			HotReloadable: setting.Hot,
+			Secret:        setting.Secret,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateConfig overrides or removes the overrides of hot-reloadable settings for all
+// replicas, recording the caller in the audit log. The overrides are validated together
+// with the rest of the configuration and rejected as a whole if it would be invalid.
+func (s *SchedulerService) UpdateConfig(ctx context.Context, req *pb.UpdateConfigRequest) (*pb.UpdateConfigResponse, error) {
+	if s.reloader == nil {
+		return nil, status.Error(codes.Unavailable, "configuration reloading is not enabled")
+	}
+
+	// Validate request
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "configuration changes need an authenticated caller")
+	}
+	if len(req.Overrides) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "overrides are required")
+	}
+
+	changes := make(map[string]*string, len(req.Overrides))
+	for _, override := range req.Overrides {
+		if override.Key == "" {
+			return nil, status.Error(codes.InvalidArgument, "override key is required")
+		}
+		if _, dup := changes[override.Key]; dup {
+			return nil, status.Errorf(codes.InvalidArgument, "%s is overridden more than once", override.Key)
+		}
+		if override.Remove {
+			changes[override.Key] = nil
+			continue
+		}
+		if override.Value == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "value of %s is required unless the override is removed", override.Key)
+		}
+		value := override.Value
+		changes[override.Key] = &value
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"actor":     principal.Subject,
+		"overrides": len(changes),
+	}).Info("Updating configuration")
+
+	entries, err := s.reloader.Set(ctx, changes, principal.Subject, req.Reason)
+	if err != nil && entries == nil {
+		switch {
+		case errors.Is(err, reload.ErrUnknownSetting), errors.Is(err, reload.ErrNotHotReloadable):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, reload.ErrInvalidConfig):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, reload.ErrNoRepository):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to update configuration")
+		return nil, status.Error(codes.Internal, "failed to update configuration")
+	}
+	if err != nil {
+		// Stored, but this replica failed to reload; it retries on its next interval
+		s.logger.WithContext(ctx).WithError(err).Warn("Configuration updated but not reloaded")
+	}
+
+	return &pb.UpdateConfigResponse{Changes: convertAuditEntriesToProto(entries)}, nil
+}
+
+// GetConfigHistory returns the latest changes to configuration overrides, newest first
+func (s *SchedulerService) GetConfigHistory(ctx context.Context, req *pb.GetConfigHistoryRequest) (*pb.GetConfigHistoryResponse, error) {
+	if s.reloader == nil {
+		return nil, status.Error(codes.Unavailable, "configuration reloading is not enabled")
+	}
+
+	// Validate request
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	if req.Key != "" && !config.Known(req.Key) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown setting %s", req.Key)
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultConfigHistoryLimit
+	}
+
+	entries, err := s.reloader.History(ctx, req.Key, limit)
+	if err != nil {
+		if errors.Is(err, reload.ErrNoRepository) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to read configuration history")
+		return nil, status.Error(codes.Internal, "failed to read configuration history")
+	}
+
+	return &pb.GetConfigHistoryResponse{Entries: convertAuditEntriesToProto(entries)}, nil
+}
+
+// convertAuditEntriesToProto converts config audit log entries to protobuf format
+func convertAuditEntriesToProto(entries []reload.AuditEntry) []*pb.ConfigAuditEntry {
+	result := make([]*pb.ConfigAuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		pbEntry := &pb.ConfigAuditEntry{
+			Id:          entry.ID,
+			Key:         entry.Key,
+			HadOverride: entry.OldValue != nil,
+			HasOverride: entry.NewValue != nil,
+			Actor:       entry.Actor,
+			Reason:      entry.Reason,
+			ChangedAt:   timestamppb.New(entry.ChangedAt),
+		}
+		if entry.OldValue != nil {
+			pbEntry.OldValue = *entry.OldValue
+		}
+		if entry.NewValue != nil {
+			pbEntry.NewValue = *entry.NewValue
+		}
+		result = append(result, pbEntry)
+	}
+	return result
+}
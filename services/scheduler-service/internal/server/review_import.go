@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"scheduler-service/internal/importer"
+	pb "scheduler-service/proto"
+)
+
+// ImportReviewLog reconstructs learners' SM-2, BKT and IRT states from the review log
+// of another flashcard app. A dry run reports the unmapped items and invalid rows
+// without storing anything.
+func (s *SchedulerService) ImportReviewLog(ctx context.Context, req *pb.ImportReviewLogRequest) (*pb.ImportReviewLogResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"format":  req.Format,
+		"source":  req.Source,
+		"dry_run": req.DryRun,
+		"bytes":   len(req.Data),
+	}).Info("Importing review log")
+
+	// Validate request
+	if req.Data == "" {
+		return nil, status.Error(codes.InvalidArgument, "data is required")
+	}
+
+	report, err := s.reviewImporter.Import(ctx, strings.NewReader(req.Data), importer.Options{
+		Format:     req.Format,
+		Source:     req.Source,
+		GradeScale: req.GradeScale,
+		DryRun:     req.DryRun,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, importer.ErrInvalidLog), errors.Is(err, importer.ErrUnsupportedFormat),
+			errors.Is(err, importer.ErrUnsupportedGradeScale):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, importer.ErrCatalogEmpty):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to import review log")
+		return nil, status.Error(codes.Internal, "failed to import review log")
+	}
+
+	return convertImportReportToProto(report), nil
+}
+
+// convertImportReportToProto converts an import report to protobuf format
+func convertImportReportToProto(report *importer.Report) *pb.ImportReviewLogResponse {
+	resp := &pb.ImportReviewLogResponse{
+		DryRun:          report.DryRun,
+		Rows:            int32(report.Rows),
+		InvalidRows:     int32(report.InvalidRows),
+		Reviews:         int32(report.Reviews),
+		UnmappedReviews: int32(report.UnmappedReviews),
+		Users:           int32(report.Users),
+		Items:           int32(report.Items),
+		Sm2States:       int32(report.SM2States),
+		BktStates:       int32(report.BKTStates),
+		IrtStates:       int32(report.IRTStates),
+		StoredStates:    report.StoredStates,
+		ExistingStates:  report.ExistingStates,
+	}
+	for _, rowErr := range report.Errors {
+		resp.Errors = append(resp.Errors, &pb.ImportRowError{Line: int32(rowErr.Line), Reason: rowErr.Reason})
+	}
+	for _, item := range report.Unmapped {
+		resp.Unmapped = append(resp.Unmapped, &pb.UnmappedItem{ExternalId: item.ExternalID, Reviews: int32(item.Reviews)})
+	}
+	return resp
+}
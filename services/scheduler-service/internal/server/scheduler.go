@@ -2,7 +2,9 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -11,12 +13,26 @@ import (
 
 	"scheduler-service/internal/algorithms"
 	"scheduler-service/internal/cache"
+	"scheduler-service/internal/catalog"
+	"scheduler-service/internal/cohort"
 	"scheduler-service/internal/config"
+	"scheduler-service/internal/curriculum"
 	"scheduler-service/internal/database"
+	"scheduler-service/internal/events"
+	"scheduler-service/internal/goals"
+	"scheduler-service/internal/importer"
+	"scheduler-service/internal/jurisdiction"
 	"scheduler-service/internal/logger"
 	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/models"
 	"scheduler-service/internal/onboarding"
+	"scheduler-service/internal/placement"
+	"scheduler-service/internal/quality"
+	"scheduler-service/internal/reload"
+	"scheduler-service/internal/replay"
 	"scheduler-service/internal/state"
+	"scheduler-service/internal/tenant"
+	"scheduler-service/internal/userdata"
 	pb "scheduler-service/proto"
 )
 
@@ -37,6 +53,20 @@ type SchedulerService struct {
 	irtManager        *state.IRTManager
 	unifiedScoring    *algorithms.UnifiedScoringAlgorithm
 	onboardingService *onboarding.OnboardingService
+	settingsManager   *state.UserSettingsManager
+	prerequisites     *curriculum.Registry
+	goalsService      *goals.Service
+	jurisdictions     *jurisdiction.Service
+	itemCatalog       *catalog.Catalog
+	placementReports  *placement.Service
+	qualityInferrer   *quality.Inferrer
+	userData          *userdata.Service
+	reviewImporter    *importer.Importer
+	replayEngine      *replay.Engine
+	health            *HealthMonitor
+	reloader          *reload.Reloader
+	tenants           *tenant.Registry
+	cohorts           *cohort.Service
 }
 
 // NewSchedulerService creates a new scheduler service instance
@@ -46,8 +76,11 @@ func NewSchedulerService(
 	metrics *metrics.Metrics,
 	db *database.DB,
 	cache *cache.RedisClient,
+	prerequisites *curriculum.Registry,
+	publisher events.EventPublisher,
+	receiptSigner *userdata.Signer,
 ) *SchedulerService {
-	// Initialize SM-2 algorithm
+	// Initialize SM-2 algorithm; its runtime parameters are set by ApplyConfig
 	sm2Algorithm := algorithms.NewSM2Algorithm()
 
 	// Initialize SM-2 state manager
@@ -61,6 +94,7 @@ func NewSchedulerService(
 
 	// Initialize IRT algorithm
 	irtAlgorithm := algorithms.NewIRTAlgorithm()
+	irtAlgorithm.PriorMean = cfg.IRT.InitialAbility
 
 	// Initialize IRT state manager
 	irtManager := state.NewIRTManager(db.DB, cache)
@@ -68,15 +102,30 @@ func NewSchedulerService(
 	// Initialize unified scoring algorithm
 	unifiedScoring := algorithms.NewUnifiedScoringAlgorithm(log)
 
+	// Initialize user settings manager (exam date, jurisdiction)
+	settingsManager := state.NewUserSettingsManager(db, cache, log)
+
+	// BKT and IRT states are kept per jurisdiction; attempts update the active one
+	bktManager.UseJurisdictionResolver(settingsManager)
+	irtManager.UseJurisdictionResolver(settingsManager)
+
 	// Initialize placement test algorithm
 	placementAlgorithm := algorithms.NewPlacementTestAlgorithm(irtAlgorithm, log)
 
 	// Initialize onboarding service
 	onboardingService := onboarding.NewOnboardingService(
-		log, db, cache, placementAlgorithm, sm2Manager, bktManager, irtManager,
+		log, db, cache, placementAlgorithm, sm2Manager, bktManager, irtManager, settingsManager, prerequisites,
+	)
+
+	// Initialize learning goals, tracked against BKT mastery
+	goalsService := goals.NewService(&cfg.Goals, log, db, cache, bktManager, publisher)
+
+	// Initialize jurisdiction switching, which carries knowledge of shared topics over
+	jurisdictions := jurisdiction.NewService(
+		&cfg.Curriculum, log, db, settingsManager, bktManager, irtManager, bktAlgorithm, irtAlgorithm, prerequisites,
 	)
 
-	return &SchedulerService{
+	service := &SchedulerService{
 		config:            cfg,
 		logger:            log,
 		metrics:           metrics,
@@ -90,7 +139,59 @@ func NewSchedulerService(
 		irtManager:        irtManager,
 		unifiedScoring:    unifiedScoring,
 		onboardingService: onboardingService,
-	}
+		settingsManager:   settingsManager,
+		prerequisites:     prerequisites,
+		goalsService:      goalsService,
+		jurisdictions:     jurisdictions,
+		itemCatalog:       catalog.NewCatalog(&cfg.Catalog, db, log),
+		placementReports:  placement.NewService(&cfg.Worker, log, db, placementAlgorithm),
+		qualityInferrer:   quality.NewInferrer(&cfg.Quality),
+		userData:          userdata.NewService(log, db, cache, receiptSigner, irtManager),
+		tenants:           tenant.NewRegistry(&cfg.Tenant, db, cache, log, unifiedScoring),
+		cohorts:           cohort.NewService(&cfg.Cohort, log, db, bktAlgorithm.MasteryThreshold),
+	}
+
+	// Imported review logs are replayed through the same algorithms as live attempts
+	replayer := replay.NewReplayer(sm2Algorithm, bktAlgorithm, irtAlgorithm)
+	service.reviewImporter = importer.NewImporter(
+		log, db, cache, service.itemCatalog, settingsManager, replayer, sm2Manager, bktManager, irtManager,
+	)
+	service.replayEngine = replay.NewEngine(log, db, cache, service.itemCatalog, settingsManager, service.qualityInferrer, replayer)
+
+	// Onboarding placement tests draw from the same item bank as GetPlacementItems
+	onboardingService.SetPlacementItemSource(service.getAvailablePlacementItems)
+
+	service.ApplyConfig(cfg)
+
+	return service
+}
+
+// OnboardingService returns the onboarding service backing the onboarding RPCs
+func (s *SchedulerService) OnboardingService() *onboarding.OnboardingService {
+	return s.onboardingService
+}
+
+// ReviewImporter returns the importer backing the ImportReviewLog RPC
+func (s *SchedulerService) ReviewImporter() *importer.Importer {
+	return s.reviewImporter
+}
+
+// Tenants returns the registry of the organizations managing cohorts of learners
+func (s *SchedulerService) Tenants() *tenant.Registry {
+	return s.tenants
+}
+
+// ReplayEngine returns the engine rebuilding learner state from the attempts table
+func (s *SchedulerService) ReplayEngine() *replay.Engine {
+	return s.replayEngine
+}
+
+// EnableOptimizedPaths routes state manager bulk reads through the prepared-statement
+// pool and the pipelined Redis client
+func (s *SchedulerService) EnableOptimizedPaths(pool *database.OptimizedPool, fastCache *cache.OptimizedRedisClient) {
+	s.sm2Manager.UseOptimizedPaths(pool, fastCache)
+	s.bktManager.UseOptimizedPool(pool)
+	s.irtManager.UseOptimizedPaths(pool, fastCache)
 }
 
 // GetNextItems returns recommended items for a user session
@@ -119,29 +220,58 @@ func (s *SchedulerService) GetNextItems(ctx context.Context, req *pb.NextItemsRe
 
 	currentTime := time.Now()
 
-	// Get SM-2 urgency scores for all user items
-	urgencyScores, err := s.sm2Manager.GetUrgencyScores(ctx, req.UserId, currentTime)
+	// Get settings for the exam date, which switches to exam preparation once the exam is
+	// within the horizon, and the jurisdiction, whose prerequisite graph gates new topics
+	settings, err := s.settingsManager.GetSettings(ctx, req.UserId)
 	if err != nil {
-		s.logger.WithContext(ctx).WithError(err).Error("Failed to get SM-2 urgency scores")
-		return nil, status.Error(codes.Internal, "failed to get urgency scores")
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get user settings, scoring without exam context")
+		settings = &models.UserSchedulerSettingsModel{UserID: req.UserId}
 	}
+	graph := s.prerequisites.Graph(settings.CountryCode)
 
-	// Get due items for prioritization
-	dueItems, err := s.sm2Manager.GetDueItems(ctx, req.UserId, currentTime)
+	// Load all SM-2 states once; urgency, due items and scoring all derive from them
+	sm2States, err := s.sm2Manager.GetUserStates(ctx, req.UserId)
 	if err != nil {
-		s.logger.WithContext(ctx).WithError(err).Error("Failed to get due items")
-		return nil, status.Error(codes.Internal, "failed to get due items")
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get SM-2 states")
+		return nil, status.Error(codes.Internal, "failed to get urgency scores")
 	}
 
-	// Get BKT mastery gaps for topic-based prioritization
-	masteryGaps, err := s.bktManager.GetMasteryGaps(ctx, req.UserId)
+	// Item memory is shared across jurisdictions and tenants, but only items published
+	// for the user's jurisdiction and in the content of the request's tenant are scheduled
+	s.itemCatalog.Refresh(ctx)
+	jurisdiction := state.NormalizeJurisdiction(settings.CountryCode)
+	sm2States = s.filterAvailableItems(ctx, sm2States, jurisdiction)
+
+	dueCount := 0
+	for _, sm2State := range sm2States {
+		if s.sm2Algorithm.IsDue(sm2State, currentTime) {
+			dueCount++
+		}
+	}
+
+	// Get BKT states for topic-based prioritization
+	userBKTStates, err := s.bktManager.GetUserStates(ctx, req.UserId)
 	if err != nil {
-		s.logger.WithContext(ctx).WithError(err).Error("Failed to get BKT mastery gaps")
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get BKT states")
 		return nil, status.Error(codes.Internal, "failed to get mastery gaps")
 	}
 
+	// Get IRT states for every topic covered by the user's items in one batch
+	userIRTStates, err := s.irtManager.GetMultipleStates(ctx, req.UserId, s.collectItemTopics(sm2States))
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get IRT states, scoring without difficulty matching")
+		userIRTStates = make(map[string]*algorithms.IRTState)
+	}
+
+	// Mastery gaps in the topics of open learning goals weigh more
+	goalWeights, err := s.goalsService.TopicWeights(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get learning goals, scoring without goal weights")
+		goalWeights = nil
+	}
+
 	// Select items based on unified scoring (SM-2 urgency, BKT mastery gaps, IRT difficulty matching)
-	selectedItems := s.selectItemsWithUnifiedScoring(ctx, req, urgencyScores, dueItems, masteryGaps, currentTime)
+	selectedItems := s.selectItemsWithUnifiedScoring(ctx, req, sm2States, userBKTStates, userIRTStates, settings.ExamDate, goalWeights, graph, jurisdiction, currentTime)
 
 	// Create session context
 	sessionContext := &pb.SessionContext{
@@ -163,8 +293,8 @@ func (s *SchedulerService) GetNextItems(ctx context.Context, req *pb.NextItemsRe
 	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
 		"user_id":        req.UserId,
 		"items_returned": len(selectedItems),
-		"due_items":      len(dueItems),
-		"total_items":    len(urgencyScores),
+		"due_items":      dueCount,
+		"total_items":    len(sm2States),
 	}).Info("Items selected successfully")
 
 	return &pb.NextItemsResponse{
@@ -281,26 +411,67 @@ func (s *SchedulerService) RecordAttempt(ctx context.Context, req *pb.AttemptReq
 		return nil, status.Error(codes.Internal, "failed to get current state")
 	}
 
+	// Get exam date so intervals stay ahead of the exam
+	examDate, err := s.settingsManager.GetExamDate(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get exam date, scheduling without deadline")
+		examDate = nil
+	}
+
+	itemTopics := []string{"general"} // Items outside the catalog count towards general knowledge
+
+	// Items outside the catalog get placeholder parameters until calibrated
+	itemParams := &algorithms.ItemParameters{
+		Difficulty:     0.0,
+		Discrimination: 1.0,
+		Guessing:       0.0,
+	}
+	var expectedTime time.Duration
+	s.itemCatalog.Refresh(ctx)
+	if item, ok := s.itemCatalog.Lookup(req.ItemId); ok {
+		if len(item.Topics) > 0 {
+			itemTopics = item.Topics
+		}
+		itemParams.Difficulty = item.Difficulty
+		itemParams.Discrimination = item.Discrimination
+		itemParams.Guessing = item.Guessing
+		expectedTime = item.EstimatedTime
+	}
+
+	// Score the attempt from its correctness, speed, confidence and hints
+	assessment := s.qualityInferrer.Assess(quality.Attempt{
+		Correct:       req.Correct,
+		ClientQuality: int(req.Quality),
+		Confidence:    int(req.Confidence),
+		TimeTaken:     time.Duration(req.TimeTakenMs) * time.Millisecond,
+		ExpectedTime:  expectedTime,
+		HintsUsed:     int(req.HintsUsed),
+	})
+	if assessment.Inferred && assessment.Quality != int(req.Quality) {
+		s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+			"client_quality":   req.Quality,
+			"inferred_quality": assessment.Quality,
+			"evidence_weight":  assessment.EvidenceWeight,
+		}).Debug("Inferred quality differs from client quality")
+	}
+
 	// Update SM-2 state based on quality
-	newSM2State, err := s.sm2Manager.UpdateState(ctx, req.UserId, req.ItemId, int(req.Quality))
+	newSM2State, err := s.sm2Manager.UpdateStateForExam(ctx, req.UserId, req.ItemId, assessment.Quality, examDate)
 	if err != nil {
 		s.logger.WithContext(ctx).WithError(err).Error("Failed to update SM-2 state")
 		return nil, status.Error(codes.Internal, "failed to update SM-2 state")
 	}
 
-	// Update BKT and IRT states for all topics associated with this item
-	// TODO: Get item topics from item metadata - for now using placeholder topics
-	itemTopics := []string{"general"} // This should come from item metadata
+	// Study time feeds the weekly re-planning of the user's study plan
+	if err := s.onboardingService.RecordStudyActivity(ctx, req.UserId, time.Duration(req.TimeTakenMs)*time.Millisecond, req.Correct); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to record study activity")
+	}
+
+	// Update BKT and IRT states for all topics associated with this item, in the
+	// user's active jurisdiction
 	masteryChanges := make(map[string]float64)
 	abilityChanges := make(map[string]float64)
 
-	// TODO: Get item parameters from item metadata - for now using default values
-	itemParams := &algorithms.ItemParameters{
-		Difficulty:     0.0, // Should come from item calibration
-		Discrimination: 1.0, // Should come from item calibration
-		Guessing:       0.0, // Should come from item calibration
-	}
-
 	for _, topic := range itemTopics {
 		// Get current BKT state before update
 		currentBKTState, err := s.bktManager.GetState(ctx, req.UserId, topic)
@@ -309,8 +480,8 @@ func (s *SchedulerService) RecordAttempt(ctx context.Context, req *pb.AttemptReq
 			continue
 		}
 
-		// Update BKT state based on correctness
-		newBKTState, err := s.bktManager.UpdateState(ctx, req.UserId, topic, req.Correct)
+		// Update BKT state based on correctness, discounted for hinted or guessed answers
+		newBKTState, err := s.bktManager.UpdateStateWeighted(ctx, req.UserId, topic, req.Correct, assessment.EvidenceWeight)
 		if err != nil {
 			s.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Error("Failed to update BKT state")
 			continue
@@ -348,6 +519,16 @@ func (s *SchedulerService) RecordAttempt(ctx context.Context, req *pb.AttemptReq
 		}).Debug("Updated IRT and BKT states for topic")
 	}
 
+	// Learning goal progress follows the updated mastery; completions are published as events
+	var completedGoalIDs []string
+	completedGoals, err := s.goalsService.UpdateProgress(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to update learning goal progress")
+	}
+	for _, goal := range completedGoals {
+		completedGoalIDs = append(completedGoalIDs, goal.ID)
+	}
+
 	// Create state update response
 	stateUpdate := &pb.UserStateUpdate{
 		MasteryChanges: masteryChanges,
@@ -374,9 +555,12 @@ func (s *SchedulerService) RecordAttempt(ctx context.Context, req *pb.AttemptReq
 		}
 
 		// Track SM-2 specific metrics
-		s.trackSM2Metrics(currentState, newSM2State, req.Quality)
+		s.trackSM2Metrics(currentState, newSM2State, int32(assessment.Quality))
 	}
 
+	// Other replicas may hold this user's states in their in-process tier
+	s.invalidateUserCache(ctx, req.UserId)
+
 	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
 		"user_id":      req.UserId,
 		"item_id":      req.ItemId,
@@ -388,9 +572,11 @@ func (s *SchedulerService) RecordAttempt(ctx context.Context, req *pb.AttemptReq
 	}).Info("SM-2 state updated successfully")
 
 	return &pb.AttemptResponse{
-		Success:     true,
-		Message:     "Attempt recorded and SM-2 state updated successfully",
-		StateUpdate: stateUpdate,
+		Success:          true,
+		Message:          "Attempt recorded and SM-2 state updated successfully",
+		StateUpdate:      stateUpdate,
+		CompletedGoalIds: completedGoalIDs,
+		Quality:          int32(assessment.Quality),
 	}, nil
 }
 
@@ -408,6 +594,45 @@ func (s *SchedulerService) InitializeUser(ctx context.Context, req *pb.Initializ
 	if req.CountryCode == "" {
 		return nil, status.Error(codes.InvalidArgument, "country_code is required")
 	}
+	if req.ExamDate != nil && !req.ExamDate.AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "exam_date must be in the future")
+	}
+
+	// New users adopt the jurisdiction; users already preparing for another one switch to
+	// it, carrying their knowledge over and keeping each jurisdiction's exam date
+	settings, err := s.settingsManager.GetSettings(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get user scheduler settings")
+		return nil, status.Error(codes.Internal, "failed to get user settings")
+	}
+	examDate := settings.ExamDate
+	switch {
+	case settings.CountryCode == "":
+		if err := s.settingsManager.AdoptJurisdiction(ctx, req.UserId, req.CountryCode); err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to save user scheduler settings")
+			return nil, status.Error(codes.Internal, "failed to save user settings")
+		}
+	case state.NormalizeJurisdiction(settings.CountryCode) != state.NormalizeJurisdiction(req.CountryCode):
+		result, err := s.jurisdictions.SwitchJurisdiction(ctx, req.UserId, req.CountryCode)
+		if err != nil {
+			if errors.Is(err, jurisdiction.ErrInvalidJurisdiction) {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to switch jurisdiction")
+			return nil, status.Error(codes.Internal, "failed to switch jurisdiction")
+		}
+		examDate = result.ExamDate
+	}
+
+	// Keep a booked exam date the request leaves out
+	if req.ExamDate != nil {
+		requestedDate := req.ExamDate.AsTime()
+		if _, err := s.settingsManager.SetExamDate(ctx, req.UserId, &requestedDate); err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to save user scheduler settings")
+			return nil, status.Error(codes.Internal, "failed to save user settings")
+		}
+		examDate = &requestedDate
+	}
 
 	// Initialize SM-2 states for available items
 	// TODO: Get available items for the country/jurisdiction
@@ -435,6 +660,9 @@ func (s *SchedulerService) InitializeUser(ctx context.Context, req *pb.Initializ
 		Version:          1,
 		LastUpdated:      timestamppb.Now(),
 	}
+	if examDate != nil {
+		initialState.ExamDate = timestamppb.New(*examDate)
+	}
 
 	// If placement results are provided, initialize ability estimates
 	if req.PlacementResults != nil {
@@ -451,6 +679,8 @@ func (s *SchedulerService) InitializeUser(ctx context.Context, req *pb.Initializ
 	// TODO: Persist initial state to database
 	// For now, the state will be created on-demand when items are accessed
 
+	s.invalidateUserCache(ctx, req.UserId)
+
 	s.logger.WithContext(ctx).WithField("user_id", req.UserId).Info("User initialized successfully")
 
 	return &pb.InitializeUserResponse{
@@ -495,6 +725,18 @@ func (s *SchedulerService) GetUserState(ctx context.Context, req *pb.GetUserStat
 		pbBKTStates[topic] = s.bktManager.ConvertToProto(state)
 	}
 
+	// Get exam date for the user; the state is still served without it
+	examDate, err := s.settingsManager.GetExamDate(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get exam date, returning state without it")
+		examDate = nil
+	}
+
+	var pbExamDate *timestamppb.Timestamp
+	if examDate != nil {
+		pbExamDate = timestamppb.New(*examDate)
+	}
+
 	// Create user scheduler state
 	state := &pb.UserSchedulerState{
 		UserId:            req.UserId,
@@ -516,6 +758,7 @@ func (s *SchedulerService) GetUserState(ctx context.Context, req *pb.GetUserStat
 		TotalStudyTimeMs: 0, // TODO: Implement time tracking
 		Version:          1,
 		LastUpdated:      timestamppb.Now(),
+		ExamDate:         pbExamDate,
 	}
 
 	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
@@ -577,41 +820,179 @@ func (s *SchedulerService) GetTopicMastery(ctx context.Context, req *pb.GetTopic
 	}, nil
 }
 
-// Health performs a health check
-func (s *SchedulerService) Health(ctx context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
-	checks := make(map[string]string)
+// GetTopicGraph returns the prerequisite graph of the user's jurisdiction with their
+// mastery of each topic and whether it is unlocked
+func (s *SchedulerService) GetTopicGraph(ctx context.Context, req *pb.GetTopicGraphRequest) (*pb.GetTopicGraphResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":      req.UserId,
+		"country_code": req.CountryCode,
+	}).Info("Getting topic graph")
 
-	// Check database
-	if s.db != nil {
-		if err := s.db.Health(ctx); err != nil {
-			checks["database"] = "unhealthy: " + err.Error()
-		} else {
-			checks["database"] = "healthy"
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	countryCode := req.CountryCode
+	if countryCode == "" {
+		settings, err := s.settingsManager.GetSettings(ctx, req.UserId)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to get user settings for topic graph")
+			return nil, status.Error(codes.Internal, "failed to get user settings")
 		}
-	} else {
-		checks["database"] = "unhealthy: not configured"
+		countryCode = settings.CountryCode
 	}
 
-	// Check Redis
-	if s.cache != nil {
-		if err := s.cache.Health(ctx); err != nil {
-			checks["redis"] = "unhealthy: " + err.Error()
-		} else {
-			checks["redis"] = "healthy"
+	bktStates, err := s.bktManager.GetUserStates(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get BKT states for topic graph")
+		return nil, status.Error(codes.Internal, "failed to get topic mastery")
+	}
+
+	mastery := make(map[string]float64, len(bktStates))
+	for topic, bktState := range bktStates {
+		mastery[topic] = bktState.ProbKnowledge
+	}
+
+	graph := s.prerequisites.Graph(countryCode)
+	threshold := s.prerequisites.UnlockMastery()
+
+	topics := make([]*pb.TopicGraphNode, 0, len(graph.Topics()))
+	for _, topic := range graph.Topics() {
+		node := &pb.TopicGraphNode{
+			Topic:                topic,
+			Prerequisites:        graph.Prerequisites(topic),
+			Depth:                int32(graph.Depth(topic)),
+			Mastery:              mastery[topic],
+			MissingPrerequisites: graph.MissingPrerequisites(topic, mastery, threshold),
 		}
-	} else {
-		checks["redis"] = "unhealthy: not configured"
+		node.Unlocked = len(node.MissingPrerequisites) == 0
+		if bktState, ok := bktStates[topic]; ok {
+			node.Mastered = s.bktAlgorithm.IsMastered(bktState)
+		}
+		topics = append(topics, node)
 	}
 
-	// Determine overall status
+	return &pb.GetTopicGraphResponse{
+		Jurisdiction:  graph.Jurisdiction(),
+		Topics:        topics,
+		UnlockMastery: threshold,
+	}, nil
+}
+
+// GetStudyPlanHistory returns the revisions of the user's adaptive study plan, newest first
+func (s *SchedulerService) GetStudyPlanHistory(ctx context.Context, req *pb.GetStudyPlanHistoryRequest) (*pb.GetStudyPlanHistoryResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id": req.UserId,
+		"limit":   req.Limit,
+	}).Info("Getting study plan history")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Limit < 0 || req.Limit > 100 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be between 0 and 100")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 20
+	}
+
+	revisions, err := s.onboardingService.GetStudyPlanHistory(ctx, req.UserId, limit)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get study plan history")
+		return nil, status.Error(codes.Internal, "failed to get study plan history")
+	}
+
+	return &pb.GetStudyPlanHistoryResponse{
+		Revisions: s.convertStudyPlanRevisionsToProto(revisions),
+	}, nil
+}
+
+// SetExamDate sets or clears the user's booked exam date and reschedules existing
+// reviews so none fall after the exam
+func (s *SchedulerService) SetExamDate(ctx context.Context, req *pb.SetExamDateRequest) (*pb.SetExamDateResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":   req.UserId,
+		"exam_date": req.ExamDate,
+	}).Info("Setting exam date")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	currentTime := time.Now()
+
+	var examDate *time.Time
+	if req.ExamDate != nil {
+		date := req.ExamDate.AsTime()
+		if !date.After(currentTime) {
+			return nil, status.Error(codes.InvalidArgument, "exam_date must be in the future")
+		}
+		examDate = &date
+	}
+
+	settings, err := s.settingsManager.SetExamDate(ctx, req.UserId, examDate)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to set exam date")
+		return nil, status.Error(codes.Internal, "failed to set exam date")
+	}
+
+	if examDate == nil {
+		s.invalidateUserCache(ctx, req.UserId)
+		return &pb.SetExamDateResponse{
+			Success: true,
+			Message: "Exam date cleared",
+		}, nil
+	}
+
+	// Pull in reviews that are currently scheduled after the exam
+	rescheduled, err := s.sm2Manager.CapIntervalsForExam(ctx, req.UserId, *examDate)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to reschedule reviews for exam date")
+		return nil, status.Error(codes.Internal, "failed to reschedule reviews")
+	}
+	s.invalidateUserCache(ctx, req.UserId)
+
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":     req.UserId,
+		"exam_date":   examDate,
+		"rescheduled": rescheduled,
+	}).Info("Exam date set successfully")
+
+	return &pb.SetExamDateResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("Exam date set, %d reviews rescheduled", rescheduled),
+		ExamDate:      timestamppb.New(*examDate),
+		DaysUntilExam: settings.GetDaysUntilExam(currentTime),
+	}, nil
+}
+
+// Health performs a health check
+func (s *SchedulerService) Health(ctx context.Context, req *pb.HealthRequest) (*pb.HealthResponse, error) {
+	checks := make(map[string]string)
 	status := "healthy"
-	for _, check := range checks {
-		if check != "healthy" {
-			status = "unhealthy"
-			break
+
+	// Check each dependency; only critical ones make the service unhealthy
+	for _, check := range s.dependencyChecks() {
+		result := runDependencyCheck(ctx, check, s.config.Health.CheckTimeout)
+		if result.Error != "" {
+			checks[check.name] = "unhealthy: " + result.Error
+			if check.critical {
+				status = "unhealthy"
+			}
+		} else {
+			checks[check.name] = "healthy"
 		}
 	}
 
+	if s.health != nil && s.health.Draining() {
+		status = "draining"
+	}
+
 	return &pb.HealthResponse{
 		Status:    status,
 		Checks:    checks,
@@ -625,9 +1006,13 @@ func (s *SchedulerService) Health(ctx context.Context, req *pb.HealthRequest) (*
 func (s *SchedulerService) selectItemsWithUnifiedScoring(
 	ctx context.Context,
 	req *pb.NextItemsRequest,
-	urgencyScores map[string]float64,
-	dueItems []string,
-	masteryGaps map[string]float64,
+	sm2States map[string]*algorithms.SM2State,
+	userBKTStates map[string]*algorithms.BKTState,
+	userIRTStates map[string]*algorithms.IRTState,
+	examDate *time.Time,
+	goalWeights map[string]float64,
+	graph *curriculum.PrerequisiteGraph,
+	jurisdiction string,
 	currentTime time.Time,
 ) []*pb.RecommendedItem {
 	var items []*pb.RecommendedItem
@@ -653,67 +1038,75 @@ func (s *SchedulerService) selectItemsWithUnifiedScoring(
 		CorrectCount:      0,          // TODO: Get from session state
 		TopicsPracticed:   []string{}, // TODO: Get from session state
 		RecentItems:       req.ExcludeItems,
+		RecentSiblings:    s.siblingGroupsOf(req.ExcludeItems),
 		AverageDifficulty: 0.5, // TODO: Calculate from session
 		TargetItemCount:   int(req.Count),
 		TimeRemaining:     45 * time.Minute, // TODO: Get from session constraints
+		GoalTopicWeights:  goalWeights,
 	}
 
-	// Get user's BKT states
-	userBKTStates, err := s.bktManager.GetUserStates(ctx, req.UserId)
-	if err != nil {
-		s.logger.WithContext(ctx).WithError(err).Error("Failed to get user BKT states")
-		userBKTStates = make(map[string]*algorithms.BKTState)
-	}
-
-	// Get user's IRT states - we'll need to get them per topic as needed
-	// For now, create an empty map and populate as needed
-	userIRTStates := make(map[string]*algorithms.IRTState)
-
 	// Determine scoring strategy (could be from A/B testing or user preference)
 	strategy := "balanced" // Default strategy
 	// TODO: Add strategy field to NextItemsRequest proto if needed
 
+	// Tenants may score their learners' items with a strategy of their own
+	if t, ok := tenant.FromContext(ctx); ok && t.Strategy() != "" {
+		strategy = t.Strategy()
+	}
+
+	// Switch to exam preparation once the exam is within the horizon
+	if examDate != nil && s.sm2Algorithm.GetExamPressure(currentTime, *examDate) > 0 {
+		strategy = "exam_prep"
+		sessionContext.ExamDate = examDate
+		sessionContext.ExamTopicWeights = graph.ExamWeights()
+	}
+
 	// Score all candidate items
 	type scoredItem struct {
-		itemID   string
-		result   *algorithms.ScoringResult
-		sm2State *algorithms.SM2State
+		itemID    string
+		result    *algorithms.ScoringResult
+		sm2State  *algorithms.SM2State
+		candidate *algorithms.ItemCandidate
 	}
 
-	var scoredItems []scoredItem
+	excludedItems := make(map[string]bool, len(req.ExcludeItems))
+	for _, excludeID := range req.ExcludeItems {
+		excludedItems[excludeID] = true
+	}
 
-	for itemID := range urgencyScores {
+	mastery := make(map[string]float64, len(userBKTStates))
+	for topic, bktState := range userBKTStates {
+		mastery[topic] = bktState.ProbKnowledge
+	}
+	unlockMastery := s.prerequisites.UnlockMastery()
+	reviewedSiblings := s.recentlyReviewedSiblings(sm2States, currentTime)
+
+	scoredItems := make([]scoredItem, 0, len(sm2States))
+
+	for itemID, sm2State := range sm2States {
 		// Skip excluded items
-		excluded := false
-		for _, excludeID := range req.ExcludeItems {
-			if itemID == excludeID {
-				excluded = true
-				break
-			}
-		}
-		if excluded {
+		if excludedItems[itemID] {
 			continue
 		}
 
-		// Create item candidate
-		candidate := &algorithms.ItemCandidate{
-			ItemID:         itemID,
-			Topics:         s.getItemTopicsFromID(itemID), // TODO: Get from item metadata
-			Difficulty:     0.5,                           // TODO: Get from item metadata
-			Discrimination: 1.0,                           // TODO: Get from item metadata
-			Guessing:       0.0,                           // TODO: Get from item metadata
-			EstimatedTime:  60 * time.Second,              // TODO: Get from item metadata
-			AttemptCount:   0,                             // TODO: Get from user attempt history
-			Metadata:       make(map[string]interface{}),
+		// Hold back new items until their topics' prerequisites are mastered; items
+		// already being reviewed stay in rotation
+		if sm2State.Repetition == 0 && !s.topicsUnlocked(graph, s.getItemTopicsFromID(itemID), mastery, unlockMastery) {
+			continue
 		}
 
-		// Get SM-2 state
-		sm2State, err := s.sm2Manager.GetState(ctx, req.UserId, itemID)
-		if err != nil {
-			s.logger.WithContext(ctx).WithError(err).WithField("item_id", itemID).Debug("Failed to get SM-2 state, using default")
-			sm2State = s.sm2Algorithm.InitializeState()
+		// Bury items whose siblings were reviewed within the bury window
+		if buriedBy := buryingSibling(reviewedSiblings, s.itemCatalog.SiblingGroup(itemID), itemID); buriedBy != "" {
+			s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+				"item_id": itemID,
+				"sibling": buriedBy,
+			}).Debug("Item buried after sibling review")
+			continue
 		}
 
+		// Create item candidate
+		candidate := s.itemCandidate(itemID)
+
 		// Compute unified score
 		result, err := s.unifiedScoring.ComputeUnifiedScore(
 			ctx,
@@ -740,29 +1133,33 @@ func (s *SchedulerService) selectItemsWithUnifiedScoring(
 		}
 
 		scoredItems = append(scoredItems, scoredItem{
-			itemID:   itemID,
-			result:   result,
-			sm2State: sm2State,
+			itemID:    itemID,
+			result:    result,
+			sm2State:  sm2State,
+			candidate: candidate,
 		})
 	}
 
-	// Sort items by unified score (highest first)
-	for i := 0; i < len(scoredItems)-1; i++ {
-		for j := i + 1; j < len(scoredItems); j++ {
-			if scoredItems[j].result.UnifiedScore > scoredItems[i].result.UnifiedScore {
-				scoredItems[i], scoredItems[j] = scoredItems[j], scoredItems[i]
-			}
+	// Sort items by unified score (highest first), breaking ties by item ID for stable results
+	sort.Slice(scoredItems, func(i, j int) bool {
+		if scoredItems[i].result.UnifiedScore != scoredItems[j].result.UnifiedScore {
+			return scoredItems[i].result.UnifiedScore > scoredItems[j].result.UnifiedScore
 		}
-	}
-
-	// Select top items up to requested count
-	count := int(req.Count)
-	if count > len(scoredItems) {
-		count = len(scoredItems)
-	}
+		return scoredItems[i].itemID < scoredItems[j].itemID
+	})
 
-	for i := 0; i < count; i++ {
-		item := scoredItems[i]
+	// Select top items up to requested count, at most one item per sibling group
+	selectedGroups := make(map[string]bool)
+	for _, item := range scoredItems {
+		if len(items) >= int(req.Count) {
+			break
+		}
+		if group := item.candidate.SiblingGroup; group != "" {
+			if selectedGroups[group] {
+				continue
+			}
+			selectedGroups[group] = true
+		}
 
 		// Calculate predicted correctness based on retention probability
 		predictedCorrectness := s.sm2Algorithm.GetRetentionProbability(item.sm2State, currentTime)
@@ -771,15 +1168,21 @@ func (s *SchedulerService) selectItemsWithUnifiedScoring(
 			ItemId:               item.itemID,
 			Score:                item.result.UnifiedScore,
 			Reason:               item.result.Reason,
-			Topics:               s.getItemTopicsFromID(item.itemID), // TODO: Get from item metadata
-			Difficulty:           0.5,                                // TODO: Get from item metadata
+			Topics:               s.getItemTopicsFromID(item.itemID),
+			Difficulty:           item.candidate.Difficulty,
 			PredictedCorrectness: predictedCorrectness,
 		}
 
+		// Items the user keeps failing are remediated rather than repeated as they are
+		if s.sm2Algorithm.IsLeech(item.sm2State) {
+			s.remediateLeech(recommendedItem, sm2States, excludedItems, jurisdiction, currentTime)
+		}
+
 		items = append(items, recommendedItem)
 
 		s.logger.WithContext(ctx).WithFields(map[string]interface{}{
-			"item_id":       item.itemID,
+			"item_id":       recommendedItem.ItemId,
+			"leech_item_id": recommendedItem.LeechItemId,
 			"unified_score": item.result.UnifiedScore,
 			"urgency":       item.result.ComponentScores.UrgencyScore,
 			"mastery_gap":   item.result.ComponentScores.MasteryGapScore,
@@ -793,6 +1196,49 @@ func (s *SchedulerService) selectItemsWithUnifiedScoring(
 	return items
 }
 
+// siblingGroupsOf returns the sibling groups of the given items
+func (s *SchedulerService) siblingGroupsOf(itemIDs []string) []string {
+	var groups []string
+	for _, itemID := range itemIDs {
+		if group := s.itemCatalog.SiblingGroup(itemID); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// recentlyReviewedSiblings returns the items reviewed within the sibling bury window,
+// by sibling group
+func (s *SchedulerService) recentlyReviewedSiblings(sm2States map[string]*algorithms.SM2State, currentTime time.Time) map[string][]string {
+	reviewed := make(map[string][]string)
+	window := s.config.Catalog.SiblingBuryWindow
+	if window <= 0 {
+		return reviewed
+	}
+	for itemID, sm2State := range sm2States {
+		if !s.sm2Algorithm.ReviewedWithin(sm2State, currentTime, window) {
+			continue
+		}
+		if group := s.itemCatalog.SiblingGroup(itemID); group != "" {
+			reviewed[group] = append(reviewed[group], itemID)
+		}
+	}
+	return reviewed
+}
+
+// buryingSibling returns a recently reviewed sibling of an item, or "" when there is none
+func buryingSibling(reviewedSiblings map[string][]string, group, itemID string) string {
+	if group == "" {
+		return ""
+	}
+	for _, siblingID := range reviewedSiblings[group] {
+		if siblingID != itemID {
+			return siblingID
+		}
+	}
+	return ""
+}
+
 // generateRecommendationReason creates a human-readable reason for item recommendation
 func (s *SchedulerService) generateRecommendationReason(state *algorithms.SM2State, isDue bool, currentTime time.Time) string {
 	if isDue {
@@ -818,6 +1264,16 @@ func (s *SchedulerService) generateRecommendationReason(state *algorithms.SM2Sta
 	return "Scheduled for spaced repetition"
 }
 
+// invalidateUserCache drops a user's cached state from the in-process tier of every replica
+func (s *SchedulerService) invalidateUserCache(ctx context.Context, userID string) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.InvalidateUser(ctx, userID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("Failed to invalidate user cache")
+	}
+}
+
 // trackSM2Metrics updates metrics related to SM-2 algorithm performance
 func (s *SchedulerService) trackSM2Metrics(oldState, newState *algorithms.SM2State, quality int32) {
 	if s.metrics == nil {
@@ -882,9 +1338,10 @@ func (s *SchedulerService) InitializeAbilityFromPlacement(ctx context.Context, u
 	return nil
 }
 
-// ApplyTimeDecayToAbilities applies time-based decay to all user's IRT states
-func (s *SchedulerService) ApplyTimeDecayToAbilities(ctx context.Context, userID string) error {
-	err := s.irtManager.ApplyTimeDecay(ctx, userID)
+// ApplyTimeDecayToAbilities applies time-based decay to the user's IRT states not
+// updated within staleAfter
+func (s *SchedulerService) ApplyTimeDecayToAbilities(ctx context.Context, userID string, staleAfter time.Duration) error {
+	err := s.irtManager.ApplyTimeDecay(ctx, userID, staleAfter)
 	if err != nil {
 		return fmt.Errorf("failed to apply time decay to abilities: %w", err)
 	}
@@ -1203,8 +1660,15 @@ func (s *SchedulerService) trackIRTMetrics(oldState, newState *algorithms.IRTSta
 
 // Helper method to get item parameters (placeholder implementation)
 func (s *SchedulerService) getItemParameters(ctx context.Context, itemID string) (*algorithms.ItemParameters, error) {
-	// TODO: Implement actual item parameter retrieval from database/cache
-	// This is a placeholder implementation with default values
+	if item, ok := s.itemCatalog.Lookup(itemID); ok {
+		return &algorithms.ItemParameters{
+			Difficulty:     item.Difficulty,
+			Discrimination: item.Discrimination,
+			Guessing:       item.Guessing,
+		}, nil
+	}
+
+	// Items outside the catalog get placeholder parameters
 	return &algorithms.ItemParameters{
 		Difficulty:     0.0, // Should be calibrated from historical data
 		Discrimination: 1.0, // Should be calibrated from historical data
@@ -1214,10 +1678,34 @@ func (s *SchedulerService) getItemParameters(ctx context.Context, itemID string)
 	}, nil
 }
 
-// Helper method to get item topics from item ID (placeholder implementation)
+// itemCandidate describes an item for scoring from its catalog metadata. Items missing
+// from the catalog are scored as items of average difficulty.
+func (s *SchedulerService) itemCandidate(itemID string) *algorithms.ItemCandidate {
+	candidate := &algorithms.ItemCandidate{
+		ItemID:         itemID,
+		Topics:         s.getItemTopicsFromID(itemID),
+		Difficulty:     0.5,
+		Discrimination: 1.0,
+		Guessing:       0.0,
+		EstimatedTime:  60 * time.Second,
+		AttemptCount:   0, // TODO: Get from user attempt history
+		Metadata:       make(map[string]interface{}),
+	}
+	if item, ok := s.itemCatalog.Lookup(itemID); ok {
+		candidate.Difficulty = item.Difficulty
+		candidate.Discrimination = item.Discrimination
+		candidate.Guessing = item.Guessing
+		candidate.EstimatedTime = item.EstimatedTime
+		candidate.SiblingGroup = item.SiblingGroup
+	}
+	return candidate
+}
+
+// Helper method to get item topics from the item catalog, falling back to the item ID
 func (s *SchedulerService) getItemTopicsFromID(itemID string) []string {
-	// TODO: Implement actual item topic retrieval from database/cache
-	// This is a placeholder implementation that maps item ID to topics
+	if item, ok := s.itemCatalog.Lookup(itemID); ok && len(item.Topics) > 0 {
+		return item.Topics
+	}
 
 	// For now, derive topic from item ID pattern or use default
 	if len(itemID) > 0 {
@@ -1239,6 +1727,45 @@ func (s *SchedulerService) getItemTopicsFromID(itemID string) []string {
 	return []string{"general"}
 }
 
+// filterAvailableItems keeps the items the catalog publishes for a jurisdiction that
+// the request's tenant, if any, schedules
+func (s *SchedulerService) filterAvailableItems(ctx context.Context, sm2States map[string]*algorithms.SM2State, jurisdiction string) map[string]*algorithms.SM2State {
+	available := make(map[string]*algorithms.SM2State, len(sm2States))
+	for itemID, sm2State := range sm2States {
+		if s.itemCatalog.Available(itemID, jurisdiction) && s.tenantAllowsItem(ctx, itemID) {
+			available[itemID] = sm2State
+		}
+	}
+	return available
+}
+
+// collectItemTopics returns the distinct topics covered by the given items
+func (s *SchedulerService) collectItemTopics(sm2States map[string]*algorithms.SM2State) []string {
+	seen := make(map[string]bool)
+	var topics []string
+	for itemID := range sm2States {
+		for _, topic := range s.getItemTopicsFromID(itemID) {
+			if !seen[topic] {
+				seen[topic] = true
+				topics = append(topics, topic)
+			}
+		}
+	}
+	sort.Strings(topics)
+
+	return topics
+}
+
+// topicsUnlocked reports whether every topic's prerequisites are mastered
+func (s *SchedulerService) topicsUnlocked(graph *curriculum.PrerequisiteGraph, topics []string, mastery map[string]float64, threshold float64) bool {
+	for _, topic := range topics {
+		if !graph.IsUnlocked(topic, mastery, threshold) {
+			return false
+		}
+	}
+	return true
+}
+
 // Helper method to get item topics (placeholder implementation)
 func (s *SchedulerService) getItemTopics(ctx context.Context, itemID string) ([]string, error) {
 	// TODO: Implement actual item topic retrieval from database/cache
@@ -1323,16 +1850,7 @@ func (s *SchedulerService) ComputeItemScore(
 	userID, itemID, strategy string,
 ) (*algorithms.ScoringResult, error) {
 	// Create item candidate
-	candidate := &algorithms.ItemCandidate{
-		ItemID:         itemID,
-		Topics:         s.getItemTopicsFromID(itemID),
-		Difficulty:     0.5,              // TODO: Get from item metadata
-		Discrimination: 1.0,              // TODO: Get from item metadata
-		Guessing:       0.0,              // TODO: Get from item metadata
-		EstimatedTime:  60 * time.Second, // TODO: Get from item metadata
-		AttemptCount:   0,                // TODO: Get from user attempt history
-		Metadata:       make(map[string]interface{}),
-	}
+	candidate := s.itemCandidate(itemID)
 
 	// Get SM-2 state
 	sm2State, err := s.sm2Manager.GetState(ctx, userID, itemID)
@@ -1786,7 +2304,7 @@ func (s *SchedulerService) storePlacementState(ctx context.Context, sessionID st
 	}
 
 	// Store in Redis with 24-hour expiration
-	key := fmt.Sprintf("placement_state:%s", sessionID)
+	key := tenant.CacheKey(ctx, fmt.Sprintf("placement_state:%s", sessionID))
 	err := s.cache.Set(ctx, key, state, 24*time.Hour)
 	if err != nil {
 		return fmt.Errorf("failed to store placement state in cache: %w", err)
@@ -1801,7 +2319,7 @@ func (s *SchedulerService) retrievePlacementState(ctx context.Context, sessionID
 		return nil, fmt.Errorf("cache not available")
 	}
 
-	key := fmt.Sprintf("placement_state:%s", sessionID)
+	key := tenant.CacheKey(ctx, fmt.Sprintf("placement_state:%s", sessionID))
 	var state algorithms.PlacementTestState
 	err := s.cache.Get(ctx, key, &state)
 	if err != nil {
@@ -2147,11 +2665,54 @@ func (s *SchedulerService) convertLearningPathToProto(path *onboarding.LearningP
 			Reason:             topic.Reason,
 			Difficulty:         topic.Difficulty,
 			EstimatedTimeHours: int32(topic.EstimatedTime),
+			Prerequisites:      topic.Prerequisites,
+			Locked:             topic.Locked,
 		})
 	}
 
-	var milestones []*pb.LearningMilestone
-	for _, milestone := range path.Milestones {
+	return &pb.LearningPath{
+		PathId:                path.PathID,
+		RecommendedLevel:      path.RecommendedLevel,
+		FocusTopics:           focusTopics,
+		StudyPlan:             s.convertStudyPlanToProto(path.StudyPlan),
+		Milestones:            s.convertMilestonesToProto(path.Milestones),
+		EstimatedDurationDays: int32(path.EstimatedDuration),
+		CreatedAt:             timestamppb.New(path.CreatedAt),
+		PlanRevision:          int32(path.PlanRevision),
+	}
+}
+
+// convertStudyPlanToProto converts a study plan to protobuf format
+func (s *SchedulerService) convertStudyPlanToProto(plan onboarding.StudyPlan) *pb.StudyPlan {
+	return &pb.StudyPlan{
+		DailyMinutes:        int32(plan.DailyMinutes),
+		WeeklySchedule:      s.convertWeeklyScheduleToInt32(plan.WeeklySchedule),
+		SessionTypes:        plan.SessionTypes,
+		ReviewFrequencyDays: int32(plan.ReviewFrequency),
+		TestFrequencyDays:   int32(plan.TestFrequency),
+	}
+}
+
+// convertStudyPlanRevisionsToProto converts study plan revisions to protobuf format
+func (s *SchedulerService) convertStudyPlanRevisionsToProto(revisions []onboarding.StudyPlanRevision) []*pb.StudyPlanRevision {
+	pbRevisions := make([]*pb.StudyPlanRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		pbRevisions = append(pbRevisions, &pb.StudyPlanRevision{
+			PathId:     revision.PathID,
+			Revision:   int32(revision.Revision),
+			StudyPlan:  s.convertStudyPlanToProto(revision.StudyPlan),
+			Milestones: s.convertMilestonesToProto(revision.Milestones),
+			Reasons:    revision.Reasons,
+			CreatedAt:  timestamppb.New(revision.CreatedAt),
+		})
+	}
+	return pbRevisions
+}
+
+// convertMilestonesToProto converts learning milestones to protobuf format
+func (s *SchedulerService) convertMilestonesToProto(milestones []onboarding.LearningMilestone) []*pb.LearningMilestone {
+	var pbMilestones []*pb.LearningMilestone
+	for _, milestone := range milestones {
 		pbMilestone := &pb.LearningMilestone{
 			Id:             milestone.ID,
 			Title:          milestone.Title,
@@ -2164,24 +2725,9 @@ func (s *SchedulerService) convertLearningPathToProto(path *onboarding.LearningP
 		if milestone.CompletedAt != nil {
 			pbMilestone.CompletedAt = timestamppb.New(*milestone.CompletedAt)
 		}
-		milestones = append(milestones, pbMilestone)
-	}
-
-	return &pb.LearningPath{
-		PathId:           path.PathID,
-		RecommendedLevel: path.RecommendedLevel,
-		FocusTopics:      focusTopics,
-		StudyPlan: &pb.StudyPlan{
-			DailyMinutes:        int32(path.StudyPlan.DailyMinutes),
-			WeeklySchedule:      s.convertWeeklyScheduleToInt32(path.StudyPlan.WeeklySchedule),
-			SessionTypes:        path.StudyPlan.SessionTypes,
-			ReviewFrequencyDays: int32(path.StudyPlan.ReviewFrequency),
-			TestFrequencyDays:   int32(path.StudyPlan.TestFrequency),
-		},
-		Milestones:           milestones,
-		EstimatedDurationDays: int32(path.EstimatedDuration),
-		CreatedAt:            timestamppb.New(path.CreatedAt),
+		pbMilestones = append(pbMilestones, pbMilestone)
 	}
+	return pbMilestones
 }
 
 // convertPreferencesFromProto converts protobuf preferences to internal format
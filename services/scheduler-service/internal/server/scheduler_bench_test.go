@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/events"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+	pb "scheduler-service/proto"
+)
+
+const benchItemStates = 5000
+
+// benchItemPrefixes spreads benchmark items across the topics derived by getItemTopicsFromID
+var benchItemPrefixes = []string{"t", "r", "s", "p", "g"}
+
+var (
+	benchMetricsOnce sync.Once
+	benchMetrics     *metrics.Metrics
+)
+
+// sharedBenchMetrics registers Prometheus metrics once per test binary
+func sharedBenchMetrics() *metrics.Metrics {
+	benchMetricsOnce.Do(func() {
+		benchMetrics = metrics.New()
+	})
+	return benchMetrics
+}
+
+// generateSM2States builds a realistic spread of new, due and scheduled items
+func generateSM2States(n int, now time.Time) map[string]*algorithms.SM2State {
+	rng := rand.New(rand.NewSource(42))
+	states := make(map[string]*algorithms.SM2State, n)
+
+	for i := 0; i < n; i++ {
+		itemID := fmt.Sprintf("%s%07d", benchItemPrefixes[i%len(benchItemPrefixes)], i)
+		interval := 1 + rng.Intn(60)
+		lastReviewed := now.Add(-time.Duration(rng.Intn(90*24)) * time.Hour)
+		states[itemID] = &algorithms.SM2State{
+			EasinessFactor: 1.3 + rng.Float64()*1.2,
+			Interval:       interval,
+			Repetition:     rng.Intn(8),
+			LastReviewed:   lastReviewed,
+			NextDue:        lastReviewed.AddDate(0, 0, interval),
+		}
+	}
+
+	return states
+}
+
+func generateBKTStates(now time.Time) map[string]*algorithms.BKTState {
+	states := make(map[string]*algorithms.BKTState)
+	for i, topic := range []string{"traffic_signs", "road_rules", "safety", "parking", "general"} {
+		states[topic] = &algorithms.BKTState{
+			ProbKnowledge: 0.2 + 0.15*float64(i),
+			ProbGuess:     0.25,
+			ProbSlip:      0.1,
+			ProbLearn:     0.15,
+			AttemptsCount: 20,
+			CorrectCount:  10 + i,
+			LastUpdated:   now.Add(-time.Hour),
+			Confidence:    0.6,
+		}
+	}
+	return states
+}
+
+// reportLatencyPercentiles reports p50 and p99 per-operation latency in milliseconds
+func reportLatencyPercentiles(b *testing.B, latencies []time.Duration) {
+	if len(latencies) == 0 {
+		return
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) float64 {
+		idx := int(float64(len(latencies)-1) * p)
+		return float64(latencies[idx].Microseconds()) / 1000.0
+	}
+
+	b.ReportMetric(percentile(0.50), "p50-ms")
+	b.ReportMetric(percentile(0.99), "p99-ms")
+}
+
+// BenchmarkSelectItemsWithUnifiedScoring_5000States measures in-memory ranking for a
+// user with 5,000 item states, i.e. GetNextItems without storage round trips
+func BenchmarkSelectItemsWithUnifiedScoring_5000States(b *testing.B) {
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	service := &SchedulerService{
+		config:         &config.Config{},
+		logger:         log,
+		metrics:        &metrics.Metrics{},
+		sm2Algorithm:   algorithms.NewSM2Algorithm(),
+		bktAlgorithm:   algorithms.NewBKTAlgorithm(),
+		irtAlgorithm:   algorithms.NewIRTAlgorithm(),
+		unifiedScoring: algorithms.NewUnifiedScoringAlgorithm(log),
+	}
+
+	now := time.Now()
+	sm2States := generateSM2States(benchItemStates, now)
+	bktStates := generateBKTStates(now)
+	irtStates := make(map[string]*algorithms.IRTState)
+	for _, topic := range service.collectItemTopics(sm2States) {
+		irtStates[topic] = service.irtAlgorithm.InitializeState(topic)
+	}
+
+	req := &pb.NextItemsRequest{
+		UserId:      "bench-user",
+		SessionId:   "bench-session",
+		SessionType: pb.SessionType_PRACTICE,
+		Count:       20,
+	}
+	ctx := context.Background()
+
+	latencies := make([]time.Duration, 0, b.N)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		start := time.Now()
+		items := service.selectItemsWithUnifiedScoring(ctx, req, sm2States, bktStates, irtStates, nil, nil, nil, "", now)
+		latencies = append(latencies, time.Since(start))
+
+		if len(items) != int(req.Count) {
+			b.Fatalf("expected %d items, got %d", req.Count, len(items))
+		}
+	}
+
+	b.StopTimer()
+	reportLatencyPercentiles(b, latencies)
+}
+
+// BenchmarkGetNextItems_5000States measures the full GetNextItems path against real
+// Postgres and Redis with the optimized pool and client enabled. It runs only when
+// SCHEDULER_BENCH_DATABASE_URL and SCHEDULER_BENCH_REDIS_URL are set.
+func BenchmarkGetNextItems_5000States(b *testing.B) {
+	databaseURL := os.Getenv("SCHEDULER_BENCH_DATABASE_URL")
+	redisURL := os.Getenv("SCHEDULER_BENCH_REDIS_URL")
+	if databaseURL == "" || redisURL == "" {
+		b.Skip("SCHEDULER_BENCH_DATABASE_URL and SCHEDULER_BENCH_REDIS_URL not set")
+	}
+
+	cfg, err := config.Parse(config.Values{
+		"DATABASE_URL": databaseURL,
+		"REDIS_URL":    redisURL,
+		"LOG_LEVEL":    "error",
+		"LOG_FORMAT":   "text",
+	})
+	if err != nil {
+		b.Fatalf("invalid configuration: %v", err)
+	}
+	log := logger.New(&cfg.Logging)
+	metricsInstance := sharedBenchMetrics()
+
+	db, err := database.New(&cfg.Database, metricsInstance, log)
+	if err != nil {
+		b.Fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	redisClient, err := cache.New(&cfg.Redis, metricsInstance, log)
+	if err != nil {
+		b.Fatalf("failed to connect to Redis: %v", err)
+	}
+	defer redisClient.Close()
+
+	perfCfg := config.LoadPerformanceConfig()
+	pool, err := database.NewOptimizedPool(databaseURL, &perfCfg.Database, log, metricsInstance)
+	if err != nil {
+		b.Fatalf("failed to create optimized pool: %v", err)
+	}
+	defer pool.Close()
+
+	fastCache, err := cache.NewOptimizedRedisClient(&cfg.Redis, &perfCfg.Cache, log, metricsInstance)
+	if err != nil {
+		b.Fatalf("failed to create optimized Redis client: %v", err)
+	}
+	defer fastCache.Close()
+
+	service := NewSchedulerService(cfg, log, metricsInstance, db, redisClient, nil, events.NewNoOpEventPublisher(), nil)
+	service.EnableOptimizedPaths(pool, fastCache)
+
+	ctx := context.Background()
+	userID := fmt.Sprintf("00000000-0000-4000-8000-%012d", time.Now().UnixNano()%1e12)
+	seedBenchUser(b, ctx, pool, userID)
+	defer cleanupBenchUser(ctx, pool, redisClient, userID)
+
+	req := &pb.NextItemsRequest{
+		UserId:      userID,
+		SessionId:   "bench-session",
+		SessionType: pb.SessionType_PRACTICE,
+		Count:       20,
+	}
+
+	// Warm the cache so the benchmark measures the steady state
+	if _, err := service.GetNextItems(ctx, req); err != nil {
+		b.Fatalf("warm-up GetNextItems failed: %v", err)
+	}
+
+	latencies := make([]time.Duration, 0, b.N)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		start := time.Now()
+		if _, err := service.GetNextItems(ctx, req); err != nil {
+			b.Fatalf("GetNextItems failed: %v", err)
+		}
+		latencies = append(latencies, time.Since(start))
+	}
+
+	b.StopTimer()
+	reportLatencyPercentiles(b, latencies)
+}
+
+// seedBenchUser inserts 5,000 SM-2 states for the benchmark user in batches
+func seedBenchUser(b *testing.B, ctx context.Context, pool *database.OptimizedPool, userID string) {
+	b.Helper()
+
+	const batchSize = 500
+	now := time.Now()
+	states := generateSM2States(benchItemStates, now)
+
+	var queries []string
+	var argsList [][]interface{}
+	i := 0
+	for _, state := range states {
+		queries = append(queries,
+			`INSERT INTO sm2_states (user_id, item_id, easiness_factor, interval_days, repetition, next_due, last_reviewed)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`)
+		argsList = append(argsList, []interface{}{
+			userID, fmt.Sprintf("00000000-0000-4000-9000-%012d", i),
+			state.EasinessFactor, state.Interval, state.Repetition, state.NextDue, state.LastReviewed,
+		})
+		i++
+
+		if len(queries) == batchSize {
+			if err := pool.BatchExec(ctx, queries, argsList); err != nil {
+				b.Fatalf("failed to seed SM-2 states: %v", err)
+			}
+			queries, argsList = nil, nil
+		}
+	}
+
+	if len(queries) > 0 {
+		if err := pool.BatchExec(ctx, queries, argsList); err != nil {
+			b.Fatalf("failed to seed SM-2 states: %v", err)
+		}
+	}
+}
+
+func cleanupBenchUser(ctx context.Context, pool *database.OptimizedPool, redisClient *cache.RedisClient, userID string) {
+	pool.ExecContext(ctx, "DELETE FROM sm2_states WHERE user_id = $1", userID)
+	redisClient.DeletePattern(ctx, "sm2:user:"+userID+":*")
+}
@@ -2,7 +2,12 @@ package server
 
 import (
 	"context"
+	"database/sql/driver"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"scheduler-service/internal/config"
 	"scheduler-service/internal/logger"
@@ -59,26 +64,18 @@ func TestSchedulerService_Health(t *testing.T) {
 }
 
 func TestSchedulerService_GetNextItems_Validation(t *testing.T) {
-	// Create test configuration
-	cfg := &config.Config{
-		Logging: config.LoggingConfig{
-			Level:  "info",
-			Format: "text",
+	// Create scheduler service over a database holding the user's SM-2 states
+	now := time.Now()
+	service := newTestSchedulerService(t, map[string]*fakeTable{
+		"sm2_states": {
+			columns: []string{"user_id", "item_id", "easiness_factor", "interval_days", "repetition", "next_due", "last_reviewed", "lapses"},
+			rows: [][]driver.Value{
+				{"test-user", "stop_sign_1", 2.5, int64(1), int64(1), now.Add(-time.Hour), now.Add(-25 * time.Hour), int64(0)},
+				{"test-user", "speed_limit_1", 2.3, int64(6), int64(2), now.Add(-2 * time.Hour), now.Add(-6 * 24 * time.Hour), int64(1)},
+				{"test-user", "parking_1", 2.6, int64(0), int64(0), now, now, int64(0)},
+			},
 		},
-	}
-
-	// Create logger and reuse metrics from previous test
-	log := logger.New(&cfg.Logging)
-	metricsInstance := &metrics.Metrics{} // Use empty struct for testing
-
-	// Create scheduler service
-	service := &SchedulerService{
-		config:  cfg,
-		logger:  log,
-		metrics: metricsInstance,
-		db:      nil,
-		cache:   nil,
-	}
+	})
 
 	// Test with empty user ID (should fail validation)
 	req := &pb.NextItemsRequest{
@@ -106,7 +103,7 @@ func TestSchedulerService_GetNextItems_Validation(t *testing.T) {
 		t.Error("Expected validation error for invalid count")
 	}
 
-	// Test with valid request (should succeed with placeholder data)
+	// Test with valid request (should recommend the user's items)
 	req = &pb.NextItemsRequest{
 		UserId:      "test-user",
 		SessionId:   "test-session",
@@ -131,3 +128,87 @@ func TestSchedulerService_GetNextItems_Validation(t *testing.T) {
 		t.Error("Expected strategy to be set")
 	}
 }
+
+func TestBuryingSibling(t *testing.T) {
+	reviewed := map[string][]string{"stop_sign": {"stop_sign_1"}}
+
+	if got := buryingSibling(reviewed, "stop_sign", "stop_sign_2"); got != "stop_sign_1" {
+		t.Errorf("Expected stop_sign_2 to be buried by stop_sign_1, got %q", got)
+	}
+	if got := buryingSibling(reviewed, "stop_sign", "stop_sign_1"); got != "" {
+		t.Errorf("Expected the reviewed item not to bury itself, got %q", got)
+	}
+	if got := buryingSibling(reviewed, "", "unrelated"); got != "" {
+		t.Errorf("Expected items without a sibling group not to be buried, got %q", got)
+	}
+}
+
+func TestSchedulerService_InitializeUser_SwitchesJurisdiction(t *testing.T) {
+	// Create scheduler service over a database holding a user booked for an exam in GB
+	now := time.Now()
+	service := newTestSchedulerService(t, map[string]*fakeTable{
+		"user_scheduler_settings": {
+			columns: []string{"user_id", "country_code", "exam_date", "created_at", "updated_at"},
+			rows: [][]driver.Value{
+				{"test-user", "GB", now.Add(30 * 24 * time.Hour), now, now},
+			},
+		},
+	})
+
+	// Re-initializing in another country switches jurisdiction, leaving the GB exam date behind
+	resp, err := service.InitializeUser(context.Background(), &pb.InitializeUserRequest{
+		UserId:      "test-user",
+		CountryCode: "US",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error re-initializing in another country: %v", err)
+	}
+	if resp.GetInitialState().GetExamDate() != nil {
+		t.Errorf("Expected the GB exam date not to carry over to US, got %v", resp.GetInitialState().GetExamDate().AsTime())
+	}
+
+	// Re-initializing in the same country keeps the booked exam date
+	resp, err = service.InitializeUser(context.Background(), &pb.InitializeUserRequest{
+		UserId:      "test-user",
+		CountryCode: "gb",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error re-initializing in the same country: %v", err)
+	}
+	if resp.GetInitialState().GetExamDate() == nil {
+		t.Error("Expected the booked exam date to be kept")
+	}
+
+	// Unknown jurisdictions are rejected
+	if _, err := service.InitializeUser(context.Background(), &pb.InitializeUserRequest{
+		UserId:      "test-user",
+		CountryCode: "not-a-country",
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for an unknown jurisdiction, got %v", err)
+	}
+}
+
+func TestSchedulerService_ItemCandidate(t *testing.T) {
+	// Create scheduler service over a catalog holding one calibrated item
+	service := newTestSchedulerService(t, map[string]*fakeTable{
+		"items": {
+			columns: []string{"id", "slug", "topics", "jurisdictions", "difficulty", "discrimination", "guessing", "estimated_time", "sibling_group"},
+			rows: [][]driver.Value{
+				{"stop_sign_1", "stop-sign-1", []byte(`["traffic_signs"]`), []byte(`[]`), 1.8, 1.4, 0.2, int64(45), "stop_sign"},
+			},
+		},
+	})
+	service.itemCatalog.Refresh(context.Background())
+
+	candidate := service.itemCandidate("stop_sign_1")
+	if candidate.Difficulty != 1.8 || candidate.Discrimination != 1.4 || candidate.Guessing != 0.2 {
+		t.Errorf("Expected the item to be scored by its catalog parameters, got %+v", candidate)
+	}
+	if candidate.EstimatedTime != 45*time.Second || candidate.SiblingGroup != "stop_sign" {
+		t.Errorf("Expected the item's catalog time and sibling group, got %+v", candidate)
+	}
+
+	if unknown := service.itemCandidate("unknown_item"); unknown.Difficulty != 0.5 || unknown.Discrimination != 1.0 {
+		t.Errorf("Expected items missing from the catalog to be scored as average items, got %+v", unknown)
+	}
+}
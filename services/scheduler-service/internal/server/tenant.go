@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/tenant"
+	pb "scheduler-service/proto"
+)
+
+// tenantInterceptor resolves the tenant a request is made within from its
+// "x-tenant-id" metadata, or from the tenant a learner's access token is bound to, and
+// checks that the user the request acts on belongs to it. Requests on a member of a
+// tenant are always made within one of the user's tenants, so leaving the header out
+// does not lift the tenant's restrictions. Other requests without a tenant are served
+// as before. It runs after authentication and before rate limiting, so tenants get
+// buckets of their own.
+func tenantInterceptor(registry *tenant.Registry, metrics *metrics.Metrics, log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] || strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+			return handler(ctx, req)
+		}
+
+		tenantID, err := requestTenantID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if userReq, ok := req.(userScopedRequest); ok && userReq.GetUserId() != "" {
+			memberships, err := registry.Memberships(ctx, userReq.GetUserId())
+			if err != nil {
+				log.WithContext(ctx).WithError(err).Error("Failed to get tenant memberships")
+				return nil, status.Error(codes.Internal, "failed to check tenant membership")
+			}
+			resolved, err := membershipTenantID(memberships, tenantID)
+			if err != nil {
+				log.WithContext(ctx).WithFields(map[string]interface{}{
+					"method":  info.FullMethod,
+					"tenant":  tenantID,
+					"user_id": userReq.GetUserId(),
+				}).Warn("Rejected request for a user outside the tenant")
+				return nil, err
+			}
+			tenantID = resolved
+		}
+		if tenantID == "" {
+			return handler(ctx, req)
+		}
+
+		t, err := registry.Get(ctx, tenantID)
+		if err != nil {
+			log.WithContext(ctx).WithField("tenant", tenantID).Debug("Rejected request for unknown tenant")
+			return nil, status.Error(codes.NotFound, "unknown tenant")
+		}
+
+		if userReq, ok := req.(userScopedRequest); ok && userReq.GetUserId() != "" {
+			if _, err := registry.Member(ctx, t.ID, userReq.GetUserId()); err != nil {
+				if errors.Is(err, tenant.ErrNotMember) {
+					log.WithContext(ctx).WithFields(map[string]interface{}{
+						"method":  info.FullMethod,
+						"tenant":  t.ID,
+						"user_id": userReq.GetUserId(),
+					}).Warn("Rejected request for a user outside the tenant")
+					return nil, status.Error(codes.PermissionDenied, "user is not a member of the tenant")
+				}
+				log.WithContext(ctx).WithError(err).Error("Failed to check tenant membership")
+				return nil, status.Error(codes.Internal, "failed to check tenant membership")
+			}
+		}
+
+		start := time.Now()
+		resp, err := handler(tenant.NewContext(ctx, t), req)
+		if metrics != nil {
+			result := "success"
+			if err != nil {
+				result = "error"
+			}
+			metrics.RecordTenantRequest(t.ID, info.FullMethod, result, time.Since(start))
+		}
+		return resp, err
+	}
+}
+
+// requestTenantID returns the tenant a request is made within. Learners whose access
+// token is bound to a tenant cannot make requests within another one.
+func requestTenantID(ctx context.Context) (string, error) {
+	tenantID := tenant.IDFromMetadata(ctx)
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.Tenant == "" {
+		return tenantID, nil
+	}
+	if tenantID != "" && tenantID != principal.Tenant {
+		return "", status.Error(codes.PermissionDenied, "access token is bound to another tenant")
+	}
+	return principal.Tenant, nil
+}
+
+// membershipTenantID returns the tenant a request on a user is made within: the
+// requested or bound tenant, which the user must belong to, or the tenant the user
+// joined first when none was. Requests on users without memberships keep the
+// requested tenant, whose membership check rejects them.
+func membershipTenantID(memberships []tenant.Member, requested string) (string, error) {
+	if requested == "" {
+		if len(memberships) == 0 {
+			return "", nil
+		}
+		return memberships[0].TenantID, nil
+	}
+	if len(memberships) == 0 {
+		return requested, nil
+	}
+	for _, member := range memberships {
+		if member.TenantID == requested {
+			return requested, nil
+		}
+	}
+	return "", status.Error(codes.PermissionDenied, "user is not a member of the tenant")
+}
+
+// UpsertTenant creates or replaces an organization with its item subset and scoring strategy
+func (s *SchedulerService) UpsertTenant(ctx context.Context, req *pb.UpsertTenantRequest) (*pb.UpsertTenantResponse, error) {
+	if req.Tenant == nil {
+		return nil, status.Error(codes.InvalidArgument, "tenant is required")
+	}
+	s.logger.WithContext(ctx).WithField("tenant", req.Tenant.Id).Info("Upserting tenant")
+
+	t := convertTenantFromProto(req.Tenant)
+	if err := s.tenants.Upsert(ctx, t); err != nil {
+		if errors.Is(err, tenant.ErrInvalidTenant) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to upsert tenant")
+		return nil, status.Error(codes.Internal, "failed to upsert tenant")
+	}
+
+	return &pb.UpsertTenantResponse{Tenant: convertTenantToProto(t)}, nil
+}
+
+// UpdateTenantMembers adds learners and instructors to an organization, or removes them
+func (s *SchedulerService) UpdateTenantMembers(ctx context.Context, req *pb.UpdateTenantMembersRequest) (*pb.UpdateTenantMembersResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"tenant": req.TenantId,
+		"add":    len(req.Add),
+		"remove": len(req.RemoveUserIds),
+	}).Info("Updating tenant members")
+
+	// Validate request
+	if req.TenantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant_id is required")
+	}
+
+	members := make([]tenant.Member, 0, len(req.Add))
+	for _, member := range req.Add {
+		role := member.Role
+		if role == "" {
+			role = tenant.RoleLearner
+		}
+		members = append(members, tenant.Member{TenantID: req.TenantId, UserID: member.UserId, Role: role})
+	}
+
+	if err := s.tenants.UpdateMembers(ctx, req.TenantId, members, req.RemoveUserIds); err != nil {
+		switch {
+		case errors.Is(err, tenant.ErrInvalidTenant):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, tenant.ErrUnknownTenant):
+			return nil, status.Error(codes.NotFound, "unknown tenant")
+		}
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to update tenant members")
+		return nil, status.Error(codes.Internal, "failed to update tenant members")
+	}
+
+	return &pb.UpdateTenantMembersResponse{
+		Added:   int32(len(members)),
+		Removed: int32(len(req.RemoveUserIds)),
+	}, nil
+}
+
+// tenantAllowsItem reports whether the tenant of the request, if any, schedules an item
+func (s *SchedulerService) tenantAllowsItem(ctx context.Context, itemID string) bool {
+	t, ok := tenant.FromContext(ctx)
+	return !ok || t.AllowsItem(itemID, s.getItemTopicsFromID(itemID))
+}
+
+func convertTenantFromProto(t *pb.Tenant) *tenant.Tenant {
+	converted := &tenant.Tenant{
+		ID:              t.Id,
+		Name:            t.Name,
+		ScoringStrategy: t.ScoringStrategy,
+		Topics:          t.Topics,
+		Active:          !t.Disabled,
+	}
+	if w := t.Weights; w != nil {
+		converted.Weights = &algorithms.ScoringWeights{
+			Urgency:     w.Urgency,
+			Mastery:     w.Mastery,
+			Difficulty:  w.Difficulty,
+			Exploration: w.Exploration,
+		}
+	}
+	if len(t.ItemIds) > 0 {
+		converted.Items = make(map[string]bool, len(t.ItemIds))
+		for _, itemID := range t.ItemIds {
+			converted.Items[itemID] = true
+		}
+	}
+	return converted
+}
+
+func convertTenantToProto(t *tenant.Tenant) *pb.Tenant {
+	converted := &pb.Tenant{
+		Id:              t.ID,
+		Name:            t.Name,
+		ScoringStrategy: t.ScoringStrategy,
+		Topics:          t.Topics,
+		Disabled:        !t.Active,
+	}
+	if w := t.Weights; w != nil {
+		converted.Weights = &pb.TenantScoringWeights{
+			Urgency:     w.Urgency,
+			Mastery:     w.Mastery,
+			Difficulty:  w.Difficulty,
+			Exploration: w.Exploration,
+		}
+	}
+	for itemID := range t.Items {
+		converted.ItemIds = append(converted.ItemIds, itemID)
+	}
+	sort.Strings(converted.ItemIds)
+	return converted
+}
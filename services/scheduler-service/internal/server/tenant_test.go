@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"scheduler-service/internal/auth"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/tenant"
+	pb "scheduler-service/proto"
+)
+
+func withTenantHeader(ctx context.Context, tenantID string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(tenant.MetadataKey, tenantID))
+}
+
+func TestRequestTenantID(t *testing.T) {
+	bound := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "user-1", Tenant: "school-1"})
+	gateway := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "gateway", Service: true})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+		code     codes.Code
+	}{
+		{"no tenant", context.Background(), "", codes.OK},
+		{"header", withTenantHeader(gateway, "school-2"), "school-2", codes.OK},
+		{"bound token", bound, "school-1", codes.OK},
+		{"bound token with matching header", withTenantHeader(bound, "school-1"), "school-1", codes.OK},
+		{"bound token with another tenant", withTenantHeader(bound, "school-2"), "", codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantID, err := requestTenantID(tt.ctx)
+			if status.Code(err) != tt.code {
+				t.Fatalf("Expected code %v, got %v", tt.code, err)
+			}
+			if tenantID != tt.expected {
+				t.Errorf("Expected tenant %q, got %q", tt.expected, tenantID)
+			}
+		})
+	}
+}
+
+func TestMembershipTenantID(t *testing.T) {
+	memberships := []tenant.Member{{TenantID: "school-1"}, {TenantID: "school-2"}}
+
+	tests := []struct {
+		name        string
+		memberships []tenant.Member
+		requested   string
+		expected    string
+		code        codes.Code
+	}{
+		{"no memberships", nil, "", "", codes.OK},
+		{"no memberships with header", nil, "school-3", "school-3", codes.OK},
+		{"member without header", memberships, "", "school-1", codes.OK},
+		{"member choosing a tenant", memberships, "school-2", "school-2", codes.OK},
+		{"member naming another tenant", memberships, "school-3", "", codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantID, err := membershipTenantID(tt.memberships, tt.requested)
+			if status.Code(err) != tt.code {
+				t.Fatalf("Expected code %v, got %v", tt.code, err)
+			}
+			if tenantID != tt.expected {
+				t.Errorf("Expected tenant %q, got %q", tt.expected, tenantID)
+			}
+		})
+	}
+}
+
+func TestTenantInterceptor_BoundTokenOutsideMemberships(t *testing.T) {
+	// The user only belongs to school-2
+	db := newFakeDB(t, map[string]*fakeTable{
+		"tenant_members": {
+			columns: []string{"tenant_id", "user_id", "role", "joined_at"},
+			rows:    [][]driver.Value{{"school-2", "user-1", tenant.RoleLearner, time.Now()}},
+		},
+	})
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	registry := tenant.NewRegistry(&config.TenantConfig{RefreshInterval: time.Minute}, db, nil, log, nil)
+	interceptor := tenantInterceptor(registry, nil, log)
+
+	bound := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "user-1", Tenant: "school-1"})
+	gateway := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "gateway", Service: true})
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"token bound to another tenant", bound},
+		{"header naming another tenant", withTenantHeader(gateway, "school-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: pb.SchedulerService_GetNextItems_FullMethodName}
+
+			_, err := interceptor(tt.ctx, &pb.NextItemsRequest{UserId: "user-1"}, info, handler)
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("Expected PermissionDenied, got %v", err)
+			}
+			if called {
+				t.Error("Expected the request not to be served within the user's own tenant")
+			}
+		})
+	}
+}
+
+func TestRateLimitRequest_Tenant(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), &tenant.Tenant{ID: "school-1"})
+	req := rateLimitRequest(ctx, pb.SchedulerService_GetNextItems_FullMethodName, &pb.NextItemsRequest{UserId: "user-1"})
+	if req.Tenant != "school-1" {
+		t.Errorf("Expected requests within a tenant to be charged to it, got %+v", req)
+	}
+}
+
+func TestConvertTenant(t *testing.T) {
+	converted := convertTenantFromProto(&pb.Tenant{
+		Id:      "school-1",
+		Name:    "Downtown Driving School",
+		Weights: &pb.TenantScoringWeights{Urgency: 0.4, Mastery: 0.4, Difficulty: 0.1, Exploration: 0.1},
+		ItemIds: []string{"item-2", "item-1"},
+	})
+	if !converted.Active || converted.Weights == nil || !converted.Items["item-1"] {
+		t.Fatalf("Unexpected tenant %+v", converted)
+	}
+
+	back := convertTenantToProto(converted)
+	if back.Disabled || len(back.ItemIds) != 2 || back.ItemIds[0] != "item-1" {
+		t.Errorf("Unexpected tenant %+v", back)
+	}
+}
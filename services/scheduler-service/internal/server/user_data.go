@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"scheduler-service/internal/userdata"
+	pb "scheduler-service/proto"
+)
+
+// ExportUserLearningState returns everything the scheduler keeps about the user, for
+// data access requests, with a signed receipt of the export
+func (s *SchedulerService) ExportUserLearningState(ctx context.Context, req *pb.ExportUserLearningStateRequest) (*pb.ExportUserLearningStateResponse, error) {
+	s.logger.WithContext(ctx).WithField("user_id", req.UserId).Info("Exporting user learning state")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	data, receipt, err := s.userData.Export(ctx, req.UserId)
+	if err != nil {
+		return nil, s.userDataError(ctx, err, "failed to export user learning state")
+	}
+
+	return &pb.ExportUserLearningStateResponse{
+		Data:    string(data),
+		Receipt: convertReceiptToProto(receipt),
+	}, nil
+}
+
+// DeleteUserLearningState purges everything the scheduler keeps about the user, for
+// erasure requests, and returns a signed receipt. Repeating a request_id purges again
+// and returns the original receipt.
+func (s *SchedulerService) DeleteUserLearningState(ctx context.Context, req *pb.DeleteUserLearningStateRequest) (*pb.DeleteUserLearningStateResponse, error) {
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":    req.UserId,
+		"request_id": req.RequestId,
+	}).Info("Deleting user learning state")
+
+	// Validate request
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.RequestId == "" {
+		return nil, status.Error(codes.InvalidArgument, "request_id is required")
+	}
+
+	receipt, replayed, err := s.userData.Delete(ctx, req.UserId, req.RequestId)
+	if err != nil {
+		return nil, s.userDataError(ctx, err, "failed to delete user learning state")
+	}
+
+	return &pb.DeleteUserLearningStateResponse{
+		Receipt:  convertReceiptToProto(receipt),
+		Replayed: replayed,
+	}, nil
+}
+
+// userDataError converts a user data service error to a gRPC status
+func (s *SchedulerService) userDataError(ctx context.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, userdata.ErrInvalidUserID), errors.Is(err, userdata.ErrRequestIDRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, userdata.ErrRequestIDConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, userdata.ErrSigningUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	s.logger.WithContext(ctx).WithError(err).Error(message)
+	return status.Error(codes.Internal, message)
+}
+
+// convertReceiptToProto converts a signed receipt to protobuf format
+func convertReceiptToProto(receipt *userdata.SignedReceipt) *pb.LearningStateReceipt {
+	return &pb.LearningStateReceipt{
+		ReceiptId:  receipt.ReceiptID,
+		Operation:  receipt.Operation,
+		UserId:     receipt.UserID,
+		RequestId:  receipt.RequestID,
+		IssuedAt:   timestamppb.New(receipt.IssuedAt),
+		TableRows:  receipt.TableRows,
+		CacheKeys:  int32(receipt.CacheKeys),
+		DataSha256: receipt.DataSHA256,
+		Payload:    receipt.Payload,
+		Signature:  receipt.Signature,
+		KeyId:      receipt.KeyID,
+	}
+}
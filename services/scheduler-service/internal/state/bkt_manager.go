@@ -13,6 +13,7 @@ import (
 	pb "scheduler-service/proto"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BKTStateManager handles BKT state persistence and caching
@@ -21,6 +22,13 @@ type BKTStateManager struct {
 	db           *database.DB
 	cache        *cache.RedisClient
 	logger       *logger.Logger
+
+	// Optional prepared-statement pool for bulk reads
+	pool *database.OptimizedPool
+
+	// Resolves the jurisdiction whose states a user works in; all users
+	// share the default jurisdiction when unset
+	jurisdictions JurisdictionResolver
 }
 
 // NewBKTStateManager creates a new BKT state manager
@@ -38,21 +46,53 @@ func NewBKTStateManager(
 	}
 }
 
-// GetState retrieves BKT state for a user's topic, creating if not exists
+// UseOptimizedPool routes bulk reads through the prepared-statement pool.
+// Passing nil restores the default GORM path.
+func (m *BKTStateManager) UseOptimizedPool(pool *database.OptimizedPool) {
+	m.pool = pool
+}
+
+// UseJurisdictionResolver keys states by the jurisdiction each user is preparing for
+func (m *BKTStateManager) UseJurisdictionResolver(resolver JurisdictionResolver) {
+	m.jurisdictions = resolver
+}
+
+// activeJurisdiction returns the jurisdiction whose states the user currently works in
+func (m *BKTStateManager) activeJurisdiction(ctx context.Context, userID string) (string, error) {
+	if m.jurisdictions == nil {
+		return NormalizeJurisdiction(""), nil
+	}
+	jurisdiction, err := m.jurisdictions.ActiveJurisdiction(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve jurisdiction: %w", err)
+	}
+	return jurisdiction, nil
+}
+
+// GetState retrieves BKT state for a user's topic in their active jurisdiction, creating if not exists
 func (m *BKTStateManager) GetState(ctx context.Context, userID, topic string) (*algorithms.BKTState, error) {
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetStateIn(ctx, userID, jurisdiction, topic)
+}
+
+// GetStateIn retrieves BKT state for a user's topic in the given jurisdiction, creating if not exists
+func (m *BKTStateManager) GetStateIn(ctx context.Context, userID, jurisdiction, topic string) (*algorithms.BKTState, error) {
 	// Try cache first
-	cacheKey := fmt.Sprintf("bkt:%s:%s", userID, topic)
+	cacheKey := m.getCacheKey(userID, jurisdiction, topic)
 
 	if m.cache != nil {
 		var state algorithms.BKTState
-		err := m.cache.Get(ctx, cacheKey, &state)
+		err := m.cache.GetUser(ctx, userID, cacheKey, &state)
 		if err == nil {
 			// Apply time decay if needed
 			currentTime := time.Now()
 			if currentTime.Sub(state.LastUpdated).Hours() > 24 {
 				decayedState := m.bktAlgorithm.ApplyTimeDecay(&state, currentTime)
 				// Update cache with decayed state
-				go m.cacheState(context.Background(), userID, topic, decayedState)
+				go m.cacheState(context.Background(), userID, jurisdiction, topic, decayedState)
 				return decayedState, nil
 			}
 			return &state, nil
@@ -61,7 +101,9 @@ func (m *BKTStateManager) GetState(ctx context.Context, userID, topic string) (*
 
 	// Load from database
 	var dbState models.BKTStateModel
-	err := m.db.WithContext(ctx).Where("user_id = ? AND topic = ?", userID, topic).First(&dbState).Error
+	err := m.db.WithContext(ctx).
+		Where("user_id = ? AND jurisdiction = ? AND topic = ?", userID, jurisdiction, topic).
+		First(&dbState).Error
 
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -71,6 +113,7 @@ func (m *BKTStateManager) GetState(ctx context.Context, userID, topic string) (*
 			// Save to database
 			dbState = models.BKTStateModel{
 				UserID:        userID,
+				Jurisdiction:  jurisdiction,
 				Topic:         topic,
 				ProbKnowledge: state.ProbKnowledge,
 				ProbGuess:     state.ProbGuess,
@@ -84,21 +127,23 @@ func (m *BKTStateManager) GetState(ctx context.Context, userID, topic string) (*
 
 			if err := m.db.WithContext(ctx).Create(&dbState).Error; err != nil {
 				m.logger.WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
-					"user_id": userID,
-					"topic":   topic,
+					"user_id":      userID,
+					"jurisdiction": jurisdiction,
+					"topic":        topic,
 				}).Error("Failed to create BKT state")
 				return nil, fmt.Errorf("failed to create BKT state: %w", err)
 			}
 
 			// Cache the new state
-			go m.cacheState(context.Background(), userID, topic, state)
+			go m.cacheState(context.Background(), userID, jurisdiction, topic, state)
 
 			return state, nil
 		}
 
 		m.logger.WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
-			"user_id": userID,
-			"topic":   topic,
+			"user_id":      userID,
+			"jurisdiction": jurisdiction,
+			"topic":        topic,
 		}).Error("Failed to get BKT state from database")
 		return nil, fmt.Errorf("failed to get BKT state: %w", err)
 	}
@@ -122,25 +167,37 @@ func (m *BKTStateManager) GetState(ctx context.Context, userID, topic string) (*
 	}
 
 	// Cache the state
-	go m.cacheState(context.Background(), userID, topic, state)
+	go m.cacheState(context.Background(), userID, jurisdiction, topic, state)
 
 	return state, nil
 }
 
-// UpdateState updates BKT state based on user response
+// UpdateState updates BKT state in the user's active jurisdiction based on user response
 func (m *BKTStateManager) UpdateState(ctx context.Context, userID, topic string, correct bool) (*algorithms.BKTState, error) {
+	return m.UpdateStateWeighted(ctx, userID, topic, correct, 1.0)
+}
+
+// UpdateStateWeighted updates the BKT state with a response that counts as partial
+// evidence of knowledge, weighted by weight in [0, 1]
+func (m *BKTStateManager) UpdateStateWeighted(ctx context.Context, userID, topic string, correct bool, weight float64) (*algorithms.BKTState, error) {
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get current state
-	currentState, err := m.GetState(ctx, userID, topic)
+	currentState, err := m.GetStateIn(ctx, userID, jurisdiction, topic)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current BKT state: %w", err)
 	}
 
 	// Update state using BKT algorithm
-	newState := m.bktAlgorithm.UpdateState(currentState, correct)
+	newState := m.bktAlgorithm.UpdateStateWeighted(currentState, correct, weight)
 
 	// Save to database
 	dbState := models.BKTStateModel{
 		UserID:        userID,
+		Jurisdiction:  jurisdiction,
 		Topic:         topic,
 		ProbKnowledge: newState.ProbKnowledge,
 		ProbGuess:     newState.ProbGuess,
@@ -155,20 +212,23 @@ func (m *BKTStateManager) UpdateState(ctx context.Context, userID, topic string,
 	err = m.db.WithContext(ctx).Save(&dbState).Error
 	if err != nil {
 		m.logger.WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
-			"user_id": userID,
-			"topic":   topic,
-			"correct": correct,
+			"user_id":      userID,
+			"jurisdiction": jurisdiction,
+			"topic":        topic,
+			"correct":      correct,
 		}).Error("Failed to update BKT state in database")
 		return nil, fmt.Errorf("failed to update BKT state: %w", err)
 	}
 
 	// Update cache
-	go m.cacheState(context.Background(), userID, topic, newState)
+	go m.cacheState(context.Background(), userID, jurisdiction, topic, newState)
 
 	m.logger.WithContext(ctx).WithFields(map[string]interface{}{
 		"user_id":        userID,
+		"jurisdiction":   jurisdiction,
 		"topic":          topic,
 		"correct":        correct,
+		"evidence":       weight,
 		"old_knowledge":  currentState.ProbKnowledge,
 		"new_knowledge":  newState.ProbKnowledge,
 		"attempts_count": newState.AttemptsCount,
@@ -179,10 +239,23 @@ func (m *BKTStateManager) UpdateState(ctx context.Context, userID, topic string,
 	return newState, nil
 }
 
-// GetUserStates retrieves all BKT states for a user
+// GetUserStates retrieves all BKT states for a user in their active jurisdiction
 func (m *BKTStateManager) GetUserStates(ctx context.Context, userID string) (map[string]*algorithms.BKTState, error) {
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetUserStatesIn(ctx, userID, jurisdiction)
+}
+
+// GetUserStatesIn retrieves all BKT states for a user in the given jurisdiction
+func (m *BKTStateManager) GetUserStatesIn(ctx context.Context, userID, jurisdiction string) (map[string]*algorithms.BKTState, error) {
+	if m.pool != nil {
+		return m.getUserStatesFromPool(ctx, userID, jurisdiction)
+	}
+
 	var dbStates []models.BKTStateModel
-	err := m.db.WithContext(ctx).Where("user_id = ?", userID).Find(&dbStates).Error
+	err := m.db.WithContext(ctx).Where("user_id = ? AND jurisdiction = ?", userID, jurisdiction).Find(&dbStates).Error
 	if err != nil {
 		m.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("Failed to get user BKT states")
 		return nil, fmt.Errorf("failed to get user BKT states: %w", err)
@@ -214,6 +287,164 @@ func (m *BKTStateManager) GetUserStates(ctx context.Context, userID string) (map
 	return states, nil
 }
 
+// getUserStatesFromPool loads all of a user's BKT states with a single prepared statement
+func (m *BKTStateManager) getUserStatesFromPool(ctx context.Context, userID, jurisdiction string) (map[string]*algorithms.BKTState, error) {
+	rows, err := m.pool.QueryContext(ctx,
+		`SELECT topic, prob_knowledge, prob_guess, prob_slip, prob_learn,
+			attempts_count, correct_count, confidence, last_updated
+		FROM bkt_states WHERE user_id = $1 AND jurisdiction = $2`, userID, jurisdiction)
+	if err != nil {
+		m.logger.WithContext(ctx).WithError(err).WithField("user_id", userID).Error("Failed to get user BKT states")
+		return nil, fmt.Errorf("failed to get user BKT states: %w", err)
+	}
+	defer rows.Close()
+
+	states := make(map[string]*algorithms.BKTState)
+	currentTime := time.Now()
+
+	for rows.Next() {
+		var topic string
+		state := &algorithms.BKTState{}
+		if err := rows.Scan(&topic, &state.ProbKnowledge, &state.ProbGuess, &state.ProbSlip, &state.ProbLearn,
+			&state.AttemptsCount, &state.CorrectCount, &state.Confidence, &state.LastUpdated); err != nil {
+			return nil, fmt.Errorf("failed to scan BKT state: %w", err)
+		}
+
+		// Apply time decay if needed
+		if currentTime.Sub(state.LastUpdated).Hours() > 24 {
+			state = m.bktAlgorithm.ApplyTimeDecay(state, currentTime)
+		}
+
+		states[topic] = state
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read user BKT states: %w", err)
+	}
+
+	return states, nil
+}
+
+// ApplyTimeDecay persists time decay for all of a user's BKT states, in every jurisdiction,
+// that have not been updated within staleAfter, so reads no longer need to decay them
+func (m *BKTStateManager) ApplyTimeDecay(ctx context.Context, userID string, staleAfter time.Duration) error {
+	currentTime := time.Now()
+
+	var dbStates []models.BKTStateModel
+	err := m.db.WithContext(ctx).
+		Where("user_id = ? AND last_updated < ?", userID, currentTime.Add(-staleAfter)).
+		Find(&dbStates).Error
+	if err != nil {
+		return fmt.Errorf("failed to get stale BKT states: %w", err)
+	}
+
+	for _, dbState := range dbStates {
+		state := &algorithms.BKTState{
+			ProbKnowledge: dbState.ProbKnowledge,
+			ProbGuess:     dbState.ProbGuess,
+			ProbSlip:      dbState.ProbSlip,
+			ProbLearn:     dbState.ProbLearn,
+			AttemptsCount: dbState.AttemptsCount,
+			CorrectCount:  dbState.CorrectCount,
+			LastUpdated:   dbState.LastUpdated,
+			Confidence:    dbState.Confidence,
+		}
+
+		decayedState := m.bktAlgorithm.ApplyTimeDecay(state, currentTime)
+
+		// last_updated moves forward with the decay, so later reads decay only from now;
+		// activity is read from attempts, never from last_updated
+		decayedState.LastUpdated = currentTime
+		err := m.db.WithContext(ctx).Model(&dbState).UpdateColumns(map[string]interface{}{
+			"prob_knowledge": decayedState.ProbKnowledge,
+			"confidence":     decayedState.Confidence,
+			"last_updated":   currentTime,
+			"updated_at":     currentTime,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to save decayed BKT state for topic %s: %w", dbState.Topic, err)
+		}
+
+		m.cacheState(ctx, userID, dbState.Jurisdiction, dbState.Topic, decayedState)
+	}
+
+	return nil
+}
+
+// SeedStates stores initial BKT states for topics the user has no state for yet in the
+// given jurisdiction; existing states are left untouched. It returns the number stored.
+func (m *BKTStateManager) SeedStates(ctx context.Context, userID, jurisdiction string, states map[string]*algorithms.BKTState) (int64, error) {
+	if len(states) == 0 {
+		return 0, nil
+	}
+
+	dbStates := make([]models.BKTStateModel, 0, len(states))
+	for topic, state := range states {
+		dbStates = append(dbStates, models.BKTStateModel{
+			UserID:        userID,
+			Jurisdiction:  jurisdiction,
+			Topic:         topic,
+			ProbKnowledge: state.ProbKnowledge,
+			ProbGuess:     state.ProbGuess,
+			ProbSlip:      state.ProbSlip,
+			ProbLearn:     state.ProbLearn,
+			AttemptsCount: state.AttemptsCount,
+			CorrectCount:  state.CorrectCount,
+			Confidence:    state.Confidence,
+			LastUpdated:   state.LastUpdated,
+		})
+	}
+
+	result := m.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&dbStates)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to seed BKT states: %w", result.Error)
+	}
+
+	// Drop cached defaults so the seeded states are read back
+	if m.cache != nil {
+		for topic := range states {
+			if err := m.cache.Delete(ctx, m.getCacheKey(userID, jurisdiction, topic)); err != nil {
+				m.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate cached BKT state")
+			}
+		}
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetUsersWithStaleStates returns users having BKT states not updated since the given time,
+// those with the stalest states first
+func (m *BKTStateManager) GetUsersWithStaleStates(ctx context.Context, olderThan time.Time, limit int) ([]string, error) {
+	var userIDs []string
+	err := m.db.WithContext(ctx).Model(&models.BKTStateModel{}).
+		Where("last_updated < ?", olderThan).
+		Group("user_id").
+		Order("MIN(last_updated)").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users with stale BKT states: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// GetRecentlyActiveUsers returns users who attempted any item since the given time.
+// It reads attempts rather than last_updated, which the decay job also moves forward.
+func (m *BKTStateManager) GetRecentlyActiveUsers(ctx context.Context, since time.Time, limit int) ([]string, error) {
+	var userIDs []string
+	err := m.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT user_id::text
+		FROM attempts
+		WHERE created_at >= ?
+		LIMIT ?`, since, limit).
+		Scan(&userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recently active BKT users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
 // GetMasteryGaps returns mastery gaps for all topics for a user
 func (m *BKTStateManager) GetMasteryGaps(ctx context.Context, userID string) (map[string]float64, error) {
 	states, err := m.GetUserStates(ctx, userID)
@@ -229,6 +460,34 @@ func (m *BKTStateManager) GetMasteryGaps(ctx context.Context, userID string) (ma
 	return gaps, nil
 }
 
+// TopicProgress summarizes a user's learning progress on one topic
+type TopicProgress struct {
+	Mastery  float64 // P(L)
+	Velocity float64 // expected mastery gain per attempt
+	Attempts int
+	Mastered bool
+}
+
+// GetLearningProgress returns mastery and learning velocity for all topics for a user
+func (m *BKTStateManager) GetLearningProgress(ctx context.Context, userID string) (map[string]TopicProgress, error) {
+	states, err := m.GetUserStates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make(map[string]TopicProgress, len(states))
+	for topic, state := range states {
+		progress[topic] = TopicProgress{
+			Mastery:  state.ProbKnowledge,
+			Velocity: m.bktAlgorithm.CalculateLearningVelocity(state),
+			Attempts: state.AttemptsCount,
+			Mastered: m.bktAlgorithm.IsMastered(state),
+		}
+	}
+
+	return progress, nil
+}
+
 // GetMasteredTopics returns list of mastered topics for a user
 func (m *BKTStateManager) GetMasteredTopics(ctx context.Context, userID string) ([]string, error) {
 	states, err := m.GetUserStates(ctx, userID)
@@ -387,20 +646,24 @@ func (m *BKTStateManager) GetVisualizationData(ctx context.Context, userID strin
 // Helper methods
 
 // cacheState caches BKT state in Redis
-func (m *BKTStateManager) cacheState(ctx context.Context, userID, topic string, state *algorithms.BKTState) {
+func (m *BKTStateManager) cacheState(ctx context.Context, userID, jurisdiction, topic string, state *algorithms.BKTState) {
 	if m.cache == nil {
 		return
 	}
 
-	cacheKey := fmt.Sprintf("bkt:%s:%s", userID, topic)
+	cacheKey := m.getCacheKey(userID, jurisdiction, topic)
 
-	// Cache for 30 minutes
-	err := m.cache.Set(ctx, cacheKey, state, 30*time.Minute)
+	// Cache for the configured state TTL
+	err := m.cache.SetUser(ctx, userID, cacheKey, state, m.cache.StateTTL())
 	if err != nil {
 		m.logger.WithContext(ctx).WithError(err).Error("Failed to cache BKT state")
 	}
 }
 
+func (m *BKTStateManager) getCacheKey(userID, jurisdiction, topic string) string {
+	return fmt.Sprintf("bkt:%s:%s:%s", userID, jurisdiction, topic)
+}
+
 // MasteryAlert represents a mastery threshold alert
 type MasteryAlert struct {
 	UserID      string    `json:"user_id"`
@@ -7,9 +7,11 @@ import (
 
 	"scheduler-service/internal/algorithms"
 	"scheduler-service/internal/cache"
+	"scheduler-service/internal/database"
 	"scheduler-service/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // IRTManager manages IRT (Item Response Theory) state for users
@@ -17,6 +19,14 @@ type IRTManager struct {
 	db        *gorm.DB
 	cache     *cache.RedisClient
 	algorithm *algorithms.IRTAlgorithm
+
+	// Optional optimized paths for bulk reads
+	pool      *database.OptimizedPool
+	fastCache *cache.OptimizedRedisClient
+
+	// Resolves the jurisdiction whose states a user works in; all users
+	// share the default jurisdiction when unset
+	jurisdictions JurisdictionResolver
 }
 
 // NewIRTManager creates a new IRT state manager
@@ -28,18 +38,53 @@ func NewIRTManager(db *gorm.DB, cache *cache.RedisClient) *IRTManager {
 	}
 }
 
-// GetState retrieves IRT state for a user and topic
+// UseOptimizedPaths routes bulk reads through the prepared-statement pool and the
+// pipelined Redis client. Either argument may be nil to keep the default path.
+func (m *IRTManager) UseOptimizedPaths(pool *database.OptimizedPool, fastCache *cache.OptimizedRedisClient) {
+	m.pool = pool
+	m.fastCache = fastCache
+}
+
+// UseJurisdictionResolver keys states by the jurisdiction each user is preparing for
+func (m *IRTManager) UseJurisdictionResolver(resolver JurisdictionResolver) {
+	m.jurisdictions = resolver
+}
+
+// activeJurisdiction returns the jurisdiction whose states the user currently works in
+func (m *IRTManager) activeJurisdiction(ctx context.Context, userID string) (string, error) {
+	if m.jurisdictions == nil {
+		return NormalizeJurisdiction(""), nil
+	}
+	jurisdiction, err := m.jurisdictions.ActiveJurisdiction(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve jurisdiction: %w", err)
+	}
+	return jurisdiction, nil
+}
+
+// GetState retrieves IRT state for a user and topic in their active jurisdiction
 func (m *IRTManager) GetState(ctx context.Context, userID, topic string) (*algorithms.IRTState, error) {
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetStateIn(ctx, userID, jurisdiction, topic)
+}
+
+// GetStateIn retrieves IRT state for a user and topic in the given jurisdiction
+func (m *IRTManager) GetStateIn(ctx context.Context, userID, jurisdiction, topic string) (*algorithms.IRTState, error) {
 	// Try cache first
-	cacheKey := fmt.Sprintf("irt_state:%s:%s", userID, topic)
+	cacheKey := m.getCacheKey(userID, jurisdiction, topic)
 	var cachedState algorithms.IRTState
-	if err := m.cache.Get(ctx, cacheKey, &cachedState); err == nil {
+	if err := m.cache.GetUser(ctx, userID, cacheKey, &cachedState); err == nil {
 		return &cachedState, nil
 	}
 
 	// Fallback to database
 	var model models.IRTStateModel
-	err := m.db.WithContext(ctx).Where("user_id = ? AND topic = ?", userID, topic).First(&model).Error
+	err := m.db.WithContext(ctx).
+		Where("user_id = ? AND jurisdiction = ? AND topic = ?", userID, jurisdiction, topic).
+		First(&model).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			// Initialize new state
@@ -61,15 +106,20 @@ func (m *IRTManager) GetState(ctx context.Context, userID, topic string) (*algor
 	}
 
 	// Cache the state
-	m.cache.Set(ctx, cacheKey, state, 30*time.Minute)
+	m.cache.SetUser(ctx, userID, cacheKey, state, m.cache.StateTTL())
 
 	return state, nil
 }
 
-// UpdateState updates IRT state after an attempt
+// UpdateState updates IRT state in the user's active jurisdiction after an attempt
 func (m *IRTManager) UpdateState(ctx context.Context, userID, topic string, itemParams *algorithms.ItemParameters, correct bool) (*algorithms.IRTState, error) {
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get current state
-	currentState, err := m.GetState(ctx, userID, topic)
+	currentState, err := m.GetStateIn(ctx, userID, jurisdiction, topic)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current state: %w", err)
 	}
@@ -80,6 +130,7 @@ func (m *IRTManager) UpdateState(ctx context.Context, userID, topic string, item
 	// Save to database
 	model := &models.IRTStateModel{
 		UserID:        userID,
+		Jurisdiction:  jurisdiction,
 		Topic:         topic,
 		Theta:         newState.Theta,
 		ThetaVariance: newState.ThetaVariance,
@@ -95,21 +146,29 @@ func (m *IRTManager) UpdateState(ctx context.Context, userID, topic string, item
 	}
 
 	// Update cache
-	cacheKey := fmt.Sprintf("irt_state:%s:%s", userID, topic)
-	m.cache.Set(ctx, cacheKey, newState, 30*time.Minute)
+	m.cache.SetUser(ctx, userID, m.getCacheKey(userID, jurisdiction, topic), newState, m.cache.StateTTL())
 
 	return newState, nil
 }
 
-// GetMultipleStates retrieves IRT states for multiple topics
+// GetMultipleStates retrieves IRT states for multiple topics in the user's active jurisdiction
 func (m *IRTManager) GetMultipleStates(ctx context.Context, userID string, topics []string) (map[string]*algorithms.IRTState, error) {
 	states := make(map[string]*algorithms.IRTState)
 
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.pool != nil && m.fastCache != nil {
+		return m.getMultipleStatesBatched(ctx, userID, jurisdiction, topics)
+	}
+
 	// Try to get from cache first
 	for _, topic := range topics {
-		cacheKey := fmt.Sprintf("irt_state:%s:%s", userID, topic)
+		cacheKey := m.getCacheKey(userID, jurisdiction, topic)
 		var cachedState algorithms.IRTState
-		if err := m.cache.Get(ctx, cacheKey, &cachedState); err == nil {
+		if err := m.cache.GetUser(ctx, userID, cacheKey, &cachedState); err == nil {
 			states[topic] = &cachedState
 		}
 	}
@@ -124,7 +183,9 @@ func (m *IRTManager) GetMultipleStates(ctx context.Context, userID string, topic
 
 	if len(missingTopics) > 0 {
 		var models []models.IRTStateModel
-		err := m.db.WithContext(ctx).Where("user_id = ? AND topic IN ?", userID, missingTopics).Find(&models).Error
+		err := m.db.WithContext(ctx).
+			Where("user_id = ? AND jurisdiction = ? AND topic IN ?", userID, jurisdiction, missingTopics).
+			Find(&models).Error
 		if err != nil {
 			return nil, fmt.Errorf("failed to get IRT states: %w", err)
 		}
@@ -145,8 +206,7 @@ func (m *IRTManager) GetMultipleStates(ctx context.Context, userID string, topic
 			foundTopics[model.Topic] = true
 
 			// Cache the state
-			cacheKey := fmt.Sprintf("irt_state:%s:%s", userID, model.Topic)
-			m.cache.Set(ctx, cacheKey, state, 30*time.Minute)
+			m.cache.SetUser(ctx, userID, m.getCacheKey(userID, jurisdiction, model.Topic), state, m.cache.StateTTL())
 		}
 
 		// Initialize states for topics not found in database
@@ -161,8 +221,107 @@ func (m *IRTManager) GetMultipleStates(ctx context.Context, userID string, topic
 	return states, nil
 }
 
-// InitializeFromPlacement initializes IRT state from placement test results
+// getMultipleStatesBatched reads all cached topics with one MGET, loads the misses
+// with one query and writes them back with one pipeline
+func (m *IRTManager) getMultipleStatesBatched(ctx context.Context, userID, jurisdiction string, topics []string) (map[string]*algorithms.IRTState, error) {
+	states := make(map[string]*algorithms.IRTState, len(topics))
+	if len(topics) == 0 {
+		return states, nil
+	}
+
+	keys := make([]string, 0, len(topics))
+	topicByKey := make(map[string]string, len(topics))
+	for _, topic := range topics {
+		key := m.getCacheKey(userID, jurisdiction, topic)
+
+		// Serve from the in-process tier where possible
+		var localState algorithms.IRTState
+		if err := m.cache.GetLocal(key, &localState); err == nil {
+			states[topic] = &localState
+			continue
+		}
+
+		keys = append(keys, key)
+		topicByKey[key] = topic
+	}
+	if len(keys) == 0 {
+		return states, nil
+	}
+
+	missingKeys, err := m.fastCache.MGetJSON(ctx, keys, func(key string) interface{} {
+		state := &algorithms.IRTState{}
+		states[topicByKey[key]] = state
+		return state
+	})
+	if err != nil {
+		// Cache unavailable, load everything from the database
+		missingKeys = keys
+	}
+
+	missing := make(map[string]bool, len(missingKeys))
+	for _, key := range missingKeys {
+		missing[key] = true
+	}
+	for _, key := range keys {
+		if !missing[key] {
+			m.cache.SetLocal(userID, key, states[topicByKey[key]])
+		}
+	}
+	if len(missingKeys) == 0 {
+		return states, nil
+	}
+
+	missingTopics := make([]string, len(missingKeys))
+	for i, key := range missingKeys {
+		missingTopics[i] = topicByKey[key]
+		delete(states, missingTopics[i])
+	}
+
+	rows, err := m.pool.QueryContext(ctx,
+		`SELECT topic, theta, theta_variance, confidence, attempts_count, correct_count, last_updated
+		FROM irt_states WHERE user_id = $1 AND jurisdiction = $2 AND topic = ANY($3)`, userID, jurisdiction, missingTopics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IRT states: %w", err)
+	}
+	defer rows.Close()
+
+	toCache := make(map[string]interface{})
+	for rows.Next() {
+		var topic string
+		state := &algorithms.IRTState{UpdateHistory: make([]float64, 0)}
+		if err := rows.Scan(&topic, &state.Theta, &state.ThetaVariance, &state.Confidence,
+			&state.AttemptsCount, &state.CorrectCount, &state.LastUpdated); err != nil {
+			return nil, fmt.Errorf("failed to scan IRT state: %w", err)
+		}
+		states[topic] = state
+		toCache[m.getCacheKey(userID, jurisdiction, topic)] = state
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read IRT states: %w", err)
+	}
+
+	// Initialize states for topics not found in database
+	for _, topic := range missingTopics {
+		if _, exists := states[topic]; !exists {
+			states[topic] = m.algorithm.InitializeState(topic)
+		}
+	}
+
+	m.fastCache.MSetJSON(ctx, toCache, m.cache.StateTTL())
+	for key, state := range toCache {
+		m.cache.SetLocal(userID, key, state)
+	}
+
+	return states, nil
+}
+
+// InitializeFromPlacement initializes IRT state in the user's active jurisdiction from placement test results
 func (m *IRTManager) InitializeFromPlacement(ctx context.Context, userID, topic string, responses []bool, itemParams []*algorithms.ItemParameters) (*algorithms.IRTState, error) {
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Estimate ability from placement test
 	state, err := m.algorithm.EstimateAbilityFromPlacement(responses, itemParams)
 	if err != nil {
@@ -172,6 +331,7 @@ func (m *IRTManager) InitializeFromPlacement(ctx context.Context, userID, topic
 	// Save to database
 	model := &models.IRTStateModel{
 		UserID:        userID,
+		Jurisdiction:  jurisdiction,
 		Topic:         topic,
 		Theta:         state.Theta,
 		ThetaVariance: state.ThetaVariance,
@@ -187,23 +347,83 @@ func (m *IRTManager) InitializeFromPlacement(ctx context.Context, userID, topic
 	}
 
 	// Cache the state
-	cacheKey := fmt.Sprintf("irt_state:%s:%s", userID, topic)
-	m.cache.Set(ctx, cacheKey, state, 30*time.Minute)
+	m.cache.SetUser(ctx, userID, m.getCacheKey(userID, jurisdiction, topic), state, m.cache.StateTTL())
 
 	return state, nil
 }
 
-// ApplyTimeDecay applies time-based decay to all user's IRT states
-func (m *IRTManager) ApplyTimeDecay(ctx context.Context, userID string) error {
-	// Get all topics for user
+// GetUserStatesIn retrieves all IRT states for a user in the given jurisdiction
+func (m *IRTManager) GetUserStatesIn(ctx context.Context, userID, jurisdiction string) (map[string]*algorithms.IRTState, error) {
 	var models []models.IRTStateModel
-	err := m.db.WithContext(ctx).Where("user_id = ?", userID).Find(&models).Error
+	err := m.db.WithContext(ctx).Where("user_id = ? AND jurisdiction = ?", userID, jurisdiction).Find(&models).Error
 	if err != nil {
-		return fmt.Errorf("failed to get user IRT states: %w", err)
+		return nil, fmt.Errorf("failed to get user IRT states: %w", err)
+	}
+
+	states := make(map[string]*algorithms.IRTState, len(models))
+	for _, model := range models {
+		states[model.Topic] = &algorithms.IRTState{
+			Theta:         model.Theta,
+			ThetaVariance: model.ThetaVariance,
+			Confidence:    model.Confidence,
+			AttemptsCount: model.AttemptsCount,
+			CorrectCount:  model.CorrectCount,
+			LastUpdated:   model.LastUpdated,
+			UpdateHistory: make([]float64, 0),
+		}
+	}
+
+	return states, nil
+}
+
+// SeedStates stores initial IRT states for topics the user has no state for yet in the
+// given jurisdiction; existing states are left untouched. It returns the number stored.
+func (m *IRTManager) SeedStates(ctx context.Context, userID, jurisdiction string, states map[string]*algorithms.IRTState) (int64, error) {
+	if len(states) == 0 {
+		return 0, nil
+	}
+
+	rows := make([]models.IRTStateModel, 0, len(states))
+	for topic, state := range states {
+		rows = append(rows, models.IRTStateModel{
+			UserID:        userID,
+			Jurisdiction:  jurisdiction,
+			Topic:         topic,
+			Theta:         state.Theta,
+			ThetaVariance: state.ThetaVariance,
+			Confidence:    state.Confidence,
+			AttemptsCount: state.AttemptsCount,
+			CorrectCount:  state.CorrectCount,
+			LastUpdated:   state.LastUpdated,
+		})
+	}
+
+	result := m.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to seed IRT states: %w", result.Error)
+	}
+
+	// Drop cached defaults so the seeded states are read back
+	for topic := range states {
+		m.cache.Delete(ctx, m.getCacheKey(userID, jurisdiction, topic))
 	}
 
+	return result.RowsAffected, nil
+}
+
+// ApplyTimeDecay persists time decay for all of a user's IRT states, in every jurisdiction,
+// that have not been updated within staleAfter
+func (m *IRTManager) ApplyTimeDecay(ctx context.Context, userID string, staleAfter time.Duration) error {
 	currentTime := time.Now()
 
+	var models []models.IRTStateModel
+	err := m.db.WithContext(ctx).
+		Where("user_id = ? AND last_updated < ?", userID, currentTime.Add(-staleAfter)).
+		Find(&models).Error
+	if err != nil {
+		return fmt.Errorf("failed to get stale IRT states: %w", err)
+	}
+
 	// Apply decay to each state
 	for _, model := range models {
 		state := &algorithms.IRTState{
@@ -219,25 +439,43 @@ func (m *IRTManager) ApplyTimeDecay(ctx context.Context, userID string) error {
 		// Apply time decay
 		decayedState := m.algorithm.ApplyTimeDecay(state, currentTime)
 
-		// Update if there was significant decay
-		if decayedState.Confidence != state.Confidence || decayedState.ThetaVariance != state.ThetaVariance {
-			model.Confidence = decayedState.Confidence
-			model.ThetaVariance = decayedState.ThetaVariance
-
-			err = m.db.WithContext(ctx).Save(&model).Error
-			if err != nil {
-				return fmt.Errorf("failed to save decayed IRT state for topic %s: %w", model.Topic, err)
-			}
-
-			// Update cache
-			cacheKey := fmt.Sprintf("irt_state:%s:%s", userID, model.Topic)
-			m.cache.Set(ctx, cacheKey, decayedState, 30*time.Minute)
+		// last_updated moves forward even when nothing decayed, so the next run decays
+		// only from now and the state is not selected as stale again
+		decayedState.LastUpdated = currentTime
+		err = m.db.WithContext(ctx).Model(&model).UpdateColumns(map[string]interface{}{
+			"confidence":     decayedState.Confidence,
+			"theta_variance": decayedState.ThetaVariance,
+			"last_updated":   currentTime,
+			"updated_at":     currentTime,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to save decayed IRT state for topic %s: %w", model.Topic, err)
 		}
+
+		// Update cache with the state as stored
+		m.cache.SetUser(ctx, userID, m.getCacheKey(userID, model.Jurisdiction, model.Topic), decayedState, m.cache.StateTTL())
 	}
 
 	return nil
 }
 
+// GetUsersWithStaleStates returns users having IRT states not updated since the given time,
+// those with the stalest states first
+func (m *IRTManager) GetUsersWithStaleStates(ctx context.Context, olderThan time.Time, limit int) ([]string, error) {
+	var userIDs []string
+	err := m.db.WithContext(ctx).Model(&models.IRTStateModel{}).
+		Where("last_updated < ?", olderThan).
+		Group("user_id").
+		Order("MIN(last_updated)").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users with stale IRT states: %w", err)
+	}
+
+	return userIDs, nil
+}
+
 // GetDifficultyMatch calculates how well an item matches user's ability
 func (m *IRTManager) GetDifficultyMatch(ctx context.Context, userID, topic string, itemParams *algorithms.ItemParameters) (float64, error) {
 	state, err := m.GetState(ctx, userID, topic)
@@ -283,18 +521,22 @@ func (m *IRTManager) CalibrateItemParameters(responses []bool, abilities []float
 	return m.algorithm.CalibrateItemParameters(responses, abilities)
 }
 
-// InvalidateCache removes IRT state from cache
+// InvalidateCache removes IRT state in the user's active jurisdiction from cache
 func (m *IRTManager) InvalidateCache(ctx context.Context, userID, topic string) error {
-	cacheKey := fmt.Sprintf("irt_state:%s:%s", userID, topic)
-	return m.cache.Delete(ctx, cacheKey)
+	jurisdiction, err := m.activeJurisdiction(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return m.cache.Delete(ctx, m.getCacheKey(userID, jurisdiction, topic))
 }
 
-// InvalidateUserCache removes all IRT states for a user from cache
+// InvalidateUserCache removes all IRT states for a user from cache, in every
+// jurisdiction and on every replica
 func (m *IRTManager) InvalidateUserCache(ctx context.Context, userID string) error {
-	// Since there's no DelPattern method, we'll need to implement this differently
-	// For now, we'll just return nil and let individual cache entries expire
-	// TODO: Implement pattern-based deletion if needed
-	return nil
+	if _, err := m.cache.DeletePattern(ctx, m.getCacheKey(userID, "*", "*")); err != nil {
+		return fmt.Errorf("failed to invalidate IRT cache: %w", err)
+	}
+	return m.cache.InvalidateUser(ctx, userID)
 }
 
 // GetConfidenceInterval calculates confidence interval for user's ability
@@ -324,8 +566,14 @@ func (m *IRTManager) BatchUpdateStates(ctx context.Context, updates []IRTStateUp
 
 	// Process each update
 	for _, update := range updates {
+		jurisdiction, err := m.activeJurisdiction(ctx, update.UserID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
 		// Get current state
-		currentState, err := m.GetState(ctx, update.UserID, update.Topic)
+		currentState, err := m.GetStateIn(ctx, update.UserID, jurisdiction, update.Topic)
 		if err != nil {
 			tx.Rollback()
 			return fmt.Errorf("failed to get current state for %s:%s: %w", update.UserID, update.Topic, err)
@@ -337,6 +585,7 @@ func (m *IRTManager) BatchUpdateStates(ctx context.Context, updates []IRTStateUp
 		// Save to database
 		model := &models.IRTStateModel{
 			UserID:        update.UserID,
+			Jurisdiction:  jurisdiction,
 			Topic:         update.Topic,
 			Theta:         newState.Theta,
 			ThetaVariance: newState.ThetaVariance,
@@ -353,14 +602,17 @@ func (m *IRTManager) BatchUpdateStates(ctx context.Context, updates []IRTStateUp
 		}
 
 		// Update cache
-		cacheKey := fmt.Sprintf("irt_state:%s:%s", update.UserID, update.Topic)
-		m.cache.Set(ctx, cacheKey, newState, 30*time.Minute)
+		m.cache.SetUser(ctx, update.UserID, m.getCacheKey(update.UserID, jurisdiction, update.Topic), newState, m.cache.StateTTL())
 	}
 
 	// Commit transaction
 	return tx.Commit().Error
 }
 
+func (m *IRTManager) getCacheKey(userID, jurisdiction, topic string) string {
+	return fmt.Sprintf("irt_state:%s:%s:%s", userID, jurisdiction, topic)
+}
+
 // IRTStateUpdate represents a batch update for IRT state
 type IRTStateUpdate struct {
 	UserID     string
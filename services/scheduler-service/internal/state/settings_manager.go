@@ -0,0 +1,142 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/curriculum"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// JurisdictionResolver resolves the jurisdiction a user is currently preparing for
+type JurisdictionResolver interface {
+	ActiveJurisdiction(ctx context.Context, userID string) (string, error)
+}
+
+// UserSettingsManager handles persistence and caching of per-user scheduler settings
+type UserSettingsManager struct {
+	db     *database.DB
+	cache  *cache.RedisClient
+	logger *logger.Logger
+}
+
+// NewUserSettingsManager creates a new user settings manager
+func NewUserSettingsManager(db *database.DB, cache *cache.RedisClient, logger *logger.Logger) *UserSettingsManager {
+	return &UserSettingsManager{
+		db:     db,
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// GetSettings retrieves scheduler settings for a user.
+// Users without stored settings get an empty settings record.
+func (m *UserSettingsManager) GetSettings(ctx context.Context, userID string) (*models.UserSchedulerSettingsModel, error) {
+	cacheKey := m.getCacheKey(userID)
+	var settings models.UserSchedulerSettingsModel
+	if err := m.cache.GetUser(ctx, userID, cacheKey, &settings); err == nil {
+		return &settings, nil
+	}
+
+	err := m.db.WithContext(ctx).Where("user_id = ?", userID).First(&settings).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &models.UserSchedulerSettingsModel{UserID: userID}, nil
+		}
+		return nil, fmt.Errorf("failed to get user scheduler settings: %w", err)
+	}
+
+	if err := m.cache.SetUser(ctx, userID, cacheKey, &settings, m.cache.StateTTL()); err != nil {
+		m.logger.WithContext(ctx).WithError(err).Warn("Failed to cache user scheduler settings")
+	}
+
+	return &settings, nil
+}
+
+// SaveSettings persists scheduler settings for a user
+func (m *UserSettingsManager) SaveSettings(ctx context.Context, settings *models.UserSchedulerSettingsModel) error {
+	settings.UpdatedAt = time.Now()
+	if settings.CreatedAt.IsZero() {
+		settings.CreatedAt = settings.UpdatedAt
+	}
+
+	if err := m.db.WithContext(ctx).Save(settings).Error; err != nil {
+		return fmt.Errorf("failed to save user scheduler settings: %w", err)
+	}
+
+	if err := m.cache.Delete(ctx, m.getCacheKey(settings.UserID)); err != nil {
+		m.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate user scheduler settings cache")
+	}
+
+	return nil
+}
+
+// GetExamDate returns the user's booked exam date, or nil if none is set
+func (m *UserSettingsManager) GetExamDate(ctx context.Context, userID string) (*time.Time, error) {
+	settings, err := m.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return settings.ExamDate, nil
+}
+
+// SetExamDate stores the user's booked exam date. A nil date clears it.
+func (m *UserSettingsManager) SetExamDate(ctx context.Context, userID string, examDate *time.Time) (*models.UserSchedulerSettingsModel, error) {
+	settings, err := m.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.ExamDate = examDate
+	if err := m.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// ActiveJurisdiction returns the jurisdiction the user is currently preparing for,
+// which selects the BKT and IRT states their attempts update
+func (m *UserSettingsManager) ActiveJurisdiction(ctx context.Context, userID string) (string, error) {
+	settings, err := m.GetSettings(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeJurisdiction(settings.CountryCode), nil
+}
+
+// AdoptJurisdiction makes the jurisdiction active for a user who has none yet.
+// Users already preparing for a jurisdiction change it with a jurisdiction switch.
+func (m *UserSettingsManager) AdoptJurisdiction(ctx context.Context, userID, countryCode string) error {
+	settings, err := m.GetSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if settings.CountryCode != "" || countryCode == "" {
+		return nil
+	}
+
+	settings.CountryCode = strings.ToUpper(countryCode)
+	return m.SaveSettings(ctx, settings)
+}
+
+// NormalizeJurisdiction maps a country code to the jurisdiction its states are stored under.
+// Users without a country code study the default jurisdiction.
+func NormalizeJurisdiction(countryCode string) string {
+	countryCode = strings.TrimSpace(countryCode)
+	if countryCode == "" || strings.EqualFold(countryCode, curriculum.DefaultJurisdiction) {
+		return curriculum.DefaultJurisdiction
+	}
+	return strings.ToUpper(countryCode)
+}
+
+func (m *UserSettingsManager) getCacheKey(userID string) string {
+	return fmt.Sprintf("scheduler_settings:%s", userID)
+}
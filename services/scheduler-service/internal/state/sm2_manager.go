@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	pb "scheduler-service/proto"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // SM2StateManager handles persistence and caching of SM-2 states
@@ -21,6 +23,10 @@ type SM2StateManager struct {
 	db        *database.DB
 	cache     *cache.RedisClient
 	logger    *logger.Logger
+
+	// Optional optimized paths for bulk reads
+	pool      *database.OptimizedPool
+	fastCache *cache.OptimizedRedisClient
 }
 
 // NewSM2StateManager creates a new SM-2 state manager
@@ -38,12 +44,19 @@ func NewSM2StateManager(
 	}
 }
 
+// UseOptimizedPaths routes bulk reads through the prepared-statement pool and the
+// pipelined Redis client. Either argument may be nil to keep the default path.
+func (sm *SM2StateManager) UseOptimizedPaths(pool *database.OptimizedPool, fastCache *cache.OptimizedRedisClient) {
+	sm.pool = pool
+	sm.fastCache = fastCache
+}
+
 // GetState retrieves SM-2 state for a user-item pair
 func (sm *SM2StateManager) GetState(ctx context.Context, userID, itemID string) (*algorithms.SM2State, error) {
 	// Try cache first
 	cacheKey := sm.getCacheKey(userID, itemID)
 	var state algorithms.SM2State
-	if err := sm.cache.Get(ctx, cacheKey, &state); err == nil {
+	if err := sm.cache.GetUser(ctx, userID, cacheKey, &state); err == nil {
 		return &state, nil
 	}
 
@@ -63,6 +76,13 @@ func (sm *SM2StateManager) GetState(ctx context.Context, userID, itemID string)
 
 // UpdateState updates SM-2 state based on user response
 func (sm *SM2StateManager) UpdateState(ctx context.Context, userID, itemID string, quality int) (*algorithms.SM2State, error) {
+	return sm.UpdateStateForExam(ctx, userID, itemID, quality, nil)
+}
+
+// UpdateStateForExam updates SM-2 state based on user response, capping the new
+// interval so the next review lands before the exam date. A nil exam date behaves
+// like UpdateState.
+func (sm *SM2StateManager) UpdateStateForExam(ctx context.Context, userID, itemID string, quality int, examDate *time.Time) (*algorithms.SM2State, error) {
 	// Get current state
 	currentState, err := sm.GetState(ctx, userID, itemID)
 	if err != nil {
@@ -70,21 +90,108 @@ func (sm *SM2StateManager) UpdateState(ctx context.Context, userID, itemID strin
 	}
 
 	// Update state using algorithm
-	newState := sm.algorithm.UpdateState(currentState, quality)
+	var newState *algorithms.SM2State
+	if examDate != nil {
+		newState = sm.algorithm.UpdateStateForExam(currentState, quality, *examDate)
+	} else {
+		newState = sm.algorithm.UpdateState(currentState, quality)
+	}
 
 	// Persist to database
 	if err := sm.saveStateToDB(ctx, userID, itemID, newState); err != nil {
 		return nil, fmt.Errorf("failed to save SM-2 state to database: %w", err)
 	}
 
+	if newState.Lapses > currentState.Lapses && sm.algorithm.IsLeech(newState) {
+		sm.logger.WithContext(ctx).WithFields(map[string]interface{}{
+			"user_id": userID,
+			"item_id": itemID,
+			"lapses":  newState.Lapses,
+		}).Info("Item lapsed as a leech")
+	}
+
 	// Update cache
 	if err := sm.cacheState(ctx, userID, itemID, newState); err != nil {
 		sm.logger.WithContext(ctx).WithError(err).Warn("Failed to update SM-2 state in cache")
 	}
+	if err := sm.invalidateUserStates(ctx, userID); err != nil {
+		sm.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate user SM-2 states cache")
+	}
 
 	return newState, nil
 }
 
+// CapIntervalsForExam reschedules a user's existing items so that no review is due
+// after the exam date. Returns the number of items that were rescheduled.
+func (sm *SM2StateManager) CapIntervalsForExam(ctx context.Context, userID string, examDate time.Time) (int, error) {
+	states, err := sm.getUserStatesFromDB(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user SM-2 states: %w", err)
+	}
+
+	rescheduled := 0
+	for itemID, state := range states {
+		capped := sm.algorithm.CapIntervalForExam(state, examDate)
+		if capped.Interval == state.Interval {
+			continue
+		}
+
+		if err := sm.saveStateToDB(ctx, userID, itemID, capped); err != nil {
+			return rescheduled, fmt.Errorf("failed to save rescheduled SM-2 state: %w", err)
+		}
+		if err := sm.InvalidateCache(ctx, userID, itemID); err != nil {
+			sm.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate rescheduled SM-2 state cache")
+		}
+		rescheduled++
+	}
+
+	return rescheduled, nil
+}
+
+// Leech is an item a user keeps failing, with its SM-2 state
+type Leech struct {
+	ItemID string
+	State  *algorithms.SM2State
+}
+
+// GetLeeches returns the user's leeches, most lapses first. A limit of 0 returns all
+// of them; none are returned while leech detection is disabled.
+func (sm *SM2StateManager) GetLeeches(ctx context.Context, userID string, limit int) ([]Leech, error) {
+	threshold := sm.algorithm.Parameters().LeechThreshold
+	if threshold <= 0 {
+		return nil, nil
+	}
+
+	query := sm.db.WithContext(ctx).
+		Where("user_id = ? AND lapses >= ?", userID, threshold).
+		Order("lapses DESC, item_id")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var rows []models.SM2StateModel
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query leeches: %w", err)
+	}
+
+	leeches := make([]Leech, 0, len(rows))
+	for _, model := range rows {
+		leeches = append(leeches, Leech{
+			ItemID: model.ItemID,
+			State: &algorithms.SM2State{
+				EasinessFactor: model.EasinessFactor,
+				Interval:       model.IntervalDays,
+				Repetition:     model.Repetition,
+				NextDue:        model.NextDue,
+				LastReviewed:   model.LastReviewed,
+				Lapses:         model.Lapses,
+			},
+		})
+	}
+
+	return leeches, nil
+}
+
 // InitializeState creates initial SM-2 state for a new user-item pair
 func (sm *SM2StateManager) InitializeState(ctx context.Context, userID, itemID string) (*algorithms.SM2State, error) {
 	// Check if state already exists
@@ -112,21 +219,48 @@ func (sm *SM2StateManager) InitializeState(ctx context.Context, userID, itemID s
 
 // GetUserStates retrieves all SM-2 states for a user
 func (sm *SM2StateManager) GetUserStates(ctx context.Context, userID string) (map[string]*algorithms.SM2State, error) {
+	// The generation is read before the database, so a list read before an
+	// invalidation is written under a generation that is no longer served
+	generation, err := sm.userStatesGeneration(ctx, userID)
+	if err != nil {
+		sm.logger.WithContext(ctx).WithError(err).Warn("Failed to get user SM-2 states cache generation")
+		states, err := sm.getUserStatesFromDB(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user SM-2 states from database: %w", err)
+		}
+		return states, nil
+	}
+
 	// Try to get from cache first
-	cacheKey := fmt.Sprintf("sm2:user:%s:all", userID)
+	cacheKey := sm.getUserCacheKey(userID, generation)
 	var states map[string]*algorithms.SM2State
-	if err := sm.cache.Get(ctx, cacheKey, &states); err == nil {
+	if sm.fastCache != nil {
+		if err := sm.cache.GetLocal(cacheKey, &states); err == nil {
+			return states, nil
+		}
+		if err := sm.fastCache.GetJSON(ctx, cacheKey, &states); err == nil {
+			sm.cache.SetLocal(userID, cacheKey, states)
+			return states, nil
+		}
+	} else if err := sm.cache.GetUser(ctx, userID, cacheKey, &states); err == nil {
 		return states, nil
 	}
 
 	// Fallback to database
-	states, err := sm.getUserStatesFromDB(ctx, userID)
+	states, err = sm.getUserStatesFromDB(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user SM-2 states from database: %w", err)
 	}
 
-	// Cache the result for 15 minutes
-	sm.cache.Set(ctx, cacheKey, states, 15*time.Minute)
+	// Cache the result for half the state TTL, as any review changes it
+	listTTL := sm.cache.StateTTL() / 2
+	if sm.fastCache != nil {
+		// Written through the pipeline so large state maps do not delay the response
+		sm.fastCache.SetJSONAsync(cacheKey, states, listTTL)
+		sm.cache.SetLocal(userID, cacheKey, states)
+	} else {
+		sm.cache.SetUser(ctx, userID, cacheKey, states, listTTL)
+	}
 
 	return states, nil
 }
@@ -217,6 +351,60 @@ func (sm *SM2StateManager) GetAnalytics(ctx context.Context, userID string, curr
 	return analytics, nil
 }
 
+// GetRecentlyActiveUsers returns users who reviewed any item since the given time
+func (sm *SM2StateManager) GetRecentlyActiveUsers(ctx context.Context, since time.Time, limit int) ([]string, error) {
+	var userIDs []string
+	err := sm.db.WithContext(ctx).Model(&models.SM2StateModel{}).
+		Where("last_reviewed >= ?", since).
+		Distinct("user_id").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recently active users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// SeedStates stores SM-2 states for items the user has no state for yet; existing
+// states are left untouched. It returns the number stored.
+func (sm *SM2StateManager) SeedStates(ctx context.Context, userID string, states map[string]*algorithms.SM2State) (int64, error) {
+	if len(states) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	rows := make([]models.SM2StateModel, 0, len(states))
+	for itemID, state := range states {
+		rows = append(rows, models.SM2StateModel{
+			UserID:         userID,
+			ItemID:         itemID,
+			EasinessFactor: state.EasinessFactor,
+			IntervalDays:   state.Interval,
+			Repetition:     state.Repetition,
+			NextDue:        state.NextDue,
+			LastReviewed:   state.LastReviewed,
+			Lapses:         state.Lapses,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	result := sm.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to seed SM-2 states: %w", result.Error)
+	}
+
+	// Drop cached defaults so the seeded states are read back
+	for itemID := range states {
+		if err := sm.InvalidateCache(ctx, userID, itemID); err != nil {
+			sm.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate cached SM-2 state")
+		}
+	}
+
+	return result.RowsAffected, nil
+}
+
 // InvalidateCache removes cached SM-2 state for a user-item pair
 func (sm *SM2StateManager) InvalidateCache(ctx context.Context, userID, itemID string) error {
 	// Remove individual item cache
@@ -226,8 +414,7 @@ func (sm *SM2StateManager) InvalidateCache(ctx context.Context, userID, itemID s
 	}
 
 	// Remove user's all states cache
-	userCacheKey := fmt.Sprintf("sm2:user:%s:all", userID)
-	if err := sm.cache.Delete(ctx, userCacheKey); err != nil {
+	if err := sm.invalidateUserStates(ctx, userID); err != nil {
 		sm.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate user SM-2 states cache")
 	}
 
@@ -240,10 +427,36 @@ func (sm *SM2StateManager) getCacheKey(userID, itemID string) string {
 	return fmt.Sprintf("sm2:%s:%s", userID, itemID)
 }
 
+func (sm *SM2StateManager) getUserCacheKey(userID string, generation int64) string {
+	return fmt.Sprintf("sm2:user:%s:all:%d", userID, generation)
+}
+
+func (sm *SM2StateManager) getUserGenerationKey(userID string) string {
+	return fmt.Sprintf("sm2:user:%s:generation", userID)
+}
+
+// userStatesGeneration returns the generation of a user's cached state list, 0 when
+// it was not invalidated within the state TTL
+func (sm *SM2StateManager) userStatesGeneration(ctx context.Context, userID string) (int64, error) {
+	var generation int64
+	err := sm.cache.Get(ctx, sm.getUserGenerationKey(userID), &generation)
+	if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+		return 0, err
+	}
+	return generation, nil
+}
+
+// invalidateUserStates starts a new generation of a user's cached state list, so
+// lists read from the database before now are never served, even when their write
+// lands later. Generations are unique and outlive the lists cached under them.
+func (sm *SM2StateManager) invalidateUserStates(ctx context.Context, userID string) error {
+	return sm.cache.Set(ctx, sm.getUserGenerationKey(userID), time.Now().UnixNano(), sm.cache.StateTTL())
+}
+
 func (sm *SM2StateManager) cacheState(ctx context.Context, userID, itemID string, state *algorithms.SM2State) error {
 	cacheKey := sm.getCacheKey(userID, itemID)
-	// Cache for 30 minutes
-	return sm.cache.Set(ctx, cacheKey, state, 30*time.Minute)
+	// Cache for the configured state TTL
+	return sm.cache.SetUser(ctx, userID, cacheKey, state, sm.cache.StateTTL())
 }
 
 func (sm *SM2StateManager) getStateFromDB(ctx context.Context, userID, itemID string) (*algorithms.SM2State, error) {
@@ -265,6 +478,7 @@ func (sm *SM2StateManager) getStateFromDB(ctx context.Context, userID, itemID st
 		Repetition:     model.Repetition,
 		NextDue:        model.NextDue,
 		LastReviewed:   model.LastReviewed,
+		Lapses:         model.Lapses,
 	}
 
 	return state, nil
@@ -279,6 +493,7 @@ func (sm *SM2StateManager) saveStateToDB(ctx context.Context, userID, itemID str
 		Repetition:     state.Repetition,
 		NextDue:        state.NextDue,
 		LastReviewed:   state.LastReviewed,
+		Lapses:         state.Lapses,
 		UpdatedAt:      time.Now(),
 	}
 
@@ -305,6 +520,10 @@ func (sm *SM2StateManager) stateExists(ctx context.Context, userID, itemID strin
 }
 
 func (sm *SM2StateManager) getUserStatesFromDB(ctx context.Context, userID string) (map[string]*algorithms.SM2State, error) {
+	if sm.pool != nil {
+		return sm.getUserStatesFromPool(ctx, userID)
+	}
+
 	var models []models.SM2StateModel
 
 	err := sm.db.WithContext(ctx).Where("user_id = ?", userID).Find(&models).Error
@@ -320,6 +539,7 @@ func (sm *SM2StateManager) getUserStatesFromDB(ctx context.Context, userID strin
 			Repetition:     model.Repetition,
 			NextDue:        model.NextDue,
 			LastReviewed:   model.LastReviewed,
+			Lapses:         model.Lapses,
 		}
 		states[model.ItemID] = state
 	}
@@ -327,6 +547,32 @@ func (sm *SM2StateManager) getUserStatesFromDB(ctx context.Context, userID strin
 	return states, nil
 }
 
+// getUserStatesFromPool loads all of a user's SM-2 states with a single prepared statement
+func (sm *SM2StateManager) getUserStatesFromPool(ctx context.Context, userID string) (map[string]*algorithms.SM2State, error) {
+	rows, err := sm.pool.QueryContext(ctx,
+		`SELECT item_id, easiness_factor, interval_days, repetition, next_due, last_reviewed, lapses
+		FROM sm2_states WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user SM-2 states: %w", err)
+	}
+	defer rows.Close()
+
+	states := make(map[string]*algorithms.SM2State)
+	for rows.Next() {
+		var itemID string
+		state := &algorithms.SM2State{}
+		if err := rows.Scan(&itemID, &state.EasinessFactor, &state.Interval, &state.Repetition, &state.NextDue, &state.LastReviewed, &state.Lapses); err != nil {
+			return nil, fmt.Errorf("failed to scan SM-2 state: %w", err)
+		}
+		states[itemID] = state
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read user SM-2 states: %w", err)
+	}
+
+	return states, nil
+}
+
 func (sm *SM2StateManager) getLearningStage(state *algorithms.SM2State) string {
 	switch {
 	case state.Repetition == 0:
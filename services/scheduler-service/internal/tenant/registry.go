@@ -0,0 +1,253 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+)
+
+// Strategies is where tenants' scoring strategies are looked up and registered,
+// implemented by the unified scoring algorithm
+type Strategies interface {
+	GetScoringStrategy(name string) (algorithms.ScoringStrategy, bool)
+	AddScoringStrategy(strategy algorithms.ScoringStrategy) error
+}
+
+// Registry is an in-process snapshot of the active tenants, reloaded once it is older
+// than the refresh interval, with memberships looked up on demand and cached. Tenants
+// with their own weights get a scoring strategy registered under "tenant:<id>".
+type Registry struct {
+	repository      *Repository
+	cache           *cache.RedisClient
+	logger          *logger.Logger
+	strategies      Strategies
+	refreshInterval time.Duration
+	membershipTTL   time.Duration
+
+	mu       sync.RWMutex
+	tenants  map[string]*Tenant
+	loadedAt time.Time
+
+	// Serializes reloads so concurrent requests share one query
+	refreshMu sync.Mutex
+}
+
+// NewRegistry creates a tenant registry. The cache may be nil to look memberships up
+// in the database on every request.
+func NewRegistry(cfg *config.TenantConfig, db *database.DB, cache *cache.RedisClient, log *logger.Logger, strategies Strategies) *Registry {
+	return &Registry{
+		repository:      NewRepository(db),
+		cache:           cache,
+		logger:          log,
+		strategies:      strategies,
+		refreshInterval: cfg.RefreshInterval,
+		membershipTTL:   cfg.MembershipTTL,
+		tenants:         make(map[string]*Tenant),
+	}
+}
+
+// Refresh reloads the tenants when the snapshot is older than the refresh interval.
+// Failed loads are logged and retried after the next interval, keeping the previous snapshot.
+func (r *Registry) Refresh(ctx context.Context) {
+	if !r.stale() {
+		return
+	}
+
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
+	if !r.stale() {
+		return
+	}
+
+	tenants, err := r.repository.List(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.loadedAt = time.Now()
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Warn("Failed to load tenants, keeping previous snapshot")
+		return
+	}
+
+	snapshot := make(map[string]*Tenant, len(tenants))
+	for _, t := range tenants {
+		r.registerStrategy(ctx, t)
+		snapshot[t.ID] = t
+	}
+	r.tenants = snapshot
+}
+
+// stale reports whether the snapshot is due for a reload
+func (r *Registry) stale() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.loadedAt.IsZero() || time.Since(r.loadedAt) >= r.refreshInterval
+}
+
+// registerStrategy registers the scoring strategy of a tenant with its own weights,
+// based on the tenant's strategy. A tenant whose strategy cannot be registered falls
+// back to its base strategy.
+func (r *Registry) registerStrategy(ctx context.Context, t *Tenant) {
+	if t.Weights == nil || r.strategies == nil {
+		return
+	}
+
+	base := t.ScoringStrategy
+	if base == "" {
+		base = "balanced"
+	}
+	strategy, ok := r.strategies.GetScoringStrategy(base)
+	if !ok {
+		r.logger.WithContext(ctx).WithField("tenant", t.ID).Warnf("Unknown scoring strategy %q, using the default", base)
+		strategy, _ = r.strategies.GetScoringStrategy("balanced")
+		t.ScoringStrategy = ""
+	}
+
+	strategy.Name = t.Strategy()
+	strategy.Description = fmt.Sprintf("%s weights of tenant %s", base, t.ID)
+	strategy.Weights = *t.Weights
+	if err := r.strategies.AddScoringStrategy(strategy); err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithField("tenant", t.ID).Warn("Failed to register tenant scoring strategy")
+		t.Weights = nil
+	}
+}
+
+// Get returns an active tenant
+func (r *Registry) Get(ctx context.Context, tenantID string) (*Tenant, error) {
+	r.Refresh(ctx)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tenants[tenantID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTenant, tenantID)
+	}
+	return t, nil
+}
+
+// Member returns a user's membership of a tenant
+func (r *Registry) Member(ctx context.Context, tenantID, userID string) (*Member, error) {
+	key := memberCacheKey(tenantID, userID)
+	if r.cache != nil {
+		var member Member
+		if err := r.cache.Get(ctx, key, &member); err == nil {
+			return &member, nil
+		} else if !errors.Is(err, cache.ErrCacheMiss) {
+			r.logger.WithContext(ctx).WithError(err).Warn("Failed to get cached tenant membership")
+		}
+	}
+
+	member, err := r.repository.Member(ctx, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrNotMember
+	}
+
+	if r.cache != nil {
+		if err := r.cache.Set(ctx, key, member, r.membershipTTL); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Warn("Failed to cache tenant membership")
+		}
+	}
+	return member, nil
+}
+
+// Memberships returns a user's memberships of active tenants, earliest joined first.
+// Users without any are cached too, since most requests are made by them.
+func (r *Registry) Memberships(ctx context.Context, userID string) ([]Member, error) {
+	key := membershipsCacheKey(userID)
+	if r.cache != nil {
+		var members []Member
+		if err := r.cache.Get(ctx, key, &members); err == nil {
+			return members, nil
+		} else if !errors.Is(err, cache.ErrCacheMiss) {
+			r.logger.WithContext(ctx).WithError(err).Warn("Failed to get cached tenant memberships")
+		}
+	}
+
+	members, err := r.repository.Memberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.cache != nil {
+		if err := r.cache.Set(ctx, key, members, r.membershipTTL); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Warn("Failed to cache tenant memberships")
+		}
+	}
+	return members, nil
+}
+
+// Upsert creates or replaces a tenant. The change is visible on this replica at once
+// and on others after their next refresh.
+func (r *Registry) Upsert(ctx context.Context, t *Tenant) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if t.ScoringStrategy != "" && r.strategies != nil {
+		if _, ok := r.strategies.GetScoringStrategy(t.ScoringStrategy); !ok {
+			return fmt.Errorf("%w: unknown scoring strategy %q", ErrInvalidTenant, t.ScoringStrategy)
+		}
+	}
+	if err := r.repository.Upsert(ctx, t); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.loadedAt = time.Time{}
+	r.mu.Unlock()
+	return nil
+}
+
+// UpdateMembers adds users to a tenant, or changes their role, and removes others
+func (r *Registry) UpdateMembers(ctx context.Context, tenantID string, add []Member, remove []string) error {
+	for _, member := range add {
+		if member.UserID == "" {
+			return fmt.Errorf("%w: member user_id is required", ErrInvalidTenant)
+		}
+		if member.Role != RoleLearner && member.Role != RoleInstructor {
+			return fmt.Errorf("%w: role must be %s or %s, got %q", ErrInvalidTenant, RoleLearner, RoleInstructor, member.Role)
+		}
+	}
+	if _, err := r.Get(ctx, tenantID); err != nil {
+		return err
+	}
+
+	if err := r.repository.AddMembers(ctx, tenantID, add); err != nil {
+		return err
+	}
+	if err := r.repository.RemoveMembers(ctx, tenantID, remove); err != nil {
+		return err
+	}
+
+	// Cached memberships of changed members would otherwise outlive the change
+	if r.cache != nil {
+		keys := make([]string, 0, 2*(len(add)+len(remove)))
+		for _, member := range add {
+			keys = append(keys, memberCacheKey(tenantID, member.UserID), membershipsCacheKey(member.UserID))
+		}
+		for _, userID := range remove {
+			keys = append(keys, memberCacheKey(tenantID, userID), membershipsCacheKey(userID))
+		}
+		if err := r.cache.Delete(ctx, keys...); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate cached tenant memberships")
+		}
+	}
+	return nil
+}
+
+func memberCacheKey(tenantID, userID string) string {
+	return fmt.Sprintf("tenant:%s:member:%s", tenantID, userID)
+}
+
+func membershipsCacheKey(userID string) string {
+	return fmt.Sprintf("tenant:memberships:%s", userID)
+}
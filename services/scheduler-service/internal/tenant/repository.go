@@ -0,0 +1,235 @@
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// Repository persists tenants, their items and their members in the tenants,
+// tenant_items and tenant_members tables
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new tenant repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// tenantRow is a tenants row
+type tenantRow struct {
+	ID              string
+	Name            string
+	ScoringStrategy *string
+	ScoringWeights  []byte
+	Topics          []byte
+	IsActive        bool
+}
+
+// List returns every active tenant with its items
+func (r *Repository) List(ctx context.Context) ([]*Tenant, error) {
+	var rows []tenantRow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT id, name, scoring_strategy, scoring_weights, topics, is_active
+		FROM tenants WHERE is_active
+		ORDER BY id`).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	var items []struct {
+		TenantID string
+		ItemID   string
+	}
+	err = r.db.WithContext(ctx).Raw(`
+		SELECT ti.tenant_id, ti.item_id
+		FROM tenant_items ti JOIN tenants t ON t.id = ti.tenant_id
+		WHERE t.is_active`).
+		Scan(&items).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant items: %w", err)
+	}
+
+	tenants := make([]*Tenant, 0, len(rows))
+	byID := make(map[string]*Tenant, len(rows))
+	for _, row := range rows {
+		t, err := toTenant(row)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, t)
+		byID[t.ID] = t
+	}
+	for _, item := range items {
+		if t, ok := byID[item.TenantID]; ok {
+			if t.Items == nil {
+				t.Items = make(map[string]bool)
+			}
+			t.Items[item.ItemID] = true
+		}
+	}
+	return tenants, nil
+}
+
+// Upsert creates or replaces a tenant, including its item subset
+func (r *Repository) Upsert(ctx context.Context, t *Tenant) error {
+	topics, err := json.Marshal(nonNil(t.Topics))
+	if err != nil {
+		return fmt.Errorf("failed to marshal tenant topics: %w", err)
+	}
+	var weights []byte
+	if t.Weights != nil {
+		if weights, err = json.Marshal(t.Weights); err != nil {
+			return fmt.Errorf("failed to marshal tenant weights: %w", err)
+		}
+	}
+	var strategy *string
+	if t.ScoringStrategy != "" {
+		strategy = &t.ScoringStrategy
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO tenants (id, name, scoring_strategy, scoring_weights, topics, is_active, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+			ON CONFLICT (id) DO UPDATE SET
+				name = EXCLUDED.name,
+				scoring_strategy = EXCLUDED.scoring_strategy,
+				scoring_weights = EXCLUDED.scoring_weights,
+				topics = EXCLUDED.topics,
+				is_active = EXCLUDED.is_active,
+				updated_at = NOW()`,
+			t.ID, t.Name, strategy, weights, topics, t.Active).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`DELETE FROM tenant_items WHERE tenant_id = ?`, t.ID).Error; err != nil {
+			return err
+		}
+		for _, itemID := range sortedItems(t.Items) {
+			if err := tx.Exec(`INSERT INTO tenant_items (tenant_id, item_id) VALUES (?, ?)`, t.ID, itemID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert tenant: %w", err)
+	}
+	return nil
+}
+
+// Member returns a user's membership of a tenant, or nil if they are not a member
+func (r *Repository) Member(ctx context.Context, tenantID, userID string) (*Member, error) {
+	var members []Member
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT tenant_id, user_id, role, joined_at
+		FROM tenant_members WHERE tenant_id = ? AND user_id = ?`, tenantID, userID).
+		Scan(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant member: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return &members[0], nil
+}
+
+// Memberships returns a user's memberships of active tenants, earliest joined first
+func (r *Repository) Memberships(ctx context.Context, userID string) ([]Member, error) {
+	members := []Member{}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT m.tenant_id, m.user_id, m.role, m.joined_at
+		FROM tenant_members m JOIN tenants t ON t.id = m.tenant_id
+		WHERE m.user_id = ? AND t.is_active
+		ORDER BY m.joined_at, m.tenant_id`, userID).
+		Scan(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant memberships: %w", err)
+	}
+	return members, nil
+}
+
+// AddMembers adds users to a tenant, changing the role of existing members
+func (r *Repository) AddMembers(ctx context.Context, tenantID string, members []Member) error {
+	if len(members) == 0 {
+		return nil
+	}
+	now := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, member := range members {
+			if err := tx.Exec(`
+				INSERT INTO tenant_members (tenant_id, user_id, role, joined_at)
+				VALUES (?, ?, ?, ?)
+				ON CONFLICT (tenant_id, user_id) DO UPDATE SET role = EXCLUDED.role`,
+				tenantID, member.UserID, member.Role, now).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add tenant members: %w", err)
+	}
+	return nil
+}
+
+// RemoveMembers removes users from a tenant
+func (r *Repository) RemoveMembers(ctx context.Context, tenantID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).
+		Exec(`DELETE FROM tenant_members WHERE tenant_id = ? AND user_id IN ?`, tenantID, userIDs).Error
+	if err != nil {
+		return fmt.Errorf("failed to remove tenant members: %w", err)
+	}
+	return nil
+}
+
+func toTenant(row tenantRow) (*Tenant, error) {
+	t := &Tenant{ID: row.ID, Name: row.Name, Active: row.IsActive}
+	if row.ScoringStrategy != nil {
+		t.ScoringStrategy = *row.ScoringStrategy
+	}
+	if len(row.ScoringWeights) > 0 && string(row.ScoringWeights) != "null" {
+		var weights algorithms.ScoringWeights
+		if err := json.Unmarshal(row.ScoringWeights, &weights); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scoring weights of tenant %s: %w", row.ID, err)
+		}
+		t.Weights = &weights
+	}
+	if len(row.Topics) > 0 {
+		if err := json.Unmarshal(row.Topics, &t.Topics); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal topics of tenant %s: %w", row.ID, err)
+		}
+	}
+	return t, nil
+}
+
+func sortedItems(items map[string]bool) []string {
+	ids := make([]string, 0, len(items))
+	for id, allowed := range items {
+		if allowed {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
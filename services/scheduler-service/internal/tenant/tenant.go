@@ -0,0 +1,144 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"scheduler-service/internal/algorithms"
+)
+
+// MetadataKey is the gRPC metadata key callers name the tenant of a request in
+const MetadataKey = "x-tenant-id"
+
+// Roles of tenant members
+const (
+	RoleLearner    = "learner"
+	RoleInstructor = "instructor"
+)
+
+// strategyPrefix names the scoring strategies registered for tenants with their own weights
+const strategyPrefix = "tenant:"
+
+var (
+	// ErrUnknownTenant is returned for tenants that do not exist or are inactive
+	ErrUnknownTenant = errors.New("unknown tenant")
+	// ErrNotMember is returned when a user does not belong to a tenant
+	ErrNotMember = errors.New("user is not a member of the tenant")
+	// ErrInvalidTenant is returned for tenant definitions that cannot be stored
+	ErrInvalidTenant = errors.New("invalid tenant")
+)
+
+// Tenant is an organization, such as a driving school, managing a cohort of learners.
+// Learner state stays keyed by user; a tenant narrows the items its learners are
+// scheduled and how candidates are scored.
+type Tenant struct {
+	ID              string                     `json:"id"`
+	Name            string                     `json:"name"`
+	ScoringStrategy string                     `json:"scoring_strategy,omitempty"` // Empty uses the default strategy
+	Weights         *algorithms.ScoringWeights `json:"weights,omitempty"`          // Overrides the strategy's weights
+	Topics          []string                   `json:"topics,omitempty"`           // Empty allows every topic
+	Items           map[string]bool            `json:"-"`                          // Empty allows every item
+	Active          bool                       `json:"active"`
+}
+
+// AllowsItem reports whether the tenant's learners may be scheduled an item with topics
+func (t *Tenant) AllowsItem(itemID string, topics []string) bool {
+	if len(t.Items) > 0 && !t.Items[itemID] {
+		return false
+	}
+	if len(t.Topics) == 0 {
+		return true
+	}
+	for _, topic := range topics {
+		for _, allowed := range t.Topics {
+			if strings.EqualFold(topic, allowed) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Strategy returns the name of the scoring strategy of the tenant's learners, or "" for
+// the default strategy
+func (t *Tenant) Strategy() string {
+	if t.Weights != nil {
+		return strategyPrefix + t.ID
+	}
+	return t.ScoringStrategy
+}
+
+// Validate checks that the tenant can be stored
+func (t *Tenant) Validate() error {
+	if t.ID == "" || len(t.ID) > 64 || strings.ContainsAny(t.ID, ": ") {
+		return fmt.Errorf("%w: id must be 1 to 64 characters without colons or spaces", ErrInvalidTenant)
+	}
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTenant)
+	}
+	if strings.HasPrefix(t.ScoringStrategy, strategyPrefix) {
+		return fmt.Errorf("%w: scoring strategy %q belongs to another tenant", ErrInvalidTenant, t.ScoringStrategy)
+	}
+	if w := t.Weights; w != nil {
+		for _, weight := range []float64{w.Urgency, w.Mastery, w.Difficulty, w.Exploration} {
+			if weight < 0 || weight > 1 {
+				return fmt.Errorf("%w: weights must be between 0 and 1", ErrInvalidTenant)
+			}
+		}
+		if total := w.Urgency + w.Mastery + w.Difficulty + w.Exploration; math.Abs(total-1.0) > 0.01 {
+			return fmt.Errorf("%w: weights must sum to 1.0, got %.3f", ErrInvalidTenant, total)
+		}
+	}
+	return nil
+}
+
+// Member is a learner or instructor of a tenant
+type Member struct {
+	TenantID string    `json:"tenant_id"`
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type tenantKey struct{}
+
+// NewContext returns a context carrying the tenant a request is made within
+func NewContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// FromContext returns the tenant a request is made within, if any
+func FromContext(ctx context.Context) (*Tenant, bool) {
+	t, ok := ctx.Value(tenantKey{}).(*Tenant)
+	return t, ok && t != nil
+}
+
+// IDFromMetadata returns the tenant named in the request's metadata, or ""
+func IDFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get(MetadataKey) {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// CacheKey namespaces a cache key by the tenant of the context, for entries that
+// depend on the tenant, such as placement test state. Learner state caches are keyed
+// by user and shared across tenants, like the state itself.
+func CacheKey(ctx context.Context, key string) string {
+	if t, ok := FromContext(ctx); ok {
+		return "tenant:" + t.ID + ":" + key
+	}
+	return key
+}
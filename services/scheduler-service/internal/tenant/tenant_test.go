@@ -0,0 +1,106 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"scheduler-service/internal/algorithms"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+)
+
+func TestTenant_AllowsItem(t *testing.T) {
+	everything := &Tenant{ID: "school-1"}
+	assert.True(t, everything.AllowsItem("item-1", []string{"parking"}))
+
+	byTopic := &Tenant{ID: "school-2", Topics: []string{"Traffic_Signs"}}
+	assert.True(t, byTopic.AllowsItem("item-1", []string{"parking", "traffic_signs"}))
+	assert.False(t, byTopic.AllowsItem("item-2", []string{"parking"}))
+
+	byItem := &Tenant{ID: "school-3", Items: map[string]bool{"item-1": true}, Topics: []string{"parking"}}
+	assert.True(t, byItem.AllowsItem("item-1", []string{"parking"}))
+	assert.False(t, byItem.AllowsItem("item-2", []string{"parking"}))
+}
+
+func TestTenant_Strategy(t *testing.T) {
+	assert.Equal(t, "", (&Tenant{ID: "school-1"}).Strategy())
+	assert.Equal(t, "exam_prep", (&Tenant{ID: "school-1", ScoringStrategy: "exam_prep"}).Strategy())
+	assert.Equal(t, "tenant:school-1", (&Tenant{ID: "school-1", Weights: &algorithms.ScoringWeights{Urgency: 1}}).Strategy())
+}
+
+func TestTenant_Validate(t *testing.T) {
+	valid := &Tenant{ID: "school-1", Name: "Downtown Driving School",
+		Weights: &algorithms.ScoringWeights{Urgency: 0.4, Mastery: 0.4, Difficulty: 0.1, Exploration: 0.1}}
+	require.NoError(t, valid.Validate())
+
+	invalid := []*Tenant{
+		{Name: "No ID"},
+		{ID: "school:1", Name: "Colon"},
+		{ID: "school-1"},
+		{ID: "school-1", Name: "Borrowed strategy", ScoringStrategy: "tenant:school-2"},
+		{ID: "school-1", Name: "Bad weights", Weights: &algorithms.ScoringWeights{Urgency: 0.5, Mastery: 0.2}},
+	}
+	for _, tenant := range invalid {
+		assert.ErrorIs(t, tenant.Validate(), ErrInvalidTenant, "%+v", tenant)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, "placement_state:s1", CacheKey(ctx, "placement_state:s1"))
+
+	ctx = NewContext(ctx, &Tenant{ID: "school-1"})
+	tenant, ok := FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "school-1", tenant.ID)
+	assert.Equal(t, "tenant:school-1:placement_state:s1", CacheKey(ctx, "placement_state:s1"))
+}
+
+func TestIDFromMetadata(t *testing.T) {
+	assert.Equal(t, "", IDFromMetadata(context.Background()))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, " school-1 "))
+	assert.Equal(t, "school-1", IDFromMetadata(ctx))
+}
+
+// fakeStrategies is an in-memory set of scoring strategies
+type fakeStrategies map[string]algorithms.ScoringStrategy
+
+func (f fakeStrategies) GetScoringStrategy(name string) (algorithms.ScoringStrategy, bool) {
+	strategy, ok := f[name]
+	return strategy, ok
+}
+
+func (f fakeStrategies) AddScoringStrategy(strategy algorithms.ScoringStrategy) error {
+	f[strategy.Name] = strategy
+	return nil
+}
+
+func TestRegistry_RegisterStrategy(t *testing.T) {
+	strategies := fakeStrategies{
+		"balanced":  {Name: "balanced", Parameters: algorithms.ScoringParameters{ExplorationRate: 0.15}},
+		"exam_prep": {Name: "exam_prep", Parameters: algorithms.ScoringParameters{ExplorationRate: 0.05}},
+	}
+	r := NewRegistry(&config.TenantConfig{}, nil, nil, logger.New(&config.LoggingConfig{Level: "error", Format: "text"}), strategies)
+
+	weights := algorithms.ScoringWeights{Urgency: 0.4, Mastery: 0.4, Difficulty: 0.1, Exploration: 0.1}
+	tenant := &Tenant{ID: "school-1", ScoringStrategy: "exam_prep", Weights: &weights}
+	r.registerStrategy(context.Background(), tenant)
+
+	registered, ok := strategies["tenant:school-1"]
+	require.True(t, ok)
+	assert.Equal(t, weights, registered.Weights)
+	assert.Equal(t, 0.05, registered.Parameters.ExplorationRate, "expected the parameters of the base strategy")
+
+	// Unknown base strategies fall back to the default one
+	unknown := &Tenant{ID: "school-2", ScoringStrategy: "retired", Weights: &weights}
+	r.registerStrategy(context.Background(), unknown)
+	assert.Equal(t, 0.15, strategies["tenant:school-2"].Parameters.ExplorationRate)
+	assert.Equal(t, "", unknown.ScoringStrategy)
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+// recordSpans installs a tracer provider recording finished spans for the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestHTTPMiddleware_NamesSpanAfterRoute(t *testing.T) {
+	recorder := recordSpans(t)
+
+	var traceID string
+	router := mux.NewRouter()
+	router.Use(RouteMiddleware)
+	router.HandleFunc("/api/v1/onboarding/{userId}", func(w http.ResponseWriter, r *http.Request) {
+		traceID = trace.SpanContextFromContext(r.Context()).TraceID().String()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}).Methods(http.MethodGet)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/onboarding/user-1", nil)
+	req.Header.Set("traceparent", testTraceParent)
+	HTTPMiddleware(router).ServeHTTP(httptest.NewRecorder(), req)
+
+	if traceID != testTraceID {
+		t.Errorf("Expected handler to run in trace %s, got %s", testTraceID, traceID)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /api/v1/onboarding/{userId}" {
+		t.Errorf("Expected span named after the route, got %s", span.Name())
+	}
+	if span.Status().Code != otelcodes.Error {
+		t.Errorf("Expected a server error to mark the span as error, got %v", span.Status().Code)
+	}
+	if span.InstrumentationScope().Name != instrumentationName {
+		t.Errorf("Expected the service's instrumentation scope, got %s", span.InstrumentationScope().Name)
+	}
+}
+
+func TestUnaryServerInterceptor_UsesServiceTracer(t *testing.T) {
+	recorder := recordSpans(t)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/scheduler.SchedulerService/GetNextItems"}
+	_, err := UnaryServerInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].InstrumentationScope().Name != instrumentationName {
+		t.Errorf("Expected the service's instrumentation scope, got %s", spans[0].InstrumentationScope().Name)
+	}
+}
@@ -0,0 +1,147 @@
+package userdata
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"scheduler-service/internal/config"
+)
+
+// ErrInvalidSignature is returned when a receipt's signature does not match its payload
+var ErrInvalidSignature = errors.New("invalid receipt signature")
+
+// Receipt records an export or deletion of a user's learning state: what was covered
+// and when. It holds counts only, never the learning state itself.
+type Receipt struct {
+	ReceiptID  string           `json:"receipt_id"`
+	Operation  string           `json:"operation"`
+	UserID     string           `json:"user_id"`
+	RequestID  string           `json:"request_id,omitempty"`
+	IssuedAt   time.Time        `json:"issued_at"`
+	TableRows  map[string]int64 `json:"table_rows"`
+	CacheKeys  int              `json:"cache_keys"`
+	DataSHA256 string           `json:"data_sha256,omitempty"`
+}
+
+// SignedReceipt is a receipt with the exact payload its signature covers. The payload
+// is the receipt's JSON encoding, with object keys in a fixed order.
+type SignedReceipt struct {
+	Receipt
+	Payload   string
+	Signature string // Base64url Ed25519 signature of Payload, without padding
+	KeyID     string
+}
+
+// Signer signs receipts with an Ed25519 key so the services and auditors holding
+// the public key can check them
+type Signer struct {
+	key       ed25519.PrivateKey
+	keyID     string
+	ephemeral bool
+}
+
+// NewSigner loads the receipt signing key. Without a key file a key is generated,
+// and receipts cannot be verified after the service restarts.
+func NewSigner(cfg *config.UserDataConfig) (*Signer, error) {
+	if cfg.ReceiptKeyFile == "" {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate receipt signing key: %w", err)
+		}
+		return newSigner(key, true), nil
+	}
+
+	data, err := os.ReadFile(cfg.ReceiptKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read receipt signing key: %w", err)
+	}
+	key, err := parsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt signing key %s: %w", cfg.ReceiptKeyFile, err)
+	}
+	return newSigner(key, false), nil
+}
+
+func newSigner(key ed25519.PrivateKey, ephemeral bool) *Signer {
+	return &Signer{key: key, keyID: keyID(key.Public().(ed25519.PublicKey)), ephemeral: ephemeral}
+}
+
+// parsePrivateKeyPEM parses a PKCS#8 Ed25519 private key, as written by
+// `openssl genpkey -algorithm ed25519`
+func parsePrivateKeyPEM(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key is %T, not Ed25519", parsed)
+	}
+	return key, nil
+}
+
+// keyID identifies a public key by the first 8 bytes of its SHA-256 digest, in hex
+func keyID(publicKey ed25519.PublicKey) string {
+	digest := sha256.Sum256(publicKey)
+	return hex.EncodeToString(digest[:8])
+}
+
+// KeyID returns the ID of the signing key, included in every receipt
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey returns the key receipts are verified with
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Ephemeral reports whether the key was generated at startup rather than loaded
+func (s *Signer) Ephemeral() bool {
+	return s.ephemeral
+}
+
+// Sign encodes a receipt and signs the encoding
+func (s *Signer) Sign(receipt Receipt) (*SignedReceipt, error) {
+	payload, err := json.Marshal(receipt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode receipt: %w", err)
+	}
+
+	return &SignedReceipt{
+		Receipt:   receipt,
+		Payload:   string(payload),
+		Signature: base64.RawURLEncoding.EncodeToString(ed25519.Sign(s.key, payload)),
+		KeyID:     s.keyID,
+	}, nil
+}
+
+// VerifyReceipt checks the signature of a signed payload with a public key and
+// returns the receipt it holds
+func VerifyReceipt(publicKey ed25519.PublicKey, payload, signature string) (*Receipt, error) {
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !ed25519.Verify(publicKey, []byte(payload), sig) {
+		return nil, ErrInvalidSignature
+	}
+
+	var receipt Receipt
+	if err := json.Unmarshal([]byte(payload), &receipt); err != nil {
+		return nil, fmt.Errorf("failed to decode receipt: %w", err)
+	}
+	return &receipt, nil
+}
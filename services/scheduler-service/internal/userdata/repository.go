@@ -0,0 +1,146 @@
+package userdata
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"scheduler-service/internal/database"
+
+	"gorm.io/gorm"
+)
+
+// errReceiptNotFound is returned when no receipt was stored for a request ID
+var errReceiptNotFound = errors.New("receipt not found")
+
+// userTables are the tables holding a user's learning state, each with a user_id
+// column. Children come before their parents so rows are deleted in a valid order.
+var userTables = []string{
+	"sm2_states",
+	"bkt_states",
+	"irt_states",
+	"user_scheduler_settings",
+	"user_jurisdictions",
+	"user_onboarding",
+	"onboarding_analytics",
+	"onboarding_stage_transitions",
+	"placement_test_sessions",
+	"milestone_progress",
+	"learning_paths",
+	"study_activity",
+	"study_plan_history",
+	"learning_goals",
+	"cohort_learner_summaries",
+	"tenant_members",
+}
+
+// Repository reads and deletes a user's rows across the scheduler's tables and stores
+// deletion receipts in the user_data_receipts table
+type Repository struct {
+	db *database.DB
+}
+
+// NewRepository creates a new user data repository
+func NewRepository(db *database.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// ExportUser returns the user's rows of each table as a JSON array, with the number
+// of rows per table. All tables are read from one snapshot.
+func (r *Repository) ExportUser(ctx context.Context, userID string) (map[string]json.RawMessage, map[string]int64, error) {
+	tables := make(map[string]json.RawMessage, len(userTables))
+	counts := make(map[string]int64, len(userTables))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range userTables {
+			var rows string
+			var count int64
+			err := tx.Raw(`SELECT COALESCE(json_agg(t), '[]'::json)::text, COUNT(*) FROM `+table+` t WHERE t.user_id = ?`, userID).
+				Row().Scan(&rows, &count)
+			if err != nil {
+				return fmt.Errorf("failed to export %s: %w", table, err)
+			}
+			tables[table] = json.RawMessage(rows)
+			counts[table] = count
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tables, counts, nil
+}
+
+// DeleteUser deletes the user's rows from every table in one transaction and returns
+// the number of rows deleted per table
+func (r *Repository) DeleteUser(ctx context.Context, userID string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(userTables))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range userTables {
+			result := tx.Exec(`DELETE FROM `+table+` WHERE user_id = ?`, userID)
+			if result.Error != nil {
+				return fmt.Errorf("failed to delete from %s: %w", table, result.Error)
+			}
+			counts[table] = result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// receiptRow is a stored deletion receipt
+type receiptRow struct {
+	RequestID string
+	Payload   string
+	Signature string
+	KeyID     string
+}
+
+// Receipt returns the receipt stored for a request ID, or errReceiptNotFound
+func (r *Repository) Receipt(ctx context.Context, requestID string) (*SignedReceipt, error) {
+	var rows []receiptRow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT request_id, payload, signature, key_id
+		FROM user_data_receipts
+		WHERE request_id = ?`, requestID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errReceiptNotFound
+	}
+
+	signed := &SignedReceipt{
+		Payload:   rows[0].Payload,
+		Signature: rows[0].Signature,
+		KeyID:     rows[0].KeyID,
+	}
+	if err := json.Unmarshal([]byte(signed.Payload), &signed.Receipt); err != nil {
+		return nil, fmt.Errorf("failed to decode receipt of request %s: %w", requestID, err)
+	}
+	return signed, nil
+}
+
+// SaveReceipt stores a deletion receipt under its request ID. It reports false when a
+// receipt was already stored for the request ID, leaving that one in place.
+func (r *Repository) SaveReceipt(ctx context.Context, signed *SignedReceipt) (bool, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO user_data_receipts (request_id, receipt_id, user_id, operation, payload, signature, key_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (request_id) DO NOTHING`,
+		signed.RequestID, signed.ReceiptID, signed.UserID, signed.Operation,
+		signed.Payload, signed.Signature, signed.KeyID, signed.IssuedAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save receipt: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,318 @@
+package userdata
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+)
+
+// Operations covered by receipts
+const (
+	OperationExport = "export"
+	OperationDelete = "delete"
+)
+
+var (
+	// ErrInvalidUserID is returned for user IDs that are not UUIDs
+	ErrInvalidUserID = errors.New("user_id must be a UUID")
+	// ErrRequestIDRequired is returned for deletions without a request ID
+	ErrRequestIDRequired = errors.New("request_id is required")
+	// ErrRequestIDConflict is returned when a request ID was already used for another user
+	ErrRequestIDConflict = errors.New("request_id was already used for another user")
+	// ErrSigningUnavailable is returned when the service has no receipt signing key
+	ErrSigningUnavailable = errors.New("receipt signing key not configured")
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// cacheKeyPatterns returns the Redis key patterns of a user's cached learning state.
+// Keep them in sync with the cache key builders of the state managers, onboarding,
+// goals, placement tests and tenant memberships; placement session IDs start with the
+// user ID, and placement state cached within a tenant is prefixed by it.
+func cacheKeyPatterns(userID string) []string {
+	return []string{
+		"sm2:" + userID + ":*",
+		"sm2:user:" + userID + ":*",
+		"bkt:" + userID + ":*",
+		"irt_state:" + userID + ":*",
+		"scheduler_settings:" + userID,
+		"scheduler:goals:" + userID,
+		"scheduler:user:" + userID,
+		"scheduler:sm2:" + userID,
+		"scheduler:bkt:" + userID,
+		"scheduler:irt:" + userID,
+		"onboarding:" + userID,
+		"placement:onboarding_" + userID + "_*",
+		"placement_state:placement_" + userID + "_*",
+		"tenant:*:placement_state:placement_" + userID + "_*",
+		"tenant:*:member:" + userID,
+		"tenant:memberships:" + userID,
+	}
+}
+
+// CacheInvalidator drops a user's entries from a cache it manages
+type CacheInvalidator interface {
+	InvalidateUserCache(ctx context.Context, userID string) error
+}
+
+// Export is a user's learning state as kept by the scheduler: their rows of every
+// table and the values cached for them in Redis
+type Export struct {
+	UserID     string                     `json:"user_id"`
+	ExportedAt time.Time                  `json:"exported_at"`
+	Tables     map[string]json.RawMessage `json:"tables"`
+	Cache      map[string]json.RawMessage `json:"cache"`
+}
+
+// Service exports and deletes everything the scheduler keeps about a user, for data
+// access and erasure requests, and issues a signed receipt for each
+type Service struct {
+	logger       *logger.Logger
+	repository   *Repository
+	cache        *cache.RedisClient
+	signer       *Signer
+	invalidators []CacheInvalidator
+}
+
+// NewService creates a new user data service. Invalidators are called when a user's
+// state is deleted, after the user's cache keys are removed.
+func NewService(log *logger.Logger, db *database.DB, cache *cache.RedisClient, signer *Signer, invalidators ...CacheInvalidator) *Service {
+	return &Service{
+		logger:       log,
+		repository:   NewRepository(db),
+		cache:        cache,
+		signer:       signer,
+		invalidators: invalidators,
+	}
+}
+
+// Export collects the user's learning state as a JSON document and signs a receipt
+// holding its SHA-256 digest. Exporting changes nothing, so it can be repeated freely.
+func (s *Service) Export(ctx context.Context, userID string) ([]byte, *SignedReceipt, error) {
+	if !uuidPattern.MatchString(userID) {
+		return nil, nil, ErrInvalidUserID
+	}
+	if s.signer == nil {
+		return nil, nil, ErrSigningUnavailable
+	}
+
+	tables, tableRows, err := s.repository.ExportUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cached, err := s.exportCache(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	export := Export{
+		UserID:     userID,
+		ExportedAt: time.Now().UTC(),
+		Tables:     tables,
+		Cache:      cached,
+	}
+	data, err := json.Marshal(export)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode export: %w", err)
+	}
+
+	digest := sha256.Sum256(data)
+	receipt, err := s.newReceipt(OperationExport, userID, "", export.ExportedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt.TableRows = tableRows
+	receipt.CacheKeys = len(cached)
+	receipt.DataSHA256 = hex.EncodeToString(digest[:])
+
+	signed, err := s.signer.Sign(receipt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":    userID,
+		"receipt_id": receipt.ReceiptID,
+		"cache_keys": receipt.CacheKeys,
+	}).Info("Exported user learning state")
+
+	return data, signed, nil
+}
+
+// exportCache returns the values cached for the user by key. Values that are not
+// JSON are exported as strings.
+func (s *Service) exportCache(ctx context.Context, userID string) (map[string]json.RawMessage, error) {
+	cached := make(map[string]json.RawMessage)
+	if s.cache == nil {
+		return cached, nil
+	}
+
+	for _, pattern := range cacheKeyPatterns(userID) {
+		keys, err := s.cache.ScanKeys(ctx, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			value, err := s.cache.GetRaw(ctx, key)
+			if errors.Is(err, cache.ErrCacheMiss) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !json.Valid(value) {
+				value, _ = json.Marshal(string(value))
+			}
+			cached[key] = value
+		}
+	}
+
+	return cached, nil
+}
+
+// Delete purges the user's learning state from every table and from Redis and signs
+// a receipt of what was deleted, stored under the request ID. Repeating a request ID
+// purges again, in case state was written since, and returns the original receipt
+// with replayed set.
+func (s *Service) Delete(ctx context.Context, userID, requestID string) (signed *SignedReceipt, replayed bool, err error) {
+	if !uuidPattern.MatchString(userID) {
+		return nil, false, ErrInvalidUserID
+	}
+	if requestID == "" {
+		return nil, false, ErrRequestIDRequired
+	}
+	if s.signer == nil {
+		return nil, false, ErrSigningUnavailable
+	}
+
+	previous, err := s.repository.Receipt(ctx, requestID)
+	if err != nil && !errors.Is(err, errReceiptNotFound) {
+		return nil, false, err
+	}
+	if previous != nil && previous.UserID != userID {
+		return nil, false, ErrRequestIDConflict
+	}
+
+	// Cached state is removed before and after the rows, so reads in between cannot
+	// put deleted state back into the cache
+	cacheKeys, err := s.purgeCache(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tableRows, err := s.repository.DeleteUser(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	repopulated, err := s.purgeCache(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	cacheKeys += repopulated
+
+	if previous != nil {
+		s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+			"user_id":    userID,
+			"request_id": requestID,
+			"receipt_id": previous.ReceiptID,
+		}).Info("Repeated deletion of user learning state, returning the original receipt")
+		return previous, true, nil
+	}
+
+	receipt, err := s.newReceipt(OperationDelete, userID, requestID, time.Now().UTC())
+	if err != nil {
+		return nil, false, err
+	}
+	receipt.TableRows = tableRows
+	receipt.CacheKeys = cacheKeys
+
+	signed, err = s.signer.Sign(receipt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	saved, err := s.repository.SaveReceipt(ctx, signed)
+	if err != nil {
+		return nil, false, err
+	}
+	if !saved {
+		// A concurrent request with the same ID stored its receipt first
+		stored, err := s.repository.Receipt(ctx, requestID)
+		if err != nil {
+			return nil, false, err
+		}
+		if stored.UserID != userID {
+			return nil, false, ErrRequestIDConflict
+		}
+		return stored, true, nil
+	}
+
+	s.logger.WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id":    userID,
+		"request_id": requestID,
+		"receipt_id": receipt.ReceiptID,
+		"table_rows": tableRows,
+		"cache_keys": cacheKeys,
+	}).Info("Deleted user learning state")
+
+	return signed, false, nil
+}
+
+// purgeCache removes the user's cache keys, drops the user from the in-process tier
+// of every replica and returns how many keys were removed
+func (s *Service) purgeCache(ctx context.Context, userID string) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+
+	removed := 0
+	for _, pattern := range cacheKeyPatterns(userID) {
+		count, err := s.cache.DeletePattern(ctx, pattern)
+		if err != nil {
+			return removed, err
+		}
+		removed += count
+	}
+
+	for _, invalidator := range s.invalidators {
+		if err := invalidator.InvalidateUserCache(ctx, userID); err != nil {
+			return removed, err
+		}
+	}
+
+	if err := s.cache.InvalidateUser(ctx, userID); err != nil {
+		return removed, err
+	}
+
+	return removed, nil
+}
+
+// newReceipt starts a receipt with a new random ID
+func (s *Service) newReceipt(operation, userID, requestID string, issuedAt time.Time) (Receipt, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Receipt{}, fmt.Errorf("failed to generate receipt ID: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return Receipt{
+		ReceiptID: fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]),
+		Operation: operation,
+		UserID:    userID,
+		RequestID: requestID,
+		IssuedAt:  issuedAt,
+	}, nil
+}
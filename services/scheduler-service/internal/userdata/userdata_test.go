@@ -0,0 +1,183 @@
+package userdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+)
+
+const testUserID = "00000000-0000-4000-8000-000000000001"
+
+func TestCacheKeyPatterns_CoverUserKeys(t *testing.T) {
+	userKeys := []string{
+		"sm2:" + testUserID + ":item_1",
+		"sm2:user:" + testUserID + ":all:1767225600000000000",
+		"sm2:user:" + testUserID + ":generation",
+		"bkt:" + testUserID + ":GB:road_signs",
+		"irt_state:" + testUserID + ":default:parking",
+		"scheduler_settings:" + testUserID,
+		"scheduler:goals:" + testUserID,
+		"onboarding:" + testUserID,
+		"placement:onboarding_" + testUserID + "_1767225600",
+		"placement_state:placement_" + testUserID + "_1767225600",
+		"tenant:school-1:placement_state:placement_" + testUserID + "_1767225600",
+		"tenant:school-1:member:" + testUserID,
+		"tenant:memberships:" + testUserID,
+	}
+	otherKeys := []string{
+		"sm2:00000000-0000-4000-8000-000000000002:item_1",
+		"scheduler:item:difficulty:item_1",
+		"placement_state:placement_00000000-0000-4000-8000-000000000002_1767225600",
+		"tenant:school-1:member:00000000-0000-4000-8000-000000000002",
+		"tenant:memberships:00000000-0000-4000-8000-000000000002",
+	}
+
+	matches := func(key string) bool {
+		for _, pattern := range cacheKeyPatterns(testUserID) {
+			if ok, _ := path.Match(pattern, key); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, key := range userKeys {
+		if !matches(key) {
+			t.Errorf("expected %s to be covered", key)
+		}
+	}
+	for _, key := range otherKeys {
+		if matches(key) {
+			t.Errorf("expected %s not to be covered", key)
+		}
+	}
+}
+
+func TestService_ValidatesRequests(t *testing.T) {
+	signer, _ := NewSigner(&config.UserDataConfig{})
+	service := NewService(logger.New(&config.LoggingConfig{Level: "error", Format: "text"}), nil, nil, signer)
+	ctx := context.Background()
+
+	if _, _, err := service.Export(ctx, "user-1*"); !errors.Is(err, ErrInvalidUserID) {
+		t.Errorf("expected ErrInvalidUserID for a non-UUID user, got %v", err)
+	}
+	if _, _, err := service.Delete(ctx, "user-1*", "erasure-1"); !errors.Is(err, ErrInvalidUserID) {
+		t.Errorf("expected ErrInvalidUserID for a non-UUID user, got %v", err)
+	}
+	if _, _, err := service.Delete(ctx, testUserID, ""); !errors.Is(err, ErrRequestIDRequired) {
+		t.Errorf("expected ErrRequestIDRequired without a request ID, got %v", err)
+	}
+
+	unsigned := NewService(logger.New(&config.LoggingConfig{Level: "error", Format: "text"}), nil, nil, nil)
+	if _, _, err := unsigned.Delete(ctx, testUserID, "erasure-1"); !errors.Is(err, ErrSigningUnavailable) {
+		t.Errorf("expected ErrSigningUnavailable without a signer, got %v", err)
+	}
+}
+
+// newTestService connects to SCHEDULER_TEST_DATABASE_URL and applies migrations.
+// Tests using it are skipped when the variable is not set.
+func newTestService(t *testing.T) (*Service, *Signer, *database.DB) {
+	t.Helper()
+
+	databaseURL := os.Getenv("SCHEDULER_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("SCHEDULER_TEST_DATABASE_URL not set")
+	}
+
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	db, err := database.New(&config.DatabaseConfig{URL: databaseURL, MaxOpenConns: 5, MaxIdleConns: 1, ConnMaxLifetime: time.Minute}, &metrics.Metrics{}, log)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	signer, err := NewSigner(&config.UserDataConfig{})
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	return NewService(log, db, nil, signer), signer, db
+}
+
+func TestService_ExportAndDelete(t *testing.T) {
+	service, signer, db := newTestService(t)
+	ctx := context.Background()
+
+	suffix := time.Now().UnixNano() % 1e12
+	userID := fmt.Sprintf("00000000-0000-4000-e000-%012d", suffix)
+	requestID := fmt.Sprintf("erasure-%d", suffix)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM sm2_states WHERE user_id = ?", userID)
+		db.Exec("DELETE FROM bkt_states WHERE user_id = ?", userID)
+		db.Exec("DELETE FROM user_data_receipts WHERE user_id = ?", userID)
+	})
+
+	err := db.Exec(`INSERT INTO sm2_states (user_id, item_id) VALUES (?, gen_random_uuid()), (?, gen_random_uuid())`, userID, userID).Error
+	if err != nil {
+		t.Fatalf("failed to create SM-2 states: %v", err)
+	}
+	err = db.Exec(`INSERT INTO bkt_states (user_id, jurisdiction, topic) VALUES (?, 'default', 'road_signs')`, userID).Error
+	if err != nil {
+		t.Fatalf("failed to create BKT state: %v", err)
+	}
+
+	data, exported, err := service.Export(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	if exported.TableRows["sm2_states"] != 2 || exported.TableRows["bkt_states"] != 1 || exported.TableRows["irt_states"] != 0 {
+		t.Errorf("expected exported row counts per table, got %v", exported.TableRows)
+	}
+	if len(data) == 0 || exported.DataSHA256 == "" {
+		t.Error("expected exported data with its digest")
+	}
+	if _, err := VerifyReceipt(signer.PublicKey(), exported.Payload, exported.Signature); err != nil {
+		t.Errorf("expected a verifiable export receipt, got %v", err)
+	}
+
+	deleted, replayed, err := service.Delete(ctx, userID, requestID)
+	if err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	if replayed {
+		t.Error("expected the first deletion not to be a replay")
+	}
+	if deleted.TableRows["sm2_states"] != 2 || deleted.TableRows["bkt_states"] != 1 {
+		t.Errorf("expected deleted row counts per table, got %v", deleted.TableRows)
+	}
+
+	var remaining int64
+	db.Raw("SELECT COUNT(*) FROM sm2_states WHERE user_id = ?", userID).Scan(&remaining)
+	if remaining != 0 {
+		t.Errorf("expected no SM-2 states left, got %d", remaining)
+	}
+
+	again, replayed, err := service.Delete(ctx, userID, requestID)
+	if err != nil {
+		t.Fatalf("failed to repeat deletion: %v", err)
+	}
+	if !replayed || again.ReceiptID != deleted.ReceiptID || again.Payload != deleted.Payload {
+		t.Errorf("expected the original receipt for a repeated request, got %+v", again)
+	}
+
+	otherUser := fmt.Sprintf("00000000-0000-4000-e001-%012d", suffix)
+	if _, _, err := service.Delete(ctx, otherUser, requestID); !errors.Is(err, ErrRequestIDConflict) {
+		t.Errorf("expected ErrRequestIDConflict for a request ID of another user, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"scheduler-service/internal/cache"
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+)
+
+// LeaderElector decides which scheduler instance runs maintenance jobs
+type LeaderElector interface {
+	// TryAcquire attempts to become (or remain) leader. It returns true while leadership is held.
+	TryAcquire(ctx context.Context) (bool, error)
+	// Release gives up leadership if held
+	Release(ctx context.Context) error
+}
+
+// NewLeaderElector creates a leader elector for the configured backend
+func NewLeaderElector(cfg *config.WorkerConfig, db *database.DB, redisClient *cache.RedisClient) (LeaderElector, error) {
+	switch cfg.LeaderBackend {
+	case "postgres", "":
+		return NewPostgresLeaderElector(db, cfg.LeaderLockKey)
+	case "redis":
+		return NewRedisLeaderElector(redisClient, cfg.LeaderLockKey, cfg.LeaderLockTTL), nil
+	default:
+		return nil, fmt.Errorf("unknown leader election backend: %s", cfg.LeaderBackend)
+	}
+}
+
+// PostgresLeaderElector uses a session-level Postgres advisory lock.
+// The lock is held on a dedicated connection and released automatically if the connection drops.
+type PostgresLeaderElector struct {
+	db      *sql.DB
+	lockID  int64
+	conn    *sql.Conn
+	mu      sync.Mutex
+	timeout time.Duration
+}
+
+// NewPostgresLeaderElector creates a Postgres advisory lock elector for the given lock key
+func NewPostgresLeaderElector(db *database.DB, lockKey string) (*PostgresLeaderElector, error) {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	return &PostgresLeaderElector{
+		db:      sqlDB,
+		lockID:  advisoryLockID(lockKey),
+		timeout: 5 * time.Second,
+	}, nil
+}
+
+// TryAcquire attempts to take the advisory lock, or verifies the held connection is still alive
+func (e *PostgresLeaderElector) TryAcquire(ctx context.Context) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	if e.conn != nil {
+		// Lock is tied to the session, so a live connection means we are still leader
+		if err := e.conn.PingContext(ctx); err != nil {
+			e.conn.Close()
+			e.conn = nil
+			return false, fmt.Errorf("lost leader connection: %w", err)
+		}
+		return true, nil
+	}
+
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get connection for advisory lock: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", e.lockID).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+
+	e.conn = conn
+	return true, nil
+}
+
+// Release unlocks the advisory lock and returns the connection to the pool
+func (e *PostgresLeaderElector) Release(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	_, err := e.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", e.lockID)
+	e.conn.Close()
+	e.conn = nil
+	if err != nil {
+		return fmt.Errorf("failed to release advisory lock: %w", err)
+	}
+
+	return nil
+}
+
+// RedisLeaderElector uses a Redis key with a TTL as a lease
+type RedisLeaderElector struct {
+	cache  *cache.RedisClient
+	key    string
+	token  string
+	ttl    time.Duration
+	mu     sync.Mutex
+	leader bool
+}
+
+// NewRedisLeaderElector creates a Redis lock elector with a random owner token
+func NewRedisLeaderElector(redisClient *cache.RedisClient, lockKey string, ttl time.Duration) *RedisLeaderElector {
+	return &RedisLeaderElector{
+		cache: redisClient,
+		key:   lockKey,
+		token: newLockToken(),
+		ttl:   ttl,
+	}
+}
+
+// TryAcquire takes the lock if free, or extends the lease if already held
+func (e *RedisLeaderElector) TryAcquire(ctx context.Context) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.leader {
+		refreshed, err := e.cache.RefreshLock(ctx, e.key, e.token, e.ttl)
+		if err != nil {
+			e.leader = false
+			return false, err
+		}
+		e.leader = refreshed
+		return refreshed, nil
+	}
+
+	acquired, err := e.cache.AcquireLock(ctx, e.key, e.token, e.ttl)
+	if err != nil {
+		return false, err
+	}
+
+	e.leader = acquired
+	return acquired, nil
+}
+
+// Release deletes the lock if it is still owned by this instance
+func (e *RedisLeaderElector) Release(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.leader {
+		return nil
+	}
+
+	e.leader = false
+	return e.cache.ReleaseLock(ctx, e.key, e.token)
+}
+
+// advisoryLockID maps a lock key to the int64 id expected by pg_advisory_lock
+func advisoryLockID(lockKey string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(lockKey))
+	return int64(h.Sum64())
+}
+
+// newLockToken generates a random owner token for the Redis lock
+func newLockToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,290 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/metrics"
+)
+
+// JobFunc runs one iteration of a background job and returns the number of records processed
+type JobFunc func(ctx context.Context) (int, error)
+
+// Job describes a periodic maintenance job
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Timeout  time.Duration
+	Run      JobFunc
+}
+
+// JobStatus is the last known state of a job on this instance
+type JobStatus struct {
+	Name          string        `json:"name"`
+	Running       bool          `json:"running"`
+	LastRun       time.Time     `json:"last_run"`
+	LastSuccess   time.Time     `json:"last_success"`
+	LastDuration  time.Duration `json:"last_duration"`
+	LastProcessed int           `json:"last_processed"`
+	LastError     string        `json:"last_error,omitempty"`
+	RunCount      int           `json:"run_count"`
+	FailureCount  int           `json:"failure_count"`
+}
+
+// Manager runs registered jobs on their intervals while this instance holds leadership
+type Manager struct {
+	elector       LeaderElector
+	renewInterval time.Duration
+	metrics       *metrics.Metrics
+	logger        *logger.Logger
+
+	mu       sync.RWMutex
+	jobs     []Job
+	statuses map[string]*JobStatus
+	leader   bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager creates a new background job manager.
+// Leadership is renewed every renewInterval, which should be well below the lock TTL.
+func NewManager(elector LeaderElector, renewInterval time.Duration, metrics *metrics.Metrics, logger *logger.Logger) *Manager {
+	return &Manager{
+		elector:       elector,
+		renewInterval: renewInterval,
+		metrics:       metrics,
+		logger:        logger,
+		statuses:      make(map[string]*JobStatus),
+	}
+}
+
+// Register adds a job to the manager. Jobs must be registered before Start.
+func (m *Manager) Register(job Job) error {
+	if job.Name == "" {
+		return fmt.Errorf("job name is required")
+	}
+	if job.Interval <= 0 {
+		return fmt.Errorf("job %s: interval must be positive", job.Name)
+	}
+	if job.Run == nil {
+		return fmt.Errorf("job %s: run function is required", job.Name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.statuses[job.Name]; exists {
+		return fmt.Errorf("job %s already registered", job.Name)
+	}
+
+	m.jobs = append(m.jobs, job)
+	m.statuses[job.Name] = &JobStatus{Name: job.Name}
+
+	return nil
+}
+
+// Start begins leader election and job scheduling
+func (m *Manager) Start(ctx context.Context) {
+	ctx, m.cancel = context.WithCancel(ctx)
+
+	m.wg.Add(1)
+	go m.runElection(ctx)
+
+	m.mu.RLock()
+	jobs := append([]Job(nil), m.jobs...)
+	m.mu.RUnlock()
+
+	for _, job := range jobs {
+		m.wg.Add(1)
+		go m.runJobLoop(ctx, job)
+	}
+
+	m.logger.WithField("jobs", len(jobs)).Info("Background worker manager started")
+}
+
+// Stop cancels running jobs, waits for them to finish and releases leadership
+func (m *Manager) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.wg.Wait()
+
+	releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := m.elector.Release(releaseCtx); err != nil {
+		m.logger.WithError(err).Warn("Failed to release maintenance leader lock")
+	}
+	m.setLeader(false)
+
+	m.logger.Info("Background worker manager stopped")
+}
+
+// IsLeader reports whether this instance currently holds leadership
+func (m *Manager) IsLeader() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.leader
+}
+
+// Status returns a snapshot of all job statuses
+func (m *Manager) Status() []JobStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]JobStatus, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		statuses = append(statuses, *m.statuses[job.Name])
+	}
+
+	return statuses
+}
+
+// RunNow runs a registered job immediately, regardless of leadership
+func (m *Manager) RunNow(ctx context.Context, name string) error {
+	m.mu.RLock()
+	var found *Job
+	for i := range m.jobs {
+		if m.jobs[i].Name == name {
+			found = &m.jobs[i]
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if found == nil {
+		return fmt.Errorf("job %s not registered", name)
+	}
+
+	return m.runJob(ctx, *found)
+}
+
+// runElection periodically acquires or renews leadership
+func (m *Manager) runElection(ctx context.Context) {
+	defer m.wg.Done()
+
+	m.electOnce(ctx)
+
+	ticker := time.NewTicker(m.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.electOnce(ctx)
+		}
+	}
+}
+
+func (m *Manager) electOnce(ctx context.Context) {
+	leader, err := m.elector.TryAcquire(ctx)
+	if err != nil && ctx.Err() == nil {
+		m.logger.WithError(err).Warn("Leader election failed")
+	}
+
+	wasLeader := m.IsLeader()
+	m.setLeader(leader)
+
+	if leader != wasLeader {
+		m.logger.WithField("leader", leader).Info("Maintenance leadership changed")
+	}
+}
+
+func (m *Manager) setLeader(leader bool) {
+	m.mu.Lock()
+	m.leader = leader
+	m.mu.Unlock()
+
+	if m.metrics != nil && m.metrics.WorkerLeader != nil {
+		m.metrics.SetWorkerLeader(leader)
+	}
+}
+
+// runJobLoop runs a job on its interval while this instance is leader
+func (m *Manager) runJobLoop(ctx context.Context, job Job) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !m.IsLeader() {
+				continue
+			}
+			if err := m.runJob(ctx, job); err != nil && ctx.Err() == nil {
+				m.logger.WithError(err).WithField("job", job.Name).Error("Background job failed")
+			}
+		}
+	}
+}
+
+// runJob executes a single job iteration and records its outcome
+func (m *Manager) runJob(ctx context.Context, job Job) error {
+	m.mu.Lock()
+	status := m.statuses[job.Name]
+	if status.Running {
+		m.mu.Unlock()
+		return fmt.Errorf("job %s is already running", job.Name)
+	}
+	status.Running = true
+	m.mu.Unlock()
+
+	if m.metrics != nil && m.metrics.WorkerJobRunning != nil {
+		m.metrics.SetJobRunning(job.Name, true)
+	}
+
+	jobCtx := ctx
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		jobCtx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	startedAt := time.Now()
+	processed, err := job.Run(jobCtx)
+	duration := time.Since(startedAt)
+
+	runStatus := "success"
+	if err != nil {
+		runStatus = "error"
+	}
+
+	m.mu.Lock()
+	status.Running = false
+	status.LastRun = startedAt
+	status.LastDuration = duration
+	status.LastProcessed = processed
+	status.RunCount++
+	if err != nil {
+		status.LastError = err.Error()
+		status.FailureCount++
+	} else {
+		status.LastError = ""
+		status.LastSuccess = startedAt.Add(duration)
+	}
+	m.mu.Unlock()
+
+	if m.metrics != nil && m.metrics.WorkerJobRuns != nil {
+		m.metrics.SetJobRunning(job.Name, false)
+		m.metrics.RecordJobRun(job.Name, runStatus, processed, startedAt, duration)
+	}
+
+	m.logger.WithFields(map[string]interface{}{
+		"job":       job.Name,
+		"status":    runStatus,
+		"processed": processed,
+		"duration":  duration.String(),
+	}).Info("Background job finished")
+
+	return err
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/logger"
+)
+
+type fakeElector struct {
+	mu       sync.Mutex
+	leader   bool
+	released bool
+}
+
+func (f *fakeElector) TryAcquire(ctx context.Context) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.leader, nil
+}
+
+func (f *fakeElector) Release(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.released = true
+	return nil
+}
+
+func newTestManager(elector LeaderElector) *Manager {
+	log := logger.New(&config.LoggingConfig{Level: "error", Format: "text"})
+	return NewManager(elector, 10*time.Millisecond, nil, log)
+}
+
+func TestRegister_Validation(t *testing.T) {
+	m := newTestManager(&fakeElector{})
+	run := func(ctx context.Context) (int, error) { return 0, nil }
+
+	assert.Error(t, m.Register(Job{Interval: time.Second, Run: run}))
+	assert.Error(t, m.Register(Job{Name: "decay", Run: run}))
+	assert.Error(t, m.Register(Job{Name: "decay", Interval: time.Second}))
+
+	require.NoError(t, m.Register(Job{Name: "decay", Interval: time.Second, Run: run}))
+	assert.Error(t, m.Register(Job{Name: "decay", Interval: time.Second, Run: run}))
+}
+
+func TestManager_RunsJobsOnlyWhenLeader(t *testing.T) {
+	elector := &fakeElector{leader: false}
+	m := newTestManager(elector)
+
+	var runs int32
+	require.NoError(t, m.Register(Job{
+		Name:     "decay",
+		Interval: 5 * time.Millisecond,
+		Run: func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&runs, 1)
+			return 3, nil
+		},
+	}))
+
+	m.Start(context.Background())
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&runs), "followers must not run jobs")
+	assert.False(t, m.IsLeader())
+
+	elector.mu.Lock()
+	elector.leader = true
+	elector.mu.Unlock()
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) > 0 }, time.Second, 5*time.Millisecond)
+	assert.True(t, m.IsLeader())
+
+	m.Stop()
+	assert.True(t, elector.released)
+	assert.False(t, m.IsLeader())
+
+	statuses := m.Status()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "decay", statuses[0].Name)
+	assert.Equal(t, 3, statuses[0].LastProcessed)
+	assert.False(t, statuses[0].LastSuccess.IsZero())
+}
+
+func TestRunNow_RecordsFailure(t *testing.T) {
+	m := newTestManager(&fakeElector{})
+
+	require.NoError(t, m.Register(Job{
+		Name:     "recalibration",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) (int, error) {
+			return 1, errors.New("database unavailable")
+		},
+	}))
+
+	err := m.RunNow(context.Background(), "recalibration")
+	require.Error(t, err)
+
+	status := m.Status()[0]
+	assert.Equal(t, 1, status.RunCount)
+	assert.Equal(t, 1, status.FailureCount)
+	assert.Equal(t, "database unavailable", status.LastError)
+	assert.True(t, status.LastSuccess.IsZero())
+
+	assert.Error(t, m.RunNow(context.Background(), "unknown"))
+}
+
+func TestRunNow_AppliesTimeout(t *testing.T) {
+	m := newTestManager(&fakeElector{})
+
+	require.NoError(t, m.Register(Job{
+		Name:     "cache_warmup",
+		Interval: time.Hour,
+		Timeout:  10 * time.Millisecond,
+		Run: func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		},
+	}))
+
+	err := m.RunNow(context.Background(), "cache_warmup")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestAdvisoryLockID_Stable(t *testing.T) {
+	assert.Equal(t, advisoryLockID("scheduler-service:maintenance-leader"), advisoryLockID("scheduler-service:maintenance-leader"))
+	assert.NotEqual(t, advisoryLockID("a"), advisoryLockID("b"))
+}
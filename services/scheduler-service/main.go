@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,20 +14,41 @@ import (
 
 	"scheduler-service/internal/cache"
 	"scheduler-service/internal/config"
+	"scheduler-service/internal/curriculum"
 	"scheduler-service/internal/database"
+	"scheduler-service/internal/events"
 	"scheduler-service/internal/logger"
 	"scheduler-service/internal/metrics"
+	"scheduler-service/internal/reload"
 	"scheduler-service/internal/server"
+	"scheduler-service/internal/tracing"
+	"scheduler-service/internal/userdata"
+	"scheduler-service/internal/worker"
 )
 
 func main() {
-	// Load configuration
-	cfg := config.Load()
+	// Load configuration from the config file and the environment
+	values, err := config.LoadValues()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.Parse(values)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Initialize logger
 	log := logger.New(&cfg.Logging)
 	log.Info("Starting Scheduler Service")
 
+	// Initialize tracing before any client is created
+	tracerProvider, err := tracing.NewProvider(context.Background(), &cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
 	// Initialize metrics
 	metricsInstance := metrics.New()
 
@@ -36,6 +59,17 @@ func main() {
 	}
 	defer db.Close()
 
+	// Schema management runs before anything else is started
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		code := runMigrate(db, log, os.Args[2:])
+		db.Close()
+		os.Exit(code)
+	}
+
+	if err := ensureSchema(db, &cfg.Database, log); err != nil {
+		log.Fatalf("Database schema check failed: %v", err)
+	}
+
 	// Initialize Redis cache
 	redisClient, err := cache.New(&cfg.Redis, metricsInstance, log)
 	if err != nil {
@@ -43,8 +77,68 @@ func main() {
 	}
 	defer redisClient.Close()
 
+	// Load topic prerequisite graphs; invalid or cyclic graphs stop startup
+	prerequisites, err := curriculum.NewRegistry(&cfg.Curriculum)
+	if err != nil {
+		log.Fatalf("Failed to load topic prerequisite graphs: %v", err)
+	}
+
+	// Domain events go to Kafka; without brokers configured they are dropped
+	eventPublisher := events.NewEventPublisher(&cfg.Kafka, log)
+	if len(cfg.Kafka.Brokers) == 0 {
+		log.Info("KAFKA_BROKERS not set, scheduler events will not be published")
+	}
+
+	// Receipts of user data exports and deletions are signed for auditing
+	receiptSigner, err := userdata.NewSigner(&cfg.UserData)
+	if err != nil {
+		log.Fatalf("Failed to load receipt signing key: %v", err)
+	}
+	if receiptSigner.Ephemeral() {
+		log.Warn("USER_DATA_RECEIPT_KEY_FILE not set, user data receipts are signed with an ephemeral key")
+	}
+
 	// Initialize scheduler service
-	schedulerService := server.NewSchedulerService(cfg, log, metricsInstance, db, redisClient)
+	schedulerService := server.NewSchedulerService(cfg, log, metricsInstance, db, redisClient, prerequisites, eventPublisher, receiptSigner)
+
+	// Review log imports and attempt replays run against the same algorithms and state
+	// as the service, then exit
+	if len(os.Args) > 1 && (os.Args[1] == "import" || os.Args[1] == "replay") {
+		var code int
+		if os.Args[1] == "import" {
+			code = runImport(schedulerService.ReviewImporter(), log, os.Args[2:])
+		} else {
+			code = runReplay(schedulerService.ReplayEngine(), log, os.Args[2:])
+		}
+		redisClient.Close()
+		db.Close()
+		os.Exit(code)
+	}
+
+	// Route hot-path reads through the optimized pool and Redis client
+	perfCfg := config.LoadPerformanceConfig()
+
+	optimizedPool, err := database.NewOptimizedPool(cfg.Database.URL, &perfCfg.Database, log, metricsInstance)
+	if err != nil {
+		log.Fatalf("Failed to initialize optimized database pool: %v", err)
+	}
+	defer optimizedPool.Close()
+
+	optimizedRedis, err := cache.NewOptimizedRedisClient(&cfg.Redis, &perfCfg.Cache, log, metricsInstance)
+	if err != nil {
+		log.Fatalf("Failed to initialize optimized Redis client: %v", err)
+	}
+	defer optimizedRedis.Close()
+
+	schedulerService.EnableOptimizedPaths(optimizedPool, optimizedRedis)
+
+	// Algorithm parameters, scoring weights and cache TTLs are reloaded from the config
+	// file and the config_values table without a restart
+	reloader := reload.NewReloader(log, db, cfg, values)
+	schedulerService.UseReloader(reloader)
+	reloadCtx, stopReload := context.WithCancel(context.Background())
+	defer stopReload()
+	go reloader.Run(reloadCtx)
 
 	// Initialize gRPC server
 	grpcServer, err := server.NewGRPCServer(cfg, log, metricsInstance, schedulerService)
@@ -52,18 +146,58 @@ func main() {
 		log.Fatalf("Failed to initialize gRPC server: %v", err)
 	}
 
+	// Rate limits are reloaded with the rest of the configuration
+	if limiter := grpcServer.RateLimiter(); limiter != nil {
+		reloader.OnReload(func(cfg *config.Config) { limiter.Configure(&cfg.RateLimit) })
+	}
+
+	// Initialize background maintenance workers
+	var workerManager *worker.Manager
+	if cfg.Worker.Enabled {
+		elector, err := worker.NewLeaderElector(&cfg.Worker, db, redisClient)
+		if err != nil {
+			log.Fatalf("Failed to initialize leader election: %v", err)
+		}
+
+		workerManager = worker.NewManager(elector, cfg.Worker.LeaderLockTTL/3, metricsInstance, log)
+		for _, job := range schedulerService.MaintenanceJobs(&cfg.Worker) {
+			if err := workerManager.Register(job); err != nil {
+				log.Fatalf("Failed to register background job: %v", err)
+			}
+		}
+		workerManager.Start(context.Background())
+	}
+
+	// REST onboarding API, served alongside metrics
+	onboardingAPI := newOnboardingAPI(cfg, log, schedulerService)
+
 	// Start metrics HTTP server
 	go func() {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
-		mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("OK"))
+		if onboardingAPI != nil {
+			mux.Handle(onboardingAPIPrefix, onboardingAPI)
+		}
+		mux.HandleFunc("/workers", func(w http.ResponseWriter, r *http.Request) {
+			if workerManager == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"leader": workerManager.IsLeader(),
+				"jobs":   workerManager.Status(),
+			})
 		})
+		// Liveness only reports that the process is up; readiness checks dependencies
+		// and turns 503 while draining on shutdown
+		mux.HandleFunc("/health", grpcServer.Health().LivenessHandler)
+		mux.HandleFunc("/health/live", grpcServer.Health().LivenessHandler)
+		mux.HandleFunc("/health/ready", grpcServer.Health().ReadinessHandler)
 
-		log.Infof("Starting metrics server on port %s", cfg.Server.HTTPPort)
+		log.Infof("Starting HTTP server on port %s", cfg.Server.HTTPPort)
 		if err := http.ListenAndServe(":"+cfg.Server.HTTPPort, mux); err != nil {
-			log.Errorf("Metrics server error: %v", err)
+			log.Errorf("HTTP server error: %v", err)
 		}
 	}()
 
@@ -82,12 +216,25 @@ func main() {
 	log.Info("Shutting down server...")
 
 	// Graceful shutdown with timeout
-	_, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Stop gRPC server
+	// Report NOT_SERVING, drain and stop the gRPC server
 	grpcServer.Stop()
 
+	// Stop reloading configuration
+	stopReload()
+
+	// Stop background workers and release leadership
+	if workerManager != nil {
+		workerManager.Stop()
+	}
+
+	// Flush pending events
+	if err := eventPublisher.Close(); err != nil {
+		log.Errorf("Error closing event publisher: %v", err)
+	}
+
 	// Close database connections
 	if err := db.Close(); err != nil {
 		log.Errorf("Error closing database: %v", err)
@@ -98,5 +245,10 @@ func main() {
 		log.Errorf("Error closing Redis: %v", err)
 	}
 
+	// Export the remaining spans
+	if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+		log.Errorf("Error shutting down tracing: %v", err)
+	}
+
 	log.Info("Server shutdown complete")
 }
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"scheduler-service/internal/config"
+	"scheduler-service/internal/database"
+	"scheduler-service/internal/logger"
+)
+
+const migrateUsage = "usage: scheduler-service migrate up | down [steps] | status"
+
+// runMigrate implements the "migrate" subcommand. Returns the process exit code.
+func runMigrate(db *database.DB, log *logger.Logger, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		log.Errorf("Failed to load migrations: %v", err)
+		return 1
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Errorf("Migration failed: %v", err)
+			return 1
+		}
+		log.Infof("Applied %d migration(s)", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				fmt.Fprintln(os.Stderr, migrateUsage)
+				return 2
+			}
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Errorf("Rollback failed: %v", err)
+			return 1
+		}
+		log.Infof("Rolled back %d migration(s)", rolledBack)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Errorf("Failed to read migration status: %v", err)
+			return 1
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%03d\t%s\t%s\n", s.Version, s.Name, state)
+		}
+		w.Flush()
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
+
+// ensureSchema refuses to serve against a database with pending migrations,
+// applying them first when DB_MIGRATE_ON_START is set
+func ensureSchema(db *database.DB, cfg *config.DatabaseConfig, log *logger.Logger) error {
+	migrator, err := database.NewMigrator(db, log)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if cfg.MigrateOnStart {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if applied > 0 {
+			log.Infof("Applied %d migration(s) at startup", applied)
+		}
+	}
+
+	if err := migrator.CheckSchema(ctx); err != nil {
+		return fmt.Errorf("%w; run \"scheduler-service migrate up\" or set DB_MIGRATE_ON_START=true", err)
+	}
+
+	return nil
+}
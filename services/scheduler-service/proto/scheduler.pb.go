@@ -113,6 +113,8 @@ type RecommendedItem struct {
 	Topics               []string `json:"topics,omitempty"`
 	Difficulty           float64  `json:"difficulty,omitempty"`
 	PredictedCorrectness float64  `json:"predicted_correctness,omitempty"`
+	Remediation          string   `json:"remediation,omitempty"`
+	LeechItemId          string   `json:"leech_item_id,omitempty"`
 }
 
 func (x *RecommendedItem) Reset()         { *x = RecommendedItem{} }
@@ -161,6 +163,20 @@ func (x *RecommendedItem) GetPredictedCorrectness() float64 {
 	return 0
 }
 
+func (x *RecommendedItem) GetRemediation() string {
+	if x != nil {
+		return x.Remediation
+	}
+	return ""
+}
+
+func (x *RecommendedItem) GetLeechItemId() string {
+	if x != nil {
+		return x.LeechItemId
+	}
+	return ""
+}
+
 // SessionConstraints defines session parameters
 type SessionConstraints struct {
 	MaxTimeMinutes        int32    `json:"max_time_minutes,omitempty"`
@@ -355,9 +371,11 @@ func (x *AttemptRequest) GetCorrect() bool {
 
 // AttemptResponse after processing attempt
 type AttemptResponse struct {
-	Success     bool             `json:"success,omitempty"`
-	Message     string           `json:"message,omitempty"`
-	StateUpdate *UserStateUpdate `json:"state_update,omitempty"`
+	Success          bool             `json:"success,omitempty"`
+	Message          string           `json:"message,omitempty"`
+	StateUpdate      *UserStateUpdate `json:"state_update,omitempty"`
+	CompletedGoalIds []string         `json:"completed_goal_ids,omitempty"`
+	Quality          int32            `json:"quality,omitempty"`
 }
 
 func (x *AttemptResponse) Reset()         { *x = AttemptResponse{} }
@@ -385,6 +403,20 @@ func (x *AttemptResponse) GetStateUpdate() *UserStateUpdate {
 	return nil
 }
 
+func (x *AttemptResponse) GetCompletedGoalIds() []string {
+	if x != nil {
+		return x.CompletedGoalIds
+	}
+	return nil
+}
+
+func (x *AttemptResponse) GetQuality() int32 {
+	if x != nil {
+		return x.Quality
+	}
+	return 0
+}
+
 // UserStateUpdate represents state changes
 type UserStateUpdate struct {
 	MasteryChanges map[string]float64 `json:"mastery_changes,omitempty"`
@@ -459,9 +491,10 @@ func (x *SM2StateUpdate) GetNextDue() *timestamppb.Timestamp {
 
 // Additional message types with minimal implementation
 type InitializeUserRequest struct {
-	UserId           string            `json:"user_id,omitempty"`
-	CountryCode      string            `json:"country_code,omitempty"`
-	PlacementResults *PlacementResults `json:"placement_results,omitempty"`
+	UserId           string                 `json:"user_id,omitempty"`
+	CountryCode      string                 `json:"country_code,omitempty"`
+	PlacementResults *PlacementResults      `json:"placement_results,omitempty"`
+	ExamDate         *timestamppb.Timestamp `json:"exam_date,omitempty"`
 }
 
 func (x *InitializeUserRequest) Reset()         { *x = InitializeUserRequest{} }
@@ -489,6 +522,13 @@ func (x *InitializeUserRequest) GetPlacementResults() *PlacementResults {
 	return nil
 }
 
+func (x *InitializeUserRequest) GetExamDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamDate
+	}
+	return nil
+}
+
 type InitializeUserResponse struct {
 	Success      bool                `json:"success,omitempty"`
 	Message      string              `json:"message,omitempty"`
@@ -574,6 +614,7 @@ type UserSchedulerState struct {
 	TotalStudyTimeMs  int64                  `json:"total_study_time_ms,omitempty"`
 	Version           int32                  `json:"version,omitempty"`
 	LastUpdated       *timestamppb.Timestamp `json:"last_updated,omitempty"`
+	ExamDate          *timestamppb.Timestamp `json:"exam_date,omitempty"`
 }
 
 func (x *UserSchedulerState) Reset()         { *x = UserSchedulerState{} }
@@ -664,6 +705,76 @@ func (x *UserSchedulerState) GetLastUpdated() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UserSchedulerState) GetExamDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamDate
+	}
+	return nil
+}
+
+// SetExamDateRequest sets or clears a user's booked exam date
+type SetExamDateRequest struct {
+	UserId   string                 `json:"user_id,omitempty"`
+	ExamDate *timestamppb.Timestamp `json:"exam_date,omitempty"`
+}
+
+func (x *SetExamDateRequest) Reset()         { *x = SetExamDateRequest{} }
+func (x *SetExamDateRequest) String() string { return "" }
+func (*SetExamDateRequest) ProtoMessage()    {}
+
+func (x *SetExamDateRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetExamDateRequest) GetExamDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamDate
+	}
+	return nil
+}
+
+type SetExamDateResponse struct {
+	Success       bool                   `json:"success,omitempty"`
+	Message       string                 `json:"message,omitempty"`
+	ExamDate      *timestamppb.Timestamp `json:"exam_date,omitempty"`
+	DaysUntilExam float64                `json:"days_until_exam,omitempty"`
+}
+
+func (x *SetExamDateResponse) Reset()         { *x = SetExamDateResponse{} }
+func (x *SetExamDateResponse) String() string { return "" }
+func (*SetExamDateResponse) ProtoMessage()    {}
+
+func (x *SetExamDateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetExamDateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetExamDateResponse) GetExamDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamDate
+	}
+	return nil
+}
+
+func (x *SetExamDateResponse) GetDaysUntilExam() float64 {
+	if x != nil {
+		return x.DaysUntilExam
+	}
+	return 0
+}
+
 type SM2State struct {
 	EasinessFactor float64                `json:"easiness_factor,omitempty"`
 	Interval       int32                  `json:"interval,omitempty"`
@@ -1357,3 +1468,3491 @@ func (x *GetAvailableStrategiesResponse) GetStrategies() []*SessionStrategy {
 	}
 	return nil
 }
+
+type InitializeOnboardingRequest struct {
+	UserId      string `json:"user_id,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+}
+
+func (x *InitializeOnboardingRequest) Reset()         { *x = InitializeOnboardingRequest{} }
+func (x *InitializeOnboardingRequest) String() string { return "" }
+func (*InitializeOnboardingRequest) ProtoMessage()    {}
+
+func (x *InitializeOnboardingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InitializeOnboardingRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+type InitializeOnboardingResponse struct {
+	Success         bool             `json:"success,omitempty"`
+	Message         string           `json:"message,omitempty"`
+	OnboardingState *OnboardingState `json:"onboarding_state,omitempty"`
+}
+
+func (x *InitializeOnboardingResponse) Reset()         { *x = InitializeOnboardingResponse{} }
+func (x *InitializeOnboardingResponse) String() string { return "" }
+func (*InitializeOnboardingResponse) ProtoMessage()    {}
+
+func (x *InitializeOnboardingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *InitializeOnboardingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InitializeOnboardingResponse) GetOnboardingState() *OnboardingState {
+	if x != nil {
+		return x.OnboardingState
+	}
+	return nil
+}
+
+type UpdateOnboardingPreferencesRequest struct {
+	UserId      string           `json:"user_id,omitempty"`
+	Preferences *UserPreferences `json:"preferences,omitempty"`
+}
+
+func (x *UpdateOnboardingPreferencesRequest) Reset()         { *x = UpdateOnboardingPreferencesRequest{} }
+func (x *UpdateOnboardingPreferencesRequest) String() string { return "" }
+func (*UpdateOnboardingPreferencesRequest) ProtoMessage()    {}
+
+func (x *UpdateOnboardingPreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateOnboardingPreferencesRequest) GetPreferences() *UserPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type UpdateOnboardingPreferencesResponse struct {
+	Success bool   `json:"success,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (x *UpdateOnboardingPreferencesResponse) Reset()         { *x = UpdateOnboardingPreferencesResponse{} }
+func (x *UpdateOnboardingPreferencesResponse) String() string { return "" }
+func (*UpdateOnboardingPreferencesResponse) ProtoMessage()    {}
+
+func (x *UpdateOnboardingPreferencesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateOnboardingPreferencesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StartPlacementTestRequest struct {
+	UserId string `json:"user_id,omitempty"`
+}
+
+func (x *StartPlacementTestRequest) Reset()         { *x = StartPlacementTestRequest{} }
+func (x *StartPlacementTestRequest) String() string { return "" }
+func (*StartPlacementTestRequest) ProtoMessage()    {}
+
+func (x *StartPlacementTestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type StartPlacementTestResponse struct {
+	Success       bool                `json:"success,omitempty"`
+	Message       string              `json:"message,omitempty"`
+	PlacementTest *PlacementTestState `json:"placement_test,omitempty"`
+}
+
+func (x *StartPlacementTestResponse) Reset()         { *x = StartPlacementTestResponse{} }
+func (x *StartPlacementTestResponse) String() string { return "" }
+func (*StartPlacementTestResponse) ProtoMessage()    {}
+
+func (x *StartPlacementTestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartPlacementTestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StartPlacementTestResponse) GetPlacementTest() *PlacementTestState {
+	if x != nil {
+		return x.PlacementTest
+	}
+	return nil
+}
+
+type CompletePlacementTestRequest struct {
+	UserId    string                `json:"user_id,omitempty"`
+	SessionId string                `json:"session_id,omitempty"`
+	Results   *PlacementTestResults `json:"results,omitempty"`
+}
+
+func (x *CompletePlacementTestRequest) Reset()         { *x = CompletePlacementTestRequest{} }
+func (x *CompletePlacementTestRequest) String() string { return "" }
+func (*CompletePlacementTestRequest) ProtoMessage()    {}
+
+func (x *CompletePlacementTestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CompletePlacementTestRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CompletePlacementTestRequest) GetResults() *PlacementTestResults {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CompletePlacementTestResponse struct {
+	Success      bool          `json:"success,omitempty"`
+	Message      string        `json:"message,omitempty"`
+	LearningPath *LearningPath `json:"learning_path,omitempty"`
+}
+
+func (x *CompletePlacementTestResponse) Reset()         { *x = CompletePlacementTestResponse{} }
+func (x *CompletePlacementTestResponse) String() string { return "" }
+func (*CompletePlacementTestResponse) ProtoMessage()    {}
+
+func (x *CompletePlacementTestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CompletePlacementTestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CompletePlacementTestResponse) GetLearningPath() *LearningPath {
+	if x != nil {
+		return x.LearningPath
+	}
+	return nil
+}
+
+type SkipPlacementTestRequest struct {
+	UserId string `json:"user_id,omitempty"`
+}
+
+func (x *SkipPlacementTestRequest) Reset()         { *x = SkipPlacementTestRequest{} }
+func (x *SkipPlacementTestRequest) String() string { return "" }
+func (*SkipPlacementTestRequest) ProtoMessage()    {}
+
+func (x *SkipPlacementTestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SkipPlacementTestResponse struct {
+	Success      bool          `json:"success,omitempty"`
+	Message      string        `json:"message,omitempty"`
+	LearningPath *LearningPath `json:"learning_path,omitempty"`
+}
+
+func (x *SkipPlacementTestResponse) Reset()         { *x = SkipPlacementTestResponse{} }
+func (x *SkipPlacementTestResponse) String() string { return "" }
+func (*SkipPlacementTestResponse) ProtoMessage()    {}
+
+func (x *SkipPlacementTestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SkipPlacementTestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SkipPlacementTestResponse) GetLearningPath() *LearningPath {
+	if x != nil {
+		return x.LearningPath
+	}
+	return nil
+}
+
+type CompleteOnboardingRequest struct {
+	UserId       string `json:"user_id,omitempty"`
+	Satisfaction int32  `json:"satisfaction,omitempty"`
+	Feedback     string `json:"feedback,omitempty"`
+}
+
+func (x *CompleteOnboardingRequest) Reset()         { *x = CompleteOnboardingRequest{} }
+func (x *CompleteOnboardingRequest) String() string { return "" }
+func (*CompleteOnboardingRequest) ProtoMessage()    {}
+
+func (x *CompleteOnboardingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CompleteOnboardingRequest) GetSatisfaction() int32 {
+	if x != nil {
+		return x.Satisfaction
+	}
+	return 0
+}
+
+func (x *CompleteOnboardingRequest) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+type CompleteOnboardingResponse struct {
+	Success bool   `json:"success,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (x *CompleteOnboardingResponse) Reset()         { *x = CompleteOnboardingResponse{} }
+func (x *CompleteOnboardingResponse) String() string { return "" }
+func (*CompleteOnboardingResponse) ProtoMessage()    {}
+
+func (x *CompleteOnboardingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CompleteOnboardingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetOnboardingStateRequest struct {
+	UserId string `json:"user_id,omitempty"`
+}
+
+func (x *GetOnboardingStateRequest) Reset()         { *x = GetOnboardingStateRequest{} }
+func (x *GetOnboardingStateRequest) String() string { return "" }
+func (*GetOnboardingStateRequest) ProtoMessage()    {}
+
+func (x *GetOnboardingStateRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetOnboardingStateResponse struct {
+	OnboardingState *OnboardingState `json:"onboarding_state,omitempty"`
+}
+
+func (x *GetOnboardingStateResponse) Reset()         { *x = GetOnboardingStateResponse{} }
+func (x *GetOnboardingStateResponse) String() string { return "" }
+func (*GetOnboardingStateResponse) ProtoMessage()    {}
+
+func (x *GetOnboardingStateResponse) GetOnboardingState() *OnboardingState {
+	if x != nil {
+		return x.OnboardingState
+	}
+	return nil
+}
+
+type GetLearningPathRequest struct {
+	UserId string `json:"user_id,omitempty"`
+}
+
+func (x *GetLearningPathRequest) Reset()         { *x = GetLearningPathRequest{} }
+func (x *GetLearningPathRequest) String() string { return "" }
+func (*GetLearningPathRequest) ProtoMessage()    {}
+
+func (x *GetLearningPathRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetLearningPathResponse struct {
+	LearningPath *LearningPath `json:"learning_path,omitempty"`
+}
+
+func (x *GetLearningPathResponse) Reset()         { *x = GetLearningPathResponse{} }
+func (x *GetLearningPathResponse) String() string { return "" }
+func (*GetLearningPathResponse) ProtoMessage()    {}
+
+func (x *GetLearningPathResponse) GetLearningPath() *LearningPath {
+	if x != nil {
+		return x.LearningPath
+	}
+	return nil
+}
+
+type OnboardingState struct {
+	UserId        string                 `json:"user_id,omitempty"`
+	CountryCode   string                 `json:"country_code,omitempty"`
+	Stage         string                 `json:"stage,omitempty"`
+	Progress      *OnboardingProgress    `json:"progress,omitempty"`
+	Preferences   *UserPreferences       `json:"preferences,omitempty"`
+	PlacementTest *PlacementTestState    `json:"placement_test,omitempty"`
+	LearningPath  *LearningPath          `json:"learning_path,omitempty"`
+	Analytics     *OnboardingAnalytics   `json:"analytics,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `json:"updated_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `json:"completed_at,omitempty"`
+}
+
+func (x *OnboardingState) Reset()         { *x = OnboardingState{} }
+func (x *OnboardingState) String() string { return "" }
+func (*OnboardingState) ProtoMessage()    {}
+
+func (x *OnboardingState) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OnboardingState) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *OnboardingState) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *OnboardingState) GetProgress() *OnboardingProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *OnboardingState) GetPreferences() *UserPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *OnboardingState) GetPlacementTest() *PlacementTestState {
+	if x != nil {
+		return x.PlacementTest
+	}
+	return nil
+}
+
+func (x *OnboardingState) GetLearningPath() *LearningPath {
+	if x != nil {
+		return x.LearningPath
+	}
+	return nil
+}
+
+func (x *OnboardingState) GetAnalytics() *OnboardingAnalytics {
+	if x != nil {
+		return x.Analytics
+	}
+	return nil
+}
+
+func (x *OnboardingState) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OnboardingState) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *OnboardingState) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type OnboardingProgress struct {
+	CurrentStage                  string   `json:"current_stage,omitempty"`
+	CompletedStages               []string `json:"completed_stages,omitempty"`
+	TotalStages                   int32    `json:"total_stages,omitempty"`
+	PercentComplete               float64  `json:"percent_complete,omitempty"`
+	EstimatedTimeRemainingMinutes int32    `json:"estimated_time_remaining_minutes,omitempty"`
+}
+
+func (x *OnboardingProgress) Reset()         { *x = OnboardingProgress{} }
+func (x *OnboardingProgress) String() string { return "" }
+func (*OnboardingProgress) ProtoMessage()    {}
+
+func (x *OnboardingProgress) GetCurrentStage() string {
+	if x != nil {
+		return x.CurrentStage
+	}
+	return ""
+}
+
+func (x *OnboardingProgress) GetCompletedStages() []string {
+	if x != nil {
+		return x.CompletedStages
+	}
+	return nil
+}
+
+func (x *OnboardingProgress) GetTotalStages() int32 {
+	if x != nil {
+		return x.TotalStages
+	}
+	return 0
+}
+
+func (x *OnboardingProgress) GetPercentComplete() float64 {
+	if x != nil {
+		return x.PercentComplete
+	}
+	return 0
+}
+
+func (x *OnboardingProgress) GetEstimatedTimeRemainingMinutes() int32 {
+	if x != nil {
+		return x.EstimatedTimeRemainingMinutes
+	}
+	return 0
+}
+
+type UserPreferences struct {
+	StudyGoal            string          `json:"study_goal,omitempty"`
+	AvailableTimeMinutes int32           `json:"available_time_minutes,omitempty"`
+	PreferredDifficulty  string          `json:"preferred_difficulty,omitempty"`
+	FocusAreas           []string        `json:"focus_areas,omitempty"`
+	LearningStyle        string          `json:"learning_style,omitempty"`
+	NotificationTimes    []string        `json:"notification_times,omitempty"`
+	WeeklySchedule       map[string]bool `json:"weekly_schedule,omitempty"`
+}
+
+func (x *UserPreferences) Reset()         { *x = UserPreferences{} }
+func (x *UserPreferences) String() string { return "" }
+func (*UserPreferences) ProtoMessage()    {}
+
+func (x *UserPreferences) GetStudyGoal() string {
+	if x != nil {
+		return x.StudyGoal
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetAvailableTimeMinutes() int32 {
+	if x != nil {
+		return x.AvailableTimeMinutes
+	}
+	return 0
+}
+
+func (x *UserPreferences) GetPreferredDifficulty() string {
+	if x != nil {
+		return x.PreferredDifficulty
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetFocusAreas() []string {
+	if x != nil {
+		return x.FocusAreas
+	}
+	return nil
+}
+
+func (x *UserPreferences) GetLearningStyle() string {
+	if x != nil {
+		return x.LearningStyle
+	}
+	return ""
+}
+
+func (x *UserPreferences) GetNotificationTimes() []string {
+	if x != nil {
+		return x.NotificationTimes
+	}
+	return nil
+}
+
+func (x *UserPreferences) GetWeeklySchedule() map[string]bool {
+	if x != nil {
+		return x.WeeklySchedule
+	}
+	return nil
+}
+
+type PlacementTestState struct {
+	SessionId      string                 `json:"session_id,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	ItemsCompleted int32                  `json:"items_completed,omitempty"`
+	TotalItems     int32                  `json:"total_items,omitempty"`
+	CurrentAbility float64                `json:"current_ability,omitempty"`
+	Confidence     float64                `json:"confidence,omitempty"`
+	TopicAbilities map[string]float64     `json:"topic_abilities,omitempty"`
+	Results        *PlacementTestResults  `json:"results,omitempty"`
+	StartedAt      *timestamppb.Timestamp `json:"started_at,omitempty"`
+	CompletedAt    *timestamppb.Timestamp `json:"completed_at,omitempty"`
+}
+
+func (x *PlacementTestState) Reset()         { *x = PlacementTestState{} }
+func (x *PlacementTestState) String() string { return "" }
+func (*PlacementTestState) ProtoMessage()    {}
+
+func (x *PlacementTestState) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PlacementTestState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PlacementTestState) GetItemsCompleted() int32 {
+	if x != nil {
+		return x.ItemsCompleted
+	}
+	return 0
+}
+
+func (x *PlacementTestState) GetTotalItems() int32 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *PlacementTestState) GetCurrentAbility() float64 {
+	if x != nil {
+		return x.CurrentAbility
+	}
+	return 0
+}
+
+func (x *PlacementTestState) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *PlacementTestState) GetTopicAbilities() map[string]float64 {
+	if x != nil {
+		return x.TopicAbilities
+	}
+	return nil
+}
+
+func (x *PlacementTestState) GetResults() *PlacementTestResults {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *PlacementTestState) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *PlacementTestState) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type PlacementTestResults struct {
+	UserId            string             `json:"user_id,omitempty"`
+	SessionId         string             `json:"session_id,omitempty"`
+	TopicAbilities    map[string]float64 `json:"topic_abilities,omitempty"`
+	TopicConfidence   map[string]float64 `json:"topic_confidence,omitempty"`
+	OverallAbility    float64            `json:"overall_ability,omitempty"`
+	OverallConfidence float64            `json:"overall_confidence,omitempty"`
+	ItemsAdministered int32              `json:"items_administered,omitempty"`
+	CorrectResponses  int32              `json:"correct_responses,omitempty"`
+	TotalTimeMs       int32              `json:"total_time_ms,omitempty"`
+	FinalSe           float64            `json:"final_se,omitempty"`
+	RecommendedLevel  string             `json:"recommended_level,omitempty"`
+	StrengthAreas     []string           `json:"strength_areas,omitempty"`
+	WeaknessAreas     []string           `json:"weakness_areas,omitempty"`
+	StoppingReason    string             `json:"stopping_reason,omitempty"`
+}
+
+func (x *PlacementTestResults) Reset()         { *x = PlacementTestResults{} }
+func (x *PlacementTestResults) String() string { return "" }
+func (*PlacementTestResults) ProtoMessage()    {}
+
+func (x *PlacementTestResults) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlacementTestResults) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PlacementTestResults) GetTopicAbilities() map[string]float64 {
+	if x != nil {
+		return x.TopicAbilities
+	}
+	return nil
+}
+
+func (x *PlacementTestResults) GetTopicConfidence() map[string]float64 {
+	if x != nil {
+		return x.TopicConfidence
+	}
+	return nil
+}
+
+func (x *PlacementTestResults) GetOverallAbility() float64 {
+	if x != nil {
+		return x.OverallAbility
+	}
+	return 0
+}
+
+func (x *PlacementTestResults) GetOverallConfidence() float64 {
+	if x != nil {
+		return x.OverallConfidence
+	}
+	return 0
+}
+
+func (x *PlacementTestResults) GetItemsAdministered() int32 {
+	if x != nil {
+		return x.ItemsAdministered
+	}
+	return 0
+}
+
+func (x *PlacementTestResults) GetCorrectResponses() int32 {
+	if x != nil {
+		return x.CorrectResponses
+	}
+	return 0
+}
+
+func (x *PlacementTestResults) GetTotalTimeMs() int32 {
+	if x != nil {
+		return x.TotalTimeMs
+	}
+	return 0
+}
+
+func (x *PlacementTestResults) GetFinalSe() float64 {
+	if x != nil {
+		return x.FinalSe
+	}
+	return 0
+}
+
+func (x *PlacementTestResults) GetRecommendedLevel() string {
+	if x != nil {
+		return x.RecommendedLevel
+	}
+	return ""
+}
+
+func (x *PlacementTestResults) GetStrengthAreas() []string {
+	if x != nil {
+		return x.StrengthAreas
+	}
+	return nil
+}
+
+func (x *PlacementTestResults) GetWeaknessAreas() []string {
+	if x != nil {
+		return x.WeaknessAreas
+	}
+	return nil
+}
+
+func (x *PlacementTestResults) GetStoppingReason() string {
+	if x != nil {
+		return x.StoppingReason
+	}
+	return ""
+}
+
+type LearningPath struct {
+	PathId                string                 `json:"path_id,omitempty"`
+	RecommendedLevel      string                 `json:"recommended_level,omitempty"`
+	FocusTopics           []*TopicRecommendation `json:"focus_topics,omitempty"`
+	StudyPlan             *StudyPlan             `json:"study_plan,omitempty"`
+	Milestones            []*LearningMilestone   `json:"milestones,omitempty"`
+	EstimatedDurationDays int32                  `json:"estimated_duration_days,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `json:"created_at,omitempty"`
+	PlanRevision          int32                  `json:"plan_revision,omitempty"`
+}
+
+func (x *LearningPath) Reset()         { *x = LearningPath{} }
+func (x *LearningPath) String() string { return "" }
+func (*LearningPath) ProtoMessage()    {}
+
+func (x *LearningPath) GetPathId() string {
+	if x != nil {
+		return x.PathId
+	}
+	return ""
+}
+
+func (x *LearningPath) GetRecommendedLevel() string {
+	if x != nil {
+		return x.RecommendedLevel
+	}
+	return ""
+}
+
+func (x *LearningPath) GetFocusTopics() []*TopicRecommendation {
+	if x != nil {
+		return x.FocusTopics
+	}
+	return nil
+}
+
+func (x *LearningPath) GetStudyPlan() *StudyPlan {
+	if x != nil {
+		return x.StudyPlan
+	}
+	return nil
+}
+
+func (x *LearningPath) GetMilestones() []*LearningMilestone {
+	if x != nil {
+		return x.Milestones
+	}
+	return nil
+}
+
+func (x *LearningPath) GetEstimatedDurationDays() int32 {
+	if x != nil {
+		return x.EstimatedDurationDays
+	}
+	return 0
+}
+
+func (x *LearningPath) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LearningPath) GetPlanRevision() int32 {
+	if x != nil {
+		return x.PlanRevision
+	}
+	return 0
+}
+
+type TopicRecommendation struct {
+	Topic              string   `json:"topic,omitempty"`
+	Priority           string   `json:"priority,omitempty"`
+	Reason             string   `json:"reason,omitempty"`
+	Difficulty         float64  `json:"difficulty,omitempty"`
+	EstimatedTimeHours int32    `json:"estimated_time_hours,omitempty"`
+	Prerequisites      []string `json:"prerequisites,omitempty"`
+	Locked             bool     `json:"locked,omitempty"`
+}
+
+func (x *TopicRecommendation) Reset()         { *x = TopicRecommendation{} }
+func (x *TopicRecommendation) String() string { return "" }
+func (*TopicRecommendation) ProtoMessage()    {}
+
+func (x *TopicRecommendation) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *TopicRecommendation) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *TopicRecommendation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TopicRecommendation) GetDifficulty() float64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *TopicRecommendation) GetEstimatedTimeHours() int32 {
+	if x != nil {
+		return x.EstimatedTimeHours
+	}
+	return 0
+}
+
+func (x *TopicRecommendation) GetPrerequisites() []string {
+	if x != nil {
+		return x.Prerequisites
+	}
+	return nil
+}
+
+func (x *TopicRecommendation) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+type StudyPlan struct {
+	DailyMinutes        int32            `json:"daily_minutes,omitempty"`
+	WeeklySchedule      map[string]int32 `json:"weekly_schedule,omitempty"`
+	SessionTypes        []string         `json:"session_types,omitempty"`
+	ReviewFrequencyDays int32            `json:"review_frequency_days,omitempty"`
+	TestFrequencyDays   int32            `json:"test_frequency_days,omitempty"`
+}
+
+func (x *StudyPlan) Reset()         { *x = StudyPlan{} }
+func (x *StudyPlan) String() string { return "" }
+func (*StudyPlan) ProtoMessage()    {}
+
+func (x *StudyPlan) GetDailyMinutes() int32 {
+	if x != nil {
+		return x.DailyMinutes
+	}
+	return 0
+}
+
+func (x *StudyPlan) GetWeeklySchedule() map[string]int32 {
+	if x != nil {
+		return x.WeeklySchedule
+	}
+	return nil
+}
+
+func (x *StudyPlan) GetSessionTypes() []string {
+	if x != nil {
+		return x.SessionTypes
+	}
+	return nil
+}
+
+func (x *StudyPlan) GetReviewFrequencyDays() int32 {
+	if x != nil {
+		return x.ReviewFrequencyDays
+	}
+	return 0
+}
+
+func (x *StudyPlan) GetTestFrequencyDays() int32 {
+	if x != nil {
+		return x.TestFrequencyDays
+	}
+	return 0
+}
+
+type LearningMilestone struct {
+	Id             string                 `json:"id,omitempty"`
+	Title          string                 `json:"title,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	TargetAccuracy float64                `json:"target_accuracy,omitempty"`
+	RequiredTopics []string               `json:"required_topics,omitempty"`
+	EstimatedDate  *timestamppb.Timestamp `json:"estimated_date,omitempty"`
+	IsCompleted    bool                   `json:"is_completed,omitempty"`
+	CompletedAt    *timestamppb.Timestamp `json:"completed_at,omitempty"`
+}
+
+func (x *LearningMilestone) Reset()         { *x = LearningMilestone{} }
+func (x *LearningMilestone) String() string { return "" }
+func (*LearningMilestone) ProtoMessage()    {}
+
+func (x *LearningMilestone) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LearningMilestone) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LearningMilestone) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LearningMilestone) GetTargetAccuracy() float64 {
+	if x != nil {
+		return x.TargetAccuracy
+	}
+	return 0
+}
+
+func (x *LearningMilestone) GetRequiredTopics() []string {
+	if x != nil {
+		return x.RequiredTopics
+	}
+	return nil
+}
+
+func (x *LearningMilestone) GetEstimatedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedDate
+	}
+	return nil
+}
+
+func (x *LearningMilestone) GetIsCompleted() bool {
+	if x != nil {
+		return x.IsCompleted
+	}
+	return false
+}
+
+func (x *LearningMilestone) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type OnboardingAnalytics struct {
+	TimeSpentSeconds map[string]int32 `json:"time_spent_seconds,omitempty"`
+	InteractionCount map[string]int32 `json:"interaction_count,omitempty"`
+	DropoffPoints    []string         `json:"dropoff_points,omitempty"`
+	CompletionRate   float64          `json:"completion_rate,omitempty"`
+	UserSatisfaction int32            `json:"user_satisfaction,omitempty"`
+	FeedbackComments string           `json:"feedback_comments,omitempty"`
+}
+
+func (x *OnboardingAnalytics) Reset()         { *x = OnboardingAnalytics{} }
+func (x *OnboardingAnalytics) String() string { return "" }
+func (*OnboardingAnalytics) ProtoMessage()    {}
+
+func (x *OnboardingAnalytics) GetTimeSpentSeconds() map[string]int32 {
+	if x != nil {
+		return x.TimeSpentSeconds
+	}
+	return nil
+}
+
+func (x *OnboardingAnalytics) GetInteractionCount() map[string]int32 {
+	if x != nil {
+		return x.InteractionCount
+	}
+	return nil
+}
+
+func (x *OnboardingAnalytics) GetDropoffPoints() []string {
+	if x != nil {
+		return x.DropoffPoints
+	}
+	return nil
+}
+
+func (x *OnboardingAnalytics) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *OnboardingAnalytics) GetUserSatisfaction() int32 {
+	if x != nil {
+		return x.UserSatisfaction
+	}
+	return 0
+}
+
+func (x *OnboardingAnalytics) GetFeedbackComments() string {
+	if x != nil {
+		return x.FeedbackComments
+	}
+	return ""
+}
+
+// Topic prerequisite graph messages
+type GetTopicGraphRequest struct {
+	UserId      string `json:"user_id,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+}
+
+func (x *GetTopicGraphRequest) Reset()         { *x = GetTopicGraphRequest{} }
+func (x *GetTopicGraphRequest) String() string { return "" }
+func (*GetTopicGraphRequest) ProtoMessage()    {}
+
+func (x *GetTopicGraphRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetTopicGraphRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+type GetTopicGraphResponse struct {
+	Jurisdiction  string            `json:"jurisdiction,omitempty"`
+	Topics        []*TopicGraphNode `json:"topics,omitempty"`
+	UnlockMastery float64           `json:"unlock_mastery,omitempty"`
+}
+
+func (x *GetTopicGraphResponse) Reset()         { *x = GetTopicGraphResponse{} }
+func (x *GetTopicGraphResponse) String() string { return "" }
+func (*GetTopicGraphResponse) ProtoMessage()    {}
+
+func (x *GetTopicGraphResponse) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *GetTopicGraphResponse) GetTopics() []*TopicGraphNode {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *GetTopicGraphResponse) GetUnlockMastery() float64 {
+	if x != nil {
+		return x.UnlockMastery
+	}
+	return 0
+}
+
+type TopicGraphNode struct {
+	Topic                string   `json:"topic,omitempty"`
+	Prerequisites        []string `json:"prerequisites,omitempty"`
+	Depth                int32    `json:"depth,omitempty"`
+	Mastery              float64  `json:"mastery,omitempty"`
+	Mastered             bool     `json:"mastered,omitempty"`
+	Unlocked             bool     `json:"unlocked,omitempty"`
+	MissingPrerequisites []string `json:"missing_prerequisites,omitempty"`
+}
+
+func (x *TopicGraphNode) Reset()         { *x = TopicGraphNode{} }
+func (x *TopicGraphNode) String() string { return "" }
+func (*TopicGraphNode) ProtoMessage()    {}
+
+func (x *TopicGraphNode) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *TopicGraphNode) GetPrerequisites() []string {
+	if x != nil {
+		return x.Prerequisites
+	}
+	return nil
+}
+
+func (x *TopicGraphNode) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *TopicGraphNode) GetMastery() float64 {
+	if x != nil {
+		return x.Mastery
+	}
+	return 0
+}
+
+func (x *TopicGraphNode) GetMastered() bool {
+	if x != nil {
+		return x.Mastered
+	}
+	return false
+}
+
+func (x *TopicGraphNode) GetUnlocked() bool {
+	if x != nil {
+		return x.Unlocked
+	}
+	return false
+}
+
+func (x *TopicGraphNode) GetMissingPrerequisites() []string {
+	if x != nil {
+		return x.MissingPrerequisites
+	}
+	return nil
+}
+
+// Adaptive study plan history messages
+type GetStudyPlanHistoryRequest struct {
+	UserId string `json:"user_id,omitempty"`
+	Limit  int32  `json:"limit,omitempty"`
+}
+
+func (x *GetStudyPlanHistoryRequest) Reset()         { *x = GetStudyPlanHistoryRequest{} }
+func (x *GetStudyPlanHistoryRequest) String() string { return "" }
+func (*GetStudyPlanHistoryRequest) ProtoMessage()    {}
+
+func (x *GetStudyPlanHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetStudyPlanHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetStudyPlanHistoryResponse struct {
+	Revisions []*StudyPlanRevision `json:"revisions,omitempty"`
+}
+
+func (x *GetStudyPlanHistoryResponse) Reset()         { *x = GetStudyPlanHistoryResponse{} }
+func (x *GetStudyPlanHistoryResponse) String() string { return "" }
+func (*GetStudyPlanHistoryResponse) ProtoMessage()    {}
+
+func (x *GetStudyPlanHistoryResponse) GetRevisions() []*StudyPlanRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type StudyPlanRevision struct {
+	PathId     string                 `json:"path_id,omitempty"`
+	Revision   int32                  `json:"revision,omitempty"`
+	StudyPlan  *StudyPlan             `json:"study_plan,omitempty"`
+	Milestones []*LearningMilestone   `json:"milestones,omitempty"`
+	Reasons    []string               `json:"reasons,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `json:"created_at,omitempty"`
+}
+
+func (x *StudyPlanRevision) Reset()         { *x = StudyPlanRevision{} }
+func (x *StudyPlanRevision) String() string { return "" }
+func (*StudyPlanRevision) ProtoMessage()    {}
+
+func (x *StudyPlanRevision) GetPathId() string {
+	if x != nil {
+		return x.PathId
+	}
+	return ""
+}
+
+func (x *StudyPlanRevision) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *StudyPlanRevision) GetStudyPlan() *StudyPlan {
+	if x != nil {
+		return x.StudyPlan
+	}
+	return nil
+}
+
+func (x *StudyPlanRevision) GetMilestones() []*LearningMilestone {
+	if x != nil {
+		return x.Milestones
+	}
+	return nil
+}
+
+func (x *StudyPlanRevision) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *StudyPlanRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Learning goal messages
+type LearningGoal struct {
+	Id             string                 `json:"id,omitempty"`
+	UserId         string                 `json:"user_id,omitempty"`
+	Title          string                 `json:"title,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	TargetTopics   []string               `json:"target_topics,omitempty"`
+	TargetMastery  float64                `json:"target_mastery,omitempty"`
+	TargetDate     *timestamppb.Timestamp `json:"target_date,omitempty"`
+	CurrentMastery float64                `json:"current_mastery,omitempty"`
+	IsCompleted    bool                   `json:"is_completed,omitempty"`
+	CompletedAt    *timestamppb.Timestamp `json:"completed_at,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `json:"updated_at,omitempty"`
+}
+
+func (x *LearningGoal) Reset()         { *x = LearningGoal{} }
+func (x *LearningGoal) String() string { return "" }
+func (*LearningGoal) ProtoMessage()    {}
+
+func (x *LearningGoal) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LearningGoal) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LearningGoal) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LearningGoal) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LearningGoal) GetTargetTopics() []string {
+	if x != nil {
+		return x.TargetTopics
+	}
+	return nil
+}
+
+func (x *LearningGoal) GetTargetMastery() float64 {
+	if x != nil {
+		return x.TargetMastery
+	}
+	return 0
+}
+
+func (x *LearningGoal) GetTargetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TargetDate
+	}
+	return nil
+}
+
+func (x *LearningGoal) GetCurrentMastery() float64 {
+	if x != nil {
+		return x.CurrentMastery
+	}
+	return 0
+}
+
+func (x *LearningGoal) GetIsCompleted() bool {
+	if x != nil {
+		return x.IsCompleted
+	}
+	return false
+}
+
+func (x *LearningGoal) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *LearningGoal) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LearningGoal) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type LearningGoalDefinition struct {
+	Title         string                 `json:"title,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	TargetTopics  []string               `json:"target_topics,omitempty"`
+	TargetMastery float64                `json:"target_mastery,omitempty"`
+	TargetDate    *timestamppb.Timestamp `json:"target_date,omitempty"`
+}
+
+func (x *LearningGoalDefinition) Reset()         { *x = LearningGoalDefinition{} }
+func (x *LearningGoalDefinition) String() string { return "" }
+func (*LearningGoalDefinition) ProtoMessage()    {}
+
+func (x *LearningGoalDefinition) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LearningGoalDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LearningGoalDefinition) GetTargetTopics() []string {
+	if x != nil {
+		return x.TargetTopics
+	}
+	return nil
+}
+
+func (x *LearningGoalDefinition) GetTargetMastery() float64 {
+	if x != nil {
+		return x.TargetMastery
+	}
+	return 0
+}
+
+func (x *LearningGoalDefinition) GetTargetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TargetDate
+	}
+	return nil
+}
+
+type CreateLearningGoalRequest struct {
+	UserId string                  `json:"user_id,omitempty"`
+	Goal   *LearningGoalDefinition `json:"goal,omitempty"`
+}
+
+func (x *CreateLearningGoalRequest) Reset()         { *x = CreateLearningGoalRequest{} }
+func (x *CreateLearningGoalRequest) String() string { return "" }
+func (*CreateLearningGoalRequest) ProtoMessage()    {}
+
+func (x *CreateLearningGoalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateLearningGoalRequest) GetGoal() *LearningGoalDefinition {
+	if x != nil {
+		return x.Goal
+	}
+	return nil
+}
+
+type CreateLearningGoalResponse struct {
+	Goal *LearningGoal `json:"goal,omitempty"`
+}
+
+func (x *CreateLearningGoalResponse) Reset()         { *x = CreateLearningGoalResponse{} }
+func (x *CreateLearningGoalResponse) String() string { return "" }
+func (*CreateLearningGoalResponse) ProtoMessage()    {}
+
+func (x *CreateLearningGoalResponse) GetGoal() *LearningGoal {
+	if x != nil {
+		return x.Goal
+	}
+	return nil
+}
+
+type GetLearningGoalRequest struct {
+	UserId string `json:"user_id,omitempty"`
+	GoalId string `json:"goal_id,omitempty"`
+}
+
+func (x *GetLearningGoalRequest) Reset()         { *x = GetLearningGoalRequest{} }
+func (x *GetLearningGoalRequest) String() string { return "" }
+func (*GetLearningGoalRequest) ProtoMessage()    {}
+
+func (x *GetLearningGoalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLearningGoalRequest) GetGoalId() string {
+	if x != nil {
+		return x.GoalId
+	}
+	return ""
+}
+
+type GetLearningGoalResponse struct {
+	Goal *LearningGoal `json:"goal,omitempty"`
+}
+
+func (x *GetLearningGoalResponse) Reset()         { *x = GetLearningGoalResponse{} }
+func (x *GetLearningGoalResponse) String() string { return "" }
+func (*GetLearningGoalResponse) ProtoMessage()    {}
+
+func (x *GetLearningGoalResponse) GetGoal() *LearningGoal {
+	if x != nil {
+		return x.Goal
+	}
+	return nil
+}
+
+type ListLearningGoalsRequest struct {
+	UserId           string `json:"user_id,omitempty"`
+	IncludeCompleted bool   `json:"include_completed,omitempty"`
+}
+
+func (x *ListLearningGoalsRequest) Reset()         { *x = ListLearningGoalsRequest{} }
+func (x *ListLearningGoalsRequest) String() string { return "" }
+func (*ListLearningGoalsRequest) ProtoMessage()    {}
+
+func (x *ListLearningGoalsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListLearningGoalsRequest) GetIncludeCompleted() bool {
+	if x != nil {
+		return x.IncludeCompleted
+	}
+	return false
+}
+
+type ListLearningGoalsResponse struct {
+	Goals []*LearningGoal `json:"goals,omitempty"`
+}
+
+func (x *ListLearningGoalsResponse) Reset()         { *x = ListLearningGoalsResponse{} }
+func (x *ListLearningGoalsResponse) String() string { return "" }
+func (*ListLearningGoalsResponse) ProtoMessage()    {}
+
+func (x *ListLearningGoalsResponse) GetGoals() []*LearningGoal {
+	if x != nil {
+		return x.Goals
+	}
+	return nil
+}
+
+type UpdateLearningGoalRequest struct {
+	UserId string                  `json:"user_id,omitempty"`
+	GoalId string                  `json:"goal_id,omitempty"`
+	Goal   *LearningGoalDefinition `json:"goal,omitempty"`
+}
+
+func (x *UpdateLearningGoalRequest) Reset()         { *x = UpdateLearningGoalRequest{} }
+func (x *UpdateLearningGoalRequest) String() string { return "" }
+func (*UpdateLearningGoalRequest) ProtoMessage()    {}
+
+func (x *UpdateLearningGoalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateLearningGoalRequest) GetGoalId() string {
+	if x != nil {
+		return x.GoalId
+	}
+	return ""
+}
+
+func (x *UpdateLearningGoalRequest) GetGoal() *LearningGoalDefinition {
+	if x != nil {
+		return x.Goal
+	}
+	return nil
+}
+
+type UpdateLearningGoalResponse struct {
+	Goal *LearningGoal `json:"goal,omitempty"`
+}
+
+func (x *UpdateLearningGoalResponse) Reset()         { *x = UpdateLearningGoalResponse{} }
+func (x *UpdateLearningGoalResponse) String() string { return "" }
+func (*UpdateLearningGoalResponse) ProtoMessage()    {}
+
+func (x *UpdateLearningGoalResponse) GetGoal() *LearningGoal {
+	if x != nil {
+		return x.Goal
+	}
+	return nil
+}
+
+type DeleteLearningGoalRequest struct {
+	UserId string `json:"user_id,omitempty"`
+	GoalId string `json:"goal_id,omitempty"`
+}
+
+func (x *DeleteLearningGoalRequest) Reset()         { *x = DeleteLearningGoalRequest{} }
+func (x *DeleteLearningGoalRequest) String() string { return "" }
+func (*DeleteLearningGoalRequest) ProtoMessage()    {}
+
+func (x *DeleteLearningGoalRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteLearningGoalRequest) GetGoalId() string {
+	if x != nil {
+		return x.GoalId
+	}
+	return ""
+}
+
+type DeleteLearningGoalResponse struct {
+	Success bool `json:"success,omitempty"`
+}
+
+func (x *DeleteLearningGoalResponse) Reset()         { *x = DeleteLearningGoalResponse{} }
+func (x *DeleteLearningGoalResponse) String() string { return "" }
+func (*DeleteLearningGoalResponse) ProtoMessage()    {}
+
+func (x *DeleteLearningGoalResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Jurisdiction messages
+type SwitchJurisdictionRequest struct {
+	UserId      string `json:"user_id,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+}
+
+func (x *SwitchJurisdictionRequest) Reset()         { *x = SwitchJurisdictionRequest{} }
+func (x *SwitchJurisdictionRequest) String() string { return "" }
+func (*SwitchJurisdictionRequest) ProtoMessage()    {}
+
+func (x *SwitchJurisdictionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SwitchJurisdictionRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+type TopicTransfer struct {
+	Topic              string  `json:"topic,omitempty"`
+	Factor             float64 `json:"factor,omitempty"`
+	SourceMastery      float64 `json:"source_mastery,omitempty"`
+	TransferredMastery float64 `json:"transferred_mastery,omitempty"`
+}
+
+func (x *TopicTransfer) Reset()         { *x = TopicTransfer{} }
+func (x *TopicTransfer) String() string { return "" }
+func (*TopicTransfer) ProtoMessage()    {}
+
+func (x *TopicTransfer) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *TopicTransfer) GetFactor() float64 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+func (x *TopicTransfer) GetSourceMastery() float64 {
+	if x != nil {
+		return x.SourceMastery
+	}
+	return 0
+}
+
+func (x *TopicTransfer) GetTransferredMastery() float64 {
+	if x != nil {
+		return x.TransferredMastery
+	}
+	return 0
+}
+
+type SwitchJurisdictionResponse struct {
+	PreviousJurisdiction string                 `json:"previous_jurisdiction,omitempty"`
+	Jurisdiction         string                 `json:"jurisdiction,omitempty"`
+	ExamDate             *timestamppb.Timestamp `json:"exam_date,omitempty"`
+	Transfers            []*TopicTransfer       `json:"transfers,omitempty"`
+}
+
+func (x *SwitchJurisdictionResponse) Reset()         { *x = SwitchJurisdictionResponse{} }
+func (x *SwitchJurisdictionResponse) String() string { return "" }
+func (*SwitchJurisdictionResponse) ProtoMessage()    {}
+
+func (x *SwitchJurisdictionResponse) GetPreviousJurisdiction() string {
+	if x != nil {
+		return x.PreviousJurisdiction
+	}
+	return ""
+}
+
+func (x *SwitchJurisdictionResponse) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *SwitchJurisdictionResponse) GetExamDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamDate
+	}
+	return nil
+}
+
+func (x *SwitchJurisdictionResponse) GetTransfers() []*TopicTransfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+// Placement report messages
+type GetPlacementReportRequest struct {
+	CountryCode string `json:"country_code,omitempty"`
+	MisfitOnly  bool   `json:"misfit_only,omitempty"`
+}
+
+func (x *GetPlacementReportRequest) Reset()         { *x = GetPlacementReportRequest{} }
+func (x *GetPlacementReportRequest) String() string { return "" }
+func (*GetPlacementReportRequest) ProtoMessage()    {}
+
+func (x *GetPlacementReportRequest) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GetPlacementReportRequest) GetMisfitOnly() bool {
+	if x != nil {
+		return x.MisfitOnly
+	}
+	return false
+}
+
+type TestLengthCount struct {
+	ItemsAdministered int32 `json:"items_administered,omitempty"`
+	Tests             int32 `json:"tests,omitempty"`
+}
+
+func (x *TestLengthCount) Reset()         { *x = TestLengthCount{} }
+func (x *TestLengthCount) String() string { return "" }
+func (*TestLengthCount) ProtoMessage()    {}
+
+func (x *TestLengthCount) GetItemsAdministered() int32 {
+	if x != nil {
+		return x.ItemsAdministered
+	}
+	return 0
+}
+
+func (x *TestLengthCount) GetTests() int32 {
+	if x != nil {
+		return x.Tests
+	}
+	return 0
+}
+
+type StoppingReasonStats struct {
+	Reason         string  `json:"reason,omitempty"`
+	Tests          int32   `json:"tests,omitempty"`
+	Share          float64 `json:"share,omitempty"`
+	MeanTestLength float64 `json:"mean_test_length,omitempty"`
+	MeanFinalSe    float64 `json:"mean_final_se,omitempty"`
+}
+
+func (x *StoppingReasonStats) Reset()         { *x = StoppingReasonStats{} }
+func (x *StoppingReasonStats) String() string { return "" }
+func (*StoppingReasonStats) ProtoMessage()    {}
+
+func (x *StoppingReasonStats) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StoppingReasonStats) GetTests() int32 {
+	if x != nil {
+		return x.Tests
+	}
+	return 0
+}
+
+func (x *StoppingReasonStats) GetShare() float64 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+func (x *StoppingReasonStats) GetMeanTestLength() float64 {
+	if x != nil {
+		return x.MeanTestLength
+	}
+	return 0
+}
+
+func (x *StoppingReasonStats) GetMeanFinalSe() float64 {
+	if x != nil {
+		return x.MeanFinalSe
+	}
+	return 0
+}
+
+type TerminationSE struct {
+	Mean   float64 `json:"mean,omitempty"`
+	Median float64 `json:"median,omitempty"`
+	P90    float64 `json:"p90,omitempty"`
+	Max    float64 `json:"max,omitempty"`
+}
+
+func (x *TerminationSE) Reset()         { *x = TerminationSE{} }
+func (x *TerminationSE) String() string { return "" }
+func (*TerminationSE) ProtoMessage()    {}
+
+func (x *TerminationSE) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *TerminationSE) GetMedian() float64 {
+	if x != nil {
+		return x.Median
+	}
+	return 0
+}
+
+func (x *TerminationSE) GetP90() float64 {
+	if x != nil {
+		return x.P90
+	}
+	return 0
+}
+
+func (x *TerminationSE) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type ItemFit struct {
+	ItemId            string  `json:"item_id,omitempty"`
+	Responses         int32   `json:"responses,omitempty"`
+	ProportionCorrect float64 `json:"proportion_correct,omitempty"`
+	ExpectedCorrect   float64 `json:"expected_correct,omitempty"`
+	Infit             float64 `json:"infit,omitempty"`
+	Outfit            float64 `json:"outfit,omitempty"`
+	Misfit            bool    `json:"misfit,omitempty"`
+}
+
+func (x *ItemFit) Reset()         { *x = ItemFit{} }
+func (x *ItemFit) String() string { return "" }
+func (*ItemFit) ProtoMessage()    {}
+
+func (x *ItemFit) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ItemFit) GetResponses() int32 {
+	if x != nil {
+		return x.Responses
+	}
+	return 0
+}
+
+func (x *ItemFit) GetProportionCorrect() float64 {
+	if x != nil {
+		return x.ProportionCorrect
+	}
+	return 0
+}
+
+func (x *ItemFit) GetExpectedCorrect() float64 {
+	if x != nil {
+		return x.ExpectedCorrect
+	}
+	return 0
+}
+
+func (x *ItemFit) GetInfit() float64 {
+	if x != nil {
+		return x.Infit
+	}
+	return 0
+}
+
+func (x *ItemFit) GetOutfit() float64 {
+	if x != nil {
+		return x.Outfit
+	}
+	return 0
+}
+
+func (x *ItemFit) GetMisfit() bool {
+	if x != nil {
+		return x.Misfit
+	}
+	return false
+}
+
+type PlacementReport struct {
+	ReportId        string                 `json:"report_id,omitempty"`
+	Jurisdiction    string                 `json:"jurisdiction,omitempty"`
+	WindowStart     *timestamppb.Timestamp `json:"window_start,omitempty"`
+	WindowEnd       *timestamppb.Timestamp `json:"window_end,omitempty"`
+	GeneratedAt     *timestamppb.Timestamp `json:"generated_at,omitempty"`
+	TestsAnalyzed   int32                  `json:"tests_analyzed,omitempty"`
+	MeanTestLength  float64                `json:"mean_test_length,omitempty"`
+	TestLengths     []*TestLengthCount     `json:"test_lengths,omitempty"`
+	StoppingReasons []*StoppingReasonStats `json:"stopping_reasons,omitempty"`
+	TerminationSe   *TerminationSE         `json:"termination_se,omitempty"`
+	ItemFit         []*ItemFit             `json:"item_fit,omitempty"`
+}
+
+func (x *PlacementReport) Reset()         { *x = PlacementReport{} }
+func (x *PlacementReport) String() string { return "" }
+func (*PlacementReport) ProtoMessage()    {}
+
+func (x *PlacementReport) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *PlacementReport) GetJurisdiction() string {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return ""
+}
+
+func (x *PlacementReport) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *PlacementReport) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+func (x *PlacementReport) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *PlacementReport) GetTestsAnalyzed() int32 {
+	if x != nil {
+		return x.TestsAnalyzed
+	}
+	return 0
+}
+
+func (x *PlacementReport) GetMeanTestLength() float64 {
+	if x != nil {
+		return x.MeanTestLength
+	}
+	return 0
+}
+
+func (x *PlacementReport) GetTestLengths() []*TestLengthCount {
+	if x != nil {
+		return x.TestLengths
+	}
+	return nil
+}
+
+func (x *PlacementReport) GetStoppingReasons() []*StoppingReasonStats {
+	if x != nil {
+		return x.StoppingReasons
+	}
+	return nil
+}
+
+func (x *PlacementReport) GetTerminationSe() *TerminationSE {
+	if x != nil {
+		return x.TerminationSe
+	}
+	return nil
+}
+
+func (x *PlacementReport) GetItemFit() []*ItemFit {
+	if x != nil {
+		return x.ItemFit
+	}
+	return nil
+}
+
+type GetPlacementReportResponse struct {
+	Report *PlacementReport `json:"report,omitempty"`
+}
+
+func (x *GetPlacementReportResponse) Reset()         { *x = GetPlacementReportResponse{} }
+func (x *GetPlacementReportResponse) String() string { return "" }
+func (*GetPlacementReportResponse) ProtoMessage()    {}
+
+func (x *GetPlacementReportResponse) GetReport() *PlacementReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// Leech messages
+type GetLeechesRequest struct {
+	UserId string `json:"user_id,omitempty"`
+	Limit  int32  `json:"limit,omitempty"`
+}
+
+func (x *GetLeechesRequest) Reset()         { *x = GetLeechesRequest{} }
+func (x *GetLeechesRequest) String() string { return "" }
+func (*GetLeechesRequest) ProtoMessage()    {}
+
+func (x *GetLeechesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLeechesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LeechItem struct {
+	ItemId            string                 `json:"item_id,omitempty"`
+	Lapses            int32                  `json:"lapses,omitempty"`
+	EasinessFactor    float64                `json:"easiness_factor,omitempty"`
+	IntervalDays      int32                  `json:"interval_days,omitempty"`
+	LastReviewed      *timestamppb.Timestamp `json:"last_reviewed,omitempty"`
+	NextDue           *timestamppb.Timestamp `json:"next_due,omitempty"`
+	Topics            []string               `json:"topics,omitempty"`
+	Remediation       string                 `json:"remediation,omitempty"`
+	RemediationItemId string                 `json:"remediation_item_id,omitempty"`
+}
+
+func (x *LeechItem) Reset()         { *x = LeechItem{} }
+func (x *LeechItem) String() string { return "" }
+func (*LeechItem) ProtoMessage()    {}
+
+func (x *LeechItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *LeechItem) GetLapses() int32 {
+	if x != nil {
+		return x.Lapses
+	}
+	return 0
+}
+
+func (x *LeechItem) GetEasinessFactor() float64 {
+	if x != nil {
+		return x.EasinessFactor
+	}
+	return 0
+}
+
+func (x *LeechItem) GetIntervalDays() int32 {
+	if x != nil {
+		return x.IntervalDays
+	}
+	return 0
+}
+
+func (x *LeechItem) GetLastReviewed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReviewed
+	}
+	return nil
+}
+
+func (x *LeechItem) GetNextDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextDue
+	}
+	return nil
+}
+
+func (x *LeechItem) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *LeechItem) GetRemediation() string {
+	if x != nil {
+		return x.Remediation
+	}
+	return ""
+}
+
+func (x *LeechItem) GetRemediationItemId() string {
+	if x != nil {
+		return x.RemediationItemId
+	}
+	return ""
+}
+
+type GetLeechesResponse struct {
+	Leeches        []*LeechItem `json:"leeches,omitempty"`
+	LeechThreshold int32        `json:"leech_threshold,omitempty"`
+}
+
+func (x *GetLeechesResponse) Reset()         { *x = GetLeechesResponse{} }
+func (x *GetLeechesResponse) String() string { return "" }
+func (*GetLeechesResponse) ProtoMessage()    {}
+
+func (x *GetLeechesResponse) GetLeeches() []*LeechItem {
+	if x != nil {
+		return x.Leeches
+	}
+	return nil
+}
+
+func (x *GetLeechesResponse) GetLeechThreshold() int32 {
+	if x != nil {
+		return x.LeechThreshold
+	}
+	return 0
+}
+
+// User data messages
+type ExportUserLearningStateRequest struct {
+	UserId string `json:"user_id,omitempty"`
+}
+
+func (x *ExportUserLearningStateRequest) Reset()         { *x = ExportUserLearningStateRequest{} }
+func (x *ExportUserLearningStateRequest) String() string { return "" }
+func (*ExportUserLearningStateRequest) ProtoMessage()    {}
+
+func (x *ExportUserLearningStateRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Signed record of an export or deletion of a user's learning state
+type LearningStateReceipt struct {
+	ReceiptId  string                 `json:"receipt_id,omitempty"`
+	Operation  string                 `json:"operation,omitempty"`
+	UserId     string                 `json:"user_id,omitempty"`
+	RequestId  string                 `json:"request_id,omitempty"`
+	IssuedAt   *timestamppb.Timestamp `json:"issued_at,omitempty"`
+	TableRows  map[string]int64       `json:"table_rows,omitempty"`
+	CacheKeys  int32                  `json:"cache_keys,omitempty"`
+	DataSha256 string                 `json:"data_sha256,omitempty"`
+	Payload    string                 `json:"payload,omitempty"`
+	Signature  string                 `json:"signature,omitempty"`
+	KeyId      string                 `json:"key_id,omitempty"`
+}
+
+func (x *LearningStateReceipt) Reset()         { *x = LearningStateReceipt{} }
+func (x *LearningStateReceipt) String() string { return "" }
+func (*LearningStateReceipt) ProtoMessage()    {}
+
+func (x *LearningStateReceipt) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+func (x *LearningStateReceipt) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *LearningStateReceipt) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LearningStateReceipt) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *LearningStateReceipt) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *LearningStateReceipt) GetTableRows() map[string]int64 {
+	if x != nil {
+		return x.TableRows
+	}
+	return nil
+}
+
+func (x *LearningStateReceipt) GetCacheKeys() int32 {
+	if x != nil {
+		return x.CacheKeys
+	}
+	return 0
+}
+
+func (x *LearningStateReceipt) GetDataSha256() string {
+	if x != nil {
+		return x.DataSha256
+	}
+	return ""
+}
+
+func (x *LearningStateReceipt) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *LearningStateReceipt) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *LearningStateReceipt) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type ExportUserLearningStateResponse struct {
+	Data    string                `json:"data,omitempty"`
+	Receipt *LearningStateReceipt `json:"receipt,omitempty"`
+}
+
+func (x *ExportUserLearningStateResponse) Reset()         { *x = ExportUserLearningStateResponse{} }
+func (x *ExportUserLearningStateResponse) String() string { return "" }
+func (*ExportUserLearningStateResponse) ProtoMessage()    {}
+
+func (x *ExportUserLearningStateResponse) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ExportUserLearningStateResponse) GetReceipt() *LearningStateReceipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+type DeleteUserLearningStateRequest struct {
+	UserId    string `json:"user_id,omitempty"`
+	RequestId string `json:"request_id,omitempty"`
+}
+
+func (x *DeleteUserLearningStateRequest) Reset()         { *x = DeleteUserLearningStateRequest{} }
+func (x *DeleteUserLearningStateRequest) String() string { return "" }
+func (*DeleteUserLearningStateRequest) ProtoMessage()    {}
+
+func (x *DeleteUserLearningStateRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteUserLearningStateRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type DeleteUserLearningStateResponse struct {
+	Receipt  *LearningStateReceipt `json:"receipt,omitempty"`
+	Replayed bool                  `json:"replayed,omitempty"`
+}
+
+func (x *DeleteUserLearningStateResponse) Reset()         { *x = DeleteUserLearningStateResponse{} }
+func (x *DeleteUserLearningStateResponse) String() string { return "" }
+func (*DeleteUserLearningStateResponse) ProtoMessage()    {}
+
+func (x *DeleteUserLearningStateResponse) GetReceipt() *LearningStateReceipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *DeleteUserLearningStateResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+// Review log import messages
+type ImportReviewLogRequest struct {
+	Data       string `json:"data,omitempty"`
+	Format     string `json:"format,omitempty"`
+	Source     string `json:"source,omitempty"`
+	GradeScale string `json:"grade_scale,omitempty"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+}
+
+func (x *ImportReviewLogRequest) Reset()         { *x = ImportReviewLogRequest{} }
+func (x *ImportReviewLogRequest) String() string { return "" }
+func (*ImportReviewLogRequest) ProtoMessage()    {}
+
+func (x *ImportReviewLogRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ImportReviewLogRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportReviewLogRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ImportReviewLogRequest) GetGradeScale() string {
+	if x != nil {
+		return x.GradeScale
+	}
+	return ""
+}
+
+func (x *ImportReviewLogRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// Row of a review log that could not be imported
+type ImportRowError struct {
+	Line   int32  `json:"line,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (x *ImportRowError) Reset()         { *x = ImportRowError{} }
+func (x *ImportRowError) String() string { return "" }
+func (*ImportRowError) ProtoMessage()    {}
+
+func (x *ImportRowError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// External item ID that matched no published catalog item
+type UnmappedItem struct {
+	ExternalId string `json:"external_id,omitempty"`
+	Reviews    int32  `json:"reviews,omitempty"`
+}
+
+func (x *UnmappedItem) Reset()         { *x = UnmappedItem{} }
+func (x *UnmappedItem) String() string { return "" }
+func (*UnmappedItem) ProtoMessage()    {}
+
+func (x *UnmappedItem) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *UnmappedItem) GetReviews() int32 {
+	if x != nil {
+		return x.Reviews
+	}
+	return 0
+}
+
+type ImportReviewLogResponse struct {
+	DryRun          bool              `json:"dry_run,omitempty"`
+	Rows            int32             `json:"rows,omitempty"`
+	InvalidRows     int32             `json:"invalid_rows,omitempty"`
+	Errors          []*ImportRowError `json:"errors,omitempty"`
+	Reviews         int32             `json:"reviews,omitempty"`
+	UnmappedReviews int32             `json:"unmapped_reviews,omitempty"`
+	Unmapped        []*UnmappedItem   `json:"unmapped,omitempty"`
+	Users           int32             `json:"users,omitempty"`
+	Items           int32             `json:"items,omitempty"`
+	Sm2States       int32             `json:"sm2_states,omitempty"`
+	BktStates       int32             `json:"bkt_states,omitempty"`
+	IrtStates       int32             `json:"irt_states,omitempty"`
+	StoredStates    int64             `json:"stored_states,omitempty"`
+	ExistingStates  int64             `json:"existing_states,omitempty"`
+}
+
+func (x *ImportReviewLogResponse) Reset()         { *x = ImportReviewLogResponse{} }
+func (x *ImportReviewLogResponse) String() string { return "" }
+func (*ImportReviewLogResponse) ProtoMessage()    {}
+
+func (x *ImportReviewLogResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportReviewLogResponse) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetInvalidRows() int32 {
+	if x != nil {
+		return x.InvalidRows
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportReviewLogResponse) GetReviews() int32 {
+	if x != nil {
+		return x.Reviews
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetUnmappedReviews() int32 {
+	if x != nil {
+		return x.UnmappedReviews
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetUnmapped() []*UnmappedItem {
+	if x != nil {
+		return x.Unmapped
+	}
+	return nil
+}
+
+func (x *ImportReviewLogResponse) GetUsers() int32 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetItems() int32 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetSm2States() int32 {
+	if x != nil {
+		return x.Sm2States
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetBktStates() int32 {
+	if x != nil {
+		return x.BktStates
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetIrtStates() int32 {
+	if x != nil {
+		return x.IrtStates
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetStoredStates() int64 {
+	if x != nil {
+		return x.StoredStates
+	}
+	return 0
+}
+
+func (x *ImportReviewLogResponse) GetExistingStates() int64 {
+	if x != nil {
+		return x.ExistingStates
+	}
+	return 0
+}
+
+// Configuration messages
+type GetConfigRequest struct {
+}
+
+func (x *GetConfigRequest) Reset()         { *x = GetConfigRequest{} }
+func (x *GetConfigRequest) String() string { return "" }
+func (*GetConfigRequest) ProtoMessage()    {}
+
+type ConfigSetting struct {
+	Key           string `json:"key,omitempty"`
+	Value         string `json:"value,omitempty"`
+	Source        string `json:"source,omitempty"`
+	HotReloadable bool   `json:"hot_reloadable,omitempty"`
+	Secret        bool   `json:"secret,omitempty"`
+}
+
+func (x *ConfigSetting) Reset()         { *x = ConfigSetting{} }
+func (x *ConfigSetting) String() string { return "" }
+func (*ConfigSetting) ProtoMessage()    {}
+
+func (x *ConfigSetting) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigSetting) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ConfigSetting) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ConfigSetting) GetHotReloadable() bool {
+	if x != nil {
+		return x.HotReloadable
+	}
+	return false
+}
+
+func (x *ConfigSetting) GetSecret() bool {
+	if x != nil {
+		return x.Secret
+	}
+	return false
+}
+
+type GetConfigResponse struct {
+	Settings []*ConfigSetting `json:"settings,omitempty"`
+}
+
+func (x *GetConfigResponse) Reset()         { *x = GetConfigResponse{} }
+func (x *GetConfigResponse) String() string { return "" }
+func (*GetConfigResponse) ProtoMessage()    {}
+
+func (x *GetConfigResponse) GetSettings() []*ConfigSetting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type ConfigOverride struct {
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Remove bool   `json:"remove,omitempty"`
+}
+
+func (x *ConfigOverride) Reset()         { *x = ConfigOverride{} }
+func (x *ConfigOverride) String() string { return "" }
+func (*ConfigOverride) ProtoMessage()    {}
+
+func (x *ConfigOverride) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigOverride) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ConfigOverride) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type UpdateConfigRequest struct {
+	Overrides []*ConfigOverride `json:"overrides,omitempty"`
+	Reason    string            `json:"reason,omitempty"`
+}
+
+func (x *UpdateConfigRequest) Reset()         { *x = UpdateConfigRequest{} }
+func (x *UpdateConfigRequest) String() string { return "" }
+func (*UpdateConfigRequest) ProtoMessage()    {}
+
+func (x *UpdateConfigRequest) GetOverrides() []*ConfigOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *UpdateConfigRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ConfigAuditEntry struct {
+	Id          int64                  `json:"id,omitempty"`
+	Key         string                 `json:"key,omitempty"`
+	OldValue    string                 `json:"old_value,omitempty"`
+	NewValue    string                 `json:"new_value,omitempty"`
+	HadOverride bool                   `json:"had_override,omitempty"`
+	HasOverride bool                   `json:"has_override,omitempty"`
+	Actor       string                 `json:"actor,omitempty"`
+	Reason      string                 `json:"reason,omitempty"`
+	ChangedAt   *timestamppb.Timestamp `json:"changed_at,omitempty"`
+}
+
+func (x *ConfigAuditEntry) Reset()         { *x = ConfigAuditEntry{} }
+func (x *ConfigAuditEntry) String() string { return "" }
+func (*ConfigAuditEntry) ProtoMessage()    {}
+
+func (x *ConfigAuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConfigAuditEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigAuditEntry) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *ConfigAuditEntry) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+func (x *ConfigAuditEntry) GetHadOverride() bool {
+	if x != nil {
+		return x.HadOverride
+	}
+	return false
+}
+
+func (x *ConfigAuditEntry) GetHasOverride() bool {
+	if x != nil {
+		return x.HasOverride
+	}
+	return false
+}
+
+func (x *ConfigAuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ConfigAuditEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ConfigAuditEntry) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type UpdateConfigResponse struct {
+	Changes []*ConfigAuditEntry `json:"changes,omitempty"`
+}
+
+func (x *UpdateConfigResponse) Reset()         { *x = UpdateConfigResponse{} }
+func (x *UpdateConfigResponse) String() string { return "" }
+func (*UpdateConfigResponse) ProtoMessage()    {}
+
+func (x *UpdateConfigResponse) GetChanges() []*ConfigAuditEntry {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetConfigHistoryRequest struct {
+	Key   string `json:"key,omitempty"`
+	Limit int32  `json:"limit,omitempty"`
+}
+
+func (x *GetConfigHistoryRequest) Reset()         { *x = GetConfigHistoryRequest{} }
+func (x *GetConfigHistoryRequest) String() string { return "" }
+func (*GetConfigHistoryRequest) ProtoMessage()    {}
+
+func (x *GetConfigHistoryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetConfigHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetConfigHistoryResponse struct {
+	Entries []*ConfigAuditEntry `json:"entries,omitempty"`
+}
+
+func (x *GetConfigHistoryResponse) Reset()         { *x = GetConfigHistoryResponse{} }
+func (x *GetConfigHistoryResponse) String() string { return "" }
+func (*GetConfigHistoryResponse) ProtoMessage()    {}
+
+func (x *GetConfigHistoryResponse) GetEntries() []*ConfigAuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type TenantScoringWeights struct {
+	Urgency     float64 `json:"urgency,omitempty"`
+	Mastery     float64 `json:"mastery,omitempty"`
+	Difficulty  float64 `json:"difficulty,omitempty"`
+	Exploration float64 `json:"exploration,omitempty"`
+}
+
+func (x *TenantScoringWeights) Reset()         { *x = TenantScoringWeights{} }
+func (x *TenantScoringWeights) String() string { return "" }
+func (*TenantScoringWeights) ProtoMessage()    {}
+
+func (x *TenantScoringWeights) GetUrgency() float64 {
+	if x != nil {
+		return x.Urgency
+	}
+	return 0
+}
+
+func (x *TenantScoringWeights) GetMastery() float64 {
+	if x != nil {
+		return x.Mastery
+	}
+	return 0
+}
+
+func (x *TenantScoringWeights) GetDifficulty() float64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *TenantScoringWeights) GetExploration() float64 {
+	if x != nil {
+		return x.Exploration
+	}
+	return 0
+}
+
+type Tenant struct {
+	Id              string                `json:"id,omitempty"`
+	Name            string                `json:"name,omitempty"`
+	ScoringStrategy string                `json:"scoring_strategy,omitempty"`
+	Weights         *TenantScoringWeights `json:"weights,omitempty"`
+	Topics          []string              `json:"topics,omitempty"`
+	ItemIds         []string              `json:"item_ids,omitempty"`
+	Disabled        bool                  `json:"disabled,omitempty"`
+}
+
+func (x *Tenant) Reset()         { *x = Tenant{} }
+func (x *Tenant) String() string { return "" }
+func (*Tenant) ProtoMessage()    {}
+
+func (x *Tenant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetScoringStrategy() string {
+	if x != nil {
+		return x.ScoringStrategy
+	}
+	return ""
+}
+
+func (x *Tenant) GetWeights() *TenantScoringWeights {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
+func (x *Tenant) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *Tenant) GetItemIds() []string {
+	if x != nil {
+		return x.ItemIds
+	}
+	return nil
+}
+
+func (x *Tenant) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type UpsertTenantRequest struct {
+	Tenant *Tenant `json:"tenant,omitempty"`
+}
+
+func (x *UpsertTenantRequest) Reset()         { *x = UpsertTenantRequest{} }
+func (x *UpsertTenantRequest) String() string { return "" }
+func (*UpsertTenantRequest) ProtoMessage()    {}
+
+func (x *UpsertTenantRequest) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+type UpsertTenantResponse struct {
+	Tenant *Tenant `json:"tenant,omitempty"`
+}
+
+func (x *UpsertTenantResponse) Reset()         { *x = UpsertTenantResponse{} }
+func (x *UpsertTenantResponse) String() string { return "" }
+func (*UpsertTenantResponse) ProtoMessage()    {}
+
+func (x *UpsertTenantResponse) GetTenant() *Tenant {
+	if x != nil {
+		return x.Tenant
+	}
+	return nil
+}
+
+type TenantMember struct {
+	UserId string `json:"user_id,omitempty"`
+	Role   string `json:"role,omitempty"`
+}
+
+func (x *TenantMember) Reset()         { *x = TenantMember{} }
+func (x *TenantMember) String() string { return "" }
+func (*TenantMember) ProtoMessage()    {}
+
+func (x *TenantMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TenantMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type UpdateTenantMembersRequest struct {
+	TenantId      string          `json:"tenant_id,omitempty"`
+	Add           []*TenantMember `json:"add,omitempty"`
+	RemoveUserIds []string        `json:"remove_user_ids,omitempty"`
+}
+
+func (x *UpdateTenantMembersRequest) Reset()         { *x = UpdateTenantMembersRequest{} }
+func (x *UpdateTenantMembersRequest) String() string { return "" }
+func (*UpdateTenantMembersRequest) ProtoMessage()    {}
+
+func (x *UpdateTenantMembersRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *UpdateTenantMembersRequest) GetAdd() []*TenantMember {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *UpdateTenantMembersRequest) GetRemoveUserIds() []string {
+	if x != nil {
+		return x.RemoveUserIds
+	}
+	return nil
+}
+
+type UpdateTenantMembersResponse struct {
+	Added   int32 `json:"added,omitempty"`
+	Removed int32 `json:"removed,omitempty"`
+}
+
+func (x *UpdateTenantMembersResponse) Reset()         { *x = UpdateTenantMembersResponse{} }
+func (x *UpdateTenantMembersResponse) String() string { return "" }
+func (*UpdateTenantMembersResponse) ProtoMessage()    {}
+
+func (x *UpdateTenantMembersResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *UpdateTenantMembersResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type CohortTopicMastery struct {
+	Topic        string  `json:"topic,omitempty"`
+	Learners     int32   `json:"learners,omitempty"`
+	MeanMastery  float64 `json:"mean_mastery,omitempty"`
+	Mastered     int32   `json:"mastered,omitempty"`
+	Struggling   int32   `json:"struggling,omitempty"`
+	Distribution []int32 `json:"distribution,omitempty"`
+}
+
+func (x *CohortTopicMastery) Reset()         { *x = CohortTopicMastery{} }
+func (x *CohortTopicMastery) String() string { return "" }
+func (*CohortTopicMastery) ProtoMessage()    {}
+
+func (x *CohortTopicMastery) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *CohortTopicMastery) GetLearners() int32 {
+	if x != nil {
+		return x.Learners
+	}
+	return 0
+}
+
+func (x *CohortTopicMastery) GetMeanMastery() float64 {
+	if x != nil {
+		return x.MeanMastery
+	}
+	return 0
+}
+
+func (x *CohortTopicMastery) GetMastered() int32 {
+	if x != nil {
+		return x.Mastered
+	}
+	return 0
+}
+
+func (x *CohortTopicMastery) GetStruggling() int32 {
+	if x != nil {
+		return x.Struggling
+	}
+	return 0
+}
+
+func (x *CohortTopicMastery) GetDistribution() []int32 {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+type CohortLearnerMastery struct {
+	UserId       string             `json:"user_id,omitempty"`
+	MeanMastery  float64            `json:"mean_mastery,omitempty"`
+	TopicMastery map[string]float64 `json:"topic_mastery,omitempty"`
+}
+
+func (x *CohortLearnerMastery) Reset()         { *x = CohortLearnerMastery{} }
+func (x *CohortLearnerMastery) String() string { return "" }
+func (*CohortLearnerMastery) ProtoMessage()    {}
+
+func (x *CohortLearnerMastery) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CohortLearnerMastery) GetMeanMastery() float64 {
+	if x != nil {
+		return x.MeanMastery
+	}
+	return 0
+}
+
+func (x *CohortLearnerMastery) GetTopicMastery() map[string]float64 {
+	if x != nil {
+		return x.TopicMastery
+	}
+	return nil
+}
+
+type GetCohortMasteryRequest struct {
+	TenantId  string `json:"tenant_id,omitempty"`
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+func (x *GetCohortMasteryRequest) Reset()         { *x = GetCohortMasteryRequest{} }
+func (x *GetCohortMasteryRequest) String() string { return "" }
+func (*GetCohortMasteryRequest) ProtoMessage()    {}
+
+func (x *GetCohortMasteryRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetCohortMasteryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetCohortMasteryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetCohortMasteryResponse struct {
+	Topics        []*CohortTopicMastery   `json:"topics,omitempty"`
+	Learners      []*CohortLearnerMastery `json:"learners,omitempty"`
+	TotalLearners int32                   `json:"total_learners,omitempty"`
+	NextPageToken string                  `json:"next_page_token,omitempty"`
+	RefreshedAt   *timestamppb.Timestamp  `json:"refreshed_at,omitempty"`
+}
+
+func (x *GetCohortMasteryResponse) Reset()         { *x = GetCohortMasteryResponse{} }
+func (x *GetCohortMasteryResponse) String() string { return "" }
+func (*GetCohortMasteryResponse) ProtoMessage()    {}
+
+func (x *GetCohortMasteryResponse) GetTopics() []*CohortTopicMastery {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *GetCohortMasteryResponse) GetLearners() []*CohortLearnerMastery {
+	if x != nil {
+		return x.Learners
+	}
+	return nil
+}
+
+func (x *GetCohortMasteryResponse) GetTotalLearners() int32 {
+	if x != nil {
+		return x.TotalLearners
+	}
+	return 0
+}
+
+func (x *GetCohortMasteryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetCohortMasteryResponse) GetRefreshedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshedAt
+	}
+	return nil
+}
+
+type LearnerRisk struct {
+	UserId         string                 `json:"user_id,omitempty"`
+	RiskScore      float64                `json:"risk_score,omitempty"`
+	Reasons        []string               `json:"reasons,omitempty"`
+	MeanMastery    float64                `json:"mean_mastery,omitempty"`
+	WeakestTopic   string                 `json:"weakest_topic,omitempty"`
+	WeakestMastery float64                `json:"weakest_mastery,omitempty"`
+	OverdueItems   int32                  `json:"overdue_items,omitempty"`
+	RecentAccuracy float64                `json:"recent_accuracy,omitempty"`
+	LastActiveAt   *timestamppb.Timestamp `json:"last_active_at,omitempty"`
+	ExamDate       *timestamppb.Timestamp `json:"exam_date,omitempty"`
+}
+
+func (x *LearnerRisk) Reset()         { *x = LearnerRisk{} }
+func (x *LearnerRisk) String() string { return "" }
+func (*LearnerRisk) ProtoMessage()    {}
+
+func (x *LearnerRisk) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LearnerRisk) GetRiskScore() float64 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *LearnerRisk) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *LearnerRisk) GetMeanMastery() float64 {
+	if x != nil {
+		return x.MeanMastery
+	}
+	return 0
+}
+
+func (x *LearnerRisk) GetWeakestTopic() string {
+	if x != nil {
+		return x.WeakestTopic
+	}
+	return ""
+}
+
+func (x *LearnerRisk) GetWeakestMastery() float64 {
+	if x != nil {
+		return x.WeakestMastery
+	}
+	return 0
+}
+
+func (x *LearnerRisk) GetOverdueItems() int32 {
+	if x != nil {
+		return x.OverdueItems
+	}
+	return 0
+}
+
+func (x *LearnerRisk) GetRecentAccuracy() float64 {
+	if x != nil {
+		return x.RecentAccuracy
+	}
+	return 0
+}
+
+func (x *LearnerRisk) GetLastActiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActiveAt
+	}
+	return nil
+}
+
+func (x *LearnerRisk) GetExamDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamDate
+	}
+	return nil
+}
+
+type GetLearnersAtRiskRequest struct {
+	TenantId     string  `json:"tenant_id,omitempty"`
+	PageSize     int32   `json:"page_size,omitempty"`
+	PageToken    string  `json:"page_token,omitempty"`
+	MinRiskScore float64 `json:"min_risk_score,omitempty"`
+}
+
+func (x *GetLearnersAtRiskRequest) Reset()         { *x = GetLearnersAtRiskRequest{} }
+func (x *GetLearnersAtRiskRequest) String() string { return "" }
+func (*GetLearnersAtRiskRequest) ProtoMessage()    {}
+
+func (x *GetLearnersAtRiskRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetLearnersAtRiskRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetLearnersAtRiskRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetLearnersAtRiskRequest) GetMinRiskScore() float64 {
+	if x != nil {
+		return x.MinRiskScore
+	}
+	return 0
+}
+
+type GetLearnersAtRiskResponse struct {
+	Learners      []*LearnerRisk         `json:"learners,omitempty"`
+	NextPageToken string                 `json:"next_page_token,omitempty"`
+	RefreshedAt   *timestamppb.Timestamp `json:"refreshed_at,omitempty"`
+}
+
+func (x *GetLearnersAtRiskResponse) Reset()         { *x = GetLearnersAtRiskResponse{} }
+func (x *GetLearnersAtRiskResponse) String() string { return "" }
+func (*GetLearnersAtRiskResponse) ProtoMessage()    {}
+
+func (x *GetLearnersAtRiskResponse) GetLearners() []*LearnerRisk {
+	if x != nil {
+		return x.Learners
+	}
+	return nil
+}
+
+func (x *GetLearnersAtRiskResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetLearnersAtRiskResponse) GetRefreshedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshedAt
+	}
+	return nil
+}
+
+type FailedItem struct {
+	ItemId         string                 `json:"item_id,omitempty"`
+	Attempts       int32                  `json:"attempts,omitempty"`
+	Failures       int32                  `json:"failures,omitempty"`
+	FailureRate    float64                `json:"failure_rate,omitempty"`
+	Learners       int32                  `json:"learners,omitempty"`
+	LearnersFailed int32                  `json:"learners_failed,omitempty"`
+	LastFailedAt   *timestamppb.Timestamp `json:"last_failed_at,omitempty"`
+}
+
+func (x *FailedItem) Reset()         { *x = FailedItem{} }
+func (x *FailedItem) String() string { return "" }
+func (*FailedItem) ProtoMessage()    {}
+
+func (x *FailedItem) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *FailedItem) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *FailedItem) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *FailedItem) GetFailureRate() float64 {
+	if x != nil {
+		return x.FailureRate
+	}
+	return 0
+}
+
+func (x *FailedItem) GetLearners() int32 {
+	if x != nil {
+		return x.Learners
+	}
+	return 0
+}
+
+func (x *FailedItem) GetLearnersFailed() int32 {
+	if x != nil {
+		return x.LearnersFailed
+	}
+	return 0
+}
+
+func (x *FailedItem) GetLastFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFailedAt
+	}
+	return nil
+}
+
+type GetFrequentlyFailedItemsRequest struct {
+	TenantId  string `json:"tenant_id,omitempty"`
+	PageSize  int32  `json:"page_size,omitempty"`
+	PageToken string `json:"page_token,omitempty"`
+}
+
+func (x *GetFrequentlyFailedItemsRequest) Reset()         { *x = GetFrequentlyFailedItemsRequest{} }
+func (x *GetFrequentlyFailedItemsRequest) String() string { return "" }
+func (*GetFrequentlyFailedItemsRequest) ProtoMessage()    {}
+
+func (x *GetFrequentlyFailedItemsRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetFrequentlyFailedItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetFrequentlyFailedItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetFrequentlyFailedItemsResponse struct {
+	Items         []*FailedItem          `json:"items,omitempty"`
+	NextPageToken string                 `json:"next_page_token,omitempty"`
+	RefreshedAt   *timestamppb.Timestamp `json:"refreshed_at,omitempty"`
+}
+
+func (x *GetFrequentlyFailedItemsResponse) Reset()         { *x = GetFrequentlyFailedItemsResponse{} }
+func (x *GetFrequentlyFailedItemsResponse) String() string { return "" }
+func (*GetFrequentlyFailedItemsResponse) ProtoMessage()    {}
+
+func (x *GetFrequentlyFailedItemsResponse) GetItems() []*FailedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetFrequentlyFailedItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetFrequentlyFailedItemsResponse) GetRefreshedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshedAt
+	}
+	return nil
+}
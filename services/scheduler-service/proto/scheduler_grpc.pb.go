@@ -20,18 +20,40 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SchedulerService_GetNextItems_FullMethodName           = "/scheduler.SchedulerService/GetNextItems"
-	SchedulerService_GetPlacementItems_FullMethodName      = "/scheduler.SchedulerService/GetPlacementItems"
-	SchedulerService_RecordAttempt_FullMethodName          = "/scheduler.SchedulerService/RecordAttempt"
-	SchedulerService_InitializeUser_FullMethodName         = "/scheduler.SchedulerService/InitializeUser"
-	SchedulerService_GetUserState_FullMethodName           = "/scheduler.SchedulerService/GetUserState"
-	SchedulerService_GetItemDifficulty_FullMethodName      = "/scheduler.SchedulerService/GetItemDifficulty"
-	SchedulerService_GetTopicMastery_FullMethodName        = "/scheduler.SchedulerService/GetTopicMastery"
-	SchedulerService_SelectSessionStrategy_FullMethodName  = "/scheduler.SchedulerService/SelectSessionStrategy"
-	SchedulerService_UpdateSessionReward_FullMethodName    = "/scheduler.SchedulerService/UpdateSessionReward"
-	SchedulerService_GetBanditMetrics_FullMethodName       = "/scheduler.SchedulerService/GetBanditMetrics"
-	SchedulerService_GetAvailableStrategies_FullMethodName = "/scheduler.SchedulerService/GetAvailableStrategies"
-	SchedulerService_Health_FullMethodName                 = "/scheduler.SchedulerService/Health"
+	SchedulerService_GetNextItems_FullMethodName             = "/scheduler.SchedulerService/GetNextItems"
+	SchedulerService_GetPlacementItems_FullMethodName        = "/scheduler.SchedulerService/GetPlacementItems"
+	SchedulerService_RecordAttempt_FullMethodName            = "/scheduler.SchedulerService/RecordAttempt"
+	SchedulerService_InitializeUser_FullMethodName           = "/scheduler.SchedulerService/InitializeUser"
+	SchedulerService_GetUserState_FullMethodName             = "/scheduler.SchedulerService/GetUserState"
+	SchedulerService_GetItemDifficulty_FullMethodName        = "/scheduler.SchedulerService/GetItemDifficulty"
+	SchedulerService_GetTopicMastery_FullMethodName          = "/scheduler.SchedulerService/GetTopicMastery"
+	SchedulerService_SelectSessionStrategy_FullMethodName    = "/scheduler.SchedulerService/SelectSessionStrategy"
+	SchedulerService_UpdateSessionReward_FullMethodName      = "/scheduler.SchedulerService/UpdateSessionReward"
+	SchedulerService_GetBanditMetrics_FullMethodName         = "/scheduler.SchedulerService/GetBanditMetrics"
+	SchedulerService_GetAvailableStrategies_FullMethodName   = "/scheduler.SchedulerService/GetAvailableStrategies"
+	SchedulerService_Health_FullMethodName                   = "/scheduler.SchedulerService/Health"
+	SchedulerService_SetExamDate_FullMethodName              = "/scheduler.SchedulerService/SetExamDate"
+	SchedulerService_GetTopicGraph_FullMethodName            = "/scheduler.SchedulerService/GetTopicGraph"
+	SchedulerService_GetStudyPlanHistory_FullMethodName      = "/scheduler.SchedulerService/GetStudyPlanHistory"
+	SchedulerService_CreateLearningGoal_FullMethodName       = "/scheduler.SchedulerService/CreateLearningGoal"
+	SchedulerService_GetLearningGoal_FullMethodName          = "/scheduler.SchedulerService/GetLearningGoal"
+	SchedulerService_ListLearningGoals_FullMethodName        = "/scheduler.SchedulerService/ListLearningGoals"
+	SchedulerService_UpdateLearningGoal_FullMethodName       = "/scheduler.SchedulerService/UpdateLearningGoal"
+	SchedulerService_DeleteLearningGoal_FullMethodName       = "/scheduler.SchedulerService/DeleteLearningGoal"
+	SchedulerService_SwitchJurisdiction_FullMethodName       = "/scheduler.SchedulerService/SwitchJurisdiction"
+	SchedulerService_GetPlacementReport_FullMethodName       = "/scheduler.SchedulerService/GetPlacementReport"
+	SchedulerService_GetLeeches_FullMethodName               = "/scheduler.SchedulerService/GetLeeches"
+	SchedulerService_ExportUserLearningState_FullMethodName  = "/scheduler.SchedulerService/ExportUserLearningState"
+	SchedulerService_DeleteUserLearningState_FullMethodName  = "/scheduler.SchedulerService/DeleteUserLearningState"
+	SchedulerService_ImportReviewLog_FullMethodName          = "/scheduler.SchedulerService/ImportReviewLog"
+	SchedulerService_GetConfig_FullMethodName                = "/scheduler.SchedulerService/GetConfig"
+	SchedulerService_UpdateConfig_FullMethodName             = "/scheduler.SchedulerService/UpdateConfig"
+	SchedulerService_GetConfigHistory_FullMethodName         = "/scheduler.SchedulerService/GetConfigHistory"
+	SchedulerService_UpsertTenant_FullMethodName             = "/scheduler.SchedulerService/UpsertTenant"
+	SchedulerService_UpdateTenantMembers_FullMethodName      = "/scheduler.SchedulerService/UpdateTenantMembers"
+	SchedulerService_GetCohortMastery_FullMethodName         = "/scheduler.SchedulerService/GetCohortMastery"
+	SchedulerService_GetLearnersAtRisk_FullMethodName        = "/scheduler.SchedulerService/GetLearnersAtRisk"
+	SchedulerService_GetFrequentlyFailedItems_FullMethodName = "/scheduler.SchedulerService/GetFrequentlyFailedItems"
 )
 
 // SchedulerServiceClient is the client API for SchedulerService service.
@@ -60,6 +82,46 @@ type SchedulerServiceClient interface {
 	GetBanditMetrics(ctx context.Context, in *GetBanditMetricsRequest, opts ...grpc.CallOption) (*GetBanditMetricsResponse, error)
 	// Get available session strategies
 	GetAvailableStrategies(ctx context.Context, in *GetAvailableStrategiesRequest, opts ...grpc.CallOption) (*GetAvailableStrategiesResponse, error)
+	// Set or clear the user's booked exam date
+	SetExamDate(ctx context.Context, in *SetExamDateRequest, opts ...grpc.CallOption) (*SetExamDateResponse, error)
+	// Topic prerequisite graph with the user's mastery overlaid
+	GetTopicGraph(ctx context.Context, in *GetTopicGraphRequest, opts ...grpc.CallOption) (*GetTopicGraphResponse, error)
+	// Revisions of the user's adaptive study plan, newest first
+	GetStudyPlanHistory(ctx context.Context, in *GetStudyPlanHistoryRequest, opts ...grpc.CallOption) (*GetStudyPlanHistoryResponse, error)
+	// Learning goals: reach a target mastery in a set of topics, optionally by a date
+	CreateLearningGoal(ctx context.Context, in *CreateLearningGoalRequest, opts ...grpc.CallOption) (*CreateLearningGoalResponse, error)
+	GetLearningGoal(ctx context.Context, in *GetLearningGoalRequest, opts ...grpc.CallOption) (*GetLearningGoalResponse, error)
+	ListLearningGoals(ctx context.Context, in *ListLearningGoalsRequest, opts ...grpc.CallOption) (*ListLearningGoalsResponse, error)
+	UpdateLearningGoal(ctx context.Context, in *UpdateLearningGoalRequest, opts ...grpc.CallOption) (*UpdateLearningGoalResponse, error)
+	DeleteLearningGoal(ctx context.Context, in *DeleteLearningGoalRequest, opts ...grpc.CallOption) (*DeleteLearningGoalResponse, error)
+	// Switch the user to another jurisdiction, carrying over knowledge of shared topics
+	SwitchJurisdiction(ctx context.Context, in *SwitchJurisdictionRequest, opts ...grpc.CallOption) (*SwitchJurisdictionResponse, error)
+	// Latest placement test report of a jurisdiction, for content authors
+	GetPlacementReport(ctx context.Context, in *GetPlacementReportRequest, opts ...grpc.CallOption) (*GetPlacementReportResponse, error)
+	// Items the user keeps failing, most lapses first
+	GetLeeches(ctx context.Context, in *GetLeechesRequest, opts ...grpc.CallOption) (*GetLeechesResponse, error)
+	// Everything the scheduler keeps about the user, with a signed receipt
+	ExportUserLearningState(ctx context.Context, in *ExportUserLearningStateRequest, opts ...grpc.CallOption) (*ExportUserLearningStateResponse, error)
+	// Purge everything the scheduler keeps about the user, with a signed receipt
+	DeleteUserLearningState(ctx context.Context, in *DeleteUserLearningStateRequest, opts ...grpc.CallOption) (*DeleteUserLearningStateResponse, error)
+	// Reconstruct learners' state from another app's review log, or report on it in a dry run
+	ImportReviewLog(ctx context.Context, in *ImportReviewLogRequest, opts ...grpc.CallOption) (*ImportReviewLogResponse, error)
+	// Effective value and source of every configuration setting
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	// Override or reset hot-reloadable settings at runtime, recorded in the audit log
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error)
+	// Audit log of configuration overrides, newest first
+	GetConfigHistory(ctx context.Context, in *GetConfigHistoryRequest, opts ...grpc.CallOption) (*GetConfigHistoryResponse, error)
+	// Create or replace an organization with its item subset and scoring strategy
+	UpsertTenant(ctx context.Context, in *UpsertTenantRequest, opts ...grpc.CallOption) (*UpsertTenantResponse, error)
+	// Add learners and instructors to an organization, or remove them
+	UpdateTenantMembers(ctx context.Context, in *UpdateTenantMembersRequest, opts ...grpc.CallOption) (*UpdateTenantMembersResponse, error)
+	// Mastery by topic of an organization's learners, for instructors' heatmaps
+	GetCohortMastery(ctx context.Context, in *GetCohortMasteryRequest, opts ...grpc.CallOption) (*GetCohortMasteryResponse, error)
+	// Learners of an organization at risk of falling behind, riskiest first
+	GetLearnersAtRisk(ctx context.Context, in *GetLearnersAtRiskRequest, opts ...grpc.CallOption) (*GetLearnersAtRiskResponse, error)
+	// Items an organization's learners failed most often
+	GetFrequentlyFailedItems(ctx context.Context, in *GetFrequentlyFailedItemsRequest, opts ...grpc.CallOption) (*GetFrequentlyFailedItemsResponse, error)
 	// Health check
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 }
@@ -171,6 +233,204 @@ func (c *schedulerServiceClient) GetAvailableStrategies(ctx context.Context, in
 	return out, nil
 }
 
+func (c *schedulerServiceClient) SetExamDate(ctx context.Context, in *SetExamDateRequest, opts ...grpc.CallOption) (*SetExamDateResponse, error) {
+	out := new(SetExamDateResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_SetExamDate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetTopicGraph(ctx context.Context, in *GetTopicGraphRequest, opts ...grpc.CallOption) (*GetTopicGraphResponse, error) {
+	out := new(GetTopicGraphResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetTopicGraph_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetStudyPlanHistory(ctx context.Context, in *GetStudyPlanHistoryRequest, opts ...grpc.CallOption) (*GetStudyPlanHistoryResponse, error) {
+	out := new(GetStudyPlanHistoryResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetStudyPlanHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) CreateLearningGoal(ctx context.Context, in *CreateLearningGoalRequest, opts ...grpc.CallOption) (*CreateLearningGoalResponse, error) {
+	out := new(CreateLearningGoalResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_CreateLearningGoal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetLearningGoal(ctx context.Context, in *GetLearningGoalRequest, opts ...grpc.CallOption) (*GetLearningGoalResponse, error) {
+	out := new(GetLearningGoalResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetLearningGoal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListLearningGoals(ctx context.Context, in *ListLearningGoalsRequest, opts ...grpc.CallOption) (*ListLearningGoalsResponse, error) {
+	out := new(ListLearningGoalsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListLearningGoals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UpdateLearningGoal(ctx context.Context, in *UpdateLearningGoalRequest, opts ...grpc.CallOption) (*UpdateLearningGoalResponse, error) {
+	out := new(UpdateLearningGoalResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_UpdateLearningGoal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) DeleteLearningGoal(ctx context.Context, in *DeleteLearningGoalRequest, opts ...grpc.CallOption) (*DeleteLearningGoalResponse, error) {
+	out := new(DeleteLearningGoalResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_DeleteLearningGoal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) SwitchJurisdiction(ctx context.Context, in *SwitchJurisdictionRequest, opts ...grpc.CallOption) (*SwitchJurisdictionResponse, error) {
+	out := new(SwitchJurisdictionResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_SwitchJurisdiction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetPlacementReport(ctx context.Context, in *GetPlacementReportRequest, opts ...grpc.CallOption) (*GetPlacementReportResponse, error) {
+	out := new(GetPlacementReportResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetPlacementReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetLeeches(ctx context.Context, in *GetLeechesRequest, opts ...grpc.CallOption) (*GetLeechesResponse, error) {
+	out := new(GetLeechesResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetLeeches_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ExportUserLearningState(ctx context.Context, in *ExportUserLearningStateRequest, opts ...grpc.CallOption) (*ExportUserLearningStateResponse, error) {
+	out := new(ExportUserLearningStateResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ExportUserLearningState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) DeleteUserLearningState(ctx context.Context, in *DeleteUserLearningStateRequest, opts ...grpc.CallOption) (*DeleteUserLearningStateResponse, error) {
+	out := new(DeleteUserLearningStateResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_DeleteUserLearningState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ImportReviewLog(ctx context.Context, in *ImportReviewLogRequest, opts ...grpc.CallOption) (*ImportReviewLogResponse, error) {
+	out := new(ImportReviewLogResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ImportReviewLog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error) {
+	out := new(GetConfigResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error) {
+	out := new(UpdateConfigResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_UpdateConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetConfigHistory(ctx context.Context, in *GetConfigHistoryRequest, opts ...grpc.CallOption) (*GetConfigHistoryResponse, error) {
+	out := new(GetConfigHistoryResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetConfigHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UpsertTenant(ctx context.Context, in *UpsertTenantRequest, opts ...grpc.CallOption) (*UpsertTenantResponse, error) {
+	out := new(UpsertTenantResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_UpsertTenant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UpdateTenantMembers(ctx context.Context, in *UpdateTenantMembersRequest, opts ...grpc.CallOption) (*UpdateTenantMembersResponse, error) {
+	out := new(UpdateTenantMembersResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_UpdateTenantMembers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetCohortMastery(ctx context.Context, in *GetCohortMasteryRequest, opts ...grpc.CallOption) (*GetCohortMasteryResponse, error) {
+	out := new(GetCohortMasteryResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetCohortMastery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetLearnersAtRisk(ctx context.Context, in *GetLearnersAtRiskRequest, opts ...grpc.CallOption) (*GetLearnersAtRiskResponse, error) {
+	out := new(GetLearnersAtRiskResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetLearnersAtRisk_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetFrequentlyFailedItems(ctx context.Context, in *GetFrequentlyFailedItemsRequest, opts ...grpc.CallOption) (*GetFrequentlyFailedItemsResponse, error) {
+	out := new(GetFrequentlyFailedItemsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetFrequentlyFailedItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *schedulerServiceClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
 	out := new(HealthResponse)
 	err := c.cc.Invoke(ctx, SchedulerService_Health_FullMethodName, in, out, opts...)
@@ -206,6 +466,46 @@ type SchedulerServiceServer interface {
 	GetBanditMetrics(context.Context, *GetBanditMetricsRequest) (*GetBanditMetricsResponse, error)
 	// Get available session strategies
 	GetAvailableStrategies(context.Context, *GetAvailableStrategiesRequest) (*GetAvailableStrategiesResponse, error)
+	// Set or clear the user's booked exam date
+	SetExamDate(context.Context, *SetExamDateRequest) (*SetExamDateResponse, error)
+	// Topic prerequisite graph with the user's mastery overlaid
+	GetTopicGraph(context.Context, *GetTopicGraphRequest) (*GetTopicGraphResponse, error)
+	// Revisions of the user's adaptive study plan, newest first
+	GetStudyPlanHistory(context.Context, *GetStudyPlanHistoryRequest) (*GetStudyPlanHistoryResponse, error)
+	// Learning goals: reach a target mastery in a set of topics, optionally by a date
+	CreateLearningGoal(context.Context, *CreateLearningGoalRequest) (*CreateLearningGoalResponse, error)
+	GetLearningGoal(context.Context, *GetLearningGoalRequest) (*GetLearningGoalResponse, error)
+	ListLearningGoals(context.Context, *ListLearningGoalsRequest) (*ListLearningGoalsResponse, error)
+	UpdateLearningGoal(context.Context, *UpdateLearningGoalRequest) (*UpdateLearningGoalResponse, error)
+	DeleteLearningGoal(context.Context, *DeleteLearningGoalRequest) (*DeleteLearningGoalResponse, error)
+	// Switch the user to another jurisdiction, carrying over knowledge of shared topics
+	SwitchJurisdiction(context.Context, *SwitchJurisdictionRequest) (*SwitchJurisdictionResponse, error)
+	// Latest placement test report of a jurisdiction, for content authors
+	GetPlacementReport(context.Context, *GetPlacementReportRequest) (*GetPlacementReportResponse, error)
+	// Items the user keeps failing, most lapses first
+	GetLeeches(context.Context, *GetLeechesRequest) (*GetLeechesResponse, error)
+	// Everything the scheduler keeps about the user, with a signed receipt
+	ExportUserLearningState(context.Context, *ExportUserLearningStateRequest) (*ExportUserLearningStateResponse, error)
+	// Purge everything the scheduler keeps about the user, with a signed receipt
+	DeleteUserLearningState(context.Context, *DeleteUserLearningStateRequest) (*DeleteUserLearningStateResponse, error)
+	// Reconstruct learners' state from another app's review log, or report on it in a dry run
+	ImportReviewLog(context.Context, *ImportReviewLogRequest) (*ImportReviewLogResponse, error)
+	// Effective value and source of every configuration setting
+	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	// Override or reset hot-reloadable settings at runtime, recorded in the audit log
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error)
+	// Audit log of configuration overrides, newest first
+	GetConfigHistory(context.Context, *GetConfigHistoryRequest) (*GetConfigHistoryResponse, error)
+	// Create or replace an organization with its item subset and scoring strategy
+	UpsertTenant(context.Context, *UpsertTenantRequest) (*UpsertTenantResponse, error)
+	// Add learners and instructors to an organization, or remove them
+	UpdateTenantMembers(context.Context, *UpdateTenantMembersRequest) (*UpdateTenantMembersResponse, error)
+	// Mastery by topic of an organization's learners, for instructors' heatmaps
+	GetCohortMastery(context.Context, *GetCohortMasteryRequest) (*GetCohortMasteryResponse, error)
+	// Learners of an organization at risk of falling behind, riskiest first
+	GetLearnersAtRisk(context.Context, *GetLearnersAtRiskRequest) (*GetLearnersAtRiskResponse, error)
+	// Items an organization's learners failed most often
+	GetFrequentlyFailedItems(context.Context, *GetFrequentlyFailedItemsRequest) (*GetFrequentlyFailedItemsResponse, error)
 	// Health check
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 	mustEmbedUnimplementedSchedulerServiceServer()
@@ -259,6 +559,94 @@ func (UnimplementedSchedulerServiceServer) GetAvailableStrategies(context.Contex
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableStrategies not implemented")
 }
 
+func (UnimplementedSchedulerServiceServer) SetExamDate(context.Context, *SetExamDateRequest) (*SetExamDateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExamDate not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetTopicGraph(context.Context, *GetTopicGraphRequest) (*GetTopicGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopicGraph not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetStudyPlanHistory(context.Context, *GetStudyPlanHistoryRequest) (*GetStudyPlanHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStudyPlanHistory not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) CreateLearningGoal(context.Context, *CreateLearningGoalRequest) (*CreateLearningGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLearningGoal not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetLearningGoal(context.Context, *GetLearningGoalRequest) (*GetLearningGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLearningGoal not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) ListLearningGoals(context.Context, *ListLearningGoalsRequest) (*ListLearningGoalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLearningGoals not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) UpdateLearningGoal(context.Context, *UpdateLearningGoalRequest) (*UpdateLearningGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLearningGoal not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) DeleteLearningGoal(context.Context, *DeleteLearningGoalRequest) (*DeleteLearningGoalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLearningGoal not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) SwitchJurisdiction(context.Context, *SwitchJurisdictionRequest) (*SwitchJurisdictionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchJurisdiction not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetPlacementReport(context.Context, *GetPlacementReportRequest) (*GetPlacementReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlacementReport not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetLeeches(context.Context, *GetLeechesRequest) (*GetLeechesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeeches not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) ExportUserLearningState(context.Context, *ExportUserLearningStateRequest) (*ExportUserLearningStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserLearningState not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) DeleteUserLearningState(context.Context, *DeleteUserLearningStateRequest) (*DeleteUserLearningStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserLearningState not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) ImportReviewLog(context.Context, *ImportReviewLogRequest) (*ImportReviewLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportReviewLog not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateConfig not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetConfigHistory(context.Context, *GetConfigHistoryRequest) (*GetConfigHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigHistory not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) UpsertTenant(context.Context, *UpsertTenantRequest) (*UpsertTenantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertTenant not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) UpdateTenantMembers(context.Context, *UpdateTenantMembersRequest) (*UpdateTenantMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTenantMembers not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetCohortMastery(context.Context, *GetCohortMasteryRequest) (*GetCohortMasteryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCohortMastery not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetLearnersAtRisk(context.Context, *GetLearnersAtRiskRequest) (*GetLearnersAtRiskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLearnersAtRisk not implemented")
+}
+
+func (UnimplementedSchedulerServiceServer) GetFrequentlyFailedItems(context.Context, *GetFrequentlyFailedItemsRequest) (*GetFrequentlyFailedItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFrequentlyFailedItems not implemented")
+}
+
 func (UnimplementedSchedulerServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
 }
@@ -293,6 +681,402 @@ func _SchedulerService_GetNextItems_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SchedulerService_SetExamDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExamDateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).SetExamDate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_SetExamDate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).SetExamDate(ctx, req.(*SetExamDateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetTopicGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopicGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetTopicGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetTopicGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetTopicGraph(ctx, req.(*GetTopicGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetStudyPlanHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStudyPlanHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetStudyPlanHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetStudyPlanHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetStudyPlanHistory(ctx, req.(*GetStudyPlanHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_CreateLearningGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLearningGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).CreateLearningGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_CreateLearningGoal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).CreateLearningGoal(ctx, req.(*CreateLearningGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetLearningGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLearningGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetLearningGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetLearningGoal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetLearningGoal(ctx, req.(*GetLearningGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ListLearningGoals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLearningGoalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListLearningGoals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListLearningGoals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListLearningGoals(ctx, req.(*ListLearningGoalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UpdateLearningGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLearningGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UpdateLearningGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UpdateLearningGoal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UpdateLearningGoal(ctx, req.(*UpdateLearningGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_DeleteLearningGoal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLearningGoalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).DeleteLearningGoal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_DeleteLearningGoal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).DeleteLearningGoal(ctx, req.(*DeleteLearningGoalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_SwitchJurisdiction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchJurisdictionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).SwitchJurisdiction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_SwitchJurisdiction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).SwitchJurisdiction(ctx, req.(*SwitchJurisdictionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetPlacementReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlacementReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetPlacementReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetPlacementReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetPlacementReport(ctx, req.(*GetPlacementReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetLeeches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeechesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetLeeches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetLeeches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetLeeches(ctx, req.(*GetLeechesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ExportUserLearningState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserLearningStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ExportUserLearningState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ExportUserLearningState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ExportUserLearningState(ctx, req.(*ExportUserLearningStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_DeleteUserLearningState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserLearningStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).DeleteUserLearningState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_DeleteUserLearningState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).DeleteUserLearningState(ctx, req.(*DeleteUserLearningStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ImportReviewLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportReviewLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ImportReviewLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ImportReviewLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ImportReviewLog(ctx, req.(*ImportReviewLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UpdateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetConfigHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetConfigHistory(ctx, req.(*GetConfigHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UpsertTenant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertTenantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UpsertTenant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UpsertTenant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UpsertTenant(ctx, req.(*UpsertTenantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UpdateTenantMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTenantMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UpdateTenantMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UpdateTenantMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UpdateTenantMembers(ctx, req.(*UpdateTenantMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetCohortMastery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCohortMasteryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetCohortMastery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetCohortMastery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetCohortMastery(ctx, req.(*GetCohortMasteryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetLearnersAtRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLearnersAtRiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetLearnersAtRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetLearnersAtRisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetLearnersAtRisk(ctx, req.(*GetLearnersAtRiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetFrequentlyFailedItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrequentlyFailedItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetFrequentlyFailedItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetFrequentlyFailedItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetFrequentlyFailedItems(ctx, req.(*GetFrequentlyFailedItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Additional handler functions would be here for each method...
 
 // SchedulerService_ServiceDesc is the grpc.ServiceDesc for SchedulerService service.
@@ -304,6 +1088,94 @@ var SchedulerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNextItems",
 			Handler:    _SchedulerService_GetNextItems_Handler,
 		},
+		{
+			MethodName: "SetExamDate",
+			Handler:    _SchedulerService_SetExamDate_Handler,
+		},
+		{
+			MethodName: "GetTopicGraph",
+			Handler:    _SchedulerService_GetTopicGraph_Handler,
+		},
+		{
+			MethodName: "GetStudyPlanHistory",
+			Handler:    _SchedulerService_GetStudyPlanHistory_Handler,
+		},
+		{
+			MethodName: "CreateLearningGoal",
+			Handler:    _SchedulerService_CreateLearningGoal_Handler,
+		},
+		{
+			MethodName: "GetLearningGoal",
+			Handler:    _SchedulerService_GetLearningGoal_Handler,
+		},
+		{
+			MethodName: "ListLearningGoals",
+			Handler:    _SchedulerService_ListLearningGoals_Handler,
+		},
+		{
+			MethodName: "UpdateLearningGoal",
+			Handler:    _SchedulerService_UpdateLearningGoal_Handler,
+		},
+		{
+			MethodName: "DeleteLearningGoal",
+			Handler:    _SchedulerService_DeleteLearningGoal_Handler,
+		},
+		{
+			MethodName: "SwitchJurisdiction",
+			Handler:    _SchedulerService_SwitchJurisdiction_Handler,
+		},
+		{
+			MethodName: "GetPlacementReport",
+			Handler:    _SchedulerService_GetPlacementReport_Handler,
+		},
+		{
+			MethodName: "GetLeeches",
+			Handler:    _SchedulerService_GetLeeches_Handler,
+		},
+		{
+			MethodName: "ExportUserLearningState",
+			Handler:    _SchedulerService_ExportUserLearningState_Handler,
+		},
+		{
+			MethodName: "DeleteUserLearningState",
+			Handler:    _SchedulerService_DeleteUserLearningState_Handler,
+		},
+		{
+			MethodName: "ImportReviewLog",
+			Handler:    _SchedulerService_ImportReviewLog_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _SchedulerService_GetConfig_Handler,
+		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _SchedulerService_UpdateConfig_Handler,
+		},
+		{
+			MethodName: "GetConfigHistory",
+			Handler:    _SchedulerService_GetConfigHistory_Handler,
+		},
+		{
+			MethodName: "UpsertTenant",
+			Handler:    _SchedulerService_UpsertTenant_Handler,
+		},
+		{
+			MethodName: "UpdateTenantMembers",
+			Handler:    _SchedulerService_UpdateTenantMembers_Handler,
+		},
+		{
+			MethodName: "GetCohortMastery",
+			Handler:    _SchedulerService_GetCohortMastery_Handler,
+		},
+		{
+			MethodName: "GetLearnersAtRisk",
+			Handler:    _SchedulerService_GetLearnersAtRisk_Handler,
+		},
+		{
+			MethodName: "GetFrequentlyFailedItems",
+			Handler:    _SchedulerService_GetFrequentlyFailedItems_Handler,
+		},
 		// Additional method descriptors would be here...
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"scheduler-service/internal/logger"
+	"scheduler-service/internal/replay"
+)
+
+const replayUsage = `usage: scheduler-service replay run -all | -user <id> [-user <id>...] | -users-file <file>
+       scheduler-service replay diff | swap | discard <run-id>
+       scheduler-service replay list`
+
+// userList collects repeated -user flags
+type userList []string
+
+func (l *userList) String() string { return strings.Join(*l, ",") }
+
+func (l *userList) Set(userID string) error {
+	*l = append(*l, userID)
+	return nil
+}
+
+// runReplay implements the "replay" subcommand, which rebuilds learner state from the
+// attempts table into shadow tables, diffs it and swaps it in. Returns the process exit code.
+func runReplay(engine *replay.Engine, log *logger.Logger, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, replayUsage)
+		return 2
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "run":
+		flags := flag.NewFlagSet("replay run", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		all := flags.Bool("all", false, "replay everyone with attempts")
+		var users userList
+		flags.Var(&users, "user", "user to replay; repeat for a cohort")
+		usersFile := flags.String("users-file", "", "file of users to replay, one ID per line")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 0 {
+			fmt.Fprintln(os.Stderr, replayUsage)
+			return 2
+		}
+
+		if *usersFile != "" {
+			fileUsers, err := readUserIDs(*usersFile)
+			if err != nil {
+				log.Errorf("Failed to read users file: %v", err)
+				return 1
+			}
+			users = append(users, fileUsers...)
+		}
+		if *all == (len(users) > 0) {
+			fmt.Fprintln(os.Stderr, replayUsage)
+			return 2
+		}
+
+		run, err := engine.Run(ctx, replay.Scope{UserIDs: users, All: *all})
+		if err != nil {
+			log.Errorf("Replay failed: %v", err)
+			return 1
+		}
+		return printJSON(log, run)
+
+	case "diff", "swap", "discard":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, replayUsage)
+			return 2
+		}
+		runID := args[1]
+
+		var result interface{}
+		var err error
+		switch args[0] {
+		case "diff":
+			result, err = engine.Diff(ctx, runID)
+		case "swap":
+			result, err = engine.Swap(ctx, runID)
+		case "discard":
+			err = engine.Discard(ctx, runID)
+		}
+		if err != nil {
+			log.Errorf("Replay %s failed: %v", args[0], err)
+			return 1
+		}
+		if result == nil {
+			log.Infof("Discarded replay run %s", runID)
+			return 0
+		}
+		return printJSON(log, result)
+
+	case "list":
+		runs, err := engine.Runs(ctx, 20)
+		if err != nil {
+			log.Errorf("Failed to list replay runs: %v", err)
+			return 1
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSCOPE\tSTATUS\tUSERS\tATTEMPTS\tSTARTED")
+		for _, run := range runs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\n", run.ID, run.Scope, run.Status,
+				run.UsersReplayed, run.AttemptsReplayed, run.StartedAt.Format(time.RFC3339))
+		}
+		w.Flush()
+		return 0
+
+	default:
+		fmt.Fprintln(os.Stderr, replayUsage)
+		return 2
+	}
+}
+
+// readUserIDs reads one user ID per line, skipping blank lines
+func readUserIDs(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var userIDs []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if userID := strings.TrimSpace(scanner.Text()); userID != "" {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, scanner.Err()
+}
+
+// printJSON writes a subcommand's result to stdout as indented JSON
+func printJSON(log *logger.Logger, v interface{}) int {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		log.Errorf("Failed to write result: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -8,6 +8,7 @@ import (
 
 	"user-service/internal/logger"
 	"user-service/internal/metrics"
+	"user-service/internal/tracing"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -28,6 +29,7 @@ func NewRedisClient(redisURL string, db int) (*RedisClient, error) {
 	opt.MaxRetries = 3
 
 	client := redis.NewClient(opt)
+	client.AddHook(tracing.NewRedisHook())
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -29,6 +29,9 @@ type Config struct {
 	LogLevel    string
 	LogFormat   string
 
+	// Tracing configuration
+	Tracing TracingConfig
+
 	// Cache configuration
 	CacheTTL struct {
 		User           time.Duration
@@ -38,6 +41,15 @@ type Config struct {
 	}
 }
 
+// TracingConfig configures OpenTelemetry tracing
+type TracingConfig struct {
+	Exporter     string // otlp, stdout or none
+	OTLPEndpoint string
+	OTLPInsecure bool
+	ServiceName  string
+	SampleRatio  float64
+}
+
 func Load() *Config {
 	cfg := &Config{
 		GRPCPort:    getEnv("GRPC_PORT", "50051"),
@@ -54,6 +66,13 @@ func Load() *Config {
 		Environment:          getEnv("GO_ENV", "development"),
 		LogLevel:             getEnv("LOG_LEVEL", "info"),
 		LogFormat:            getEnv("LOG_FORMAT", "json"),
+		Tracing: TracingConfig{
+			Exporter:     getEnv("OTEL_TRACES_EXPORTER", "none"),
+			OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			OTLPInsecure: getEnv("OTEL_EXPORTER_OTLP_INSECURE", "true") == "true",
+			ServiceName:  getEnv("OTEL_SERVICE_NAME", "user-service"),
+			SampleRatio:  getEnvAsFloat("OTEL_TRACES_SAMPLER_ARG", 1.0),
+		},
 	}
 
 	// Set cache TTL values
@@ -80,3 +99,12 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
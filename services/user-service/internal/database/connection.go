@@ -7,6 +7,7 @@ import (
 
 	"user-service/internal/logger"
 	"user-service/internal/metrics"
+	"user-service/internal/tracing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -27,6 +28,7 @@ func NewConnection(databaseURL string) (*DB, error) {
 	config.MaxConnLifetime = time.Hour
 	config.MaxConnIdleTime = time.Minute * 30
 	config.HealthCheckPeriod = time.Minute
+	config.ConnConfig.Tracer = tracing.NewQueryTracer()
 
 	// Create connection pool
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
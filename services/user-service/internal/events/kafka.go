@@ -9,9 +9,11 @@ import (
 	"user-service/internal/config"
 	"user-service/internal/logger"
 	"user-service/internal/models"
+	"user-service/internal/tracing"
 
 	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
+	otelcodes "go.opentelemetry.io/otel/codes"
 )
 
 // EventType represents the type of event
@@ -455,8 +457,17 @@ func (p *KafkaEventPublisher) PublishSchedulerStateRestored(ctx context.Context,
 	return p.publishEvent(ctx, "user.events", userID.String(), event)
 }
 
-// publishEvent publishes an event to the specified topic
-func (p *KafkaEventPublisher) publishEvent(ctx context.Context, topic, key string, event interface{}) error {
+// publishEvent publishes an event to the specified topic. The message headers carry the
+// trace context so consumers continue the trace.
+func (p *KafkaEventPublisher) publishEvent(ctx context.Context, topic, key string, event interface{}) (err error) {
+	ctx, span := tracing.StartProducerSpan(ctx, topic)
+	defer func() {
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	log := logger.WithContext(ctx).WithField("topic", topic).WithField("key", key)
 
 	writer, exists := p.writers[topic]
@@ -475,11 +486,11 @@ func (p *KafkaEventPublisher) publishEvent(ctx context.Context, topic, key strin
 	message := kafka.Message{
 		Key:   []byte(key),
 		Value: eventData,
-		Headers: []kafka.Header{
+		Headers: tracing.InjectKafkaHeaders(ctx, []kafka.Header{
 			{Key: "content-type", Value: []byte("application/json")},
 			{Key: "source", Value: []byte("user-service")},
 			{Key: "event-id", Value: []byte(uuid.New().String())},
-		},
+		}),
 		Time: time.Now(),
 	}
 
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
@@ -50,14 +51,23 @@ func GetLogger() *logrus.Logger {
 	return log
 }
 
-// WithContext creates a logger with trace ID from gRPC context
+// WithContext creates a logger with the trace ID of the current span, or of the gRPC
+// metadata when the request is not traced
 func WithContext(ctx context.Context) *logrus.Entry {
 	logger := GetLogger()
 	entry := logger.WithFields(logrus.Fields{})
 
+	spanContext := trace.SpanContextFromContext(ctx)
+	if spanContext.IsValid() {
+		entry = entry.WithFields(logrus.Fields{
+			"trace_id": spanContext.TraceID().String(),
+			"span_id":  spanContext.SpanID().String(),
+		})
+	}
+
 	// Extract trace ID from gRPC metadata
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if traceIDs := md.Get("trace-id"); len(traceIDs) > 0 {
+		if traceIDs := md.Get("trace-id"); len(traceIDs) > 0 && !spanContext.IsValid() {
 			entry = entry.WithField("trace_id", traceIDs[0])
 		}
 		if userIDs := md.Get("user-id"); len(userIDs) > 0 {
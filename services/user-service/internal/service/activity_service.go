@@ -91,9 +91,9 @@ func (s *activityService) RecordActivity(ctx context.Context, activity *models.U
 		return fmt.Errorf("failed to create activity: %w", err)
 	}
 
-	// Publish event asynchronously
+	// Publish event asynchronously, in the request's trace but outliving it
 	go func() {
-		if err := s.PublishActivityEvent(context.Background(), activity); err != nil {
+		if err := s.PublishActivityEvent(context.WithoutCancel(ctx), activity); err != nil {
 			s.logger.Error("Failed to publish activity event", "error", err, "activity_id", activity.ID)
 		}
 	}()
@@ -132,7 +132,7 @@ func (s *activityService) RecordActivitiesBatch(ctx context.Context, activities
 	// Publish events asynchronously
 	go func() {
 		for _, activity := range activities {
-			if err := s.PublishActivityEvent(context.Background(), &activity); err != nil {
+			if err := s.PublishActivityEvent(context.WithoutCancel(ctx), &activity); err != nil {
 				s.logger.Error("Failed to publish activity event", "error", err, "activity_id", activity.ID)
 			}
 		}
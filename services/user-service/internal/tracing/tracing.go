@@ -0,0 +1,70 @@
+// Package tracing wires the OpenTelemetry instrumentation of shared/tracing to the
+// user service's configuration and tracer. Trace context follows the W3C Trace
+// Context format, so traces continue across the user, event and scheduler services.
+package tracing
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	sharedTracing "shared/tracing"
+	"shared/tracing/gintracing"
+	"shared/tracing/pgxtracing"
+	"user-service/internal/config"
+)
+
+// instrumentationName names the tracer of the service's instrumentation
+const instrumentationName = "user-service"
+
+// NewProvider installs the global tracer provider and trace context propagator
+// configured for the service
+func NewProvider(ctx context.Context, cfg *config.TracingConfig) (*sharedTracing.Provider, error) {
+	return sharedTracing.NewProvider(ctx, sharedTracing.Config{
+		Exporter:     cfg.Exporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		OTLPInsecure: cfg.OTLPInsecure,
+		ServiceName:  cfg.ServiceName,
+		SampleRatio:  cfg.SampleRatio,
+	})
+}
+
+// Tracer returns the tracer of the service's instrumentation
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// UnaryServerInterceptor records a server span for each gRPC call
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return sharedTracing.UnaryServerInterceptor(Tracer())
+}
+
+// Middleware records a server span for each request to the HTTP API
+func Middleware() gin.HandlerFunc {
+	return gintracing.Middleware(Tracer())
+}
+
+// NewQueryTracer creates a PostgreSQL query tracer for the connection config of the
+// pgx pool
+func NewQueryTracer() *pgxtracing.QueryTracer {
+	return pgxtracing.NewQueryTracer(Tracer())
+}
+
+// NewRedisHook creates a Redis hook, to be added with AddHook
+func NewRedisHook() *sharedTracing.RedisHook {
+	return sharedTracing.NewRedisHook(Tracer())
+}
+
+// StartProducerSpan starts the span of publishing a message to a topic
+func StartProducerSpan(ctx context.Context, topic string) (context.Context, trace.Span) {
+	return sharedTracing.StartProducerSpan(ctx, Tracer(), topic)
+}
+
+// InjectKafkaHeaders adds the trace context of ctx to the headers of a message
+func InjectKafkaHeaders(ctx context.Context, headers []kafka.Header) []kafka.Header {
+	return sharedTracing.InjectKafkaHeaders(ctx, headers)
+}
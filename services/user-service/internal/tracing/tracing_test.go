@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"user-service/internal/config"
+)
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+// recordSpans installs a tracer provider recording finished spans for the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	recorder := recordSpans(t)
+	gin.SetMode(gin.TestMode)
+
+	var traceID string
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/api/v1/users/:id", func(c *gin.Context) {
+		traceID = trace.SpanContextFromContext(c.Request.Context()).TraceID().String()
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/42", nil)
+	req.Header.Set("traceparent", testTraceParent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, testTraceID, traceID)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "GET /api/v1/users/:id", spans[0].Name())
+	assert.Equal(t, instrumentationName, spans[0].InstrumentationScope().Name)
+}
+
+func TestUnaryServerInterceptor_ContinuesIncomingTrace(t *testing.T) {
+	recorder := recordSpans(t)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", testTraceParent))
+	info := &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUser"}
+
+	var traceID string
+	_, err := UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		traceID = trace.SpanContextFromContext(ctx).TraceID().String()
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, testTraceID, traceID)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "user.UserService/GetUser", spans[0].Name())
+	assert.Equal(t, instrumentationName, spans[0].InstrumentationScope().Name)
+}
+
+func TestKafkaHeaders_CarryProducerSpan(t *testing.T) {
+	recordSpans(t)
+
+	ctx, span := StartProducerSpan(context.Background(), "user.events")
+	headers := InjectKafkaHeaders(ctx, []kafka.Header{{Key: "event-type", Value: []byte("user.created")}})
+	span.End()
+
+	require.Len(t, headers, 2)
+	carrier := propagation.MapCarrier{}
+	for _, header := range headers {
+		carrier[header.Key] = string(header.Value)
+	}
+	consumed := trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(context.Background(), carrier))
+	assert.Equal(t, span.SpanContext().TraceID(), consumed.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), consumed.SpanID())
+}
+
+func TestNewProvider_RejectsUnknownExporter(t *testing.T) {
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	provider, err := NewProvider(context.Background(), &config.TracingConfig{Exporter: "none"})
+	require.NoError(t, err)
+	assert.NoError(t, provider.Shutdown(context.Background()))
+
+	_, err = NewProvider(context.Background(), &config.TracingConfig{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ import (
 	"user-service/internal/metrics"
 	"user-service/internal/repository"
 	"user-service/internal/service"
+	"user-service/internal/tracing"
 	pb "user-service/proto"
 
 	"github.com/gin-gonic/gin"
@@ -46,6 +47,12 @@ func main() {
 
 	log.Info("Starting User Service...")
 
+	// Initialize tracing before any client is created
+	tracerProvider, err := tracing.NewProvider(context.Background(), &cfg.Tracing)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to initialize tracing")
+	}
+
 	// Initialize security manager
 	securityManager, err := security.InitializeSecurity(log)
 	if err != nil {
@@ -93,6 +100,7 @@ func main() {
 	// Create gRPC server with interceptors
 	grpcServer := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
+			tracing.UnaryServerInterceptor(),
 			securityInterceptor.UnaryServerInterceptor(),
 			logger.UnaryServerInterceptor(),
 			metrics.UnaryServerInterceptor(),
@@ -116,6 +124,7 @@ func main() {
 	go func() {
 		// Create Gin router
 		router := gin.New()
+		router.Use(tracing.Middleware())
 
 		// Add security middleware
 		for _, middleware := range securityManager.GetSecurityMiddleware() {
@@ -167,8 +176,10 @@ func main() {
 	// Stop gRPC server
 	grpcServer.GracefulStop()
 
-	// Use context for any cleanup if needed
-	_ = ctx
+	// Export the remaining spans
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		log.WithError(err).Error("Error shutting down tracing")
+	}
 
 	log.Info("User Service stopped")
 }
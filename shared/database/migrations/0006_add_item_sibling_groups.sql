@@ -0,0 +1,13 @@
+-- Migration: Add Item Sibling Groups
+-- Created: 2026-10-18T00:00:00.000Z
+-- Description: Group items that test the same sign or rule in different wordings, so schedulers can keep near-duplicates apart
+
+BEGIN;
+
+ALTER TABLE items ADD COLUMN sibling_group VARCHAR(255);
+
+CREATE INDEX idx_items_sibling_group ON items(sibling_group) WHERE sibling_group IS NOT NULL;
+
+COMMENT ON COLUMN items.sibling_group IS 'Items sharing a group test the same sign or rule; siblings are not scheduled close together';
+
+COMMIT;
@@ -24,6 +24,7 @@ export const items = pgTable('items', {
     // Classification and metadata
     topics: jsonb('topics').notNull().default([]), // topic tags for BKT
     jurisdictions: jsonb('jurisdictions').notNull().default([]), // applicable regions
+    siblingGroup: varchar('sibling_group', { length: 255 }), // items testing the same sign or rule
     itemType: varchar('item_type', { length: 50 }).default('multiple_choice'),
     cognitiveLevel: varchar('cognitive_level', { length: 50 }).default('knowledge'), // Bloom's taxonomy
 
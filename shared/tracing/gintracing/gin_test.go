@@ -0,0 +1,55 @@
+package gintracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+func TestMiddleware_NamesSpanAfterRoute(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previousPropagator) })
+	gin.SetMode(gin.TestMode)
+
+	var traceID string
+	router := gin.New()
+	router.Use(Middleware(tracer))
+	router.GET("/users/:id", func(c *gin.Context) {
+		traceID = TraceID(c)
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("traceparent", testTraceParent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if traceID != testTraceID {
+		t.Errorf("Expected handler to run in trace %s, got %s", testTraceID, traceID)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "GET /users/:id" {
+		t.Errorf("Expected span named after the route, got %s", spans[0].Name())
+	}
+	if spans[0].Status().Code != otelcodes.Error {
+		t.Errorf("Expected a server error to mark the span as error, got %v", spans[0].Status().Code)
+	}
+}
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartProducerSpan starts the span of publishing a message to a topic
+func StartProducerSpan(ctx context.Context, tracer trace.Tracer, topic string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingDestinationName(topic),
+		),
+	)
+}
+
+// StartBatchProducerSpan starts the span of publishing a batch of messages, which may
+// go to several topics
+func StartBatchProducerSpan(ctx context.Context, tracer trace.Tracer, count int) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "batch publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingBatchMessageCount(count),
+		),
+	)
+}
+
+// InjectKafkaHeaders adds the trace context of ctx to the headers of a message, so
+// consumers continue the producer's trace
+func InjectKafkaHeaders(ctx context.Context, headers []kafka.Header) []kafka.Header {
+	carrier := kafkaHeaderCarrier{headers: headers}
+	otel.GetTextMapPropagator().Inject(ctx, &carrier)
+	return carrier.headers
+}
+
+// ExtractKafkaHeaders returns ctx carrying the trace context of a consumed message
+func ExtractKafkaHeaders(ctx context.Context, headers []kafka.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, &kafkaHeaderCarrier{headers: headers})
+}
+
+// kafkaHeaderCarrier reads and writes trace context in Kafka message headers
+type kafkaHeaderCarrier struct {
+	headers []kafka.Header
+}
+
+func (c *kafkaHeaderCarrier) Get(key string) string {
+	for _, header := range c.headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (c *kafkaHeaderCarrier) Set(key, value string) {
+	for i, header := range c.headers {
+		if header.Key == key {
+			c.headers[i].Value = []byte(value)
+			return
+		}
+	}
+	c.headers = append(c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c *kafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.headers))
+	for _, header := range c.headers {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
@@ -0,0 +1,64 @@
+// Package pgxtracing traces PostgreSQL queries made through pgx
+package pgxtracing
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer records a client span for each PostgreSQL query. It is set on the pgx
+// connection config, so it covers database/sql connections opened through the pgx
+// driver as well as native pgx pools.
+type QueryTracer struct {
+	tracer trace.Tracer
+}
+
+// NewQueryTracer creates a query tracer recording spans of the tracer
+func NewQueryTracer(tracer trace.Tracer) *QueryTracer {
+	return &QueryTracer{tracer: tracer}
+}
+
+// TraceQueryStart starts the span of a query
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+	ctx, _ = t.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(data.SQL),
+		),
+	)
+	return ctx
+}
+
+// TraceQueryEnd ends the span of a query
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil && data.Err != pgx.ErrNoRows {
+		span.RecordError(data.Err)
+		span.SetStatus(otelcodes.Error, data.Err.Error())
+	}
+	span.End()
+}
+
+// queryOperation returns the SQL command of a query, such as SELECT, or QUERY for
+// prepared statements executed by name
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	for _, r := range fields[0] {
+		if !unicode.IsLetter(r) {
+			return "QUERY"
+		}
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,21 @@
+package pgxtracing
+
+import "testing"
+
+func TestQueryOperation(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT * FROM sm2_states WHERE user_id = $1", "SELECT"},
+		{"  insert into bkt_states (user_id) values ($1)", "INSERT"},
+		{"stmtcache_0123456789abcdef", "QUERY"},
+		{"", "QUERY"},
+	}
+
+	for _, tt := range tests {
+		if got := queryOperation(tt.sql); got != tt.want {
+			t.Errorf("queryOperation(%q) = %s, want %s", tt.sql, got, tt.want)
+		}
+	}
+}
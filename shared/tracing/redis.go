@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook records a client span for each Redis command and pipeline. Command
+// arguments are not recorded since they may hold cached user data.
+type RedisHook struct {
+	tracer trace.Tracer
+}
+
+// NewRedisHook creates a Redis hook recording spans of the tracer, to be added with AddHook
+func NewRedisHook(tracer trace.Tracer) *RedisHook {
+	return &RedisHook{tracer: tracer}
+}
+
+// BeforeProcess starts the span of a command
+func (h *RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = h.tracer.Start(ctx, cmd.FullName(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis, semconv.DBOperationName(cmd.Name())),
+	)
+	return ctx, nil
+}
+
+// AfterProcess ends the span of a command
+func (h *RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	span := trace.SpanFromContext(ctx)
+	recordRedisError(span, cmd.Err())
+	span.End()
+	return nil
+}
+
+// BeforeProcessPipeline starts the span of a pipeline
+func (h *RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	ctx, _ = h.tracer.Start(ctx, "pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperationName("pipeline"),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		),
+	)
+	return ctx, nil
+}
+
+// AfterProcessPipeline ends the span of a pipeline
+func (h *RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	span := trace.SpanFromContext(ctx)
+	for _, cmd := range cmds {
+		if recordRedisError(span, cmd.Err()) {
+			break
+		}
+	}
+	span.End()
+	return nil
+}
+
+// recordRedisError marks a span failed by a command error; cache misses are not errors
+func recordRedisError(span trace.Span, err error) bool {
+	if err == nil || err == redis.Nil {
+		return false
+	}
+	span.RecordError(err)
+	span.SetStatus(otelcodes.Error, err.Error())
+	return true
+}
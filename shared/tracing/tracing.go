@@ -0,0 +1,107 @@
+// Package tracing sets up OpenTelemetry tracing for the services and instruments
+// their gRPC servers, Redis clients and Kafka messages; gin APIs and pgx connections
+// are instrumented by the gintracing and pgxtracing packages. Trace context follows
+// the W3C Trace Context format, so traces continue across services. Each service
+// passes its own tracer, which names the instrumentation of its spans.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Exporters of finished spans
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+// Config configures the export of a service's spans
+type Config struct {
+	Exporter     string  // "otlp" to send spans to a collector, "stdout" for local runs, or "none"
+	OTLPEndpoint string  // host:port or URL of the collector's OTLP gRPC receiver
+	OTLPInsecure bool    // Connect to the collector without TLS
+	ServiceName  string  // service.name resource attribute of exported spans
+	SampleRatio  float64 // Share of traces started here that are sampled; remote sampling decisions are kept
+}
+
+// Provider exports the spans of a service
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewProvider installs the global tracer provider and trace context propagator. With
+// the "none" exporter no spans are recorded, but incoming trace context is still
+// propagated to downstream calls and messages.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return &Provider{}, nil
+	case ExporterOTLP:
+		exporter, err = otlptracegrpc.New(ctx, otlpOptions(cfg)...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return &Provider{provider: provider}, nil
+}
+
+// otlpOptions configures the OTLP exporter from a collector address, which may be
+// given as host:port or as a URL
+func otlpOptions(cfg Config) []otlptracegrpc.Option {
+	var options []otlptracegrpc.Option
+	if strings.Contains(cfg.OTLPEndpoint, "://") {
+		options = append(options, otlptracegrpc.WithEndpointURL(cfg.OTLPEndpoint))
+	} else {
+		options = append(options, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
+	}
+	if cfg.OTLPInsecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	return options
+}
+
+// Shutdown flushes buffered spans and stops exporting
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil || p.provider == nil {
+		return nil
+	}
+	return p.provider.Shutdown(ctx)
+}
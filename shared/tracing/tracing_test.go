@@ -0,0 +1,176 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+// recordSpans installs the trace context propagator and returns a tracer whose
+// finished spans are recorded for the test
+func recordSpans(t *testing.T) (trace.Tracer, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return provider.Tracer("test"), recorder
+}
+
+func TestUnaryServerInterceptor_ContinuesIncomingTrace(t *testing.T) {
+	tracer, recorder := recordSpans(t)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", testTraceParent))
+	info := &grpc.UnaryServerInfo{FullMethod: "/scheduler.SchedulerService/GetNextItems"}
+
+	var handlerTraceID string
+	_, err := UnaryServerInterceptor(tracer)(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerTraceID = trace.SpanContextFromContext(ctx).TraceID().String()
+		return nil, status.Error(codes.NotFound, "no items")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected the handler's error to be returned, got %v", err)
+	}
+
+	if handlerTraceID != testTraceID {
+		t.Errorf("Expected handler to run in trace %s, got %s", testTraceID, handlerTraceID)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "scheduler.SchedulerService/GetNextItems" {
+		t.Errorf("Expected span named after the method, got %s", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("Expected a server span, got %s", span.SpanKind())
+	}
+	if span.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("Expected the caller's span as parent, got %s", span.Parent().SpanID())
+	}
+	if span.Status().Code != otelcodes.Error {
+		t.Errorf("Expected a failed call to mark the span as error, got %v", span.Status().Code)
+	}
+}
+
+func TestKafkaHeaders_RoundTrip(t *testing.T) {
+	tracer, _ := recordSpans(t)
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier{"traceparent": testTraceParent})
+	ctx, span := StartProducerSpan(ctx, tracer, "scheduler.events")
+	defer span.End()
+
+	headers := InjectKafkaHeaders(ctx, []kafka.Header{
+		{Key: "event-id", Value: []byte("event-1")},
+	})
+
+	if len(headers) != 2 || headers[0].Key != "event-id" {
+		t.Fatalf("Expected existing headers to be kept and traceparent added, got %v", headers)
+	}
+
+	consumed := trace.SpanContextFromContext(ExtractKafkaHeaders(context.Background(), headers))
+	if consumed.TraceID().String() != testTraceID {
+		t.Errorf("Expected consumer to continue trace %s, got %s", testTraceID, consumed.TraceID())
+	}
+	if consumed.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("Expected consumer's parent to be the producer span")
+	}
+}
+
+func TestKafkaHeaders_ReplacesStaleTraceParent(t *testing.T) {
+	recordSpans(t)
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier{"traceparent": testTraceParent})
+	headers := InjectKafkaHeaders(ctx, []kafka.Header{
+		{Key: "traceparent", Value: []byte("00-11111111111111111111111111111111-2222222222222222-01")},
+	})
+
+	if len(headers) != 1 {
+		t.Fatalf("Expected the traceparent header to be replaced, got %v", headers)
+	}
+	if string(headers[0].Value) != testTraceParent {
+		t.Errorf("Expected traceparent %s, got %s", testTraceParent, headers[0].Value)
+	}
+}
+
+func TestRecordRedisError_IgnoresCacheMisses(t *testing.T) {
+	tracer, recorder := recordSpans(t)
+
+	_, span := tracer.Start(context.Background(), "get")
+	if recordRedisError(span, redis.Nil) {
+		t.Errorf("Expected no error to be recorded for a cache miss")
+	}
+	span.End()
+
+	_, span = tracer.Start(context.Background(), "get")
+	if !recordRedisError(span, errors.New("connection refused")) {
+		t.Errorf("Expected a command error to be recorded")
+	}
+	span.End()
+
+	spans := recorder.Ended()
+	if spans[0].Status().Code == otelcodes.Error {
+		t.Errorf("Expected cache miss span not to be an error")
+	}
+	if spans[1].Status().Code != otelcodes.Error {
+		t.Errorf("Expected failed command span to be an error")
+	}
+}
+
+func TestStartBatchProducerSpan(t *testing.T) {
+	tracer, recorder := recordSpans(t)
+
+	_, span := StartBatchProducerSpan(context.Background(), tracer, 3)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].SpanKind() != trace.SpanKindProducer {
+		t.Fatalf("Expected 1 producer span, got %v", spans)
+	}
+	if spans[0].Name() != "batch publish" {
+		t.Errorf("Expected a batch publish span, got %s", spans[0].Name())
+	}
+}
+
+func TestNewProvider_Exporters(t *testing.T) {
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	provider, err := NewProvider(context.Background(), Config{Exporter: ExporterNone})
+	if err != nil {
+		t.Fatalf("Expected the none exporter to be accepted, got %v", err)
+	}
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected shutting down without an exporter to succeed, got %v", err)
+	}
+
+	if _, err := NewProvider(context.Background(), Config{Exporter: "zipkin"}); err == nil {
+		t.Error("Expected an unknown exporter to be rejected")
+	}
+}